@@ -8,15 +8,65 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // hotel time zones don't depend on the zone database of the host
 
 	"applicationDesignTest/internal/api/add_availability"
+	"applicationDesignTest/internal/api/cancel_order"
+	"applicationDesignTest/internal/api/change_order_status"
+	"applicationDesignTest/internal/api/check_in"
+	"applicationDesignTest/internal/api/check_out"
+	"applicationDesignTest/internal/api/confirm_hold"
+	"applicationDesignTest/internal/api/create_hold"
+	"applicationDesignTest/internal/api/create_hotel"
 	"applicationDesignTest/internal/api/create_order"
+	"applicationDesignTest/internal/api/create_promo"
+	"applicationDesignTest/internal/api/create_room_type"
+	"applicationDesignTest/internal/api/create_webhook"
+	"applicationDesignTest/internal/api/delete_webhook"
+	"applicationDesignTest/internal/api/get_calendar"
+	"applicationDesignTest/internal/api/get_hotel"
+	"applicationDesignTest/internal/api/get_loyalty"
 	"applicationDesignTest/internal/api/get_order"
+	"applicationDesignTest/internal/api/get_room_type"
+	"applicationDesignTest/internal/api/get_rooming_list"
+	"applicationDesignTest/internal/api/get_webhook"
+	"applicationDesignTest/internal/api/list_dead_letters"
+	"applicationDesignTest/internal/api/list_hotels"
+	"applicationDesignTest/internal/api/list_restrictions"
+	"applicationDesignTest/internal/api/list_room_types"
+	"applicationDesignTest/internal/api/list_webhooks"
+	"applicationDesignTest/internal/api/modify_order"
+	"applicationDesignTest/internal/api/reduce_capacity"
+	"applicationDesignTest/internal/api/search_availability"
+	"applicationDesignTest/internal/api/set_cancellation_policy"
+	"applicationDesignTest/internal/api/set_capacity"
+	"applicationDesignTest/internal/api/set_rate"
+	"applicationDesignTest/internal/api/set_restriction"
+	"applicationDesignTest/internal/api/update_hotel"
+	"applicationDesignTest/internal/api/update_room_type"
+	"applicationDesignTest/internal/api/update_webhook"
+	"applicationDesignTest/internal/api/upload_availability"
 	"applicationDesignTest/internal/config"
+	"applicationDesignTest/internal/domain"
 	"applicationDesignTest/internal/fixtures"
+	"applicationDesignTest/internal/mail"
+	"applicationDesignTest/internal/payment"
+	"applicationDesignTest/internal/storage/filestore"
 	"applicationDesignTest/internal/storage/memorystore"
+	"applicationDesignTest/internal/storage/sqlstore"
 	"applicationDesignTest/internal/usecase/booking"
+	"applicationDesignTest/internal/usecase/dispatcher"
+	"applicationDesignTest/internal/usecase/hold"
+	"applicationDesignTest/internal/usecase/hotel"
+	"applicationDesignTest/internal/usecase/inventory"
+	"applicationDesignTest/internal/usecase/loyalty"
+	"applicationDesignTest/internal/usecase/noshow"
+	"applicationDesignTest/internal/usecase/notification"
 	"applicationDesignTest/internal/usecase/order"
+	"applicationDesignTest/internal/usecase/pricing"
+	"applicationDesignTest/internal/usecase/promo"
+	"applicationDesignTest/internal/usecase/search"
+	"applicationDesignTest/internal/usecase/webhook"
 	"applicationDesignTest/pkg/log"
 
 	"github.com/go-chi/chi/v5"
@@ -24,6 +74,98 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 )
 
+type hotelRepository interface {
+	GetHotel(ctx context.Context, hotelID domain.HotelID) (*domain.Hotel, error)
+	GetHotels(ctx context.Context) ([]domain.Hotel, error)
+	AddHotel(ctx context.Context, hotel domain.Hotel) error
+	UpdateHotel(ctx context.Context, hotel domain.Hotel) error
+	GetRoomTypes(ctx context.Context, hotelID domain.HotelID) ([]domain.HotelRoomType, error)
+	GetRoomType(ctx context.Context, hotelID domain.HotelID, code domain.RoomType) (*domain.HotelRoomType, error)
+	AddRoomType(ctx context.Context, roomType domain.HotelRoomType) error
+	UpdateRoomType(ctx context.Context, roomType domain.HotelRoomType) error
+	GetRoomAvailability(ctx context.Context, hotelID domain.HotelID, from, to time.Time) ([]domain.RoomAvailability, error)
+	AddRoomAvailability(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error
+	AddRoomAvailabilities(ctx context.Context, changes []domain.AvailabilityChange) error
+	SetRoomCapacity(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, capacity int) error
+	ReduceRoomCapacity(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error
+	Reserve(ctx context.Context, bookings []domain.Booking) error
+	Release(ctx context.Context, bookings []domain.Booking) error
+	ReplaceReservation(ctx context.Context, released, reserved []domain.Booking) error
+}
+
+type orderRepository interface {
+	NextOrderNumber(ctx context.Context) (domain.OrderNumber, error)
+	AddOrder(ctx context.Context, order domain.Order) (*domain.Order, error)
+	GetOrderByID(ctx context.Context, id domain.OrderID) (*domain.Order, error)
+	GetOrderByNumber(ctx context.Context, orderNumber domain.OrderNumber) (*domain.Order, error)
+	GetOrders(ctx context.Context) ([]domain.Order, error)
+	UpdateOrder(ctx context.Context, orderNumber domain.OrderNumber, update func(order *domain.Order) error) (*domain.Order, error)
+}
+
+type outboxRepository interface {
+	GetEvents(ctx context.Context, afterID int64, limit int) ([]domain.Event, error)
+	GetOffset(ctx context.Context, subscriber string) (int64, error)
+	SetOffset(ctx context.Context, subscriber string, offset int64) error
+}
+
+type holdRepository interface {
+	AddHold(ctx context.Context, hold domain.Hold) error
+	GetHold(ctx context.Context, token domain.HoldToken) (*domain.Hold, error)
+	DeleteHold(ctx context.Context, token domain.HoldToken) (*domain.Hold, error)
+	GetExpiredHolds(ctx context.Context, now time.Time) ([]domain.Hold, error)
+}
+
+type rateRepository interface {
+	SetRate(ctx context.Context, rate domain.Rate) error
+	GetRate(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time) (*domain.Rate, error)
+	SetCancellationPolicy(ctx context.Context, policy domain.CancellationPolicy) error
+	GetCancellationPolicy(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType) (*domain.CancellationPolicy, error)
+}
+
+type promoRepository interface {
+	AddPromo(ctx context.Context, promo domain.Promo) error
+	GetPromo(ctx context.Context, code domain.PromoCode) (*domain.Promo, error)
+	Redeem(ctx context.Context, code domain.PromoCode, userID domain.UserID) error
+	Unredeem(ctx context.Context, code domain.PromoCode, userID domain.UserID) error
+}
+
+type loyaltyRepository interface {
+	AppendEntry(ctx context.Context, entry domain.LoyaltyEntry) (*domain.LoyaltyEntry, error)
+	GetBalance(ctx context.Context, userID domain.UserID) (int64, error)
+	GetUserEntries(ctx context.Context, userID domain.UserID) ([]domain.LoyaltyEntry, error)
+	GetOrderEntries(ctx context.Context, orderNumber domain.OrderNumber) ([]domain.LoyaltyEntry, error)
+}
+
+type webhookRepository interface {
+	AddWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error)
+	GetWebhook(ctx context.Context, id domain.WebhookID) (*domain.Webhook, error)
+	GetWebhooks(ctx context.Context) ([]domain.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id domain.WebhookID) error
+	AddDeadLetter(ctx context.Context, deadLetter domain.DeadLetter) error
+	GetDeadLetters(ctx context.Context) ([]domain.DeadLetter, error)
+}
+
+type restrictionRepository interface {
+	SetRestriction(ctx context.Context, restriction domain.Restriction) error
+	GetRestrictions(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, from, to time.Time) ([]domain.Restriction, error)
+}
+
+// storage is the configured store of hotels, orders and the rest of the booking state and the outbox
+// their events are written to.
+type storage struct {
+	hotels       hotelRepository
+	orders       orderRepository
+	outbox       outboxRepository
+	holds        holdRepository
+	rates        rateRepository
+	promos       promoRepository
+	loyalty      loyaltyRepository
+	webhooks     webhookRepository
+	restrictions restrictionRepository
+	close        func() error
+}
+
 func main() {
 	log.InitializeLogger()
 
@@ -47,17 +189,89 @@ func run() error {
 		panic(fmt.Sprintf("failed to load config: %v", err))
 	}
 
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
 	log.Info("init store")
 
-	hotelStore := memorystore.NewHotelStore()
-	orderStore := memorystore.NewOrderStore()
+	store, err := initStorage(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("can't init store: %w", err)
+	}
+
+	defer func() {
+		if err := store.close(); err != nil {
+			log.Error("failed to close store", err)
+		}
+	}()
 
+	hotelStore, orderStore := store.hotels, store.orders
+	holdStore, rateStore, promoStore, loyaltyStore := store.holds, store.rates, store.promos, store.loyalty
+	webhookStore, restrictionStore := store.webhooks, store.restrictions
+	userStore := memorystore.NewUserStore()
+
+	mailSender := mail.NewSMTPSender(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password)
+	paymentGateway := payment.NewFakeGateway()
+
+	hotelService := hotel.NewHotelService(hotelStore)
 	orderService := order.NewOrderService(orderStore)
-	bookingService := booking.NewBookingService(hotelStore, orderService)
+	searchService := search.NewSearchService(hotelStore, restrictionStore)
+	inventoryService := inventory.NewInventoryService(hotelStore, restrictionStore)
+	pricingService := pricing.NewPricingService(rateStore, hotelStore)
+	promoService := promo.NewPromoService(promoStore)
+	loyaltyService := loyalty.NewLoyaltyService(loyaltyStore, cfg.Loyalty.EarnPercent, cfg.Loyalty.PointValue)
+	notificationService := notification.NewNotificationService(userStore, mailSender, cfg.SMTP.From,
+		cfg.Notification.Attempts, cfg.Notification.Backoff)
+	bookingService := booking.NewBookingService(hotelStore, orderService, pricingService, promoService, loyaltyService,
+		inventoryService, paymentGateway)
+	holdService := hold.NewHoldService(hotelStore, holdStore, orderService, bookingService, pricingService,
+		inventoryService, cfg.Hold.TTL)
+	noShowService := noshow.NewNoShowService(hotelStore, orderStore, bookingService, cfg.NoShow.Cutoff, cfg.NoShow.PenaltyNights)
+
+	webhookService := webhook.NewWebhookService(webhookStore, &http.Client{Timeout: cfg.Webhook.Timeout},
+		cfg.Webhook.Attempts, cfg.Webhook.Backoff)
+
+	eventDispatcher := dispatcher.NewDispatcher(store.outbox, cfg.Outbox.BatchSize)
+	eventDispatcher.Subscribe("notification", notificationService)
+	eventDispatcher.Subscribe("webhook", webhookService)
 
+	createHotelHandler := create_hotel.NewHandler(hotelService)
+	updateHotelHandler := update_hotel.NewHandler(hotelService)
+	getHotelHandler := get_hotel.NewHandler(hotelService)
+	listHotelsHandler := list_hotels.NewHandler(hotelService)
+	createRoomTypeHandler := create_room_type.NewHandler(hotelService)
+	updateRoomTypeHandler := update_room_type.NewHandler(hotelService)
+	getRoomTypeHandler := get_room_type.NewHandler(hotelService)
+	listRoomTypesHandler := list_room_types.NewHandler(hotelService)
+	searchAvailabilityHandler := search_availability.NewHandler(searchService)
+	getCalendarHandler := get_calendar.NewHandler(searchService)
+	uploadAvailabilityHandler := upload_availability.NewHandler(inventoryService)
+	setCapacityHandler := set_capacity.NewHandler(inventoryService)
+	reduceCapacityHandler := reduce_capacity.NewHandler(inventoryService)
+	setRestrictionHandler := set_restriction.NewHandler(inventoryService)
+	listRestrictionsHandler := list_restrictions.NewHandler(inventoryService)
 	getOrderHandler := get_order.NewHandler(orderStore)
-	createOrderHandler := create_order.NewHandler(bookingService)
-	addAvailabilityHandler := add_availability.NewHandler(bookingService)
+	getRoomingListHandler := get_rooming_list.NewHandler(orderStore)
+	createOrderHandler := create_order.NewHandler(bookingService, hotelService)
+	addAvailabilityHandler := add_availability.NewHandler(inventoryService)
+	cancelOrderHandler := cancel_order.NewHandler(bookingService)
+	checkInHandler := check_in.NewHandler(bookingService)
+	checkOutHandler := check_out.NewHandler(bookingService)
+	modifyOrderHandler := modify_order.NewHandler(bookingService, hotelService)
+	changeOrderStatusHandler := change_order_status.NewHandler(bookingService)
+	createHoldHandler := create_hold.NewHandler(holdService, hotelService)
+	confirmHoldHandler := confirm_hold.NewHandler(holdService)
+	setRateHandler := set_rate.NewHandler(pricingService)
+	setCancellationPolicyHandler := set_cancellation_policy.NewHandler(pricingService)
+	createPromoHandler := create_promo.NewHandler(promoService)
+	getLoyaltyHandler := get_loyalty.NewHandler(loyaltyService)
+	createWebhookHandler := create_webhook.NewHandler(hotelService, webhookService)
+	listWebhooksHandler := list_webhooks.NewHandler(webhookService)
+	getWebhookHandler := get_webhook.NewHandler(webhookService)
+	updateWebhookHandler := update_webhook.NewHandler(webhookService)
+	deleteWebhookHandler := delete_webhook.NewHandler(webhookService)
+	listDeadLettersHandler := list_dead_letters.NewHandler(webhookService)
 
 	log.Info("init fixtures")
 
@@ -65,14 +279,74 @@ func run() error {
 		return fmt.Errorf("can't init fixtures: %w", err)
 	}
 
+	if err := fixtures.InitRateData(rateStore); err != nil {
+		return fmt.Errorf("can't init fixtures: %w", err)
+	}
+
+	if err := fixtures.InitUserData(userStore); err != nil {
+		return fmt.Errorf("can't init fixtures: %w", err)
+	}
+
 	log.Info("register handlers")
 
 	r := chi.NewRouter()
 	r.Use(middleware.Logger)
 
+	r.Get("/availability", searchAvailabilityHandler.Handle)
 	r.Get("/orders/{orderNumber}", getOrderHandler.Handle)
+	r.Get("/orders/{orderNumber}/rooming-list", getRoomingListHandler.Handle)
 	r.Post("/orders", createOrderHandler.Handle)
+	r.Patch("/orders/{orderNumber}", modifyOrderHandler.Handle)
+	r.Post("/orders/{orderNumber}/cancel", cancelOrderHandler.Handle)
+	r.Post("/orders/{orderNumber}/check-in", checkInHandler.Handle)
+	r.Post("/orders/{orderNumber}/check-out", checkOutHandler.Handle)
+	r.Put("/orders/{orderNumber}/status", changeOrderStatusHandler.Handle)
+	r.Post("/hotels", createHotelHandler.Handle)
+	r.Get("/hotels", listHotelsHandler.Handle)
+	r.Get("/hotels/{id}", getHotelHandler.Handle)
+	r.Put("/hotels/{id}", updateHotelHandler.Handle)
+	r.Get("/hotels/{id}/calendar", getCalendarHandler.Handle)
+	r.Get("/hotels/{id}/restrictions", listRestrictionsHandler.Handle)
+	r.Post("/hotels/{id}/room-types", createRoomTypeHandler.Handle)
+	r.Get("/hotels/{id}/room-types", listRoomTypesHandler.Handle)
+	r.Get("/hotels/{id}/room-types/{code}", getRoomTypeHandler.Handle)
+	r.Put("/hotels/{id}/room-types/{code}", updateRoomTypeHandler.Handle)
 	r.Post("/hotels/availability", addAvailabilityHandler.Handle)
+	r.Post("/hotels/availability/bulk", uploadAvailabilityHandler.Handle)
+	r.Put("/hotels/availability/capacity", setCapacityHandler.Handle)
+	r.Post("/hotels/availability/reduce", reduceCapacityHandler.Handle)
+	r.Put("/hotels/restrictions", setRestrictionHandler.Handle)
+	r.Post("/hotels/rates", setRateHandler.Handle)
+	r.Put("/hotels/cancellation-policies", setCancellationPolicyHandler.Handle)
+	r.Post("/promos", createPromoHandler.Handle)
+	r.Get("/users/{id}/loyalty", getLoyaltyHandler.Handle)
+	r.Post("/holds", createHoldHandler.Handle)
+	r.Post("/holds/{token}/confirm", confirmHoldHandler.Handle)
+	r.Post("/webhooks", createWebhookHandler.Handle)
+	r.Get("/webhooks", listWebhooksHandler.Handle)
+	r.Get("/webhooks/dead-letters", listDeadLettersHandler.Handle)
+	r.Get("/webhooks/{id}", getWebhookHandler.Handle)
+	r.Put("/webhooks/{id}", updateWebhookHandler.Handle)
+	r.Delete("/webhooks/{id}", deleteWebhookHandler.Handle)
+
+	log.Info("start hold reaper")
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	go holdService.RunReaper(workersCtx, cfg.Hold.ReaperInterval)
+
+	log.Info("start no-show job")
+
+	go noShowService.Run(workersCtx, cfg.NoShow.Interval)
+
+	log.Info("start event dispatcher")
+
+	go eventDispatcher.Run(workersCtx, cfg.Outbox.PollInterval)
+
+	log.Info("start webhook delivery")
+
+	go webhookService.Run(workersCtx)
 
 	log.Info(fmt.Sprintf("server is running on port %v", cfg.Port))
 
@@ -105,3 +379,63 @@ func run() error {
 
 	return nil
 }
+
+// initStorage creates the stores of the configured type. The memory store writes the events to the outbox
+// in memory, the file and SQL stores keep them with their state.
+func initStorage(cfg config.Storage) (*storage, error) {
+	switch cfg.Type {
+	case "memory":
+		outbox := memorystore.NewOutbox()
+
+		return &storage{
+			hotels:       memorystore.NewHotelStore(outbox),
+			orders:       memorystore.NewOrderStore(outbox),
+			outbox:       outbox,
+			holds:        memorystore.NewHoldStore(),
+			rates:        memorystore.NewRateStore(),
+			promos:       memorystore.NewPromoStore(),
+			loyalty:      memorystore.NewLoyaltyStore(),
+			webhooks:     memorystore.NewWebhookStore(),
+			restrictions: memorystore.NewRestrictionStore(),
+			close:        func() error { return nil },
+		}, nil
+	case "file":
+		store, err := filestore.Open(cfg.Dir, cfg.SnapshotEvery)
+		if err != nil {
+			return nil, err
+		}
+
+		return &storage{
+			hotels:       store,
+			orders:       store,
+			outbox:       store,
+			holds:        store,
+			rates:        store,
+			promos:       store,
+			loyalty:      store,
+			webhooks:     store,
+			restrictions: store,
+			close:        store.Close,
+		}, nil
+	case "sql":
+		store, err := sqlstore.Open(cfg.DSN)
+		if err != nil {
+			return nil, err
+		}
+
+		return &storage{
+			hotels:       store,
+			orders:       store,
+			outbox:       store,
+			holds:        store,
+			rates:        store,
+			promos:       store,
+			loyalty:      store,
+			webhooks:     store,
+			restrictions: store,
+			close:        store.Close,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown storage type '%s'", cfg.Type)
+	}
+}
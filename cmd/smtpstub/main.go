@@ -0,0 +1,42 @@
+// Command smtpstub runs a local fake SMTP server which logs every received email,
+// so the booking confirmations can be checked without a real mail server.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"applicationDesignTest/internal/mail/mailtest"
+	"applicationDesignTest/pkg/log"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:1025", "address to listen on")
+	flag.Parse()
+
+	log.InitializeLogger()
+
+	server, err := mailtest.NewServer(*addr, func(msg mailtest.Message) {
+		log.WithFields(map[string]any{
+			"from": msg.From,
+			"to":   msg.To,
+		}).Info("email received\n" + msg.Data)
+	})
+	if err != nil {
+		log.Fatal("failed to start smtp server", err)
+	}
+
+	log.Info(fmt.Sprintf("smtp server is running on %s", server.Addr()))
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+
+	<-stop
+
+	if err := server.Close(); err != nil {
+		log.Error("smtp server shutdown failed", err)
+	}
+}
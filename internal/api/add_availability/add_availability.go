@@ -20,17 +20,17 @@ type request struct {
 	RoomCount int             `json:"room_count"`
 }
 
-type BookingService interface {
+type inventoryService interface {
 	AddRoomAvailability(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error
 }
 
 type Handler struct {
-	booking BookingService
+	inventory inventoryService
 }
 
-func NewHandler(bs BookingService) *Handler {
+func NewHandler(inventoryService inventoryService) *Handler {
 	return &Handler{
-		booking: bs,
+		inventory: inventoryService,
 	}
 }
 
@@ -44,12 +44,22 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.booking.AddRoomAvailability(ctx, req.HotelID, req.RoomType, req.Date.Time, req.RoomCount); err != nil {
+	if err := h.inventory.AddRoomAvailability(ctx, req.HotelID, req.RoomType, req.Date.Time, req.RoomCount); err != nil {
 		if errors.Is(err, domain.ErrHotelNotFound) {
 			http_helpers.SendError(w, http.StatusBadRequest, "invalid hotel id", http_helpers.ErrorTypeValidationError)
 			return
 		}
 
+		if errors.Is(err, domain.ErrRoomTypeNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "invalid room type", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidAvailability) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
 		log.Error("failed to add availability", err)
 		http_helpers.SendError(w, http.StatusInternalServerError, err.Error(), http_helpers.ErrorTypeInternalError)
 		return
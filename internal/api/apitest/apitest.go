@@ -0,0 +1,60 @@
+// Package apitest checks the responses of the handlers in their tests.
+package apitest
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"applicationDesignTest/internal/api/http_helpers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// AssertSuccess checks that the handler answered with the status code and the data. The data is compared
+// by its JSON, so it's the value the service returned to the handler.
+func AssertSuccess(t *testing.T, rec *httptest.ResponseRecorder, statusCode int, data any) {
+	t.Helper()
+
+	assert.Equal(t, statusCode, rec.Code)
+
+	var resp struct {
+		Status http_helpers.HttpStatus `json:"status"`
+		Data   json.RawMessage         `json:"data"`
+	}
+
+	if !assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp), "response isn't JSON") {
+		return
+	}
+
+	assert.Equal(t, http_helpers.StatusSuccess, resp.Status)
+
+	if data == nil {
+		assert.Empty(t, resp.Data)
+		return
+	}
+
+	expected, err := json.Marshal(data)
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, string(expected), string(resp.Data))
+	}
+}
+
+// AssertError checks that the handler answered with the status code and the error of the type and message.
+func AssertError(t *testing.T, rec *httptest.ResponseRecorder, statusCode int, errorType http_helpers.ErrorType, message string) {
+	t.Helper()
+
+	assert.Equal(t, statusCode, rec.Code)
+
+	var resp http_helpers.ErrorResponse
+
+	if !assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp), "response isn't JSON") {
+		return
+	}
+
+	assert.Equal(t, http_helpers.ErrorResponse{
+		Status:  http_helpers.StatusError,
+		Error:   errorType,
+		Message: message,
+	}, resp)
+}
@@ -0,0 +1,114 @@
+package cancel_order
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/cancel_order/mocks"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockbookingService(ctrl)
+
+	r := chi.NewRouter()
+	r.Post("/orders/{orderNumber}/cancel", NewHandler(mockBookingService).Handle)
+
+	cancelledOrder := &domain.Order{
+		ID:     "order-1",
+		Number: 7,
+		Status: domain.OrderStatusCancelled,
+		Total:  domain.Money{Amount: 1000, Currency: "RUB"},
+		Cancellation: &domain.CancellationCharge{
+			Penalty: domain.Money{Amount: 200, Currency: "RUB"},
+			Refund:  domain.Money{Amount: 800, Currency: "RUB"},
+		},
+	}
+
+	transitionErr := &domain.StatusTransitionError{From: domain.OrderStatusCheckedIn, To: domain.OrderStatusCancelled}
+
+	tests := []struct {
+		name            string
+		orderNumber     string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "order number isn't a number",
+			orderNumber:     "abc",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid order number",
+		},
+		{
+			name:        "order is cancelled",
+			orderNumber: "7",
+			mockSetup: func() {
+				mockBookingService.EXPECT().CancelOrder(gomock.Any(), domain.OrderNumber(7)).Return(cancelledOrder, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   cancelledOrder,
+		},
+		{
+			name:        "order not found",
+			orderNumber: "7",
+			mockSetup: func() {
+				mockBookingService.EXPECT().CancelOrder(gomock.Any(), domain.OrderNumber(7)).Return(nil, domain.ErrOrderNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such order doesn't exist",
+		},
+		{
+			name:        "order can't be cancelled in its status",
+			orderNumber: "7",
+			mockSetup: func() {
+				mockBookingService.EXPECT().CancelOrder(gomock.Any(), domain.OrderNumber(7)).Return(nil, transitionErr)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: transitionErr.Error(),
+		},
+		{
+			name:        "unexpected error isn't disclosed",
+			orderNumber: "7",
+			mockSetup: func() {
+				mockBookingService.EXPECT().CancelOrder(gomock.Any(), domain.OrderNumber(7)).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to cancel order",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPost, "/orders/"+tt.orderNumber+"/cancel", nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cancel_order.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockbookingService is a mock of bookingService interface.
+type MockbookingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockbookingServiceMockRecorder
+}
+
+// MockbookingServiceMockRecorder is the mock recorder for MockbookingService.
+type MockbookingServiceMockRecorder struct {
+	mock *MockbookingService
+}
+
+// NewMockbookingService creates a new mock instance.
+func NewMockbookingService(ctrl *gomock.Controller) *MockbookingService {
+	mock := &MockbookingService{ctrl: ctrl}
+	mock.recorder = &MockbookingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbookingService) EXPECT() *MockbookingServiceMockRecorder {
+	return m.recorder
+}
+
+// CancelOrder mocks base method.
+func (m *MockbookingService) CancelOrder(ctx context.Context, orderNumber domain.OrderNumber) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", ctx, orderNumber)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockbookingServiceMockRecorder) CancelOrder(ctx, orderNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockbookingService)(nil).CancelOrder), ctx, orderNumber)
+}
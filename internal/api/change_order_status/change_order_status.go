@@ -0,0 +1,73 @@
+package change_order_status
+
+//go:generate mockgen -source=change_order_status.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type request struct {
+	Status domain.OrderStatus `json:"status"`
+}
+
+type bookingService interface {
+	ChangeOrderStatus(ctx context.Context, orderNumber domain.OrderNumber, status domain.OrderStatus) (*domain.Order, error)
+}
+
+type Handler struct {
+	booking bookingService
+}
+
+func NewHandler(bookingService bookingService) *Handler {
+	return &Handler{
+		booking: bookingService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orderNumber, err := strconv.Atoi(chi.URLParam(r, "orderNumber"))
+	if err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid order number", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	var req request
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid input", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	order, err := h.booking.ChangeOrderStatus(ctx, domain.OrderNumber(orderNumber), req.Status)
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "such order doesn't exist", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidStatusTransition) || errors.Is(err, domain.ErrOutsideStayDates) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to change order status", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to change order status", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, order)
+
+	log.WithField("order", order).Info("order status changed")
+}
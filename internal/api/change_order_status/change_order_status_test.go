@@ -0,0 +1,148 @@
+package change_order_status
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/change_order_status/mocks"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockbookingService(ctrl)
+
+	r := chi.NewRouter()
+	r.Put("/orders/{orderNumber}/status", NewHandler(mockBookingService).Handle)
+
+	checkedInAt := time.Date(2025, 1, 10, 14, 0, 0, 0, time.UTC)
+
+	order := &domain.Order{
+		ID:          "order-1",
+		Number:      7,
+		UserID:      1,
+		Status:      domain.OrderStatusCheckedIn,
+		CheckedInAt: &checkedInAt,
+		Total:       domain.Money{Amount: 6000, Currency: "RUB"},
+	}
+
+	transitionErr := &domain.StatusTransitionError{From: domain.OrderStatusCancelled, To: domain.OrderStatusCheckedIn}
+
+	tests := []struct {
+		name            string
+		orderNumber     string
+		body            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "order number isn't a number",
+			orderNumber:     "abc",
+			body:            `{"status": "checked_in"}`,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid order number",
+		},
+		{
+			name:            "malformed body",
+			orderNumber:     "7",
+			body:            `{"status": `,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid input",
+		},
+		{
+			name:        "status is changed",
+			orderNumber: "7",
+			body:        `{"status": "checked_in"}`,
+			mockSetup: func() {
+				mockBookingService.EXPECT().ChangeOrderStatus(gomock.Any(), domain.OrderNumber(7), domain.OrderStatusCheckedIn).
+					Return(order, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   order,
+		},
+		{
+			name:        "order not found",
+			orderNumber: "7",
+			body:        `{"status": "checked_in"}`,
+			mockSetup: func() {
+				mockBookingService.EXPECT().ChangeOrderStatus(gomock.Any(), domain.OrderNumber(7), domain.OrderStatusCheckedIn).
+					Return(nil, domain.ErrOrderNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such order doesn't exist",
+		},
+		{
+			name:        "transition isn't allowed",
+			orderNumber: "7",
+			body:        `{"status": "checked_in"}`,
+			mockSetup: func() {
+				mockBookingService.EXPECT().ChangeOrderStatus(gomock.Any(), domain.OrderNumber(7), domain.OrderStatusCheckedIn).
+					Return(nil, transitionErr)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: transitionErr.Error(),
+		},
+		{
+			name:        "check-in outside of the stay dates",
+			orderNumber: "7",
+			body:        `{"status": "checked_in"}`,
+			mockSetup: func() {
+				mockBookingService.EXPECT().ChangeOrderStatus(gomock.Any(), domain.OrderNumber(7), domain.OrderStatusCheckedIn).
+					Return(nil, fmt.Errorf("%w: stay starts on 2025-01-10", domain.ErrOutsideStayDates))
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "outside of the stay dates: stay starts on 2025-01-10",
+		},
+		{
+			name:        "unexpected error isn't disclosed",
+			orderNumber: "7",
+			body:        `{"status": "checked_in"}`,
+			mockSetup: func() {
+				mockBookingService.EXPECT().ChangeOrderStatus(gomock.Any(), domain.OrderNumber(7), domain.OrderStatusCheckedIn).
+					Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to change order status",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPut, "/orders/"+tt.orderNumber+"/status", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: change_order_status.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockbookingService is a mock of bookingService interface.
+type MockbookingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockbookingServiceMockRecorder
+}
+
+// MockbookingServiceMockRecorder is the mock recorder for MockbookingService.
+type MockbookingServiceMockRecorder struct {
+	mock *MockbookingService
+}
+
+// NewMockbookingService creates a new mock instance.
+func NewMockbookingService(ctrl *gomock.Controller) *MockbookingService {
+	mock := &MockbookingService{ctrl: ctrl}
+	mock.recorder = &MockbookingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbookingService) EXPECT() *MockbookingServiceMockRecorder {
+	return m.recorder
+}
+
+// ChangeOrderStatus mocks base method.
+func (m *MockbookingService) ChangeOrderStatus(ctx context.Context, orderNumber domain.OrderNumber, status domain.OrderStatus) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeOrderStatus", ctx, orderNumber, status)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeOrderStatus indicates an expected call of ChangeOrderStatus.
+func (mr *MockbookingServiceMockRecorder) ChangeOrderStatus(ctx, orderNumber, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeOrderStatus", reflect.TypeOf((*MockbookingService)(nil).ChangeOrderStatus), ctx, orderNumber, status)
+}
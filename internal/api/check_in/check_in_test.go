@@ -0,0 +1,130 @@
+package check_in
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/check_in/mocks"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockbookingService(ctrl)
+
+	r := chi.NewRouter()
+	r.Post("/orders/{orderNumber}/check-in", NewHandler(mockBookingService).Handle)
+
+	checkedInAt := time.Date(2025, 1, 10, 14, 0, 0, 0, time.UTC)
+
+	order := &domain.Order{
+		ID:          "order-1",
+		Number:      1,
+		Status:      domain.OrderStatusCheckedIn,
+		CreatedAt:   time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		CheckedInAt: &checkedInAt,
+		Bookings: []domain.Booking{
+			{HotelID: 1, RoomType: domain.RoomTypeLux, From: date.Date(2025, 1, 10), To: date.Date(2025, 1, 11), RoomCount: 1},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		orderNumber     string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "order number isn't a number",
+			orderNumber:     "abc",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid order number",
+		},
+		{
+			name:        "guest is checked in",
+			orderNumber: "1",
+			mockSetup: func() {
+				mockBookingService.EXPECT().CheckIn(gomock.Any(), domain.OrderNumber(1)).Return(order, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   order,
+		},
+		{
+			name:        "order not found",
+			orderNumber: "1",
+			mockSetup: func() {
+				mockBookingService.EXPECT().CheckIn(gomock.Any(), domain.OrderNumber(1)).Return(nil, domain.ErrOrderNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such order doesn't exist",
+		},
+		{
+			name:        "order isn't confirmed",
+			orderNumber: "1",
+			mockSetup: func() {
+				mockBookingService.EXPECT().CheckIn(gomock.Any(), domain.OrderNumber(1)).
+					Return(nil, &domain.StatusTransitionError{From: domain.OrderStatusPending, To: domain.OrderStatusCheckedIn})
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid order status transition: from 'pending' to 'checked_in'",
+		},
+		{
+			name:        "check-in before the stay",
+			orderNumber: "1",
+			mockSetup: func() {
+				mockBookingService.EXPECT().CheckIn(gomock.Any(), domain.OrderNumber(1)).
+					Return(nil, fmt.Errorf("%w: check-in is possible from 2025-01-10 to 2025-01-11 hotel time",
+						domain.ErrOutsideStayDates))
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "outside of the stay dates: check-in is possible from 2025-01-10 to 2025-01-11 hotel time",
+		},
+		{
+			name:        "unexpected error isn't disclosed",
+			orderNumber: "1",
+			mockSetup: func() {
+				mockBookingService.EXPECT().CheckIn(gomock.Any(), domain.OrderNumber(1)).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to check in",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPost, "/orders/"+tt.orderNumber+"/check-in", nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
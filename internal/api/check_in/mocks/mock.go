@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: check_in.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockbookingService is a mock of bookingService interface.
+type MockbookingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockbookingServiceMockRecorder
+}
+
+// MockbookingServiceMockRecorder is the mock recorder for MockbookingService.
+type MockbookingServiceMockRecorder struct {
+	mock *MockbookingService
+}
+
+// NewMockbookingService creates a new mock instance.
+func NewMockbookingService(ctrl *gomock.Controller) *MockbookingService {
+	mock := &MockbookingService{ctrl: ctrl}
+	mock.recorder = &MockbookingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbookingService) EXPECT() *MockbookingServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckIn mocks base method.
+func (m *MockbookingService) CheckIn(ctx context.Context, orderNumber domain.OrderNumber) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckIn", ctx, orderNumber)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckIn indicates an expected call of CheckIn.
+func (mr *MockbookingServiceMockRecorder) CheckIn(ctx, orderNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIn", reflect.TypeOf((*MockbookingService)(nil).CheckIn), ctx, orderNumber)
+}
@@ -0,0 +1,68 @@
+package check_out
+
+//go:generate mockgen -source=check_out.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type response struct {
+	Order    *domain.Order    `json:"order"`
+	Released []domain.Booking `json:"released"`
+}
+
+type bookingService interface {
+	CheckOut(ctx context.Context, orderNumber domain.OrderNumber) (*domain.Order, []domain.Booking, error)
+}
+
+type Handler struct {
+	booking bookingService
+}
+
+func NewHandler(bookingService bookingService) *Handler {
+	return &Handler{
+		booking: bookingService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	orderNumber, err := strconv.Atoi(chi.URLParam(r, "orderNumber"))
+	if err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid order number", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	order, released, err := h.booking.CheckOut(r.Context(), domain.OrderNumber(orderNumber))
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "such order doesn't exist", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidStatusTransition) || errors.Is(err, domain.ErrOutsideStayDates) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to check out", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to check out", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	if released == nil {
+		released = []domain.Booking{}
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, response{Order: order, Released: released})
+
+	log.WithField("order", order).Info("order checked out")
+}
@@ -0,0 +1,145 @@
+package check_out
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/check_out/mocks"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockbookingService(ctrl)
+
+	r := chi.NewRouter()
+	r.Post("/orders/{orderNumber}/check-out", NewHandler(mockBookingService).Handle)
+
+	checkedOutAt := time.Date(2025, 1, 11, 11, 0, 0, 0, time.UTC)
+
+	order := &domain.Order{
+		ID:           "order-1",
+		Number:       1,
+		Status:       domain.OrderStatusCheckedOut,
+		CreatedAt:    time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		CheckedOutAt: &checkedOutAt,
+		Bookings: []domain.Booking{
+			{HotelID: 1, RoomType: domain.RoomTypeLux, From: date.Date(2025, 1, 10), To: date.Date(2025, 1, 10), RoomCount: 1},
+		},
+	}
+
+	released := []domain.Booking{
+		{HotelID: 1, RoomType: domain.RoomTypeLux, From: date.Date(2025, 1, 11), To: date.Date(2025, 1, 12), RoomCount: 1},
+	}
+
+	tests := []struct {
+		name            string
+		orderNumber     string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "order number isn't a number",
+			orderNumber:     "abc",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid order number",
+		},
+		{
+			name:        "guest is checked out on the departure day",
+			orderNumber: "1",
+			mockSetup: func() {
+				mockBookingService.EXPECT().CheckOut(gomock.Any(), domain.OrderNumber(1)).Return(order, nil, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   response{Order: order, Released: []domain.Booking{}},
+		},
+		{
+			name:        "early check-out releases the unused nights",
+			orderNumber: "1",
+			mockSetup: func() {
+				mockBookingService.EXPECT().CheckOut(gomock.Any(), domain.OrderNumber(1)).Return(order, released, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   response{Order: order, Released: released},
+		},
+		{
+			name:        "order not found",
+			orderNumber: "1",
+			mockSetup: func() {
+				mockBookingService.EXPECT().CheckOut(gomock.Any(), domain.OrderNumber(1)).
+					Return(nil, nil, domain.ErrOrderNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such order doesn't exist",
+		},
+		{
+			name:        "order isn't checked in",
+			orderNumber: "1",
+			mockSetup: func() {
+				mockBookingService.EXPECT().CheckOut(gomock.Any(), domain.OrderNumber(1)).
+					Return(nil, nil, &domain.StatusTransitionError{From: domain.OrderStatusConfirmed, To: domain.OrderStatusCheckedOut})
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid order status transition: from 'confirmed' to 'checked_out'",
+		},
+		{
+			name:        "check-out after the departure day",
+			orderNumber: "1",
+			mockSetup: func() {
+				mockBookingService.EXPECT().CheckOut(gomock.Any(), domain.OrderNumber(1)).
+					Return(nil, nil, fmt.Errorf("%w: check-out is possible from 2025-01-10 to 2025-01-13 hotel time",
+						domain.ErrOutsideStayDates))
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "outside of the stay dates: check-out is possible from 2025-01-10 to 2025-01-13 hotel time",
+		},
+		{
+			name:        "unexpected error isn't disclosed",
+			orderNumber: "1",
+			mockSetup: func() {
+				mockBookingService.EXPECT().CheckOut(gomock.Any(), domain.OrderNumber(1)).
+					Return(nil, nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to check out",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPost, "/orders/"+tt.orderNumber+"/check-out", nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
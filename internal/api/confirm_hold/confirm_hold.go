@@ -0,0 +1,87 @@
+package confirm_hold
+
+//go:generate mockgen -source=confirm_hold.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// request is the optional body with the card the order is paid with.
+type request struct {
+	PaymentToken domain.PaymentToken `json:"payment_token"`
+}
+
+type holdService interface {
+	ConfirmHold(ctx context.Context, token domain.HoldToken, paymentToken domain.PaymentToken) (*domain.Order, error)
+}
+
+type Handler struct {
+	hold holdService
+}
+
+func NewHandler(holdService holdService) *Handler {
+	return &Handler{
+		hold: holdService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	token := domain.HoldToken(chi.URLParam(r, "token"))
+
+	var req request
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		log.Warning(fmt.Sprintf("failed to decode request: %s", err.Error()))
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid input", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	order, err := h.hold.ConfirmHold(ctx, token, req.PaymentToken)
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderAlreadyExists) {
+			http_helpers.SendSuccess(w, http.StatusOK, order)
+			return
+		}
+
+		if errors.Is(err, domain.ErrHoldNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "such hold doesn't exist", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrHoldExpired) {
+			http_helpers.SendError(w, http.StatusBadRequest, "hold expired", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrPaymentDeclined) {
+			http_helpers.SendError(w, http.StatusPaymentRequired, err.Error(), http_helpers.ErrorTypePaymentError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrPaymentTimeout) {
+			http_helpers.SendError(w, http.StatusGatewayTimeout, err.Error(), http_helpers.ErrorTypePaymentError)
+			return
+		}
+
+		log.Error("failed to confirm hold", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to confirm hold", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusCreated, order)
+
+	log.WithField("order", order).Info("hold confirmed")
+}
@@ -0,0 +1,156 @@
+package confirm_hold
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/confirm_hold/mocks"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHoldService := mocks.NewMockholdService(ctrl)
+
+	r := chi.NewRouter()
+	r.Post("/holds/{token}/confirm", NewHandler(mockHoldService).Handle)
+
+	order := &domain.Order{
+		ID:     "hold-1",
+		Number: 7,
+		UserID: 1,
+		Status: domain.OrderStatusConfirmed,
+		Total:  domain.Money{Amount: 3000, Currency: "RUB"},
+	}
+
+	tests := []struct {
+		name            string
+		body            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "malformed body",
+			body:            `{"payment_token": `,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid input",
+		},
+		{
+			name: "hold is confirmed with the card",
+			body: `{"payment_token": "tok_visa"}`,
+			mockSetup: func() {
+				mockHoldService.EXPECT().ConfirmHold(gomock.Any(), domain.HoldToken("hold-1"), domain.PaymentToken("tok_visa")).
+					Return(order, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedData:   order,
+		},
+		{
+			name: "hold is confirmed without a body",
+			mockSetup: func() {
+				mockHoldService.EXPECT().ConfirmHold(gomock.Any(), domain.HoldToken("hold-1"), domain.PaymentToken("")).
+					Return(order, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedData:   order,
+		},
+		{
+			name: "retried confirmation returns the stored order",
+			body: `{"payment_token": "tok_visa"}`,
+			mockSetup: func() {
+				mockHoldService.EXPECT().ConfirmHold(gomock.Any(), domain.HoldToken("hold-1"), domain.PaymentToken("tok_visa")).
+					Return(order, domain.ErrOrderAlreadyExists)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   order,
+		},
+		{
+			name: "hold not found",
+			body: `{"payment_token": "tok_visa"}`,
+			mockSetup: func() {
+				mockHoldService.EXPECT().ConfirmHold(gomock.Any(), domain.HoldToken("hold-1"), domain.PaymentToken("tok_visa")).
+					Return(nil, domain.ErrHoldNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such hold doesn't exist",
+		},
+		{
+			name: "hold expired",
+			body: `{"payment_token": "tok_visa"}`,
+			mockSetup: func() {
+				mockHoldService.EXPECT().ConfirmHold(gomock.Any(), domain.HoldToken("hold-1"), domain.PaymentToken("tok_visa")).
+					Return(nil, domain.ErrHoldExpired)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "hold expired",
+		},
+		{
+			name: "payment declined",
+			body: `{"payment_token": "tok_visa"}`,
+			mockSetup: func() {
+				mockHoldService.EXPECT().ConfirmHold(gomock.Any(), domain.HoldToken("hold-1"), domain.PaymentToken("tok_visa")).
+					Return(nil, fmt.Errorf("%w: insufficient funds", domain.ErrPaymentDeclined))
+			},
+			expectedStatus:  http.StatusPaymentRequired,
+			expectedError:   http_helpers.ErrorTypePaymentError,
+			expectedMessage: "payment declined: insufficient funds",
+		},
+		{
+			name: "payment gateway timeout",
+			body: `{"payment_token": "tok_visa"}`,
+			mockSetup: func() {
+				mockHoldService.EXPECT().ConfirmHold(gomock.Any(), domain.HoldToken("hold-1"), domain.PaymentToken("tok_visa")).
+					Return(nil, domain.ErrPaymentTimeout)
+			},
+			expectedStatus:  http.StatusGatewayTimeout,
+			expectedError:   http_helpers.ErrorTypePaymentError,
+			expectedMessage: "payment gateway timeout",
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			body: `{"payment_token": "tok_visa"}`,
+			mockSetup: func() {
+				mockHoldService.EXPECT().ConfirmHold(gomock.Any(), domain.HoldToken("hold-1"), domain.PaymentToken("tok_visa")).
+					Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to confirm hold",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPost, "/holds/hold-1/confirm", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: confirm_hold.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockholdService is a mock of holdService interface.
+type MockholdService struct {
+	ctrl     *gomock.Controller
+	recorder *MockholdServiceMockRecorder
+}
+
+// MockholdServiceMockRecorder is the mock recorder for MockholdService.
+type MockholdServiceMockRecorder struct {
+	mock *MockholdService
+}
+
+// NewMockholdService creates a new mock instance.
+func NewMockholdService(ctrl *gomock.Controller) *MockholdService {
+	mock := &MockholdService{ctrl: ctrl}
+	mock.recorder = &MockholdServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockholdService) EXPECT() *MockholdServiceMockRecorder {
+	return m.recorder
+}
+
+// ConfirmHold mocks base method.
+func (m *MockholdService) ConfirmHold(ctx context.Context, token domain.HoldToken, paymentToken domain.PaymentToken) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmHold", ctx, token, paymentToken)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmHold indicates an expected call of ConfirmHold.
+func (mr *MockholdServiceMockRecorder) ConfirmHold(ctx, token, paymentToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmHold", reflect.TypeOf((*MockholdService)(nil).ConfirmHold), ctx, token, paymentToken)
+}
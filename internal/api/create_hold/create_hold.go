@@ -0,0 +1,151 @@
+package create_hold
+
+//go:generate mockgen -source=create_hold.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+	"applicationDesignTest/pkg/log"
+)
+
+type request struct {
+	UserID   domain.UserID `json:"user_id"`
+	Bookings []booking     `json:"booking"`
+}
+
+type booking struct {
+	HotelID   domain.HotelID         `json:"hotel_id"`
+	RoomType  domain.RoomType        `json:"room_type"`
+	From      date.CustomDate        `json:"from"`
+	To        date.CustomDate        `json:"to"`
+	RoomCount int                    `json:"room_count"`
+	Rooms     []domain.RoomOccupancy `json:"rooms"`
+}
+
+type holdService interface {
+	CreateHold(ctx context.Context, userID domain.UserID, bookings []domain.Booking) (*domain.Hold, error)
+}
+
+// roomTypeService is the room type catalog of the hotels the bookings are validated against.
+type roomTypeService interface {
+	GetRoomType(ctx context.Context, hotelID domain.HotelID, code domain.RoomType) (*domain.HotelRoomType, error)
+}
+
+type Handler struct {
+	hold    holdService
+	catalog roomTypeService
+}
+
+func NewHandler(holdService holdService, roomTypeService roomTypeService) *Handler {
+	return &Handler{
+		hold:    holdService,
+		catalog: roomTypeService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req request
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warning(fmt.Sprintf("failed to decode request: %s", err.Error()))
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid input", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	if len(req.Bookings) == 0 {
+		http_helpers.SendError(w, http.StatusBadRequest, "booking is empty", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	bookings := make([]domain.Booking, 0, len(req.Bookings))
+
+	for _, book := range req.Bookings {
+		if book.To.Before(book.From.Time) {
+			http_helpers.SendError(w, http.StatusBadRequest,
+				fmt.Sprintf("invalid date range for hotel id %v", book.HotelID),
+				http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		roomType, err := h.catalog.GetRoomType(ctx, book.HotelID, book.RoomType)
+		if err != nil {
+			if errors.Is(err, domain.ErrHotelNotFound) {
+				http_helpers.SendError(w, http.StatusBadRequest,
+					fmt.Sprintf("invalid hotel id %v", book.HotelID), http_helpers.ErrorTypeValidationError)
+				return
+			}
+
+			if errors.Is(err, domain.ErrRoomTypeNotFound) {
+				http_helpers.SendError(w, http.StatusBadRequest,
+					fmt.Sprintf("invalid room_type '%s' for hotel id %v", book.RoomType, book.HotelID),
+					http_helpers.ErrorTypeValidationError)
+				return
+			}
+
+			log.Error("failed to get room type", err)
+			http_helpers.SendError(w, http.StatusInternalServerError, "failed to create hold", http_helpers.ErrorTypeInternalError)
+			return
+		}
+
+		newBooking := domain.Booking{
+			HotelID:   book.HotelID,
+			RoomType:  book.RoomType,
+			From:      book.From.Time,
+			To:        book.To.Time,
+			RoomCount: book.RoomCount,
+			Rooms:     book.Rooms,
+		}
+
+		if err := newBooking.Validate(); err != nil {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if err := newBooking.ValidateOccupancy(*roomType); err != nil {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		bookings = append(bookings, newBooking)
+	}
+
+	hold, err := h.hold.CreateHold(ctx, req.UserID, bookings)
+	if err != nil {
+		if errors.Is(err, domain.ErrHotelNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "invalid hotel id", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrRoomTypeNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "invalid room type", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrRoomsNotAvailable) || errors.Is(err, domain.ErrRestrictionViolated) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrRateNotFound) || errors.Is(err, domain.ErrCurrencyMismatch) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to create hold", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to create hold", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusCreated, hold)
+
+	log.WithField("hold", hold).Info("hold successfully created")
+}
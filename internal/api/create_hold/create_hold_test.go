@@ -0,0 +1,225 @@
+package create_hold
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/create_hold/mocks"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHoldService := mocks.NewMockholdService(ctrl)
+	mockRoomTypeService := mocks.NewMockroomTypeService(ctrl)
+
+	h := NewHandler(mockHoldService, mockRoomTypeService)
+
+	validBody := `{"user_id": 1, "booking": [{"hotel_id": 1, "room_type": "single",
+		"from": "2025-01-10", "to": "2025-01-12", "room_count": 1, "rooms": [{"adults": 1}]}]}`
+
+	roomType := &domain.HotelRoomType{HotelID: 1, Code: domain.RoomTypeSingle, Name: "Single", Capacity: 1}
+
+	bookings := []domain.Booking{{
+		HotelID:   1,
+		RoomType:  domain.RoomTypeSingle,
+		From:      date.Date(2025, 1, 10),
+		To:        date.Date(2025, 1, 12),
+		RoomCount: 1,
+		Rooms:     []domain.RoomOccupancy{{Adults: 1}},
+	}}
+
+	createdAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	hold := &domain.Hold{
+		Token:     "hold-1",
+		UserID:    1,
+		Bookings:  bookings,
+		Total:     domain.Money{Amount: 3000, Currency: "RUB"},
+		CreatedAt: createdAt,
+		ExpiresAt: createdAt.Add(15 * time.Minute),
+	}
+
+	tests := []struct {
+		name            string
+		body            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "malformed body",
+			body:            `{"booking": `,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid input",
+		},
+		{
+			name:            "empty booking",
+			body:            `{"user_id": 1, "booking": []}`,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "booking is empty",
+		},
+		{
+			name: "stay ends before it starts",
+			body: `{"user_id": 1, "booking": [{"hotel_id": 1, "room_type": "single",
+				"from": "2025-01-12", "to": "2025-01-10", "room_count": 1}]}`,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid date range for hotel id 1",
+		},
+		{
+			name: "unknown hotel",
+			body: validBody,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeSingle).
+					Return(nil, domain.ErrHotelNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel id 1",
+		},
+		{
+			name: "unknown room type",
+			body: validBody,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeSingle).
+					Return(nil, domain.ErrRoomTypeNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid room_type 'single' for hotel id 1",
+		},
+		{
+			name: "room type lookup fails",
+			body: validBody,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeSingle).
+					Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to create hold",
+		},
+		{
+			name: "rooms described don't match the room count",
+			body: `{"user_id": 1, "booking": [{"hotel_id": 1, "room_type": "single",
+				"from": "2025-01-10", "to": "2025-01-12", "room_count": 2, "rooms": [{"adults": 1}]}]}`,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeSingle).
+					Return(roomType, nil)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid occupancy: 2 rooms of 'single' booked, but 1 described",
+		},
+		{
+			name: "rooms are held",
+			body: validBody,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeSingle).
+					Return(roomType, nil)
+				mockHoldService.EXPECT().CreateHold(gomock.Any(), domain.UserID(1), bookings).Return(hold, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedData:   hold,
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			body: validBody,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeSingle).
+					Return(roomType, nil)
+				mockHoldService.EXPECT().CreateHold(gomock.Any(), domain.UserID(1), bookings).
+					Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to create hold",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPost, "/holds", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			h.Handle(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
+
+// TestHandler_Handle_Rejected checks how the errors of the hold service are answered.
+func TestHandler_Handle_Rejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHoldService := mocks.NewMockholdService(ctrl)
+	mockRoomTypeService := mocks.NewMockroomTypeService(ctrl)
+
+	h := NewHandler(mockHoldService, mockRoomTypeService)
+
+	body := `{"user_id": 1, "booking": [{"hotel_id": 1, "room_type": "single",
+		"from": "2025-01-10", "to": "2025-01-12", "room_count": 1}]}`
+
+	roomType := &domain.HotelRoomType{HotelID: 1, Code: domain.RoomTypeSingle, Name: "Single", Capacity: 1}
+
+	tests := []struct {
+		err             error
+		expectedMessage string
+	}{
+		{err: domain.ErrHotelNotFound, expectedMessage: "invalid hotel id"},
+		{err: domain.ErrRoomTypeNotFound, expectedMessage: "invalid room type"},
+		{
+			err:             fmt.Errorf("%w: 'single' on 2025-01-11", domain.ErrRoomsNotAvailable),
+			expectedMessage: "rooms not available: 'single' on 2025-01-11",
+		},
+		{
+			err:             fmt.Errorf("%w: minimum stay is 3 nights", domain.ErrRestrictionViolated),
+			expectedMessage: "restriction violated: minimum stay is 3 nights",
+		},
+		{err: domain.ErrRateNotFound, expectedMessage: "rate not found"},
+		{err: domain.ErrCurrencyMismatch, expectedMessage: "currency mismatch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeSingle).
+				Return(roomType, nil)
+			mockHoldService.EXPECT().CreateHold(gomock.Any(), domain.UserID(1), gomock.Any()).Return(nil, tt.err)
+
+			req := httptest.NewRequest(http.MethodPost, "/holds", strings.NewReader(body))
+			rec := httptest.NewRecorder()
+
+			h.Handle(rec, req)
+
+			apitest.AssertError(t, rec, http.StatusBadRequest, http_helpers.ErrorTypeValidationError, tt.expectedMessage)
+		})
+	}
+}
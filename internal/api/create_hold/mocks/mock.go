@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: create_hold.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockholdService is a mock of holdService interface.
+type MockholdService struct {
+	ctrl     *gomock.Controller
+	recorder *MockholdServiceMockRecorder
+}
+
+// MockholdServiceMockRecorder is the mock recorder for MockholdService.
+type MockholdServiceMockRecorder struct {
+	mock *MockholdService
+}
+
+// NewMockholdService creates a new mock instance.
+func NewMockholdService(ctrl *gomock.Controller) *MockholdService {
+	mock := &MockholdService{ctrl: ctrl}
+	mock.recorder = &MockholdServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockholdService) EXPECT() *MockholdServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateHold mocks base method.
+func (m *MockholdService) CreateHold(ctx context.Context, userID domain.UserID, bookings []domain.Booking) (*domain.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", ctx, userID, bookings)
+	ret0, _ := ret[0].(*domain.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockholdServiceMockRecorder) CreateHold(ctx, userID, bookings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockholdService)(nil).CreateHold), ctx, userID, bookings)
+}
+
+// MockroomTypeService is a mock of roomTypeService interface.
+type MockroomTypeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockroomTypeServiceMockRecorder
+}
+
+// MockroomTypeServiceMockRecorder is the mock recorder for MockroomTypeService.
+type MockroomTypeServiceMockRecorder struct {
+	mock *MockroomTypeService
+}
+
+// NewMockroomTypeService creates a new mock instance.
+func NewMockroomTypeService(ctrl *gomock.Controller) *MockroomTypeService {
+	mock := &MockroomTypeService{ctrl: ctrl}
+	mock.recorder = &MockroomTypeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockroomTypeService) EXPECT() *MockroomTypeServiceMockRecorder {
+	return m.recorder
+}
+
+// GetRoomType mocks base method.
+func (m *MockroomTypeService) GetRoomType(ctx context.Context, hotelID domain.HotelID, code domain.RoomType) (*domain.HotelRoomType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoomType", ctx, hotelID, code)
+	ret0, _ := ret[0].(*domain.HotelRoomType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoomType indicates an expected call of GetRoomType.
+func (mr *MockroomTypeServiceMockRecorder) GetRoomType(ctx, hotelID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoomType", reflect.TypeOf((*MockroomTypeService)(nil).GetRoomType), ctx, hotelID, code)
+}
@@ -0,0 +1,75 @@
+package create_hotel
+
+//go:generate mockgen -source=create_hotel.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+)
+
+type request struct {
+	ID           domain.HotelID `json:"id"`
+	Name         string         `json:"name"`
+	Address      string         `json:"address"`
+	City         string         `json:"city"`
+	Timezone     string         `json:"timezone"`
+	StarRating   int            `json:"star_rating"`
+	CheckInTime  string         `json:"check_in_time"`
+	CheckOutTime string         `json:"check_out_time"`
+	NoShowCutoff string         `json:"no_show_cutoff"`
+}
+
+type hotelService interface {
+	AddHotel(ctx context.Context, hotel domain.Hotel) (*domain.Hotel, error)
+}
+
+type Handler struct {
+	hotels hotelService
+}
+
+func NewHandler(hotelService hotelService) *Handler {
+	return &Handler{
+		hotels: hotelService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	var req request
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warning(fmt.Sprintf("failed to decode request: %s", err.Error()))
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid input", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	hotel, err := h.hotels.AddHotel(r.Context(), domain.Hotel{
+		ID:           req.ID,
+		Name:         req.Name,
+		Address:      req.Address,
+		City:         req.City,
+		Timezone:     req.Timezone,
+		StarRating:   req.StarRating,
+		CheckInTime:  req.CheckInTime,
+		CheckOutTime: req.CheckOutTime,
+		NoShowCutoff: req.NoShowCutoff,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidHotel) || errors.Is(err, domain.ErrHotelAlreadyExists) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to create hotel", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to create hotel", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusCreated, hotel)
+}
@@ -0,0 +1,117 @@
+package create_hotel
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/create_hotel/mocks"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelService := mocks.NewMockhotelService(ctrl)
+
+	h := NewHandler(mockHotelService)
+
+	validBody := `{"id": 3, "name": "Grand", "address": "Tverskaya 1", "city": "Moscow", "timezone": "Europe/Moscow",
+		"star_rating": 5, "check_in_time": "14:00", "check_out_time": "12:00", "no_show_cutoff": "23:00"}`
+
+	hotel := domain.Hotel{
+		ID:           3,
+		Name:         "Grand",
+		Address:      "Tverskaya 1",
+		City:         "Moscow",
+		Timezone:     "Europe/Moscow",
+		StarRating:   5,
+		CheckInTime:  "14:00",
+		CheckOutTime: "12:00",
+		NoShowCutoff: "23:00",
+	}
+
+	tests := []struct {
+		name            string
+		body            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "malformed body",
+			body:            `{"name": `,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid input",
+		},
+		{
+			name: "hotel is created",
+			body: validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().AddHotel(gomock.Any(), hotel).Return(&hotel, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedData:   hotel,
+		},
+		{
+			name: "invalid hotel",
+			body: validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().AddHotel(gomock.Any(), hotel).
+					Return(nil, fmt.Errorf("%w: unknown timezone 'Europe/Moscow'", domain.ErrInvalidHotel))
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel: unknown timezone 'Europe/Moscow'",
+		},
+		{
+			name: "hotel id is taken",
+			body: validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().AddHotel(gomock.Any(), hotel).Return(nil, domain.ErrHotelAlreadyExists)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "hotel already exists",
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			body: validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().AddHotel(gomock.Any(), hotel).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to create hotel",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPost, "/hotels", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			h.Handle(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
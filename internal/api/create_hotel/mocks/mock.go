@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: create_hotel.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockhotelService is a mock of hotelService interface.
+type MockhotelService struct {
+	ctrl     *gomock.Controller
+	recorder *MockhotelServiceMockRecorder
+}
+
+// MockhotelServiceMockRecorder is the mock recorder for MockhotelService.
+type MockhotelServiceMockRecorder struct {
+	mock *MockhotelService
+}
+
+// NewMockhotelService creates a new mock instance.
+func NewMockhotelService(ctrl *gomock.Controller) *MockhotelService {
+	mock := &MockhotelService{ctrl: ctrl}
+	mock.recorder = &MockhotelServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhotelService) EXPECT() *MockhotelServiceMockRecorder {
+	return m.recorder
+}
+
+// AddHotel mocks base method.
+func (m *MockhotelService) AddHotel(ctx context.Context, hotel domain.Hotel) (*domain.Hotel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddHotel", ctx, hotel)
+	ret0, _ := ret[0].(*domain.Hotel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddHotel indicates an expected call of AddHotel.
+func (mr *MockhotelServiceMockRecorder) AddHotel(ctx, hotel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHotel", reflect.TypeOf((*MockhotelService)(nil).AddHotel), ctx, hotel)
+}
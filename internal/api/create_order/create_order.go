@@ -1,5 +1,7 @@
 package create_order
 
+//go:generate mockgen -source=create_order.go -destination=mocks/mock.go -package=mocks
+
 import (
 	"context"
 	"encoding/json"
@@ -14,30 +16,41 @@ import (
 )
 
 type request struct {
-	ID       domain.OrderID `json:"id"`
-	UserID   domain.UserID  `json:"user_id"`
-	Bookings []booking      `json:"booking"`
+	ID            domain.OrderID      `json:"id"`
+	UserID        domain.UserID       `json:"user_id"`
+	PromoCode     domain.PromoCode    `json:"promo_code"`
+	LoyaltyPoints int64               `json:"loyalty_points"`
+	PaymentToken  domain.PaymentToken `json:"payment_token"`
+	Bookings      []booking           `json:"booking"`
 }
 
 type booking struct {
-	HotelID   domain.HotelID  `json:"hotel_id"`
-	RoomType  domain.RoomType `json:"room_type"`
-	From      date.CustomDate `json:"from"`
-	To        date.CustomDate `json:"to"`
-	RoomCount int             `json:"room_count"`
+	HotelID   domain.HotelID         `json:"hotel_id"`
+	RoomType  domain.RoomType        `json:"room_type"`
+	From      date.CustomDate        `json:"from"`
+	To        date.CustomDate        `json:"to"`
+	RoomCount int                    `json:"room_count"`
+	Rooms     []domain.RoomOccupancy `json:"rooms"`
 }
 
 type bookingService interface {
 	CreateOrder(ctx context.Context, order domain.Order) (*domain.Order, error)
 }
 
+// roomTypeService is the room type catalog of the hotels the bookings are validated against.
+type roomTypeService interface {
+	GetRoomType(ctx context.Context, hotelID domain.HotelID, code domain.RoomType) (*domain.HotelRoomType, error)
+}
+
 type Handler struct {
 	booking bookingService
+	catalog roomTypeService
 }
 
-func NewHandler(bookingService bookingService) *Handler {
+func NewHandler(bookingService bookingService, roomTypeService roomTypeService) *Handler {
 	return &Handler{
 		booking: bookingService,
+		catalog: roomTypeService,
 	}
 }
 
@@ -57,9 +70,17 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.LoyaltyPoints < 0 {
+		http_helpers.SendError(w, http.StatusBadRequest, "loyalty points can't be negative", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
 	order := domain.Order{
-		ID:     req.ID,
-		UserID: req.UserID,
+		ID:            req.ID,
+		UserID:        req.UserID,
+		PromoCode:     req.PromoCode,
+		LoyaltyPoints: req.LoyaltyPoints,
+		PaymentToken:  req.PaymentToken,
 	}
 
 	for _, book := range req.Bookings {
@@ -70,10 +91,23 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		if !domain.RoomTypes.Contains(book.RoomType) {
-			http_helpers.SendError(w, http.StatusBadRequest,
-				fmt.Sprintf("invalid room_type '%s' for hotel id %v", book.RoomType, book.HotelID),
-				http_helpers.ErrorTypeValidationError)
+		roomType, err := h.catalog.GetRoomType(ctx, book.HotelID, book.RoomType)
+		if err != nil {
+			if errors.Is(err, domain.ErrHotelNotFound) {
+				http_helpers.SendError(w, http.StatusBadRequest,
+					fmt.Sprintf("invalid hotel id %v", book.HotelID), http_helpers.ErrorTypeValidationError)
+				return
+			}
+
+			if errors.Is(err, domain.ErrRoomTypeNotFound) {
+				http_helpers.SendError(w, http.StatusBadRequest,
+					fmt.Sprintf("invalid room_type '%s' for hotel id %v", book.RoomType, book.HotelID),
+					http_helpers.ErrorTypeValidationError)
+				return
+			}
+
+			log.Error("failed to get room type", err)
+			http_helpers.SendError(w, http.StatusInternalServerError, "failed to create order", http_helpers.ErrorTypeInternalError)
 			return
 		}
 
@@ -83,6 +117,17 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 			From:      book.From.Time,
 			To:        book.To.Time,
 			RoomCount: book.RoomCount,
+			Rooms:     book.Rooms,
+		}
+
+		if err := newBooking.Validate(); err != nil {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if err := newBooking.ValidateOccupancy(*roomType); err != nil {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
 		}
 
 		order.Bookings = append(order.Bookings, newBooking)
@@ -91,7 +136,12 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 	createdOrder, err := h.booking.CreateOrder(ctx, order)
 	if err != nil {
 		if errors.Is(err, domain.ErrOrderAlreadyExists) {
-			http_helpers.SendSuccess(w, http.StatusOK, createdOrder)
+			if createdOrder != nil {
+				http_helpers.SendSuccess(w, http.StatusOK, createdOrder)
+				return
+			}
+
+			http_helpers.SendError(w, http.StatusConflict, "order already exists", http_helpers.ErrorTypeValidationError)
 			return
 		}
 
@@ -105,11 +155,37 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		if errors.Is(err, domain.ErrRoomsNotAvailable) {
+		if errors.Is(err, domain.ErrRoomsNotAvailable) || errors.Is(err, domain.ErrRestrictionViolated) {
 			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
 			return
 		}
 
+		if errors.Is(err, domain.ErrRateNotFound) || errors.Is(err, domain.ErrCurrencyMismatch) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrPromoNotFound) || errors.Is(err, domain.ErrPromoNotApplicable) ||
+			errors.Is(err, domain.ErrPromoUsageLimit) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidPoints) || errors.Is(err, domain.ErrInsufficientPoints) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrPaymentDeclined) {
+			http_helpers.SendError(w, http.StatusPaymentRequired, err.Error(), http_helpers.ErrorTypePaymentError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrPaymentTimeout) {
+			http_helpers.SendError(w, http.StatusGatewayTimeout, err.Error(), http_helpers.ErrorTypePaymentError)
+			return
+		}
+
 		log.Error("failed to create order", err)
 		http_helpers.SendError(w, http.StatusInternalServerError, "failed to create order", http_helpers.ErrorTypeInternalError)
 		return
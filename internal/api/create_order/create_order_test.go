@@ -0,0 +1,345 @@
+package create_order
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/create_order/mocks"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockbookingService(ctrl)
+	mockRoomTypeService := mocks.NewMockroomTypeService(ctrl)
+
+	h := NewHandler(mockBookingService, mockRoomTypeService)
+
+	validBody := `{"id": "order-1", "user_id": 1, "promo_code": "SUMMER", "loyalty_points": 100,
+		"payment_token": "tok_visa", "booking": [{"hotel_id": 1, "room_type": "double", "from": "2025-01-10",
+		"to": "2025-01-12", "room_count": 1, "rooms": [{"adults": 2, "guest_names": ["Ivan Petrov"]}]}]}`
+
+	roomType := &domain.HotelRoomType{HotelID: 1, Code: domain.RoomTypeDouble, Name: "Double", Capacity: 2}
+
+	order := domain.Order{
+		ID:            "order-1",
+		UserID:        1,
+		PromoCode:     "SUMMER",
+		LoyaltyPoints: 100,
+		PaymentToken:  "tok_visa",
+		Bookings: []domain.Booking{{
+			HotelID:   1,
+			RoomType:  domain.RoomTypeDouble,
+			From:      date.Date(2025, 1, 10),
+			To:        date.Date(2025, 1, 12),
+			RoomCount: 1,
+			Rooms:     []domain.RoomOccupancy{{Adults: 2, GuestNames: []string{"Ivan Petrov"}}},
+		}},
+	}
+
+	createdOrder := &domain.Order{
+		ID:       "order-1",
+		Number:   7,
+		UserID:   1,
+		Status:   domain.OrderStatusConfirmed,
+		Bookings: order.Bookings,
+		Subtotal: domain.Money{Amount: 6000, Currency: "RUB"},
+		Discounts: []domain.Discount{
+			{Source: domain.DiscountSourcePromo, PromoCode: "SUMMER", Amount: domain.Money{Amount: 600, Currency: "RUB"}},
+		},
+		Total: domain.Money{Amount: 5400, Currency: "RUB"},
+	}
+
+	tests := []struct {
+		name            string
+		body            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "malformed body",
+			body:            `{"booking": `,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid input",
+		},
+		{
+			name:            "empty booking",
+			body:            `{"id": "order-1", "booking": []}`,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "booking is empty",
+		},
+		{
+			name: "negative loyalty points",
+			body: `{"id": "order-1", "loyalty_points": -1, "booking": [{"hotel_id": 1, "room_type": "double",
+				"from": "2025-01-10", "to": "2025-01-12", "room_count": 1}]}`,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "loyalty points can't be negative",
+		},
+		{
+			name: "stay ends before it starts",
+			body: `{"id": "order-1", "booking": [{"hotel_id": 1, "room_type": "double",
+				"from": "2025-01-12", "to": "2025-01-10", "room_count": 1}]}`,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid date range for hotel id 1",
+		},
+		{
+			name: "unknown hotel",
+			body: validBody,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeDouble).
+					Return(nil, domain.ErrHotelNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel id 1",
+		},
+		{
+			name: "unknown room type",
+			body: validBody,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeDouble).
+					Return(nil, domain.ErrRoomTypeNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid room_type 'double' for hotel id 1",
+		},
+		{
+			name: "room type lookup fails",
+			body: validBody,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeDouble).
+					Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to create order",
+		},
+		{
+			name: "no rooms booked",
+			body: `{"id": "order-1", "booking": [{"hotel_id": 1, "room_type": "double",
+				"from": "2025-01-10", "to": "2025-01-12", "room_count": 0}]}`,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeDouble).
+					Return(roomType, nil)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid booking: room count of 'double' must be at least 1, got 0",
+		},
+		{
+			name: "more guests than the room takes",
+			body: `{"id": "order-1", "booking": [{"hotel_id": 1, "room_type": "double",
+				"from": "2025-01-10", "to": "2025-01-12", "room_count": 1, "rooms": [{"adults": 3}]}]}`,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeDouble).
+					Return(roomType, nil)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid occupancy: room 1 of 'double' has 3 guests, but takes at most 2",
+		},
+		{
+			name: "order is created",
+			body: validBody,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeDouble).
+					Return(roomType, nil)
+				mockBookingService.EXPECT().CreateOrder(gomock.Any(), order).Return(createdOrder, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedData:   createdOrder,
+		},
+		{
+			name: "retried order returns the stored one",
+			body: validBody,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeDouble).
+					Return(roomType, nil)
+				mockBookingService.EXPECT().CreateOrder(gomock.Any(), order).Return(createdOrder, domain.ErrOrderAlreadyExists)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   createdOrder,
+		},
+		{
+			name: "order id is taken by another order",
+			body: validBody,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeDouble).
+					Return(roomType, nil)
+				mockBookingService.EXPECT().CreateOrder(gomock.Any(), order).Return(nil, domain.ErrOrderAlreadyExists)
+			},
+			expectedStatus:  http.StatusConflict,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "order already exists",
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			body: validBody,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeDouble).
+					Return(roomType, nil)
+				mockBookingService.EXPECT().CreateOrder(gomock.Any(), order).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to create order",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			h.Handle(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
+
+// TestHandler_Handle_Rejected checks how the errors of the booking service are answered.
+func TestHandler_Handle_Rejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockbookingService(ctrl)
+	mockRoomTypeService := mocks.NewMockroomTypeService(ctrl)
+
+	h := NewHandler(mockBookingService, mockRoomTypeService)
+
+	body := `{"id": "order-1", "booking": [{"hotel_id": 1, "room_type": "double",
+		"from": "2025-01-10", "to": "2025-01-12", "room_count": 1}]}`
+
+	roomType := &domain.HotelRoomType{HotelID: 1, Code: domain.RoomTypeDouble, Name: "Double", Capacity: 2}
+
+	tests := []struct {
+		err             error
+		expectedStatus  int
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			err:             domain.ErrHotelNotFound,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel id",
+		},
+		{
+			err:             domain.ErrRoomTypeNotFound,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid room type",
+		},
+		{
+			err:             fmt.Errorf("%w: 'double' on 2025-01-11", domain.ErrRoomsNotAvailable),
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "rooms not available: 'double' on 2025-01-11",
+		},
+		{
+			err:             fmt.Errorf("%w: stop sell on 2025-01-11", domain.ErrRestrictionViolated),
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "restriction violated: stop sell on 2025-01-11",
+		},
+		{
+			err:             domain.ErrRateNotFound,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "rate not found",
+		},
+		{
+			err:             domain.ErrCurrencyMismatch,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "currency mismatch",
+		},
+		{
+			err:             domain.ErrPromoNotFound,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "promo code not found",
+		},
+		{
+			err:             domain.ErrPromoNotApplicable,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "promo code isn't applicable",
+		},
+		{
+			err:             domain.ErrPromoUsageLimit,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "promo code usage limit reached",
+		},
+		{
+			err:             domain.ErrInvalidPoints,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid loyalty points",
+		},
+		{
+			err:             domain.ErrInsufficientPoints,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "insufficient loyalty points",
+		},
+		{
+			err:             domain.ErrPaymentDeclined,
+			expectedStatus:  http.StatusPaymentRequired,
+			expectedError:   http_helpers.ErrorTypePaymentError,
+			expectedMessage: "payment declined",
+		},
+		{
+			err:             domain.ErrPaymentTimeout,
+			expectedStatus:  http.StatusGatewayTimeout,
+			expectedError:   http_helpers.ErrorTypePaymentError,
+			expectedMessage: "payment gateway timeout",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeDouble).
+				Return(roomType, nil)
+			mockBookingService.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).Return(nil, tt.err)
+
+			req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+			rec := httptest.NewRecorder()
+
+			h.Handle(rec, req)
+
+			apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+		})
+	}
+}
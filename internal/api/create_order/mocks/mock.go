@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: create_order.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockbookingService is a mock of bookingService interface.
+type MockbookingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockbookingServiceMockRecorder
+}
+
+// MockbookingServiceMockRecorder is the mock recorder for MockbookingService.
+type MockbookingServiceMockRecorder struct {
+	mock *MockbookingService
+}
+
+// NewMockbookingService creates a new mock instance.
+func NewMockbookingService(ctrl *gomock.Controller) *MockbookingService {
+	mock := &MockbookingService{ctrl: ctrl}
+	mock.recorder = &MockbookingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbookingService) EXPECT() *MockbookingServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateOrder mocks base method.
+func (m *MockbookingService) CreateOrder(ctx context.Context, order domain.Order) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, order)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockbookingServiceMockRecorder) CreateOrder(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockbookingService)(nil).CreateOrder), ctx, order)
+}
+
+// MockroomTypeService is a mock of roomTypeService interface.
+type MockroomTypeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockroomTypeServiceMockRecorder
+}
+
+// MockroomTypeServiceMockRecorder is the mock recorder for MockroomTypeService.
+type MockroomTypeServiceMockRecorder struct {
+	mock *MockroomTypeService
+}
+
+// NewMockroomTypeService creates a new mock instance.
+func NewMockroomTypeService(ctrl *gomock.Controller) *MockroomTypeService {
+	mock := &MockroomTypeService{ctrl: ctrl}
+	mock.recorder = &MockroomTypeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockroomTypeService) EXPECT() *MockroomTypeServiceMockRecorder {
+	return m.recorder
+}
+
+// GetRoomType mocks base method.
+func (m *MockroomTypeService) GetRoomType(ctx context.Context, hotelID domain.HotelID, code domain.RoomType) (*domain.HotelRoomType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoomType", ctx, hotelID, code)
+	ret0, _ := ret[0].(*domain.HotelRoomType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoomType indicates an expected call of GetRoomType.
+func (mr *MockroomTypeServiceMockRecorder) GetRoomType(ctx, hotelID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoomType", reflect.TypeOf((*MockroomTypeService)(nil).GetRoomType), ctx, hotelID, code)
+}
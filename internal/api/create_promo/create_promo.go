@@ -0,0 +1,91 @@
+package create_promo
+
+//go:generate mockgen -source=create_promo.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+	"applicationDesignTest/pkg/log"
+)
+
+type request struct {
+	Code           domain.PromoCode    `json:"code"`
+	DiscountType   domain.DiscountType `json:"discount_type"`
+	Value          int64               `json:"value"`
+	Currency       domain.Currency     `json:"currency"`
+	ValidFrom      date.CustomDate     `json:"valid_from"`
+	ValidTo        date.CustomDate     `json:"valid_to"`
+	MinNights      int                 `json:"min_nights"`
+	HotelIDs       []domain.HotelID    `json:"hotel_ids"`
+	RoomTypes      []domain.RoomType   `json:"room_types"`
+	MaxUses        int                 `json:"max_uses"`
+	MaxUsesPerUser int                 `json:"max_uses_per_user"`
+}
+
+type promoService interface {
+	AddPromo(ctx context.Context, promo domain.Promo) error
+}
+
+type Handler struct {
+	promo promoService
+}
+
+func NewHandler(promoService promoService) *Handler {
+	return &Handler{
+		promo: promoService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req request
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warning(fmt.Sprintf("failed to decode request: %s", err.Error()))
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid input", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	for _, roomType := range req.RoomTypes {
+		if roomType == "" {
+			http_helpers.SendError(w, http.StatusBadRequest,
+				fmt.Sprintf("invalid room_type '%s'", roomType), http_helpers.ErrorTypeValidationError)
+			return
+		}
+	}
+
+	promo := domain.Promo{
+		Code:           req.Code,
+		DiscountType:   req.DiscountType,
+		Value:          req.Value,
+		Currency:       req.Currency,
+		ValidFrom:      req.ValidFrom.Time,
+		ValidTo:        req.ValidTo.Time,
+		MinNights:      req.MinNights,
+		HotelIDs:       req.HotelIDs,
+		RoomTypes:      req.RoomTypes,
+		MaxUses:        req.MaxUses,
+		MaxUsesPerUser: req.MaxUsesPerUser,
+	}
+
+	if err := h.promo.AddPromo(ctx, promo); err != nil {
+		if errors.Is(err, domain.ErrInvalidPromo) || errors.Is(err, domain.ErrPromoAlreadyExists) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to create promo", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to create promo", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusCreated, promo)
+}
@@ -0,0 +1,128 @@
+package create_promo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/create_promo/mocks"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPromoService := mocks.NewMockpromoService(ctrl)
+
+	h := NewHandler(mockPromoService)
+
+	validBody := `{"code": "WINTER10", "discount_type": "percent", "value": 10, "valid_from": "2025-01-01",
+		"valid_to": "2025-02-28", "min_nights": 2, "hotel_ids": [1], "room_types": ["lux"], "max_uses": 100,
+		"max_uses_per_user": 1}`
+
+	promo := domain.Promo{
+		Code:           "WINTER10",
+		DiscountType:   domain.DiscountTypePercent,
+		Value:          10,
+		ValidFrom:      date.Date(2025, 1, 1),
+		ValidTo:        date.Date(2025, 2, 28),
+		MinNights:      2,
+		HotelIDs:       []domain.HotelID{1},
+		RoomTypes:      []domain.RoomType{domain.RoomTypeLux},
+		MaxUses:        100,
+		MaxUsesPerUser: 1,
+	}
+
+	tests := []struct {
+		name            string
+		body            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "malformed body",
+			body:            `{"code": `,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid input",
+		},
+		{
+			name:            "empty room type",
+			body:            `{"code": "WINTER10", "room_types": [""]}`,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid room_type ''",
+		},
+		{
+			name: "promo is created",
+			body: validBody,
+			mockSetup: func() {
+				mockPromoService.EXPECT().AddPromo(gomock.Any(), promo).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedData:   promo,
+		},
+		{
+			name: "invalid promo",
+			body: validBody,
+			mockSetup: func() {
+				mockPromoService.EXPECT().AddPromo(gomock.Any(), promo).
+					Return(fmt.Errorf("%w: percent must be between 1 and 100", domain.ErrInvalidPromo))
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid promo: percent must be between 1 and 100",
+		},
+		{
+			name: "code is taken",
+			body: validBody,
+			mockSetup: func() {
+				mockPromoService.EXPECT().AddPromo(gomock.Any(), promo).Return(domain.ErrPromoAlreadyExists)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "promo code already exists",
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			body: validBody,
+			mockSetup: func() {
+				mockPromoService.EXPECT().AddPromo(gomock.Any(), promo).Return(errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to create promo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPost, "/promos", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			h.Handle(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: create_promo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockpromoService is a mock of promoService interface.
+type MockpromoService struct {
+	ctrl     *gomock.Controller
+	recorder *MockpromoServiceMockRecorder
+}
+
+// MockpromoServiceMockRecorder is the mock recorder for MockpromoService.
+type MockpromoServiceMockRecorder struct {
+	mock *MockpromoService
+}
+
+// NewMockpromoService creates a new mock instance.
+func NewMockpromoService(ctrl *gomock.Controller) *MockpromoService {
+	mock := &MockpromoService{ctrl: ctrl}
+	mock.recorder = &MockpromoServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpromoService) EXPECT() *MockpromoServiceMockRecorder {
+	return m.recorder
+}
+
+// AddPromo mocks base method.
+func (m *MockpromoService) AddPromo(ctx context.Context, promo domain.Promo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPromo", ctx, promo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPromo indicates an expected call of AddPromo.
+func (mr *MockpromoServiceMockRecorder) AddPromo(ctx, promo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPromo", reflect.TypeOf((*MockpromoService)(nil).AddPromo), ctx, promo)
+}
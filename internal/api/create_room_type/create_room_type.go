@@ -0,0 +1,82 @@
+package create_room_type
+
+//go:generate mockgen -source=create_room_type.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type request struct {
+	Code        domain.RoomType `json:"code"`
+	Name        string          `json:"name"`
+	Capacity    int             `json:"capacity"`
+	Description string          `json:"description"`
+	Amenities   []string        `json:"amenities"`
+}
+
+type hotelService interface {
+	AddRoomType(ctx context.Context, roomType domain.HotelRoomType) (*domain.HotelRoomType, error)
+}
+
+type Handler struct {
+	hotels hotelService
+}
+
+func NewHandler(hotelService hotelService) *Handler {
+	return &Handler{
+		hotels: hotelService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	hotelID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid hotel id", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	var req request
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warning(fmt.Sprintf("failed to decode request: %s", err.Error()))
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid input", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	roomType, err := h.hotels.AddRoomType(r.Context(), domain.HotelRoomType{
+		HotelID:     domain.HotelID(hotelID),
+		Code:        req.Code,
+		Name:        req.Name,
+		Capacity:    req.Capacity,
+		Description: req.Description,
+		Amenities:   req.Amenities,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrHotelNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "such hotel doesn't exist", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidRoomType) || errors.Is(err, domain.ErrRoomTypeAlreadyExists) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to create room type", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to create room type", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusCreated, roomType)
+}
@@ -0,0 +1,142 @@
+package create_room_type
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/create_room_type/mocks"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelService := mocks.NewMockhotelService(ctrl)
+
+	r := chi.NewRouter()
+	r.Post("/hotels/{id}/room-types", NewHandler(mockHotelService).Handle)
+
+	validBody := `{"code": "suite", "name": "Suite", "capacity": 4, "description": "Two rooms",
+		"amenities": ["balcony", "bath"]}`
+
+	roomType := domain.HotelRoomType{
+		HotelID:     1,
+		Code:        "suite",
+		Name:        "Suite",
+		Capacity:    4,
+		Description: "Two rooms",
+		Amenities:   []string{"balcony", "bath"},
+	}
+
+	tests := []struct {
+		name            string
+		hotelID         string
+		body            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "hotel id isn't a number",
+			hotelID:         "abc",
+			body:            validBody,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel id",
+		},
+		{
+			name:            "malformed body",
+			hotelID:         "1",
+			body:            `{"code": `,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid input",
+		},
+		{
+			name:    "room type is created",
+			hotelID: "1",
+			body:    validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().AddRoomType(gomock.Any(), roomType).Return(&roomType, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedData:   roomType,
+		},
+		{
+			name:    "hotel not found",
+			hotelID: "1",
+			body:    validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().AddRoomType(gomock.Any(), roomType).Return(nil, domain.ErrHotelNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such hotel doesn't exist",
+		},
+		{
+			name:    "invalid room type",
+			hotelID: "1",
+			body:    validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().AddRoomType(gomock.Any(), roomType).
+					Return(nil, fmt.Errorf("%w: capacity must be at least 1", domain.ErrInvalidRoomType))
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid room type: capacity must be at least 1",
+		},
+		{
+			name:    "code is taken",
+			hotelID: "1",
+			body:    validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().AddRoomType(gomock.Any(), roomType).Return(nil, domain.ErrRoomTypeAlreadyExists)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "room type already exists",
+		},
+		{
+			name:    "unexpected error isn't disclosed",
+			hotelID: "1",
+			body:    validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().AddRoomType(gomock.Any(), roomType).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to create room type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPost, "/hotels/"+tt.hotelID+"/room-types", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
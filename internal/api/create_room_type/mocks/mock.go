@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: create_room_type.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockhotelService is a mock of hotelService interface.
+type MockhotelService struct {
+	ctrl     *gomock.Controller
+	recorder *MockhotelServiceMockRecorder
+}
+
+// MockhotelServiceMockRecorder is the mock recorder for MockhotelService.
+type MockhotelServiceMockRecorder struct {
+	mock *MockhotelService
+}
+
+// NewMockhotelService creates a new mock instance.
+func NewMockhotelService(ctrl *gomock.Controller) *MockhotelService {
+	mock := &MockhotelService{ctrl: ctrl}
+	mock.recorder = &MockhotelServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhotelService) EXPECT() *MockhotelServiceMockRecorder {
+	return m.recorder
+}
+
+// AddRoomType mocks base method.
+func (m *MockhotelService) AddRoomType(ctx context.Context, roomType domain.HotelRoomType) (*domain.HotelRoomType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRoomType", ctx, roomType)
+	ret0, _ := ret[0].(*domain.HotelRoomType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddRoomType indicates an expected call of AddRoomType.
+func (mr *MockhotelServiceMockRecorder) AddRoomType(ctx, roomType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRoomType", reflect.TypeOf((*MockhotelService)(nil).AddRoomType), ctx, roomType)
+}
@@ -0,0 +1,90 @@
+package create_webhook
+
+//go:generate mockgen -source=create_webhook.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+)
+
+type request struct {
+	HotelID    domain.HotelID     `json:"hotel_id"`
+	URL        string             `json:"url"`
+	Secret     string             `json:"secret"`
+	EventTypes []domain.EventType `json:"event_types"`
+}
+
+// response shows the secret once, so the partner can keep the generated one.
+type response struct {
+	domain.WebhookInfo
+	Secret string `json:"secret"`
+}
+
+type hotelService interface {
+	GetHotel(ctx context.Context, hotelID domain.HotelID) (*domain.Hotel, error)
+}
+
+type webhookService interface {
+	AddWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error)
+}
+
+type Handler struct {
+	hotels   hotelService
+	webhooks webhookService
+}
+
+func NewHandler(hotelService hotelService, webhookService webhookService) *Handler {
+	return &Handler{
+		hotels:   hotelService,
+		webhooks: webhookService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req request
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warning(fmt.Sprintf("failed to decode request: %s", err.Error()))
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid input", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	if _, err := h.hotels.GetHotel(ctx, req.HotelID); err != nil {
+		if errors.Is(err, domain.ErrHotelNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "such hotel doesn't exist", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to get hotel", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to create webhook", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	webhook, err := h.webhooks.AddWebhook(ctx, domain.Webhook{
+		HotelID:    req.HotelID,
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidWebhook) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to create webhook", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to create webhook", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusCreated, response{WebhookInfo: webhook.Info(), Secret: webhook.Secret})
+}
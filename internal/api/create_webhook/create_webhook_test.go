@@ -0,0 +1,137 @@
+package create_webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/create_webhook/mocks"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelService := mocks.NewMockhotelService(ctrl)
+	mockWebhookService := mocks.NewMockwebhookService(ctrl)
+
+	h := NewHandler(mockHotelService, mockWebhookService)
+
+	validBody := `{"hotel_id": 1, "url": "https://partner.example.com/hook", "event_types": ["order_created"]}`
+
+	hotel := &domain.Hotel{ID: 1, Name: "Grand"}
+
+	webhook := domain.Webhook{
+		HotelID:    1,
+		URL:        "https://partner.example.com/hook",
+		EventTypes: []domain.EventType{domain.EventOrderCreated},
+	}
+
+	// the service generates the secret when the request has none
+	createdWebhook := &domain.Webhook{
+		ID:         3,
+		HotelID:    1,
+		URL:        "https://partner.example.com/hook",
+		Secret:     "generated-secret",
+		EventTypes: []domain.EventType{domain.EventOrderCreated},
+		CreatedAt:  time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name            string
+		body            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "malformed body",
+			body:            `{"hotel_id": `,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid input",
+		},
+		{
+			name: "unknown hotel",
+			body: validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().GetHotel(gomock.Any(), domain.HotelID(1)).Return(nil, domain.ErrHotelNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such hotel doesn't exist",
+		},
+		{
+			name: "hotel lookup fails",
+			body: validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().GetHotel(gomock.Any(), domain.HotelID(1)).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to create webhook",
+		},
+		{
+			name: "webhook is created with its secret shown once",
+			body: validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().GetHotel(gomock.Any(), domain.HotelID(1)).Return(hotel, nil)
+				mockWebhookService.EXPECT().AddWebhook(gomock.Any(), webhook).Return(createdWebhook, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedData:   response{WebhookInfo: createdWebhook.Info(), Secret: "generated-secret"},
+		},
+		{
+			name: "invalid webhook",
+			body: validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().GetHotel(gomock.Any(), domain.HotelID(1)).Return(hotel, nil)
+				mockWebhookService.EXPECT().AddWebhook(gomock.Any(), webhook).
+					Return(nil, fmt.Errorf("%w: url must be an absolute http(s) url", domain.ErrInvalidWebhook))
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid webhook: url must be an absolute http(s) url",
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			body: validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().GetHotel(gomock.Any(), domain.HotelID(1)).Return(hotel, nil)
+				mockWebhookService.EXPECT().AddWebhook(gomock.Any(), webhook).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to create webhook",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			h.Handle(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
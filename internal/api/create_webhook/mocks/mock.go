@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: create_webhook.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockhotelService is a mock of hotelService interface.
+type MockhotelService struct {
+	ctrl     *gomock.Controller
+	recorder *MockhotelServiceMockRecorder
+}
+
+// MockhotelServiceMockRecorder is the mock recorder for MockhotelService.
+type MockhotelServiceMockRecorder struct {
+	mock *MockhotelService
+}
+
+// NewMockhotelService creates a new mock instance.
+func NewMockhotelService(ctrl *gomock.Controller) *MockhotelService {
+	mock := &MockhotelService{ctrl: ctrl}
+	mock.recorder = &MockhotelServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhotelService) EXPECT() *MockhotelServiceMockRecorder {
+	return m.recorder
+}
+
+// GetHotel mocks base method.
+func (m *MockhotelService) GetHotel(ctx context.Context, hotelID domain.HotelID) (*domain.Hotel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHotel", ctx, hotelID)
+	ret0, _ := ret[0].(*domain.Hotel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHotel indicates an expected call of GetHotel.
+func (mr *MockhotelServiceMockRecorder) GetHotel(ctx, hotelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotel", reflect.TypeOf((*MockhotelService)(nil).GetHotel), ctx, hotelID)
+}
+
+// MockwebhookService is a mock of webhookService interface.
+type MockwebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockwebhookServiceMockRecorder
+}
+
+// MockwebhookServiceMockRecorder is the mock recorder for MockwebhookService.
+type MockwebhookServiceMockRecorder struct {
+	mock *MockwebhookService
+}
+
+// NewMockwebhookService creates a new mock instance.
+func NewMockwebhookService(ctrl *gomock.Controller) *MockwebhookService {
+	mock := &MockwebhookService{ctrl: ctrl}
+	mock.recorder = &MockwebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockwebhookService) EXPECT() *MockwebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// AddWebhook mocks base method.
+func (m *MockwebhookService) AddWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWebhook", ctx, webhook)
+	ret0, _ := ret[0].(*domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWebhook indicates an expected call of AddWebhook.
+func (mr *MockwebhookServiceMockRecorder) AddWebhook(ctx, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWebhook", reflect.TypeOf((*MockwebhookService)(nil).AddWebhook), ctx, webhook)
+}
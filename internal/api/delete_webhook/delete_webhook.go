@@ -0,0 +1,53 @@
+package delete_webhook
+
+//go:generate mockgen -source=delete_webhook.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type webhookService interface {
+	DeleteWebhook(ctx context.Context, id domain.WebhookID) error
+}
+
+type Handler struct {
+	webhooks webhookService
+}
+
+func NewHandler(webhookService webhookService) *Handler {
+	return &Handler{
+		webhooks: webhookService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid webhook id", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	if err := h.webhooks.DeleteWebhook(ctx, domain.WebhookID(id)); err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "such webhook doesn't exist", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to delete webhook", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to delete webhook", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, nil)
+}
@@ -0,0 +1,89 @@
+package delete_webhook
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/delete_webhook/mocks"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookService := mocks.NewMockwebhookService(ctrl)
+
+	r := chi.NewRouter()
+	r.Delete("/webhooks/{id}", NewHandler(mockWebhookService).Handle)
+
+	tests := []struct {
+		name            string
+		id              string
+		mockSetup       func()
+		expectedStatus  int
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "webhook id isn't a number",
+			id:              "abc",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid webhook id",
+		},
+		{
+			name: "webhook is deleted",
+			id:   "3",
+			mockSetup: func() {
+				mockWebhookService.EXPECT().DeleteWebhook(gomock.Any(), domain.WebhookID(3)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "webhook not found",
+			id:   "3",
+			mockSetup: func() {
+				mockWebhookService.EXPECT().DeleteWebhook(gomock.Any(), domain.WebhookID(3)).Return(domain.ErrWebhookNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such webhook doesn't exist",
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			id:   "3",
+			mockSetup: func() {
+				mockWebhookService.EXPECT().DeleteWebhook(gomock.Any(), domain.WebhookID(3)).Return(errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to delete webhook",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodDelete, "/webhooks/"+tt.id, nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package get_calendar
+
+//go:generate mockgen -source=get_calendar.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type calendarService interface {
+	GetCalendar(ctx context.Context, query domain.CalendarQuery) (*domain.Calendar, error)
+}
+
+type Handler struct {
+	calendar calendarService
+}
+
+func NewHandler(calendarService calendarService) *Handler {
+	return &Handler{
+		calendar: calendarService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid hotel id", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	params := r.URL.Query()
+	query := domain.CalendarQuery{HotelID: domain.HotelID(id)}
+
+	if query.From, err = time.Parse(time.DateOnly, params.Get("from")); err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid from, use YYYY-MM-DD", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	if query.To, err = time.Parse(time.DateOnly, params.Get("to")); err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid to, use YYYY-MM-DD", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	calendar, err := h.calendar.GetCalendar(r.Context(), query)
+	if err != nil {
+		if errors.Is(err, domain.ErrHotelNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "such hotel doesn't exist", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to get calendar", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to get calendar", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, calendar)
+}
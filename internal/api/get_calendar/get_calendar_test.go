@@ -0,0 +1,142 @@
+package get_calendar
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/get_calendar/mocks"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCalendarService := mocks.NewMockcalendarService(ctrl)
+
+	r := chi.NewRouter()
+	r.Get("/hotels/{id}/calendar", NewHandler(mockCalendarService).Handle)
+
+	query := domain.CalendarQuery{HotelID: 1, From: date.Date(2025, 1, 1), To: date.Date(2025, 1, 2)}
+
+	calendar := &domain.Calendar{
+		HotelID:   1,
+		From:      date.Date(2025, 1, 1),
+		To:        date.Date(2025, 1, 2),
+		RoomTypes: []domain.RoomType{domain.RoomTypeLux},
+		Days: []domain.CalendarDay{
+			{
+				Date:  date.Date(2025, 1, 1),
+				Rooms: map[domain.RoomType]domain.CalendarCell{domain.RoomTypeLux: {Total: 3, Reserved: 1, Free: 2, MinStay: 2}},
+			},
+			{
+				Date:  date.Date(2025, 1, 2),
+				Rooms: map[domain.RoomType]domain.CalendarCell{domain.RoomTypeLux: {Total: 3, Free: 3, StopSell: true}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		path            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "hotel id isn't a number",
+			path:            "/hotels/abc/calendar?from=2025-01-01&to=2025-01-02",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel id",
+		},
+		{
+			name:            "from is malformed",
+			path:            "/hotels/1/calendar?from=01.01.2025&to=2025-01-02",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid from, use YYYY-MM-DD",
+		},
+		{
+			name:            "to is missing",
+			path:            "/hotels/1/calendar?from=2025-01-01",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid to, use YYYY-MM-DD",
+		},
+		{
+			name: "calendar is returned",
+			path: "/hotels/1/calendar?from=2025-01-01&to=2025-01-02",
+			mockSetup: func() {
+				mockCalendarService.EXPECT().GetCalendar(gomock.Any(), query).Return(calendar, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   calendar,
+		},
+		{
+			name: "hotel not found",
+			path: "/hotels/1/calendar?from=2025-01-01&to=2025-01-02",
+			mockSetup: func() {
+				mockCalendarService.EXPECT().GetCalendar(gomock.Any(), query).Return(nil, domain.ErrHotelNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such hotel doesn't exist",
+		},
+		{
+			name: "invalid query",
+			path: "/hotels/1/calendar?from=2025-01-01&to=2026-01-31",
+			mockSetup: func() {
+				mockCalendarService.EXPECT().GetCalendar(gomock.Any(), domain.CalendarQuery{
+					HotelID: 1,
+					From:    date.Date(2025, 1, 1),
+					To:      date.Date(2026, 1, 31),
+				}).Return(nil, fmt.Errorf("%w: calendar can't be longer than 366 days", domain.ErrInvalidQuery))
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid query: calendar can't be longer than 366 days",
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			path: "/hotels/1/calendar?from=2025-01-01&to=2025-01-02",
+			mockSetup: func() {
+				mockCalendarService.EXPECT().GetCalendar(gomock.Any(), query).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to get calendar",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: get_calendar.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockcalendarService is a mock of calendarService interface.
+type MockcalendarService struct {
+	ctrl     *gomock.Controller
+	recorder *MockcalendarServiceMockRecorder
+}
+
+// MockcalendarServiceMockRecorder is the mock recorder for MockcalendarService.
+type MockcalendarServiceMockRecorder struct {
+	mock *MockcalendarService
+}
+
+// NewMockcalendarService creates a new mock instance.
+func NewMockcalendarService(ctrl *gomock.Controller) *MockcalendarService {
+	mock := &MockcalendarService{ctrl: ctrl}
+	mock.recorder = &MockcalendarServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcalendarService) EXPECT() *MockcalendarServiceMockRecorder {
+	return m.recorder
+}
+
+// GetCalendar mocks base method.
+func (m *MockcalendarService) GetCalendar(ctx context.Context, query domain.CalendarQuery) (*domain.Calendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendar", ctx, query)
+	ret0, _ := ret[0].(*domain.Calendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendar indicates an expected call of GetCalendar.
+func (mr *MockcalendarServiceMockRecorder) GetCalendar(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendar", reflect.TypeOf((*MockcalendarService)(nil).GetCalendar), ctx, query)
+}
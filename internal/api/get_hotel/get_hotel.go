@@ -0,0 +1,52 @@
+package get_hotel
+
+//go:generate mockgen -source=get_hotel.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type hotelService interface {
+	GetHotel(ctx context.Context, hotelID domain.HotelID) (*domain.Hotel, error)
+}
+
+type Handler struct {
+	hotels hotelService
+}
+
+func NewHandler(hotelService hotelService) *Handler {
+	return &Handler{
+		hotels: hotelService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid hotel id", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	hotel, err := h.hotels.GetHotel(r.Context(), domain.HotelID(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrHotelNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "such hotel doesn't exist", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to get hotel", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to get hotel", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, hotel)
+}
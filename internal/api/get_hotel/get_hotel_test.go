@@ -0,0 +1,93 @@
+package get_hotel
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/get_hotel/mocks"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelService := mocks.NewMockhotelService(ctrl)
+
+	r := chi.NewRouter()
+	r.Get("/hotels/{id}", NewHandler(mockHotelService).Handle)
+
+	hotel := &domain.Hotel{ID: 3, Name: "Grand", City: "Moscow", Timezone: "Europe/Moscow", CheckInTime: "14:00"}
+
+	tests := []struct {
+		name            string
+		id              string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "hotel id isn't a number",
+			id:              "abc",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel id",
+		},
+		{
+			name: "hotel is returned",
+			id:   "3",
+			mockSetup: func() {
+				mockHotelService.EXPECT().GetHotel(gomock.Any(), domain.HotelID(3)).Return(hotel, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   hotel,
+		},
+		{
+			name: "hotel not found",
+			id:   "3",
+			mockSetup: func() {
+				mockHotelService.EXPECT().GetHotel(gomock.Any(), domain.HotelID(3)).Return(nil, domain.ErrHotelNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such hotel doesn't exist",
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			id:   "3",
+			mockSetup: func() {
+				mockHotelService.EXPECT().GetHotel(gomock.Any(), domain.HotelID(3)).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to get hotel",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, "/hotels/"+tt.id, nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package get_loyalty
+
+//go:generate mockgen -source=get_loyalty.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type loyaltyService interface {
+	GetAccount(ctx context.Context, userID domain.UserID) (*domain.LoyaltyAccount, error)
+}
+
+type Handler struct {
+	loyaltyService loyaltyService
+}
+
+func NewHandler(ls loyaltyService) *Handler {
+	return &Handler{
+		loyaltyService: ls,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid user id", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	account, err := h.loyaltyService.GetAccount(ctx, domain.UserID(userID))
+	if err != nil {
+		log.Error("failed to get loyalty account", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to get loyalty account", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, account)
+}
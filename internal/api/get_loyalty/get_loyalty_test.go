@@ -0,0 +1,93 @@
+package get_loyalty
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/get_loyalty/mocks"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLoyaltyService := mocks.NewMockloyaltyService(ctrl)
+
+	r := chi.NewRouter()
+	r.Get("/users/{id}/loyalty", NewHandler(mockLoyaltyService).Handle)
+
+	account := &domain.LoyaltyAccount{
+		UserID:  1,
+		Balance: 400,
+		History: []domain.LoyaltyEntry{
+			{ID: 1, UserID: 1, OrderNumber: 7, Type: domain.LoyaltyEntryEarn, Points: 500,
+				CreatedAt: time.Date(2025, 1, 12, 10, 0, 0, 0, time.UTC)},
+			{ID: 2, UserID: 1, OrderNumber: 8, Type: domain.LoyaltyEntryRedeem, Points: -100,
+				CreatedAt: time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		userID          string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "user id isn't a number",
+			userID:          "abc",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid user id",
+		},
+		{
+			name:   "account is returned",
+			userID: "1",
+			mockSetup: func() {
+				mockLoyaltyService.EXPECT().GetAccount(gomock.Any(), domain.UserID(1)).Return(account, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   account,
+		},
+		{
+			name:   "unexpected error isn't disclosed",
+			userID: "1",
+			mockSetup: func() {
+				mockLoyaltyService.EXPECT().GetAccount(gomock.Any(), domain.UserID(1)).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to get loyalty account",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, "/users/"+tt.userID+"/loyalty", nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: get_loyalty.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockloyaltyService is a mock of loyaltyService interface.
+type MockloyaltyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockloyaltyServiceMockRecorder
+}
+
+// MockloyaltyServiceMockRecorder is the mock recorder for MockloyaltyService.
+type MockloyaltyServiceMockRecorder struct {
+	mock *MockloyaltyService
+}
+
+// NewMockloyaltyService creates a new mock instance.
+func NewMockloyaltyService(ctrl *gomock.Controller) *MockloyaltyService {
+	mock := &MockloyaltyService{ctrl: ctrl}
+	mock.recorder = &MockloyaltyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockloyaltyService) EXPECT() *MockloyaltyServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAccount mocks base method.
+func (m *MockloyaltyService) GetAccount(ctx context.Context, userID domain.UserID) (*domain.LoyaltyAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", ctx, userID)
+	ret0, _ := ret[0].(*domain.LoyaltyAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockloyaltyServiceMockRecorder) GetAccount(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockloyaltyService)(nil).GetAccount), ctx, userID)
+}
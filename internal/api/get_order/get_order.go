@@ -35,6 +35,7 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 	orderNumber, err := strconv.Atoi(orderNumberStr)
 	if err != nil {
 		http_helpers.SendError(w, http.StatusBadRequest, "invalid order number", http_helpers.ErrorTypeValidationError)
+		return
 	}
 
 	order, err := h.orderService.GetOrderByNumber(ctx, domain.OrderNumber(orderNumber))
@@ -0,0 +1,57 @@
+package get_room_type
+
+//go:generate mockgen -source=get_room_type.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type hotelService interface {
+	GetRoomType(ctx context.Context, hotelID domain.HotelID, code domain.RoomType) (*domain.HotelRoomType, error)
+}
+
+type Handler struct {
+	hotels hotelService
+}
+
+func NewHandler(hotelService hotelService) *Handler {
+	return &Handler{
+		hotels: hotelService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	hotelID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid hotel id", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	roomType, err := h.hotels.GetRoomType(r.Context(), domain.HotelID(hotelID), domain.RoomType(chi.URLParam(r, "code")))
+	if err != nil {
+		if errors.Is(err, domain.ErrHotelNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "such hotel doesn't exist", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrRoomTypeNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "such room type doesn't exist", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to get room type", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to get room type", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, roomType)
+}
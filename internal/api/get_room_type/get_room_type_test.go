@@ -0,0 +1,107 @@
+package get_room_type
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/get_room_type/mocks"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelService := mocks.NewMockhotelService(ctrl)
+
+	r := chi.NewRouter()
+	r.Get("/hotels/{id}/room-types/{code}", NewHandler(mockHotelService).Handle)
+
+	roomType := &domain.HotelRoomType{HotelID: 1, Code: domain.RoomTypeLux, Name: "Lux", Capacity: 2,
+		Amenities: []string{"minibar"}}
+
+	tests := []struct {
+		name            string
+		path            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "hotel id isn't a number",
+			path:            "/hotels/abc/room-types/lux",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel id",
+		},
+		{
+			name: "room type is returned",
+			path: "/hotels/1/room-types/lux",
+			mockSetup: func() {
+				mockHotelService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeLux).Return(roomType, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   roomType,
+		},
+		{
+			name: "hotel not found",
+			path: "/hotels/1/room-types/lux",
+			mockSetup: func() {
+				mockHotelService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeLux).
+					Return(nil, domain.ErrHotelNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such hotel doesn't exist",
+		},
+		{
+			name: "room type not found",
+			path: "/hotels/1/room-types/lux",
+			mockSetup: func() {
+				mockHotelService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeLux).
+					Return(nil, domain.ErrRoomTypeNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such room type doesn't exist",
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			path: "/hotels/1/room-types/lux",
+			mockSetup: func() {
+				mockHotelService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeLux).
+					Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to get room type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: get_room_type.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockhotelService is a mock of hotelService interface.
+type MockhotelService struct {
+	ctrl     *gomock.Controller
+	recorder *MockhotelServiceMockRecorder
+}
+
+// MockhotelServiceMockRecorder is the mock recorder for MockhotelService.
+type MockhotelServiceMockRecorder struct {
+	mock *MockhotelService
+}
+
+// NewMockhotelService creates a new mock instance.
+func NewMockhotelService(ctrl *gomock.Controller) *MockhotelService {
+	mock := &MockhotelService{ctrl: ctrl}
+	mock.recorder = &MockhotelServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhotelService) EXPECT() *MockhotelServiceMockRecorder {
+	return m.recorder
+}
+
+// GetRoomType mocks base method.
+func (m *MockhotelService) GetRoomType(ctx context.Context, hotelID domain.HotelID, code domain.RoomType) (*domain.HotelRoomType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoomType", ctx, hotelID, code)
+	ret0, _ := ret[0].(*domain.HotelRoomType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoomType indicates an expected call of GetRoomType.
+func (mr *MockhotelServiceMockRecorder) GetRoomType(ctx, hotelID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoomType", reflect.TypeOf((*MockhotelService)(nil).GetRoomType), ctx, hotelID, code)
+}
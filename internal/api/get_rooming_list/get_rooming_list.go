@@ -0,0 +1,52 @@
+package get_rooming_list
+
+//go:generate mockgen -source=get_rooming_list.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type orderService interface {
+	GetOrderByNumber(ctx context.Context, orderNumber domain.OrderNumber) (*domain.Order, error)
+}
+
+type Handler struct {
+	orderService orderService
+}
+
+func NewHandler(orderService orderService) *Handler {
+	return &Handler{
+		orderService: orderService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	orderNumber, err := strconv.Atoi(chi.URLParam(r, "orderNumber"))
+	if err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid order number", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	order, err := h.orderService.GetOrderByNumber(r.Context(), domain.OrderNumber(orderNumber))
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "such order doesn't exist", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to get order", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to get rooming list", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, order.RoomingList())
+}
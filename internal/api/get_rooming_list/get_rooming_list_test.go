@@ -0,0 +1,132 @@
+package get_rooming_list
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/get_rooming_list/mocks"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderService := mocks.NewMockorderService(ctrl)
+
+	r := chi.NewRouter()
+	r.Get("/orders/{orderNumber}/rooming-list", NewHandler(mockOrderService).Handle)
+
+	order := &domain.Order{
+		Number: 1,
+		Status: domain.OrderStatusConfirmed,
+		Bookings: []domain.Booking{{
+			HotelID:   1,
+			RoomType:  domain.RoomTypeDouble,
+			From:      date.Date(2025, 1, 10),
+			To:        date.Date(2025, 1, 11),
+			RoomCount: 2,
+			Rooms: []domain.RoomOccupancy{
+				{Adults: 2, GuestNames: []string{"Ivan Ivanov", "Maria Ivanova"}},
+				{Adults: 1, Children: 1},
+			},
+		}},
+	}
+
+	tests := []struct {
+		name            string
+		orderNumber     string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "order number isn't a number",
+			orderNumber:     "abc",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid order number",
+		},
+		{
+			name:        "rooming list has a room per booked room",
+			orderNumber: "1",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), domain.OrderNumber(1)).Return(order, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData: domain.RoomingList{
+				OrderNumber: 1,
+				Status:      domain.OrderStatusConfirmed,
+				Rooms: []domain.RoomingListRoom{
+					{
+						HotelID:    1,
+						RoomType:   domain.RoomTypeDouble,
+						Arrival:    date.Date(2025, 1, 10),
+						Departure:  date.Date(2025, 1, 12),
+						Nights:     2,
+						Adults:     2,
+						GuestNames: []string{"Ivan Ivanov", "Maria Ivanova"},
+					},
+					{
+						HotelID:    1,
+						RoomType:   domain.RoomTypeDouble,
+						Arrival:    date.Date(2025, 1, 10),
+						Departure:  date.Date(2025, 1, 12),
+						Nights:     2,
+						Adults:     1,
+						Children:   1,
+						GuestNames: []string{},
+					},
+				},
+			},
+		},
+		{
+			name:        "order not found",
+			orderNumber: "1",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), domain.OrderNumber(1)).Return(nil, domain.ErrOrderNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such order doesn't exist",
+		},
+		{
+			name:        "unexpected error isn't disclosed",
+			orderNumber: "1",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), domain.OrderNumber(1)).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to get rooming list",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, "/orders/"+tt.orderNumber+"/rooming-list", nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
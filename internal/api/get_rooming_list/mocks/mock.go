@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: get_rooming_list.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockorderService is a mock of orderService interface.
+type MockorderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderServiceMockRecorder
+}
+
+// MockorderServiceMockRecorder is the mock recorder for MockorderService.
+type MockorderServiceMockRecorder struct {
+	mock *MockorderService
+}
+
+// NewMockorderService creates a new mock instance.
+func NewMockorderService(ctrl *gomock.Controller) *MockorderService {
+	mock := &MockorderService{ctrl: ctrl}
+	mock.recorder = &MockorderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorderService) EXPECT() *MockorderServiceMockRecorder {
+	return m.recorder
+}
+
+// GetOrderByNumber mocks base method.
+func (m *MockorderService) GetOrderByNumber(ctx context.Context, orderNumber domain.OrderNumber) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderByNumber", ctx, orderNumber)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderByNumber indicates an expected call of GetOrderByNumber.
+func (mr *MockorderServiceMockRecorder) GetOrderByNumber(ctx, orderNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderByNumber", reflect.TypeOf((*MockorderService)(nil).GetOrderByNumber), ctx, orderNumber)
+}
@@ -0,0 +1,54 @@
+package get_webhook
+
+//go:generate mockgen -source=get_webhook.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type webhookService interface {
+	GetWebhook(ctx context.Context, id domain.WebhookID) (*domain.Webhook, error)
+}
+
+type Handler struct {
+	webhooks webhookService
+}
+
+func NewHandler(webhookService webhookService) *Handler {
+	return &Handler{
+		webhooks: webhookService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid webhook id", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	webhook, err := h.webhooks.GetWebhook(ctx, domain.WebhookID(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "such webhook doesn't exist", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to get webhook", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to get webhook", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, webhook.Info())
+}
@@ -0,0 +1,107 @@
+package get_webhook
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/get_webhook/mocks"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookService := mocks.NewMockwebhookService(ctrl)
+
+	r := chi.NewRouter()
+	r.Get("/webhooks/{id}", NewHandler(mockWebhookService).Handle)
+
+	webhook := &domain.Webhook{
+		ID:         3,
+		HotelID:    1,
+		URL:        "https://partner.example.com/hook",
+		Secret:     "s3cr3t",
+		EventTypes: []domain.EventType{domain.EventOrderCreated},
+		CreatedAt:  time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name            string
+		id              string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "webhook id isn't a number",
+			id:              "abc",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid webhook id",
+		},
+		{
+			name: "webhook is returned without its secret",
+			id:   "3",
+			mockSetup: func() {
+				mockWebhookService.EXPECT().GetWebhook(gomock.Any(), domain.WebhookID(3)).Return(webhook, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData: domain.WebhookInfo{
+				ID:         3,
+				HotelID:    1,
+				URL:        "https://partner.example.com/hook",
+				EventTypes: []domain.EventType{domain.EventOrderCreated},
+				CreatedAt:  time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "webhook not found",
+			id:   "3",
+			mockSetup: func() {
+				mockWebhookService.EXPECT().GetWebhook(gomock.Any(), domain.WebhookID(3)).Return(nil, domain.ErrWebhookNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such webhook doesn't exist",
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			id:   "3",
+			mockSetup: func() {
+				mockWebhookService.EXPECT().GetWebhook(gomock.Any(), domain.WebhookID(3)).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to get webhook",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, "/webhooks/"+tt.id, nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
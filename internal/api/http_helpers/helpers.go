@@ -16,6 +16,7 @@ var (
 
 	ErrorTypeValidationError ErrorType = "validation error"
 	ErrorTypeInternalError   ErrorType = "internal server error"
+	ErrorTypePaymentError    ErrorType = "payment error"
 )
 
 type SuccessResponse struct {
@@ -31,6 +32,7 @@ type ErrorResponse struct {
 
 func SendError(w http.ResponseWriter, statusCode int, errMsg string, errorType ErrorType) {
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
 
 	resp := ErrorResponse{
 		Status:  StatusError,
@@ -43,8 +45,6 @@ func SendError(w http.ResponseWriter, statusCode int, errMsg string, errorType E
 		http.Error(w, "internal server error", http.StatusInternalServerError)
 		return
 	}
-
-	w.WriteHeader(statusCode)
 }
 
 func SendSuccess(w http.ResponseWriter, statusCode int, data any) {
@@ -0,0 +1,37 @@
+package list_dead_letters
+
+//go:generate mockgen -source=list_dead_letters.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"net/http"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+)
+
+type webhookService interface {
+	GetDeadLetters(ctx context.Context) ([]domain.DeadLetter, error)
+}
+
+type Handler struct {
+	webhooks webhookService
+}
+
+func NewHandler(webhookService webhookService) *Handler {
+	return &Handler{
+		webhooks: webhookService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	deadLetters, err := h.webhooks.GetDeadLetters(r.Context())
+	if err != nil {
+		log.Error("failed to get dead letters", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to get dead letters", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, deadLetters)
+}
@@ -0,0 +1,78 @@
+package list_dead_letters
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/api/list_dead_letters/mocks"
+	"applicationDesignTest/internal/domain"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookService := mocks.NewMockwebhookService(ctrl)
+
+	h := NewHandler(mockWebhookService)
+
+	deadLetters := []domain.DeadLetter{{
+		ID:        1,
+		WebhookID: 3,
+		Event:     domain.Event{ID: 12, Type: domain.EventOrderCancelled},
+		Attempts:  5,
+		LastError: "unexpected status 503",
+		FailedAt:  time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	}}
+
+	tests := []struct {
+		name            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name: "dead letters are returned",
+			mockSetup: func() {
+				mockWebhookService.EXPECT().GetDeadLetters(gomock.Any()).Return(deadLetters, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   deadLetters,
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			mockSetup: func() {
+				mockWebhookService.EXPECT().GetDeadLetters(gomock.Any()).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to get dead letters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, "/webhooks/dead-letters", nil)
+			rec := httptest.NewRecorder()
+
+			h.Handle(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
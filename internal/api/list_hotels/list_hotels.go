@@ -0,0 +1,37 @@
+package list_hotels
+
+//go:generate mockgen -source=list_hotels.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"net/http"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+)
+
+type hotelService interface {
+	GetHotels(ctx context.Context) ([]domain.Hotel, error)
+}
+
+type Handler struct {
+	hotels hotelService
+}
+
+func NewHandler(hotelService hotelService) *Handler {
+	return &Handler{
+		hotels: hotelService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	hotels, err := h.hotels.GetHotels(r.Context())
+	if err != nil {
+		log.Error("failed to get hotels", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to get hotels", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, hotels)
+}
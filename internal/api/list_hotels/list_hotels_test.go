@@ -0,0 +1,73 @@
+package list_hotels
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/api/list_hotels/mocks"
+	"applicationDesignTest/internal/domain"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelService := mocks.NewMockhotelService(ctrl)
+
+	h := NewHandler(mockHotelService)
+
+	hotels := []domain.Hotel{
+		{ID: 1, Name: "Grand", City: "Moscow"},
+		{ID: 2, Name: "Neva", City: "Saint Petersburg", StarRating: 4},
+	}
+
+	tests := []struct {
+		name            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name: "hotels are returned",
+			mockSetup: func() {
+				mockHotelService.EXPECT().GetHotels(gomock.Any()).Return(hotels, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   hotels,
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			mockSetup: func() {
+				mockHotelService.EXPECT().GetHotels(gomock.Any()).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to get hotels",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, "/hotels", nil)
+			rec := httptest.NewRecorder()
+
+			h.Handle(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package list_restrictions
+
+//go:generate mockgen -source=list_restrictions.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type inventoryService interface {
+	GetRestrictions(ctx context.Context, query domain.CalendarQuery, roomType domain.RoomType) ([]domain.Restriction, error)
+}
+
+type Handler struct {
+	inventory inventoryService
+}
+
+func NewHandler(inventoryService inventoryService) *Handler {
+	return &Handler{
+		inventory: inventoryService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid hotel id", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	params := r.URL.Query()
+	query := domain.CalendarQuery{HotelID: domain.HotelID(id)}
+
+	if query.From, err = time.Parse(time.DateOnly, params.Get("from")); err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid from, use YYYY-MM-DD", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	if query.To, err = time.Parse(time.DateOnly, params.Get("to")); err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid to, use YYYY-MM-DD", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	restrictions, err := h.inventory.GetRestrictions(r.Context(), query, domain.RoomType(params.Get("room_type")))
+	if err != nil {
+		if errors.Is(err, domain.ErrHotelNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "such hotel doesn't exist", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to get restrictions", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to get restrictions", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, restrictions)
+}
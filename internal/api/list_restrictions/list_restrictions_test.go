@@ -0,0 +1,142 @@
+package list_restrictions
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/api/list_restrictions/mocks"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInventoryService := mocks.NewMockinventoryService(ctrl)
+
+	r := chi.NewRouter()
+	r.Get("/hotels/{id}/restrictions", NewHandler(mockInventoryService).Handle)
+
+	query := domain.CalendarQuery{HotelID: 1, From: date.Date(2025, 1, 1), To: date.Date(2025, 1, 31)}
+
+	restrictions := []domain.Restriction{
+		{HotelID: 1, RoomType: domain.RoomTypeLux, Date: date.Date(2025, 1, 10), StopSell: true},
+		{HotelID: 1, RoomType: domain.RoomTypeSingle, Date: date.Date(2025, 1, 11), ClosedToArrival: true, MinStay: 2},
+	}
+
+	tests := []struct {
+		name            string
+		path            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "hotel id isn't a number",
+			path:            "/hotels/abc/restrictions?from=2025-01-01&to=2025-01-31",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel id",
+		},
+		{
+			name:            "from is missing",
+			path:            "/hotels/1/restrictions?to=2025-01-31",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid from, use YYYY-MM-DD",
+		},
+		{
+			name:            "to is malformed",
+			path:            "/hotels/1/restrictions?from=2025-01-01&to=31.01.2025",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid to, use YYYY-MM-DD",
+		},
+		{
+			name: "restrictions of the hotel are returned",
+			path: "/hotels/1/restrictions?from=2025-01-01&to=2025-01-31",
+			mockSetup: func() {
+				mockInventoryService.EXPECT().GetRestrictions(gomock.Any(), query, domain.RoomType("")).Return(restrictions, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   restrictions,
+		},
+		{
+			name: "restrictions of the room type are returned",
+			path: "/hotels/1/restrictions?from=2025-01-01&to=2025-01-31&room_type=lux",
+			mockSetup: func() {
+				mockInventoryService.EXPECT().GetRestrictions(gomock.Any(), query, domain.RoomTypeLux).
+					Return(restrictions[:1], nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   restrictions[:1],
+		},
+		{
+			name: "hotel not found",
+			path: "/hotels/1/restrictions?from=2025-01-01&to=2025-01-31",
+			mockSetup: func() {
+				mockInventoryService.EXPECT().GetRestrictions(gomock.Any(), query, domain.RoomType("")).
+					Return(nil, domain.ErrHotelNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such hotel doesn't exist",
+		},
+		{
+			name: "invalid query",
+			path: "/hotels/1/restrictions?from=2025-01-31&to=2025-01-01",
+			mockSetup: func() {
+				mockInventoryService.EXPECT().GetRestrictions(gomock.Any(), domain.CalendarQuery{
+					HotelID: 1,
+					From:    date.Date(2025, 1, 31),
+					To:      date.Date(2025, 1, 1),
+				}, domain.RoomType("")).Return(nil, fmt.Errorf("%w: to is before from", domain.ErrInvalidQuery))
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid query: to is before from",
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			path: "/hotels/1/restrictions?from=2025-01-01&to=2025-01-31",
+			mockSetup: func() {
+				mockInventoryService.EXPECT().GetRestrictions(gomock.Any(), query, domain.RoomType("")).
+					Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to get restrictions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: list_restrictions.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockinventoryService is a mock of inventoryService interface.
+type MockinventoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockinventoryServiceMockRecorder
+}
+
+// MockinventoryServiceMockRecorder is the mock recorder for MockinventoryService.
+type MockinventoryServiceMockRecorder struct {
+	mock *MockinventoryService
+}
+
+// NewMockinventoryService creates a new mock instance.
+func NewMockinventoryService(ctrl *gomock.Controller) *MockinventoryService {
+	mock := &MockinventoryService{ctrl: ctrl}
+	mock.recorder = &MockinventoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockinventoryService) EXPECT() *MockinventoryServiceMockRecorder {
+	return m.recorder
+}
+
+// GetRestrictions mocks base method.
+func (m *MockinventoryService) GetRestrictions(ctx context.Context, query domain.CalendarQuery, roomType domain.RoomType) ([]domain.Restriction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRestrictions", ctx, query, roomType)
+	ret0, _ := ret[0].([]domain.Restriction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRestrictions indicates an expected call of GetRestrictions.
+func (mr *MockinventoryServiceMockRecorder) GetRestrictions(ctx, query, roomType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRestrictions", reflect.TypeOf((*MockinventoryService)(nil).GetRestrictions), ctx, query, roomType)
+}
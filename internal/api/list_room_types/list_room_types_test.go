@@ -0,0 +1,96 @@
+package list_room_types
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/api/list_room_types/mocks"
+	"applicationDesignTest/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelService := mocks.NewMockhotelService(ctrl)
+
+	r := chi.NewRouter()
+	r.Get("/hotels/{id}/room-types", NewHandler(mockHotelService).Handle)
+
+	roomTypes := []domain.HotelRoomType{
+		{HotelID: 1, Code: domain.RoomTypeSingle, Name: "Single", Capacity: 1},
+		{HotelID: 1, Code: domain.RoomTypeLux, Name: "Lux", Capacity: 2, Amenities: []string{"minibar"}},
+	}
+
+	tests := []struct {
+		name            string
+		hotelID         string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "hotel id isn't a number",
+			hotelID:         "abc",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel id",
+		},
+		{
+			name:    "room types are returned",
+			hotelID: "1",
+			mockSetup: func() {
+				mockHotelService.EXPECT().GetRoomTypes(gomock.Any(), domain.HotelID(1)).Return(roomTypes, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   roomTypes,
+		},
+		{
+			name:    "hotel not found",
+			hotelID: "1",
+			mockSetup: func() {
+				mockHotelService.EXPECT().GetRoomTypes(gomock.Any(), domain.HotelID(1)).Return(nil, domain.ErrHotelNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such hotel doesn't exist",
+		},
+		{
+			name:    "unexpected error isn't disclosed",
+			hotelID: "1",
+			mockSetup: func() {
+				mockHotelService.EXPECT().GetRoomTypes(gomock.Any(), domain.HotelID(1)).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to get room types",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, "/hotels/"+tt.hotelID+"/room-types", nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package list_webhooks
+
+//go:generate mockgen -source=list_webhooks.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+)
+
+type webhookService interface {
+	GetWebhooks(ctx context.Context, hotelID domain.HotelID) ([]domain.Webhook, error)
+}
+
+type Handler struct {
+	webhooks webhookService
+}
+
+func NewHandler(webhookService webhookService) *Handler {
+	return &Handler{
+		webhooks: webhookService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var hotelID int
+
+	if value := r.URL.Query().Get("hotel_id"); value != "" {
+		var err error
+
+		hotelID, err = strconv.Atoi(value)
+		if err != nil || hotelID <= 0 {
+			http_helpers.SendError(w, http.StatusBadRequest, "invalid hotel_id", http_helpers.ErrorTypeValidationError)
+			return
+		}
+	}
+
+	webhooks, err := h.webhooks.GetWebhooks(ctx, domain.HotelID(hotelID))
+	if err != nil {
+		log.Error("failed to get webhooks", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to get webhooks", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	infos := make([]domain.WebhookInfo, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		infos = append(infos, webhook.Info())
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, infos)
+}
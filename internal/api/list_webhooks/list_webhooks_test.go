@@ -0,0 +1,103 @@
+package list_webhooks
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/api/list_webhooks/mocks"
+	"applicationDesignTest/internal/domain"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookService := mocks.NewMockwebhookService(ctrl)
+
+	h := NewHandler(mockWebhookService)
+
+	createdAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	webhooks := []domain.Webhook{
+		{ID: 1, HotelID: 1, URL: "https://partner.example/hook", Secret: "s3cr3t", CreatedAt: createdAt},
+		{ID: 2, HotelID: 2, URL: "https://other.example/hook", Secret: "0th3r",
+			EventTypes: []domain.EventType{domain.EventOrderCancelled}, CreatedAt: createdAt},
+	}
+
+	tests := []struct {
+		name            string
+		query           string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "hotel id isn't a number",
+			query:           "?hotel_id=abc",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel_id",
+		},
+		{
+			name:            "hotel id isn't positive",
+			query:           "?hotel_id=0",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel_id",
+		},
+		{
+			name: "all webhooks are returned without their secrets",
+			mockSetup: func() {
+				mockWebhookService.EXPECT().GetWebhooks(gomock.Any(), domain.HotelID(0)).Return(webhooks, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   []domain.WebhookInfo{webhooks[0].Info(), webhooks[1].Info()},
+		},
+		{
+			name:  "hotel without webhooks gets an empty list",
+			query: "?hotel_id=3",
+			mockSetup: func() {
+				mockWebhookService.EXPECT().GetWebhooks(gomock.Any(), domain.HotelID(3)).Return(nil, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   []domain.WebhookInfo{},
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			mockSetup: func() {
+				mockWebhookService.EXPECT().GetWebhooks(gomock.Any(), domain.HotelID(0)).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to get webhooks",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, "/webhooks"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			h.Handle(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
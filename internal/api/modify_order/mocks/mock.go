@@ -0,0 +1,90 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: modify_order.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockbookingService is a mock of bookingService interface.
+type MockbookingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockbookingServiceMockRecorder
+}
+
+// MockbookingServiceMockRecorder is the mock recorder for MockbookingService.
+type MockbookingServiceMockRecorder struct {
+	mock *MockbookingService
+}
+
+// NewMockbookingService creates a new mock instance.
+func NewMockbookingService(ctrl *gomock.Controller) *MockbookingService {
+	mock := &MockbookingService{ctrl: ctrl}
+	mock.recorder = &MockbookingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbookingService) EXPECT() *MockbookingServiceMockRecorder {
+	return m.recorder
+}
+
+// ModifyOrder mocks base method.
+func (m *MockbookingService) ModifyOrder(ctx context.Context, orderNumber domain.OrderNumber, bookings []domain.Booking, paymentToken domain.PaymentToken) (*domain.Order, *domain.BookingsDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyOrder", ctx, orderNumber, bookings, paymentToken)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(*domain.BookingsDiff)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ModifyOrder indicates an expected call of ModifyOrder.
+func (mr *MockbookingServiceMockRecorder) ModifyOrder(ctx, orderNumber, bookings, paymentToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyOrder", reflect.TypeOf((*MockbookingService)(nil).ModifyOrder), ctx, orderNumber, bookings, paymentToken)
+}
+
+// MockroomTypeService is a mock of roomTypeService interface.
+type MockroomTypeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockroomTypeServiceMockRecorder
+}
+
+// MockroomTypeServiceMockRecorder is the mock recorder for MockroomTypeService.
+type MockroomTypeServiceMockRecorder struct {
+	mock *MockroomTypeService
+}
+
+// NewMockroomTypeService creates a new mock instance.
+func NewMockroomTypeService(ctrl *gomock.Controller) *MockroomTypeService {
+	mock := &MockroomTypeService{ctrl: ctrl}
+	mock.recorder = &MockroomTypeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockroomTypeService) EXPECT() *MockroomTypeServiceMockRecorder {
+	return m.recorder
+}
+
+// GetRoomType mocks base method.
+func (m *MockroomTypeService) GetRoomType(ctx context.Context, hotelID domain.HotelID, code domain.RoomType) (*domain.HotelRoomType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoomType", ctx, hotelID, code)
+	ret0, _ := ret[0].(*domain.HotelRoomType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoomType indicates an expected call of GetRoomType.
+func (mr *MockroomTypeServiceMockRecorder) GetRoomType(ctx, hotelID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoomType", reflect.TypeOf((*MockroomTypeService)(nil).GetRoomType), ctx, hotelID, code)
+}
@@ -0,0 +1,186 @@
+package modify_order
+
+//go:generate mockgen -source=modify_order.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+	"applicationDesignTest/pkg/log"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type request struct {
+	// PaymentToken is the card the raised total is charged to.
+	PaymentToken domain.PaymentToken `json:"payment_token"`
+	Bookings     []booking           `json:"booking"`
+}
+
+type booking struct {
+	HotelID   domain.HotelID         `json:"hotel_id"`
+	RoomType  domain.RoomType        `json:"room_type"`
+	From      date.CustomDate        `json:"from"`
+	To        date.CustomDate        `json:"to"`
+	RoomCount int                    `json:"room_count"`
+	Rooms     []domain.RoomOccupancy `json:"rooms"`
+}
+
+type response struct {
+	Order *domain.Order        `json:"order"`
+	Diff  *domain.BookingsDiff `json:"diff"`
+}
+
+type bookingService interface {
+	ModifyOrder(ctx context.Context, orderNumber domain.OrderNumber, bookings []domain.Booking,
+		paymentToken domain.PaymentToken) (*domain.Order, *domain.BookingsDiff, error)
+}
+
+// roomTypeService is the room type catalog of the hotels the bookings are validated against.
+type roomTypeService interface {
+	GetRoomType(ctx context.Context, hotelID domain.HotelID, code domain.RoomType) (*domain.HotelRoomType, error)
+}
+
+type Handler struct {
+	booking bookingService
+	catalog roomTypeService
+}
+
+func NewHandler(bookingService bookingService, roomTypeService roomTypeService) *Handler {
+	return &Handler{
+		booking: bookingService,
+		catalog: roomTypeService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orderNumber, err := strconv.Atoi(chi.URLParam(r, "orderNumber"))
+	if err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid order number", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	var req request
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warning(fmt.Sprintf("failed to decode request: %s", err.Error()))
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid input", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	if len(req.Bookings) == 0 {
+		http_helpers.SendError(w, http.StatusBadRequest, "booking is empty", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	bookings := make([]domain.Booking, 0, len(req.Bookings))
+
+	for _, book := range req.Bookings {
+		if book.To.Before(book.From.Time) {
+			http_helpers.SendError(w, http.StatusBadRequest,
+				fmt.Sprintf("invalid date range for hotel id %v", book.HotelID),
+				http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		roomType, err := h.catalog.GetRoomType(ctx, book.HotelID, book.RoomType)
+		if err != nil {
+			if errors.Is(err, domain.ErrHotelNotFound) {
+				http_helpers.SendError(w, http.StatusBadRequest,
+					fmt.Sprintf("invalid hotel id %v", book.HotelID), http_helpers.ErrorTypeValidationError)
+				return
+			}
+
+			if errors.Is(err, domain.ErrRoomTypeNotFound) {
+				http_helpers.SendError(w, http.StatusBadRequest,
+					fmt.Sprintf("invalid room_type '%s' for hotel id %v", book.RoomType, book.HotelID),
+					http_helpers.ErrorTypeValidationError)
+				return
+			}
+
+			log.Error("failed to get room type", err)
+			http_helpers.SendError(w, http.StatusInternalServerError, "failed to modify order", http_helpers.ErrorTypeInternalError)
+			return
+		}
+
+		newBooking := domain.Booking{
+			HotelID:   book.HotelID,
+			RoomType:  book.RoomType,
+			From:      book.From.Time,
+			To:        book.To.Time,
+			RoomCount: book.RoomCount,
+			Rooms:     book.Rooms,
+		}
+
+		if err := newBooking.Validate(); err != nil {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if err := newBooking.ValidateOccupancy(*roomType); err != nil {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		bookings = append(bookings, newBooking)
+	}
+
+	order, diff, err := h.booking.ModifyOrder(ctx, domain.OrderNumber(orderNumber), bookings, req.PaymentToken)
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "such order doesn't exist", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrHotelNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "invalid hotel id", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrRoomTypeNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "invalid room type", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrRoomsNotAvailable) || errors.Is(err, domain.ErrRestrictionViolated) ||
+			errors.Is(err, domain.ErrOrderNotModifiable) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrRateNotFound) || errors.Is(err, domain.ErrCurrencyMismatch) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrPaymentDeclined) {
+			http_helpers.SendError(w, http.StatusPaymentRequired, err.Error(), http_helpers.ErrorTypePaymentError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrPaymentTimeout) {
+			http_helpers.SendError(w, http.StatusGatewayTimeout, err.Error(), http_helpers.ErrorTypePaymentError)
+			return
+		}
+
+		log.Error("failed to modify order", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to modify order", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, response{
+		Order: order,
+		Diff:  diff,
+	})
+
+	log.WithField("order", order).Info("order modified")
+}
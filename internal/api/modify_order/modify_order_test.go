@@ -0,0 +1,307 @@
+package modify_order
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/api/modify_order/mocks"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockbookingService(ctrl)
+	mockRoomTypeService := mocks.NewMockroomTypeService(ctrl)
+
+	r := chi.NewRouter()
+	r.Patch("/orders/{orderNumber}", NewHandler(mockBookingService, mockRoomTypeService).Handle)
+
+	validBody := `{"payment_token": "tok_visa", "booking": [{"hotel_id": 1, "room_type": "double",
+		"from": "2025-01-10", "to": "2025-01-14", "room_count": 1, "rooms": [{"adults": 1, "children": 1}]}]}`
+
+	roomType := &domain.HotelRoomType{HotelID: 1, Code: domain.RoomTypeDouble, Name: "Double", Capacity: 2}
+
+	bookings := []domain.Booking{{
+		HotelID:   1,
+		RoomType:  domain.RoomTypeDouble,
+		From:      date.Date(2025, 1, 10),
+		To:        date.Date(2025, 1, 14),
+		RoomCount: 1,
+		Rooms:     []domain.RoomOccupancy{{Adults: 1, Children: 1}},
+	}}
+
+	oldBookings := []domain.Booking{{
+		HotelID:   1,
+		RoomType:  domain.RoomTypeDouble,
+		From:      date.Date(2025, 1, 10),
+		To:        date.Date(2025, 1, 12),
+		RoomCount: 1,
+	}}
+
+	modifiedOrder := &domain.Order{
+		ID:       "order-1",
+		Number:   7,
+		UserID:   1,
+		Status:   domain.OrderStatusConfirmed,
+		Bookings: bookings,
+		Subtotal: domain.Money{Amount: 10000, Currency: "RUB"},
+		Total:    domain.Money{Amount: 10000, Currency: "RUB"},
+	}
+
+	diff := &domain.BookingsDiff{Added: bookings, Removed: oldBookings}
+
+	tests := []struct {
+		name            string
+		orderNumber     string
+		body            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "order number isn't a number",
+			orderNumber:     "abc",
+			body:            validBody,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid order number",
+		},
+		{
+			name:            "malformed body",
+			orderNumber:     "7",
+			body:            `{"booking": `,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid input",
+		},
+		{
+			name:            "empty booking",
+			orderNumber:     "7",
+			body:            `{"booking": []}`,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "booking is empty",
+		},
+		{
+			name:        "stay ends before it starts",
+			orderNumber: "7",
+			body: `{"booking": [{"hotel_id": 1, "room_type": "double",
+				"from": "2025-01-14", "to": "2025-01-10", "room_count": 1}]}`,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid date range for hotel id 1",
+		},
+		{
+			name:        "unknown hotel",
+			orderNumber: "7",
+			body:        validBody,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeDouble).
+					Return(nil, domain.ErrHotelNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel id 1",
+		},
+		{
+			name:        "unknown room type",
+			orderNumber: "7",
+			body:        validBody,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeDouble).
+					Return(nil, domain.ErrRoomTypeNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid room_type 'double' for hotel id 1",
+		},
+		{
+			name:        "room type lookup fails",
+			orderNumber: "7",
+			body:        validBody,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeDouble).
+					Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to modify order",
+		},
+		{
+			name:        "room without adults",
+			orderNumber: "7",
+			body: `{"booking": [{"hotel_id": 1, "room_type": "double",
+				"from": "2025-01-10", "to": "2025-01-14", "room_count": 1, "rooms": [{"children": 2}]}]}`,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeDouble).
+					Return(roomType, nil)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid occupancy: room 1 of 'double' must have at least one adult",
+		},
+		{
+			name:        "order is modified",
+			orderNumber: "7",
+			body:        validBody,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeDouble).
+					Return(roomType, nil)
+				mockBookingService.EXPECT().ModifyOrder(gomock.Any(), domain.OrderNumber(7), bookings,
+					domain.PaymentToken("tok_visa")).Return(modifiedOrder, diff, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   response{Order: modifiedOrder, Diff: diff},
+		},
+		{
+			name:        "unexpected error isn't disclosed",
+			orderNumber: "7",
+			body:        validBody,
+			mockSetup: func() {
+				mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeDouble).
+					Return(roomType, nil)
+				mockBookingService.EXPECT().ModifyOrder(gomock.Any(), domain.OrderNumber(7), bookings,
+					domain.PaymentToken("tok_visa")).Return(nil, nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to modify order",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPatch, "/orders/"+tt.orderNumber, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
+
+// TestHandler_Handle_Rejected checks how the errors of the booking service are answered.
+func TestHandler_Handle_Rejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockbookingService(ctrl)
+	mockRoomTypeService := mocks.NewMockroomTypeService(ctrl)
+
+	r := chi.NewRouter()
+	r.Patch("/orders/{orderNumber}", NewHandler(mockBookingService, mockRoomTypeService).Handle)
+
+	body := `{"booking": [{"hotel_id": 1, "room_type": "double",
+		"from": "2025-01-10", "to": "2025-01-14", "room_count": 1}]}`
+
+	roomType := &domain.HotelRoomType{HotelID: 1, Code: domain.RoomTypeDouble, Name: "Double", Capacity: 2}
+
+	tests := []struct {
+		err             error
+		expectedStatus  int
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			err:             domain.ErrOrderNotFound,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such order doesn't exist",
+		},
+		{
+			err:             domain.ErrHotelNotFound,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel id",
+		},
+		{
+			err:             domain.ErrRoomTypeNotFound,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid room type",
+		},
+		{
+			err:             fmt.Errorf("%w: 'double' on 2025-01-13", domain.ErrRoomsNotAvailable),
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "rooms not available: 'double' on 2025-01-13",
+		},
+		{
+			err:             fmt.Errorf("%w: closed to departure on 2025-01-15", domain.ErrRestrictionViolated),
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "restriction violated: closed to departure on 2025-01-15",
+		},
+		{
+			err:             fmt.Errorf("%w: order is checked in", domain.ErrOrderNotModifiable),
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "order can't be modified: order is checked in",
+		},
+		{
+			err:             domain.ErrRateNotFound,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "rate not found",
+		},
+		{
+			err:             domain.ErrCurrencyMismatch,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "currency mismatch",
+		},
+		{
+			err:             domain.ErrPaymentDeclined,
+			expectedStatus:  http.StatusPaymentRequired,
+			expectedError:   http_helpers.ErrorTypePaymentError,
+			expectedMessage: "payment declined",
+		},
+		{
+			err:             domain.ErrPaymentTimeout,
+			expectedStatus:  http.StatusGatewayTimeout,
+			expectedError:   http_helpers.ErrorTypePaymentError,
+			expectedMessage: "payment gateway timeout",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			mockRoomTypeService.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomTypeDouble).
+				Return(roomType, nil)
+			mockBookingService.EXPECT().ModifyOrder(gomock.Any(), domain.OrderNumber(7), gomock.Any(), gomock.Any()).
+				Return(nil, nil, tt.err)
+
+			req := httptest.NewRequest(http.MethodPatch, "/orders/7", strings.NewReader(body))
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reduce_capacity.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockinventoryService is a mock of inventoryService interface.
+type MockinventoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockinventoryServiceMockRecorder
+}
+
+// MockinventoryServiceMockRecorder is the mock recorder for MockinventoryService.
+type MockinventoryServiceMockRecorder struct {
+	mock *MockinventoryService
+}
+
+// NewMockinventoryService creates a new mock instance.
+func NewMockinventoryService(ctrl *gomock.Controller) *MockinventoryService {
+	mock := &MockinventoryService{ctrl: ctrl}
+	mock.recorder = &MockinventoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockinventoryService) EXPECT() *MockinventoryServiceMockRecorder {
+	return m.recorder
+}
+
+// ReduceRoomCapacity mocks base method.
+func (m *MockinventoryService) ReduceRoomCapacity(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReduceRoomCapacity", ctx, hotelID, roomType, date, rooms)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReduceRoomCapacity indicates an expected call of ReduceRoomCapacity.
+func (mr *MockinventoryServiceMockRecorder) ReduceRoomCapacity(ctx, hotelID, roomType, date, rooms interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReduceRoomCapacity", reflect.TypeOf((*MockinventoryService)(nil).ReduceRoomCapacity), ctx, hotelID, roomType, date, rooms)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: search_availability.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MocksearchService is a mock of searchService interface.
+type MocksearchService struct {
+	ctrl     *gomock.Controller
+	recorder *MocksearchServiceMockRecorder
+}
+
+// MocksearchServiceMockRecorder is the mock recorder for MocksearchService.
+type MocksearchServiceMockRecorder struct {
+	mock *MocksearchService
+}
+
+// NewMocksearchService creates a new mock instance.
+func NewMocksearchService(ctrl *gomock.Controller) *MocksearchService {
+	mock := &MocksearchService{ctrl: ctrl}
+	mock.recorder = &MocksearchServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksearchService) EXPECT() *MocksearchServiceMockRecorder {
+	return m.recorder
+}
+
+// SearchAvailability mocks base method.
+func (m *MocksearchService) SearchAvailability(ctx context.Context, query domain.AvailabilityQuery) (*domain.AvailabilityPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAvailability", ctx, query)
+	ret0, _ := ret[0].(*domain.AvailabilityPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchAvailability indicates an expected call of SearchAvailability.
+func (mr *MocksearchServiceMockRecorder) SearchAvailability(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAvailability", reflect.TypeOf((*MocksearchService)(nil).SearchAvailability), ctx, query)
+}
@@ -0,0 +1,84 @@
+package search_availability
+
+//go:generate mockgen -source=search_availability.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+type searchService interface {
+	SearchAvailability(ctx context.Context, query domain.AvailabilityQuery) (*domain.AvailabilityPage, error)
+}
+
+type Handler struct {
+	search searchService
+}
+
+func NewHandler(searchService searchService) *Handler {
+	return &Handler{
+		search: searchService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	query := domain.AvailabilityQuery{
+		City:     params.Get("city"),
+		RoomType: domain.RoomType(params.Get("room_type")),
+		Rooms:    1,
+		Limit:    defaultLimit,
+	}
+
+	var err error
+
+	if query.From, err = time.Parse(time.DateOnly, params.Get("from")); err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid from, use YYYY-MM-DD", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	if query.To, err = time.Parse(time.DateOnly, params.Get("to")); err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid to, use YYYY-MM-DD", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	for name, value := range map[string]*int{"rooms": &query.Rooms, "offset": &query.Offset, "limit": &query.Limit} {
+		if param := params.Get(name); param != "" {
+			if *value, err = strconv.Atoi(param); err != nil {
+				http_helpers.SendError(w, http.StatusBadRequest, "invalid "+name, http_helpers.ErrorTypeValidationError)
+				return
+			}
+		}
+	}
+
+	if query.Limit > maxLimit {
+		query.Limit = maxLimit
+	}
+
+	page, err := h.search.SearchAvailability(r.Context(), query)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to search availability", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to search availability", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, page)
+}
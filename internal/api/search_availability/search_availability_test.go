@@ -0,0 +1,147 @@
+package search_availability
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/api/search_availability/mocks"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSearchService := mocks.NewMocksearchService(ctrl)
+
+	h := NewHandler(mockSearchService)
+
+	defaultQuery := domain.AvailabilityQuery{
+		From:  date.Date(2025, 1, 10),
+		To:    date.Date(2025, 1, 12),
+		Rooms: 1,
+		Limit: defaultLimit,
+	}
+
+	page := &domain.AvailabilityPage{
+		Items: []domain.RoomTypeAvailability{
+			{HotelID: 1, HotelName: "Grand", City: "Moscow", RoomType: domain.RoomTypeLux, Name: "Lux", Capacity: 2,
+				Bookable: true, MinRooms: 3},
+			{HotelID: 1, HotelName: "Grand", City: "Moscow", RoomType: domain.RoomTypeSingle, Name: "Single",
+				Capacity: 1, MinRooms: 5, Restriction: domain.RuleMinStay},
+		},
+		Total: 2,
+		Limit: defaultLimit,
+	}
+
+	tests := []struct {
+		name            string
+		query           string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "from is missing",
+			query:           "?to=2025-01-12",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid from, use YYYY-MM-DD",
+		},
+		{
+			name:            "malformed to",
+			query:           "?from=2025-01-10&to=12.01.2025",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid to, use YYYY-MM-DD",
+		},
+		{
+			name:            "rooms isn't a number",
+			query:           "?from=2025-01-10&to=2025-01-12&rooms=two",
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid rooms",
+		},
+		{
+			name:  "defaults are applied",
+			query: "?from=2025-01-10&to=2025-01-12",
+			mockSetup: func() {
+				mockSearchService.EXPECT().SearchAvailability(gomock.Any(), defaultQuery).Return(page, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   page,
+		},
+		{
+			name:  "filters are passed and the limit is capped",
+			query: "?from=2025-01-10&to=2025-01-12&city=Moscow&room_type=lux&rooms=2&offset=20&limit=1000",
+			mockSetup: func() {
+				mockSearchService.EXPECT().SearchAvailability(gomock.Any(), domain.AvailabilityQuery{
+					From:     date.Date(2025, 1, 10),
+					To:       date.Date(2025, 1, 12),
+					Rooms:    2,
+					City:     "Moscow",
+					RoomType: domain.RoomTypeLux,
+					Offset:   20,
+					Limit:    maxLimit,
+				}).Return(&domain.AvailabilityPage{Items: []domain.RoomTypeAvailability{}, Total: 2, Offset: 20, Limit: maxLimit}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   &domain.AvailabilityPage{Items: []domain.RoomTypeAvailability{}, Total: 2, Offset: 20, Limit: maxLimit},
+		},
+		{
+			name:  "invalid query",
+			query: "?from=2025-01-12&to=2025-01-10",
+			mockSetup: func() {
+				mockSearchService.EXPECT().SearchAvailability(gomock.Any(), domain.AvailabilityQuery{
+					From:  date.Date(2025, 1, 12),
+					To:    date.Date(2025, 1, 10),
+					Rooms: 1,
+					Limit: defaultLimit,
+				}).Return(nil, fmt.Errorf("%w: to is before from", domain.ErrInvalidQuery))
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid query: to is before from",
+		},
+		{
+			name:  "unexpected error isn't disclosed",
+			query: "?from=2025-01-10&to=2025-01-12",
+			mockSetup: func() {
+				mockSearchService.EXPECT().SearchAvailability(gomock.Any(), defaultQuery).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to search availability",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, "/availability"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			h.Handle(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: set_cancellation_policy.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockpricingService is a mock of pricingService interface.
+type MockpricingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockpricingServiceMockRecorder
+}
+
+// MockpricingServiceMockRecorder is the mock recorder for MockpricingService.
+type MockpricingServiceMockRecorder struct {
+	mock *MockpricingService
+}
+
+// NewMockpricingService creates a new mock instance.
+func NewMockpricingService(ctrl *gomock.Controller) *MockpricingService {
+	mock := &MockpricingService{ctrl: ctrl}
+	mock.recorder = &MockpricingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpricingService) EXPECT() *MockpricingServiceMockRecorder {
+	return m.recorder
+}
+
+// SetCancellationPolicy mocks base method.
+func (m *MockpricingService) SetCancellationPolicy(ctx context.Context, policy domain.CancellationPolicy) (*domain.CancellationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCancellationPolicy", ctx, policy)
+	ret0, _ := ret[0].(*domain.CancellationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCancellationPolicy indicates an expected call of SetCancellationPolicy.
+func (mr *MockpricingServiceMockRecorder) SetCancellationPolicy(ctx, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCancellationPolicy", reflect.TypeOf((*MockpricingService)(nil).SetCancellationPolicy), ctx, policy)
+}
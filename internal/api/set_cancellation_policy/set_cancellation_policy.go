@@ -0,0 +1,77 @@
+package set_cancellation_policy
+
+//go:generate mockgen -source=set_cancellation_policy.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+)
+
+type request struct {
+	HotelID        domain.HotelID  `json:"hotel_id"`
+	RoomType       domain.RoomType `json:"room_type"`
+	FreeDays       int             `json:"free_days"`
+	PenaltyPercent int             `json:"penalty_percent"`
+	PenaltyNights  int             `json:"penalty_nights"`
+	NonRefundable  bool            `json:"non_refundable"`
+}
+
+type pricingService interface {
+	SetCancellationPolicy(ctx context.Context, policy domain.CancellationPolicy) (*domain.CancellationPolicy, error)
+}
+
+type Handler struct {
+	pricing pricingService
+}
+
+func NewHandler(pricingService pricingService) *Handler {
+	return &Handler{
+		pricing: pricingService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	var req request
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid input", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	policy, err := h.pricing.SetCancellationPolicy(r.Context(), domain.CancellationPolicy{
+		HotelID:        req.HotelID,
+		RoomType:       req.RoomType,
+		FreeDays:       req.FreeDays,
+		PenaltyPercent: req.PenaltyPercent,
+		PenaltyNights:  req.PenaltyNights,
+		NonRefundable:  req.NonRefundable,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrHotelNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "invalid hotel id", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrRoomTypeNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "invalid room type", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidPolicy) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to set cancellation policy", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to set cancellation policy", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, policy)
+}
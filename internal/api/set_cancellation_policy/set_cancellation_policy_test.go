@@ -0,0 +1,127 @@
+package set_cancellation_policy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/api/set_cancellation_policy/mocks"
+	"applicationDesignTest/internal/domain"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPricingService := mocks.NewMockpricingService(ctrl)
+
+	h := NewHandler(mockPricingService)
+
+	validBody := `{"hotel_id": 1, "room_type": "lux", "free_days": 3, "penalty_percent": 50}`
+
+	policy := domain.CancellationPolicy{
+		HotelID:        1,
+		RoomType:       domain.RoomTypeLux,
+		FreeDays:       3,
+		PenaltyPercent: 50,
+	}
+
+	tests := []struct {
+		name            string
+		body            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "malformed body",
+			body:            `{"hotel_id": `,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid input",
+		},
+		{
+			name: "policy is set",
+			body: validBody,
+			mockSetup: func() {
+				mockPricingService.EXPECT().SetCancellationPolicy(gomock.Any(), policy).Return(&policy, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   policy,
+		},
+		{
+			name: "invalid policy",
+			body: `{"hotel_id": 1, "room_type": "lux", "penalty_nights": 1, "non_refundable": true}`,
+			mockSetup: func() {
+				nonRefundable := domain.CancellationPolicy{
+					HotelID:       1,
+					RoomType:      domain.RoomTypeLux,
+					PenaltyNights: 1,
+					NonRefundable: true,
+				}
+				mockPricingService.EXPECT().SetCancellationPolicy(gomock.Any(), nonRefundable).
+					Return(nil, fmt.Errorf("%w: non-refundable policy has no free days or penalty", domain.ErrInvalidPolicy))
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid cancellation policy: non-refundable policy has no free days or penalty",
+		},
+		{
+			name: "unknown hotel",
+			body: validBody,
+			mockSetup: func() {
+				mockPricingService.EXPECT().SetCancellationPolicy(gomock.Any(), policy).Return(nil, domain.ErrHotelNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel id",
+		},
+		{
+			name: "unknown room type",
+			body: validBody,
+			mockSetup: func() {
+				mockPricingService.EXPECT().SetCancellationPolicy(gomock.Any(), policy).Return(nil, domain.ErrRoomTypeNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid room type",
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			body: validBody,
+			mockSetup: func() {
+				mockPricingService.EXPECT().SetCancellationPolicy(gomock.Any(), policy).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to set cancellation policy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPut, "/hotels/cancellation-policies", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			h.Handle(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: set_capacity.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockinventoryService is a mock of inventoryService interface.
+type MockinventoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockinventoryServiceMockRecorder
+}
+
+// MockinventoryServiceMockRecorder is the mock recorder for MockinventoryService.
+type MockinventoryServiceMockRecorder struct {
+	mock *MockinventoryService
+}
+
+// NewMockinventoryService creates a new mock instance.
+func NewMockinventoryService(ctrl *gomock.Controller) *MockinventoryService {
+	mock := &MockinventoryService{ctrl: ctrl}
+	mock.recorder = &MockinventoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockinventoryService) EXPECT() *MockinventoryServiceMockRecorder {
+	return m.recorder
+}
+
+// SetRoomCapacity mocks base method.
+func (m *MockinventoryService) SetRoomCapacity(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, capacity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRoomCapacity", ctx, hotelID, roomType, date, capacity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRoomCapacity indicates an expected call of SetRoomCapacity.
+func (mr *MockinventoryServiceMockRecorder) SetRoomCapacity(ctx, hotelID, roomType, date, capacity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRoomCapacity", reflect.TypeOf((*MockinventoryService)(nil).SetRoomCapacity), ctx, hotelID, roomType, date, capacity)
+}
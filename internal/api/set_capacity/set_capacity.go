@@ -0,0 +1,69 @@
+package set_capacity
+
+//go:generate mockgen -source=set_capacity.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+	"applicationDesignTest/pkg/log"
+)
+
+type request struct {
+	HotelID  domain.HotelID  `json:"hotel_id"`
+	RoomType domain.RoomType `json:"room_type"`
+	Date     date.CustomDate `json:"date"`
+	Capacity int             `json:"capacity"`
+}
+
+type inventoryService interface {
+	SetRoomCapacity(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, capacity int) error
+}
+
+type Handler struct {
+	inventory inventoryService
+}
+
+func NewHandler(inventoryService inventoryService) *Handler {
+	return &Handler{
+		inventory: inventoryService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	var req request
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid input", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	if err := h.inventory.SetRoomCapacity(r.Context(), req.HotelID, req.RoomType, req.Date.Time, req.Capacity); err != nil {
+		if errors.Is(err, domain.ErrHotelNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "invalid hotel id", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrRoomTypeNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "invalid room type", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidAvailability) || errors.Is(err, domain.ErrCapacityBelowReserved) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to set capacity", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to set capacity", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, nil)
+}
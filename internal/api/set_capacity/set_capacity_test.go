@@ -0,0 +1,132 @@
+package set_capacity
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/api/set_capacity/mocks"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInventoryService := mocks.NewMockinventoryService(ctrl)
+
+	h := NewHandler(mockInventoryService)
+
+	validBody := `{"hotel_id": 1, "room_type": "lux", "date": "2025-01-10", "capacity": 3}`
+
+	tests := []struct {
+		name            string
+		body            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "malformed date",
+			body:            `{"hotel_id": 1, "room_type": "lux", "date": "10.01.2025", "capacity": 3}`,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid input",
+		},
+		{
+			name: "capacity is set",
+			body: validBody,
+			mockSetup: func() {
+				mockInventoryService.EXPECT().SetRoomCapacity(gomock.Any(), domain.HotelID(1), domain.RoomTypeLux, date.Date(2025, 1, 10), 3).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "unknown hotel",
+			body: validBody,
+			mockSetup: func() {
+				mockInventoryService.EXPECT().SetRoomCapacity(gomock.Any(), domain.HotelID(1), domain.RoomTypeLux, date.Date(2025, 1, 10), 3).
+					Return(domain.ErrHotelNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel id",
+		},
+		{
+			name: "unknown room type",
+			body: validBody,
+			mockSetup: func() {
+				mockInventoryService.EXPECT().SetRoomCapacity(gomock.Any(), domain.HotelID(1), domain.RoomTypeLux, date.Date(2025, 1, 10), 3).
+					Return(domain.ErrRoomTypeNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid room type",
+		},
+		{
+			name: "invalid availability",
+			body: validBody,
+			mockSetup: func() {
+				mockInventoryService.EXPECT().SetRoomCapacity(gomock.Any(), domain.HotelID(1), domain.RoomTypeLux, date.Date(2025, 1, 10), 3).
+					Return(fmt.Errorf("%w: capacity can't be negative", domain.ErrInvalidAvailability))
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid availability: capacity can't be negative",
+		},
+		{
+			name: "capacity is below the reserved rooms",
+			body: validBody,
+			mockSetup: func() {
+				mockInventoryService.EXPECT().SetRoomCapacity(gomock.Any(), domain.HotelID(1), domain.RoomTypeLux, date.Date(2025, 1, 10), 3).
+					Return(&domain.CapacityBelowReservedError{
+						RoomType: domain.RoomTypeLux,
+						Date:     date.Date(2025, 1, 10),
+						Capacity: 3,
+						Reserved: 3,
+					})
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "capacity is below reserved rooms: room 'lux' has 3 reserved rooms on 2025-01-10, capacity 3 requested",
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			body: validBody,
+			mockSetup: func() {
+				mockInventoryService.EXPECT().SetRoomCapacity(gomock.Any(), domain.HotelID(1), domain.RoomTypeLux, date.Date(2025, 1, 10), 3).
+					Return(errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to set capacity",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPut, "/hotels/availability/capacity", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			h.Handle(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: set_rate.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockpricingService is a mock of pricingService interface.
+type MockpricingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockpricingServiceMockRecorder
+}
+
+// MockpricingServiceMockRecorder is the mock recorder for MockpricingService.
+type MockpricingServiceMockRecorder struct {
+	mock *MockpricingService
+}
+
+// NewMockpricingService creates a new mock instance.
+func NewMockpricingService(ctrl *gomock.Controller) *MockpricingService {
+	mock := &MockpricingService{ctrl: ctrl}
+	mock.recorder = &MockpricingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpricingService) EXPECT() *MockpricingServiceMockRecorder {
+	return m.recorder
+}
+
+// SetRate mocks base method.
+func (m *MockpricingService) SetRate(ctx context.Context, rate domain.Rate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRate", ctx, rate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRate indicates an expected call of SetRate.
+func (mr *MockpricingServiceMockRecorder) SetRate(ctx, rate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRate", reflect.TypeOf((*MockpricingService)(nil).SetRate), ctx, rate)
+}
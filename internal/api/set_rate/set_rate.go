@@ -0,0 +1,81 @@
+package set_rate
+
+//go:generate mockgen -source=set_rate.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+	"applicationDesignTest/pkg/log"
+)
+
+type request struct {
+	HotelID  domain.HotelID  `json:"hotel_id"`
+	RoomType domain.RoomType `json:"room_type"`
+	Date     date.CustomDate `json:"date"`
+	Amount   int64           `json:"amount"`
+	Currency domain.Currency `json:"currency"`
+}
+
+type pricingService interface {
+	SetRate(ctx context.Context, rate domain.Rate) error
+}
+
+type Handler struct {
+	pricing pricingService
+}
+
+func NewHandler(ps pricingService) *Handler {
+	return &Handler{
+		pricing: ps,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req request
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid input", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	rate := domain.Rate{
+		HotelID:  req.HotelID,
+		RoomType: req.RoomType,
+		Date:     req.Date.Time,
+		Price: domain.Money{
+			Amount:   req.Amount,
+			Currency: req.Currency,
+		},
+	}
+
+	if err := h.pricing.SetRate(ctx, rate); err != nil {
+		if errors.Is(err, domain.ErrHotelNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "invalid hotel id", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrRoomTypeNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "invalid room type", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidPrice) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to set rate", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to set rate", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, nil)
+}
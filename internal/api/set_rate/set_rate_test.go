@@ -0,0 +1,120 @@
+package set_rate
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/api/set_rate/mocks"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPricingService := mocks.NewMockpricingService(ctrl)
+
+	h := NewHandler(mockPricingService)
+
+	validBody := `{"hotel_id": 1, "room_type": "lux", "date": "2025-01-10", "amount": 150000, "currency": "RUB"}`
+
+	rate := domain.Rate{
+		HotelID:  1,
+		RoomType: domain.RoomTypeLux,
+		Date:     date.Date(2025, 1, 10),
+		Price:    domain.Money{Amount: 150000, Currency: "RUB"},
+	}
+
+	tests := []struct {
+		name            string
+		body            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "malformed date",
+			body:            `{"hotel_id": 1, "room_type": "lux", "date": "10.01.2025"}`,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid input",
+		},
+		{
+			name: "rate is set",
+			body: validBody,
+			mockSetup: func() {
+				mockPricingService.EXPECT().SetRate(gomock.Any(), rate).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "unknown hotel",
+			body: validBody,
+			mockSetup: func() {
+				mockPricingService.EXPECT().SetRate(gomock.Any(), rate).Return(domain.ErrHotelNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel id",
+		},
+		{
+			name: "unknown room type",
+			body: validBody,
+			mockSetup: func() {
+				mockPricingService.EXPECT().SetRate(gomock.Any(), rate).Return(domain.ErrRoomTypeNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid room type",
+		},
+		{
+			name: "invalid price",
+			body: validBody,
+			mockSetup: func() {
+				mockPricingService.EXPECT().SetRate(gomock.Any(), rate).
+					Return(fmt.Errorf("%w: currency must be RUB", domain.ErrInvalidPrice))
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid price: currency must be RUB",
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			body: validBody,
+			mockSetup: func() {
+				mockPricingService.EXPECT().SetRate(gomock.Any(), rate).Return(errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to set rate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPut, "/rates", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			h.Handle(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: set_restriction.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockinventoryService is a mock of inventoryService interface.
+type MockinventoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockinventoryServiceMockRecorder
+}
+
+// MockinventoryServiceMockRecorder is the mock recorder for MockinventoryService.
+type MockinventoryServiceMockRecorder struct {
+	mock *MockinventoryService
+}
+
+// NewMockinventoryService creates a new mock instance.
+func NewMockinventoryService(ctrl *gomock.Controller) *MockinventoryService {
+	mock := &MockinventoryService{ctrl: ctrl}
+	mock.recorder = &MockinventoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockinventoryService) EXPECT() *MockinventoryServiceMockRecorder {
+	return m.recorder
+}
+
+// SetRestriction mocks base method.
+func (m *MockinventoryService) SetRestriction(ctx context.Context, restriction domain.Restriction) (*domain.Restriction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRestriction", ctx, restriction)
+	ret0, _ := ret[0].(*domain.Restriction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRestriction indicates an expected call of SetRestriction.
+func (mr *MockinventoryServiceMockRecorder) SetRestriction(ctx, restriction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRestriction", reflect.TypeOf((*MockinventoryService)(nil).SetRestriction), ctx, restriction)
+}
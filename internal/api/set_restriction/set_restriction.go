@@ -0,0 +1,82 @@
+package set_restriction
+
+//go:generate mockgen -source=set_restriction.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+	"applicationDesignTest/pkg/log"
+)
+
+type request struct {
+	HotelID           domain.HotelID  `json:"hotel_id"`
+	RoomType          domain.RoomType `json:"room_type"`
+	Date              date.CustomDate `json:"date"`
+	StopSell          bool            `json:"stop_sell"`
+	ClosedToArrival   bool            `json:"closed_to_arrival"`
+	ClosedToDeparture bool            `json:"closed_to_departure"`
+	MinStay           int             `json:"min_stay"`
+	MaxStay           int             `json:"max_stay"`
+}
+
+type inventoryService interface {
+	SetRestriction(ctx context.Context, restriction domain.Restriction) (*domain.Restriction, error)
+}
+
+type Handler struct {
+	inventory inventoryService
+}
+
+func NewHandler(inventoryService inventoryService) *Handler {
+	return &Handler{
+		inventory: inventoryService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	var req request
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid input", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	restriction, err := h.inventory.SetRestriction(r.Context(), domain.Restriction{
+		HotelID:           req.HotelID,
+		RoomType:          req.RoomType,
+		Date:              req.Date.Time,
+		StopSell:          req.StopSell,
+		ClosedToArrival:   req.ClosedToArrival,
+		ClosedToDeparture: req.ClosedToDeparture,
+		MinStay:           req.MinStay,
+		MaxStay:           req.MaxStay,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrHotelNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "invalid hotel id", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrRoomTypeNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "invalid room type", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidRestriction) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to set restriction", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to set restriction", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, restriction)
+}
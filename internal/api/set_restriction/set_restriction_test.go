@@ -0,0 +1,146 @@
+package set_restriction
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/api/set_restriction/mocks"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInventoryService := mocks.NewMockinventoryService(ctrl)
+
+	h := NewHandler(mockInventoryService)
+
+	validBody := `{"hotel_id": 1, "room_type": "lux", "date": "2025-01-10", "closed_to_arrival": true, "min_stay": 2, "max_stay": 5}`
+
+	restriction := domain.Restriction{
+		HotelID:         1,
+		RoomType:        domain.RoomTypeLux,
+		Date:            date.Date(2025, 1, 10),
+		ClosedToArrival: true,
+		MinStay:         2,
+		MaxStay:         5,
+	}
+
+	tests := []struct {
+		name            string
+		body            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "malformed body",
+			body:            `{"hotel_id": `,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid input",
+		},
+		{
+			name: "restriction is set",
+			body: validBody,
+			mockSetup: func() {
+				mockInventoryService.EXPECT().SetRestriction(gomock.Any(), restriction).Return(&restriction, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   restriction,
+		},
+		{
+			name: "stop sell is set",
+			body: `{"hotel_id": 1, "room_type": "lux", "date": "2025-01-10", "stop_sell": true, "closed_to_departure": true}`,
+			mockSetup: func() {
+				stopSell := domain.Restriction{
+					HotelID:           1,
+					RoomType:          domain.RoomTypeLux,
+					Date:              date.Date(2025, 1, 10),
+					StopSell:          true,
+					ClosedToDeparture: true,
+				}
+				mockInventoryService.EXPECT().SetRestriction(gomock.Any(), stopSell).Return(&stopSell, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData: domain.Restriction{
+				HotelID:           1,
+				RoomType:          domain.RoomTypeLux,
+				Date:              date.Date(2025, 1, 10),
+				StopSell:          true,
+				ClosedToDeparture: true,
+			},
+		},
+		{
+			name: "unknown hotel",
+			body: validBody,
+			mockSetup: func() {
+				mockInventoryService.EXPECT().SetRestriction(gomock.Any(), restriction).Return(nil, domain.ErrHotelNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel id",
+		},
+		{
+			name: "unknown room type",
+			body: validBody,
+			mockSetup: func() {
+				mockInventoryService.EXPECT().SetRestriction(gomock.Any(), restriction).Return(nil, domain.ErrRoomTypeNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid room type",
+		},
+		{
+			name: "invalid restriction",
+			body: validBody,
+			mockSetup: func() {
+				mockInventoryService.EXPECT().SetRestriction(gomock.Any(), restriction).
+					Return(nil, fmt.Errorf("%w: min stay is greater than max stay", domain.ErrInvalidRestriction))
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid restriction: min stay is greater than max stay",
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			body: validBody,
+			mockSetup: func() {
+				mockInventoryService.EXPECT().SetRestriction(gomock.Any(), restriction).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to set restriction",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPut, "/hotels/restrictions", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			h.Handle(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
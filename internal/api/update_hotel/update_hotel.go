@@ -0,0 +1,88 @@
+package update_hotel
+
+//go:generate mockgen -source=update_hotel.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type request struct {
+	Name         string `json:"name"`
+	Address      string `json:"address"`
+	City         string `json:"city"`
+	Timezone     string `json:"timezone"`
+	StarRating   int    `json:"star_rating"`
+	CheckInTime  string `json:"check_in_time"`
+	CheckOutTime string `json:"check_out_time"`
+	NoShowCutoff string `json:"no_show_cutoff"`
+}
+
+type hotelService interface {
+	UpdateHotel(ctx context.Context, hotel domain.Hotel) (*domain.Hotel, error)
+}
+
+type Handler struct {
+	hotels hotelService
+}
+
+func NewHandler(hotelService hotelService) *Handler {
+	return &Handler{
+		hotels: hotelService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid hotel id", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	var req request
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warning(fmt.Sprintf("failed to decode request: %s", err.Error()))
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid input", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	hotel, err := h.hotels.UpdateHotel(r.Context(), domain.Hotel{
+		ID:           domain.HotelID(id),
+		Name:         req.Name,
+		Address:      req.Address,
+		City:         req.City,
+		Timezone:     req.Timezone,
+		StarRating:   req.StarRating,
+		CheckInTime:  req.CheckInTime,
+		CheckOutTime: req.CheckOutTime,
+		NoShowCutoff: req.NoShowCutoff,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrHotelNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "such hotel doesn't exist", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidHotel) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to update hotel", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to update hotel", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, hotel)
+}
@@ -0,0 +1,134 @@
+package update_hotel
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/api/update_hotel/mocks"
+	"applicationDesignTest/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelService := mocks.NewMockhotelService(ctrl)
+
+	r := chi.NewRouter()
+	r.Put("/hotels/{id}", NewHandler(mockHotelService).Handle)
+
+	validBody := `{"name": "Grand", "city": "Moscow", "timezone": "Europe/Moscow", "star_rating": 5,
+		"check_in_time": "15:00", "check_out_time": "11:00", "no_show_cutoff": "22:00"}`
+
+	// the id is taken from the path
+	hotel := domain.Hotel{
+		ID:           3,
+		Name:         "Grand",
+		City:         "Moscow",
+		Timezone:     "Europe/Moscow",
+		StarRating:   5,
+		CheckInTime:  "15:00",
+		CheckOutTime: "11:00",
+		NoShowCutoff: "22:00",
+	}
+
+	tests := []struct {
+		name            string
+		id              string
+		body            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "hotel id isn't a number",
+			id:              "abc",
+			body:            validBody,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel id",
+		},
+		{
+			name:            "malformed body",
+			id:              "3",
+			body:            `{"name": `,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid input",
+		},
+		{
+			name: "hotel is updated",
+			id:   "3",
+			body: validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().UpdateHotel(gomock.Any(), hotel).Return(&hotel, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   hotel,
+		},
+		{
+			name: "hotel not found",
+			id:   "3",
+			body: validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().UpdateHotel(gomock.Any(), hotel).Return(nil, domain.ErrHotelNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such hotel doesn't exist",
+		},
+		{
+			name: "invalid hotel",
+			id:   "3",
+			body: validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().UpdateHotel(gomock.Any(), hotel).
+					Return(nil, fmt.Errorf("%w: check-in time must be HH:MM", domain.ErrInvalidHotel))
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel: check-in time must be HH:MM",
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			id:   "3",
+			body: validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().UpdateHotel(gomock.Any(), hotel).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to update hotel",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPut, "/hotels/"+tt.id, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
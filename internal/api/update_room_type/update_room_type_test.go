@@ -0,0 +1,142 @@
+package update_room_type
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/api/update_room_type/mocks"
+	"applicationDesignTest/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelService := mocks.NewMockhotelService(ctrl)
+
+	r := chi.NewRouter()
+	r.Put("/hotels/{id}/room-types/{code}", NewHandler(mockHotelService).Handle)
+
+	validBody := `{"name": "Lux", "capacity": 3, "description": "Sea view", "amenities": ["minibar"]}`
+
+	// the hotel and the code are taken from the path
+	roomType := domain.HotelRoomType{
+		HotelID:     1,
+		Code:        domain.RoomTypeLux,
+		Name:        "Lux",
+		Capacity:    3,
+		Description: "Sea view",
+		Amenities:   []string{"minibar"},
+	}
+
+	tests := []struct {
+		name            string
+		path            string
+		body            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "hotel id isn't a number",
+			path:            "/hotels/abc/room-types/lux",
+			body:            validBody,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel id",
+		},
+		{
+			name:            "malformed body",
+			path:            "/hotels/1/room-types/lux",
+			body:            `{"name": `,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid input",
+		},
+		{
+			name: "room type is updated",
+			path: "/hotels/1/room-types/lux",
+			body: validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().UpdateRoomType(gomock.Any(), roomType).Return(&roomType, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   roomType,
+		},
+		{
+			name: "hotel not found",
+			path: "/hotels/1/room-types/lux",
+			body: validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().UpdateRoomType(gomock.Any(), roomType).Return(nil, domain.ErrHotelNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such hotel doesn't exist",
+		},
+		{
+			name: "room type not found",
+			path: "/hotels/1/room-types/lux",
+			body: validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().UpdateRoomType(gomock.Any(), roomType).Return(nil, domain.ErrRoomTypeNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such room type doesn't exist",
+		},
+		{
+			name: "invalid room type",
+			path: "/hotels/1/room-types/lux",
+			body: validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().UpdateRoomType(gomock.Any(), roomType).
+					Return(nil, fmt.Errorf("%w: name is required", domain.ErrInvalidRoomType))
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid room type: name is required",
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			path: "/hotels/1/room-types/lux",
+			body: validBody,
+			mockSetup: func() {
+				mockHotelService.EXPECT().UpdateRoomType(gomock.Any(), roomType).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to update room type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPut, tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
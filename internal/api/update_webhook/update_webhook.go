@@ -0,0 +1,90 @@
+package update_webhook
+
+//go:generate mockgen -source=update_webhook.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type request struct {
+	URL        string             `json:"url"`
+	Secret     string             `json:"secret"`
+	EventTypes []domain.EventType `json:"event_types"`
+}
+
+type webhookService interface {
+	GetWebhook(ctx context.Context, id domain.WebhookID) (*domain.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error)
+}
+
+type Handler struct {
+	webhooks webhookService
+}
+
+func NewHandler(webhookService webhookService) *Handler {
+	return &Handler{
+		webhooks: webhookService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid webhook id", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	var req request
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Warning(fmt.Sprintf("failed to decode request: %s", err.Error()))
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid input", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	current, err := h.webhooks.GetWebhook(ctx, domain.WebhookID(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "such webhook doesn't exist", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to get webhook", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to update webhook", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	// the hotel of the webhook can't be changed
+	webhook, err := h.webhooks.UpdateWebhook(ctx, domain.Webhook{
+		ID:         current.ID,
+		HotelID:    current.HotelID,
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidWebhook) || errors.Is(err, domain.ErrWebhookNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to update webhook", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to update webhook", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, webhook.Info())
+}
@@ -0,0 +1,165 @@
+package update_webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/api/update_webhook/mocks"
+	"applicationDesignTest/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookService := mocks.NewMockwebhookService(ctrl)
+
+	r := chi.NewRouter()
+	r.Put("/webhooks/{id}", NewHandler(mockWebhookService).Handle)
+
+	validBody := `{"hotel_id": 2, "url": "https://partner.example/new", "secret": "s3cr3t",
+		"event_types": ["order_cancelled"]}`
+
+	createdAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	current := &domain.Webhook{ID: 1, HotelID: 1, URL: "https://partner.example/hook", Secret: "old", CreatedAt: createdAt}
+
+	// the hotel of the webhook is kept whatever the request says
+	webhook := domain.Webhook{
+		ID:         1,
+		HotelID:    1,
+		URL:        "https://partner.example/new",
+		Secret:     "s3cr3t",
+		EventTypes: []domain.EventType{domain.EventOrderCancelled},
+	}
+
+	updated := webhook
+	updated.CreatedAt = createdAt
+
+	tests := []struct {
+		name            string
+		id              string
+		body            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "webhook id isn't a number",
+			id:              "abc",
+			body:            validBody,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid webhook id",
+		},
+		{
+			name:            "malformed body",
+			id:              "1",
+			body:            `{"url": `,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid input",
+		},
+		{
+			name: "webhook not found",
+			id:   "1",
+			body: validBody,
+			mockSetup: func() {
+				mockWebhookService.EXPECT().GetWebhook(gomock.Any(), domain.WebhookID(1)).Return(nil, domain.ErrWebhookNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "such webhook doesn't exist",
+		},
+		{
+			name: "webhook lookup fails",
+			id:   "1",
+			body: validBody,
+			mockSetup: func() {
+				mockWebhookService.EXPECT().GetWebhook(gomock.Any(), domain.WebhookID(1)).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to update webhook",
+		},
+		{
+			name: "webhook is updated in its hotel without its secret shown",
+			id:   "1",
+			body: validBody,
+			mockSetup: func() {
+				mockWebhookService.EXPECT().GetWebhook(gomock.Any(), domain.WebhookID(1)).Return(current, nil)
+				mockWebhookService.EXPECT().UpdateWebhook(gomock.Any(), webhook).Return(&updated, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   updated.Info(),
+		},
+		{
+			name: "invalid webhook",
+			id:   "1",
+			body: validBody,
+			mockSetup: func() {
+				mockWebhookService.EXPECT().GetWebhook(gomock.Any(), domain.WebhookID(1)).Return(current, nil)
+				mockWebhookService.EXPECT().UpdateWebhook(gomock.Any(), webhook).
+					Return(nil, fmt.Errorf("%w: unknown event type 'room_cleaned'", domain.ErrInvalidWebhook))
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid webhook: unknown event type 'room_cleaned'",
+		},
+		{
+			name: "webhook is deleted meanwhile",
+			id:   "1",
+			body: validBody,
+			mockSetup: func() {
+				mockWebhookService.EXPECT().GetWebhook(gomock.Any(), domain.WebhookID(1)).Return(current, nil)
+				mockWebhookService.EXPECT().UpdateWebhook(gomock.Any(), webhook).Return(nil, domain.ErrWebhookNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "webhook not found",
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			id:   "1",
+			body: validBody,
+			mockSetup: func() {
+				mockWebhookService.EXPECT().GetWebhook(gomock.Any(), domain.WebhookID(1)).Return(current, nil)
+				mockWebhookService.EXPECT().UpdateWebhook(gomock.Any(), webhook).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to update webhook",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPut, "/webhooks/"+tt.id, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
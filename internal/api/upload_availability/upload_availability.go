@@ -0,0 +1,111 @@
+package upload_availability
+
+//go:generate mockgen -source=upload_availability.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+	"applicationDesignTest/pkg/log"
+)
+
+var weekdays = map[string]time.Weekday{
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+	"sun": time.Sunday,
+}
+
+type request struct {
+	HotelID domain.HotelID `json:"hotel_id"`
+	DryRun  bool           `json:"dry_run"`
+	Ranges  []rangeRequest `json:"ranges"`
+}
+
+type rangeRequest struct {
+	RoomTypes []domain.RoomType `json:"room_types"`
+	From      date.CustomDate   `json:"from"`
+	To        date.CustomDate   `json:"to"`
+	Weekdays  []string          `json:"weekdays"`
+	Rooms     int               `json:"rooms"`
+}
+
+type inventoryService interface {
+	UploadAvailability(ctx context.Context, upload domain.AvailabilityUpload) (*domain.AvailabilityUploadResult, error)
+}
+
+type Handler struct {
+	inventory inventoryService
+}
+
+func NewHandler(inventoryService inventoryService) *Handler {
+	return &Handler{
+		inventory: inventoryService,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	var req request
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_helpers.SendError(w, http.StatusBadRequest, "invalid input", http_helpers.ErrorTypeValidationError)
+		return
+	}
+
+	upload := domain.AvailabilityUpload{HotelID: req.HotelID, DryRun: req.DryRun}
+
+	for _, rr := range req.Ranges {
+		availabilityRange := domain.AvailabilityRange{
+			RoomTypes: rr.RoomTypes,
+			From:      rr.From.Time,
+			To:        rr.To.Time,
+			Rooms:     rr.Rooms,
+		}
+
+		for _, name := range rr.Weekdays {
+			weekday, ok := weekdays[strings.ToLower(name)]
+			if !ok {
+				http_helpers.SendError(w, http.StatusBadRequest, "invalid weekday '"+name+"', use mon..sun", http_helpers.ErrorTypeValidationError)
+				return
+			}
+
+			availabilityRange.Weekdays = append(availabilityRange.Weekdays, weekday)
+		}
+
+		upload.Ranges = append(upload.Ranges, availabilityRange)
+	}
+
+	result, err := h.inventory.UploadAvailability(r.Context(), upload)
+	if err != nil {
+		if errors.Is(err, domain.ErrHotelNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "invalid hotel id", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrRoomTypeNotFound) {
+			http_helpers.SendError(w, http.StatusBadRequest, "invalid room type", http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidAvailability) {
+			http_helpers.SendError(w, http.StatusBadRequest, err.Error(), http_helpers.ErrorTypeValidationError)
+			return
+		}
+
+		log.Error("failed to upload availability", err)
+		http_helpers.SendError(w, http.StatusInternalServerError, "failed to upload availability", http_helpers.ErrorTypeInternalError)
+		return
+	}
+
+	http_helpers.SendSuccess(w, http.StatusOK, result)
+}
@@ -0,0 +1,146 @@
+package upload_availability
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/api/apitest"
+	"applicationDesignTest/internal/api/http_helpers"
+	"applicationDesignTest/internal/api/upload_availability/mocks"
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInventoryService := mocks.NewMockinventoryService(ctrl)
+
+	h := NewHandler(mockInventoryService)
+
+	validBody := `{"hotel_id": 1, "dry_run": true, "ranges": [
+		{"room_types": ["lux"], "from": "2025-01-01", "to": "2025-01-31", "weekdays": ["Sat", "sun"], "rooms": 2}]}`
+
+	upload := domain.AvailabilityUpload{
+		HotelID: 1,
+		DryRun:  true,
+		Ranges: []domain.AvailabilityRange{{
+			RoomTypes: []domain.RoomType{domain.RoomTypeLux},
+			From:      date.Date(2025, 1, 1),
+			To:        date.Date(2025, 1, 31),
+			Weekdays:  []time.Weekday{time.Saturday, time.Sunday},
+			Rooms:     2,
+		}},
+	}
+
+	result := &domain.AvailabilityUploadResult{
+		DryRun: true,
+		Nights: []domain.AvailabilityUploadNight{
+			{RoomType: domain.RoomTypeLux, Date: date.Date(2025, 1, 4), Added: 2, Capacity: 5, Rooms: 4},
+			{RoomType: domain.RoomTypeLux, Date: date.Date(2025, 1, 5), Added: 2, Capacity: 2, Rooms: 2},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		body            string
+		mockSetup       func()
+		expectedStatus  int
+		expectedData    any
+		expectedError   http_helpers.ErrorType
+		expectedMessage string
+	}{
+		{
+			name:            "malformed body",
+			body:            `{"hotel_id": `,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid input",
+		},
+		{
+			name: "unknown weekday",
+			body: `{"hotel_id": 1, "ranges": [
+				{"room_types": ["lux"], "from": "2025-01-01", "to": "2025-01-31", "weekdays": ["saturday"], "rooms": 2}]}`,
+			mockSetup:       func() {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid weekday 'saturday', use mon..sun",
+		},
+		{
+			name: "availability is uploaded",
+			body: validBody,
+			mockSetup: func() {
+				mockInventoryService.EXPECT().UploadAvailability(gomock.Any(), upload).Return(result, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedData:   result,
+		},
+		{
+			name: "unknown hotel",
+			body: validBody,
+			mockSetup: func() {
+				mockInventoryService.EXPECT().UploadAvailability(gomock.Any(), upload).Return(nil, domain.ErrHotelNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid hotel id",
+		},
+		{
+			name: "unknown room type",
+			body: validBody,
+			mockSetup: func() {
+				mockInventoryService.EXPECT().UploadAvailability(gomock.Any(), upload).Return(nil, domain.ErrRoomTypeNotFound)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid room type",
+		},
+		{
+			name: "invalid availability",
+			body: validBody,
+			mockSetup: func() {
+				mockInventoryService.EXPECT().UploadAvailability(gomock.Any(), upload).
+					Return(nil, fmt.Errorf("%w: range 0: invalid date range", domain.ErrInvalidAvailability))
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   http_helpers.ErrorTypeValidationError,
+			expectedMessage: "invalid availability: range 0: invalid date range",
+		},
+		{
+			name: "unexpected error isn't disclosed",
+			body: validBody,
+			mockSetup: func() {
+				mockInventoryService.EXPECT().UploadAvailability(gomock.Any(), upload).Return(nil, errors.New("store error"))
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedError:   http_helpers.ErrorTypeInternalError,
+			expectedMessage: "failed to upload availability",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPost, "/hotels/availability/bulk", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			h.Handle(rec, req)
+
+			if tt.expectedError != "" {
+				apitest.AssertError(t, rec, tt.expectedStatus, tt.expectedError, tt.expectedMessage)
+			} else {
+				apitest.AssertSuccess(t, rec, tt.expectedStatus, tt.expectedData)
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"applicationDesignTest/pkg/log"
 
@@ -12,8 +14,133 @@ type Server struct {
 	Port string `mapstructure:"port"`
 }
 
+type Storage struct {
+	Type          string `mapstructure:"type"` // memory, file or sql
+	Dir           string `mapstructure:"dir"`
+	SnapshotEvery int    `mapstructure:"snapshot_every"`
+	DSN           string `mapstructure:"dsn"` // path to the SQLite database
+}
+
+type Hold struct {
+	TTL            time.Duration `mapstructure:"ttl"`
+	ReaperInterval time.Duration `mapstructure:"reaper_interval"`
+}
+
+// NoShow configures the job marking the orders whose guests didn't arrive. Cutoff is the local time
+// on the day after the arrival for the hotels without their own cutoff, PenaltyNights is the number
+// of the first nights charged.
+type NoShow struct {
+	Interval      time.Duration `mapstructure:"interval"`
+	Cutoff        string        `mapstructure:"cutoff"`
+	PenaltyNights int           `mapstructure:"penalty_nights"`
+}
+
+type Loyalty struct {
+	EarnPercent int64 `mapstructure:"earn_percent"`
+	PointValue  int64 `mapstructure:"point_value"`
+}
+
+type SMTP struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	From     string `mapstructure:"from"`
+}
+
+type Notification struct {
+	Attempts int           `mapstructure:"attempts"`
+	Backoff  time.Duration `mapstructure:"backoff"`
+}
+
+type Webhook struct {
+	Attempts int           `mapstructure:"attempts"`
+	Backoff  time.Duration `mapstructure:"backoff"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+}
+
+type Outbox struct {
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	BatchSize    int           `mapstructure:"batch_size"`
+}
+
 type Config struct {
-	Server `mapstructure:"server"`
+	Server       `mapstructure:"server"`
+	Storage      Storage      `mapstructure:"storage"`
+	Hold         Hold         `mapstructure:"hold"`
+	NoShow       NoShow       `mapstructure:"no_show"`
+	Loyalty      Loyalty      `mapstructure:"loyalty"`
+	SMTP         SMTP         `mapstructure:"smtp"`
+	Notification Notification `mapstructure:"notification"`
+	Outbox       Outbox       `mapstructure:"outbox"`
+	Webhook      Webhook      `mapstructure:"webhook"`
+}
+
+// Validate checks the values the service can't run with, e.g. a zero interval of a background job.
+func (c *Config) Validate() error {
+	var errs []error
+
+	durations := []struct {
+		key   string
+		value time.Duration
+	}{
+		{"hold.ttl", c.Hold.TTL},
+		{"hold.reaper_interval", c.Hold.ReaperInterval},
+		{"no_show.interval", c.NoShow.Interval},
+		{"notification.backoff", c.Notification.Backoff},
+		{"outbox.poll_interval", c.Outbox.PollInterval},
+		{"webhook.backoff", c.Webhook.Backoff},
+		{"webhook.timeout", c.Webhook.Timeout},
+	}
+
+	for _, d := range durations {
+		if d.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive, got %s", d.key, d.value))
+		}
+	}
+
+	counts := []struct {
+		key   string
+		value int
+	}{
+		{"notification.attempts", c.Notification.Attempts},
+		{"outbox.batch_size", c.Outbox.BatchSize},
+		{"webhook.attempts", c.Webhook.Attempts},
+	}
+
+	for _, n := range counts {
+		if n.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive, got %d", n.key, n.value))
+		}
+	}
+
+	if c.NoShow.PenaltyNights < 0 {
+		errs = append(errs, fmt.Errorf("no_show.penalty_nights can't be negative, got %d", c.NoShow.PenaltyNights))
+	}
+
+	if _, err := time.Parse("15:04", c.NoShow.Cutoff); err != nil {
+		errs = append(errs, fmt.Errorf("no_show.cutoff must be HH:MM, got '%s'", c.NoShow.Cutoff))
+	}
+
+	if c.Loyalty.EarnPercent < 0 {
+		errs = append(errs, fmt.Errorf("loyalty.earn_percent can't be negative, got %d", c.Loyalty.EarnPercent))
+	}
+
+	if c.Loyalty.PointValue <= 0 {
+		errs = append(errs, fmt.Errorf("loyalty.point_value must be positive, got %d", c.Loyalty.PointValue))
+	}
+
+	switch c.Storage.Type {
+	case "memory", "sql":
+	case "file":
+		if c.Storage.SnapshotEvery <= 0 {
+			errs = append(errs, fmt.Errorf("storage.snapshot_every must be positive, got %d", c.Storage.SnapshotEvery))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown storage.type '%s'", c.Storage.Type))
+	}
+
+	return errors.Join(errs...)
 }
 
 func LoadConfig(configPath string) (*Config, error) {
@@ -31,7 +158,53 @@ func LoadConfig(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("failed to bind env: %w", err)
 	}
 
+	// STORAGE_TYPE
+	if err := viper.BindEnv("storage.type"); err != nil {
+		return nil, fmt.Errorf("failed to bind env: %w", err)
+	}
+
+	// HOLD_TTL
+	if err := viper.BindEnv("hold.ttl"); err != nil {
+		return nil, fmt.Errorf("failed to bind env: %w", err)
+	}
+
+	// SMTP_HOST
+	if err := viper.BindEnv("smtp.host"); err != nil {
+		return nil, fmt.Errorf("failed to bind env: %w", err)
+	}
+
+	// SMTP_PORT
+	if err := viper.BindEnv("smtp.port"); err != nil {
+		return nil, fmt.Errorf("failed to bind env: %w", err)
+	}
+
+	// SMTP_PASSWORD
+	if err := viper.BindEnv("smtp.password"); err != nil {
+		return nil, fmt.Errorf("failed to bind env: %w", err)
+	}
+
 	viper.SetDefault("server.port", "8080")
+	viper.SetDefault("storage.type", "memory")
+	viper.SetDefault("storage.dir", "data")
+	viper.SetDefault("storage.snapshot_every", 1000)
+	viper.SetDefault("storage.dsn", "data/booking.db")
+	viper.SetDefault("hold.ttl", 15*time.Minute)
+	viper.SetDefault("hold.reaper_interval", time.Minute)
+	viper.SetDefault("no_show.interval", 5*time.Minute)
+	viper.SetDefault("no_show.cutoff", "06:00")
+	viper.SetDefault("no_show.penalty_nights", 1)
+	viper.SetDefault("loyalty.earn_percent", 5)
+	viper.SetDefault("loyalty.point_value", 100)
+	viper.SetDefault("smtp.host", "localhost")
+	viper.SetDefault("smtp.port", 1025)
+	viper.SetDefault("smtp.from", "booking@localhost")
+	viper.SetDefault("notification.attempts", 5)
+	viper.SetDefault("notification.backoff", time.Second)
+	viper.SetDefault("outbox.poll_interval", time.Second)
+	viper.SetDefault("outbox.batch_size", 100)
+	viper.SetDefault("webhook.attempts", 5)
+	viper.SetDefault("webhook.backoff", 500*time.Millisecond)
+	viper.SetDefault("webhook.timeout", 5*time.Second)
 
 	if err := viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
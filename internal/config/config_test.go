@@ -0,0 +1,88 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func() Config {
+		return Config{
+			Storage:      Storage{Type: "memory"},
+			Hold:         Hold{TTL: 15 * time.Minute, ReaperInterval: time.Minute},
+			NoShow:       NoShow{Interval: 5 * time.Minute, Cutoff: "06:00", PenaltyNights: 1},
+			Loyalty:      Loyalty{EarnPercent: 5, PointValue: 100},
+			Notification: Notification{Attempts: 5, Backoff: time.Second},
+			Outbox:       Outbox{PollInterval: time.Second, BatchSize: 100},
+			Webhook:      Webhook{Attempts: 5, Backoff: time.Second, Timeout: 5 * time.Second},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		change        func(c *Config)
+		expectedError string
+	}{
+		{
+			name:   "valid",
+			change: func(c *Config) {},
+		},
+		{
+			name:          "zero outbox poll interval",
+			change:        func(c *Config) { c.Outbox.PollInterval = 0 },
+			expectedError: "outbox.poll_interval must be positive, got 0s",
+		},
+		{
+			name:          "zero hold reaper interval",
+			change:        func(c *Config) { c.Hold.ReaperInterval = 0 },
+			expectedError: "hold.reaper_interval must be positive, got 0s",
+		},
+		{
+			name:          "negative no-show interval",
+			change:        func(c *Config) { c.NoShow.Interval = -time.Minute },
+			expectedError: "no_show.interval must be positive, got -1m0s",
+		},
+		{
+			name:          "invalid no-show cutoff",
+			change:        func(c *Config) { c.NoShow.Cutoff = "6am" },
+			expectedError: "no_show.cutoff must be HH:MM, got '6am'",
+		},
+		{
+			name:          "zero loyalty point value",
+			change:        func(c *Config) { c.Loyalty.PointValue = 0 },
+			expectedError: "loyalty.point_value must be positive, got 0",
+		},
+		{
+			name:          "zero batch size",
+			change:        func(c *Config) { c.Outbox.BatchSize = 0 },
+			expectedError: "outbox.batch_size must be positive, got 0",
+		},
+		{
+			name:          "file storage without snapshots",
+			change:        func(c *Config) { c.Storage = Storage{Type: "file"} },
+			expectedError: "storage.snapshot_every must be positive, got 0",
+		},
+		{
+			name:          "unknown storage",
+			change:        func(c *Config) { c.Storage.Type = "redis" },
+			expectedError: "unknown storage.type 'redis'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.change(&cfg)
+
+			err := cfg.Validate()
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
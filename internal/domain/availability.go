@@ -0,0 +1,250 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// MaxSearchNights limits the stay of an availability search.
+	MaxSearchNights = 90
+	// MaxCalendarDays limits the period of an availability calendar.
+	MaxCalendarDays = 366
+)
+
+// RoomAvailability is the number of free rooms of the room type in the hotel on the date.
+// Capacity is the number of rooms added to the availability, free or reserved.
+type RoomAvailability struct {
+	HotelID  HotelID   `json:"hotel_id"`
+	RoomType RoomType  `json:"room_type"`
+	Date     time.Time `json:"date"`
+	Rooms    int       `json:"rooms"`
+	Capacity int       `json:"capacity"`
+}
+
+// Reserved returns the number of reserved rooms.
+func (a RoomAvailability) Reserved() int {
+	return max(a.Capacity-a.Rooms, 0)
+}
+
+// AvailabilityQuery searches the room types which have Rooms free rooms every night from From to To inclusive.
+// Empty City and RoomType match any city and room type.
+type AvailabilityQuery struct {
+	From     time.Time
+	To       time.Time
+	Rooms    int
+	City     string
+	RoomType RoomType
+	Offset   int
+	Limit    int
+}
+
+func (q *AvailabilityQuery) Validate() error {
+	if q.From.IsZero() || q.To.IsZero() {
+		return fmt.Errorf("%w: from and to are required", ErrInvalidQuery)
+	}
+
+	if q.To.Before(q.From) {
+		return fmt.Errorf("%w: invalid date range", ErrInvalidQuery)
+	}
+
+	if nights := int(q.To.Sub(q.From).Hours()/24) + 1; nights > MaxSearchNights {
+		return fmt.Errorf("%w: stay can't be longer than %d nights", ErrInvalidQuery, MaxSearchNights)
+	}
+
+	if q.Rooms <= 0 {
+		return fmt.Errorf("%w: rooms must be positive", ErrInvalidQuery)
+	}
+
+	if q.Offset < 0 || q.Limit <= 0 {
+		return fmt.Errorf("%w: invalid pagination", ErrInvalidQuery)
+	}
+
+	return nil
+}
+
+// RoomTypeAvailability is the availability of the room type for the whole stay. MinRooms is the smallest
+// number of free rooms among the nights of the stay. Restriction is the rule closing the stay to sale,
+// the stay isn't bookable if it's set.
+type RoomTypeAvailability struct {
+	HotelID     HotelID         `json:"hotel_id"`
+	HotelName   string          `json:"hotel_name"`
+	City        string          `json:"city,omitempty"`
+	RoomType    RoomType        `json:"room_type"`
+	Name        string          `json:"name"`
+	Capacity    int             `json:"capacity"`
+	Bookable    bool            `json:"bookable"`
+	MinRooms    int             `json:"min_rooms"`
+	Restriction RestrictionRule `json:"restriction,omitempty"`
+}
+
+// AvailabilityPage is a page of the search results ordered by hotel and room type.
+type AvailabilityPage struct {
+	Items  []RoomTypeAvailability `json:"items"`
+	Total  int                    `json:"total"`
+	Offset int                    `json:"offset"`
+	Limit  int                    `json:"limit"`
+}
+
+// CalendarQuery requests the availability calendar of the hotel from From to To inclusive.
+type CalendarQuery struct {
+	HotelID HotelID
+	From    time.Time
+	To      time.Time
+}
+
+func (q *CalendarQuery) Validate() error {
+	if q.From.IsZero() || q.To.IsZero() {
+		return fmt.Errorf("%w: from and to are required", ErrInvalidQuery)
+	}
+
+	if q.To.Before(q.From) {
+		return fmt.Errorf("%w: invalid date range", ErrInvalidQuery)
+	}
+
+	if days := int(q.To.Sub(q.From).Hours()/24) + 1; days > MaxCalendarDays {
+		return fmt.Errorf("%w: calendar can't be longer than %d days", ErrInvalidQuery, MaxCalendarDays)
+	}
+
+	return nil
+}
+
+// CalendarCell counts the rooms of the room type on the date and has the rules of its restriction.
+// The free rooms of a stop-sell date can't be booked.
+type CalendarCell struct {
+	Total             int  `json:"total"`
+	Reserved          int  `json:"reserved"`
+	Free              int  `json:"free"`
+	StopSell          bool `json:"stop_sell,omitempty"`
+	ClosedToArrival   bool `json:"closed_to_arrival,omitempty"`
+	ClosedToDeparture bool `json:"closed_to_departure,omitempty"`
+	MinStay           int  `json:"min_stay,omitempty"`
+	MaxStay           int  `json:"max_stay,omitempty"`
+}
+
+// CalendarDay has a cell for every room type of the hotel catalog.
+type CalendarDay struct {
+	Date  time.Time                 `json:"date"`
+	Rooms map[RoomType]CalendarCell `json:"rooms"`
+}
+
+// Calendar is the matrix of the dates and room types of the hotel, a day for every date of the period.
+type Calendar struct {
+	HotelID   HotelID       `json:"hotel_id"`
+	From      time.Time     `json:"from"`
+	To        time.Time     `json:"to"`
+	RoomTypes []RoomType    `json:"room_types"`
+	Days      []CalendarDay `json:"days"`
+}
+
+// AvailabilityUpload adds the rooms of its ranges to the availability of the hotel at once.
+// A dry run reports the result without changing the availability.
+type AvailabilityUpload struct {
+	HotelID HotelID
+	Ranges  []AvailabilityRange
+	DryRun  bool
+}
+
+// AvailabilityRange adds Rooms rooms of every room type on the dates from From to To inclusive
+// which fall on Weekdays. Empty Weekdays match every day of the week.
+type AvailabilityRange struct {
+	RoomTypes []RoomType
+	From      time.Time
+	To        time.Time
+	Weekdays  []time.Weekday
+	Rooms     int
+}
+
+func (u *AvailabilityUpload) Validate() error {
+	if len(u.Ranges) == 0 {
+		return fmt.Errorf("%w: ranges are required", ErrInvalidAvailability)
+	}
+
+	for i, r := range u.Ranges {
+		if len(r.RoomTypes) == 0 {
+			return fmt.Errorf("%w: range %d: room types are required", ErrInvalidAvailability, i)
+		}
+
+		if r.From.IsZero() || r.To.IsZero() {
+			return fmt.Errorf("%w: range %d: from and to are required", ErrInvalidAvailability, i)
+		}
+
+		if r.To.Before(r.From) {
+			return fmt.Errorf("%w: range %d: invalid date range", ErrInvalidAvailability, i)
+		}
+
+		if days := int(r.To.Sub(r.From).Hours()/24) + 1; days > MaxCalendarDays {
+			return fmt.Errorf("%w: range %d can't be longer than %d days", ErrInvalidAvailability, i, MaxCalendarDays)
+		}
+
+		for _, weekday := range r.Weekdays {
+			if weekday < time.Sunday || weekday > time.Saturday {
+				return fmt.Errorf("%w: range %d: invalid weekday", ErrInvalidAvailability, i)
+			}
+		}
+
+		if r.Rooms <= 0 {
+			return fmt.Errorf("%w: range %d: rooms must be positive", ErrInvalidAvailability, i)
+		}
+	}
+
+	return nil
+}
+
+// Changes returns the rooms added by the ranges per room type and date, the ranges overlapping
+// on a date are summed. The changes are ordered by room type and date.
+func (u *AvailabilityUpload) Changes() []AvailabilityChange {
+	type night struct {
+		roomType RoomType
+		date     time.Time
+	}
+
+	added := make(map[night]int)
+
+	for _, r := range u.Ranges {
+		weekdays := make(map[time.Weekday]bool, len(r.Weekdays))
+		for _, weekday := range r.Weekdays {
+			weekdays[weekday] = true
+		}
+
+		for date := r.From; !date.After(r.To); date = date.AddDate(0, 0, 1) {
+			if len(weekdays) > 0 && !weekdays[date.Weekday()] {
+				continue
+			}
+
+			for _, roomType := range r.RoomTypes {
+				added[night{roomType: roomType, date: date}] += r.Rooms
+			}
+		}
+	}
+
+	changes := make([]AvailabilityChange, 0, len(added))
+	for n, rooms := range added {
+		changes = append(changes, AvailabilityChange{HotelID: u.HotelID, RoomType: n.roomType, Date: n.date, Delta: rooms})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].RoomType != changes[j].RoomType {
+			return changes[i].RoomType < changes[j].RoomType
+		}
+		return changes[i].Date.Before(changes[j].Date)
+	})
+
+	return changes
+}
+
+// AvailabilityUploadNight is a night changed by the upload: the added rooms and the resulting availability.
+type AvailabilityUploadNight struct {
+	RoomType RoomType  `json:"room_type"`
+	Date     time.Time `json:"date"`
+	Added    int       `json:"added"`
+	Capacity int       `json:"capacity"`
+	Rooms    int       `json:"rooms"`
+}
+
+// AvailabilityUploadResult reports the nights changed by the upload ordered by room type and date.
+type AvailabilityUploadResult struct {
+	DryRun bool                      `json:"dry_run"`
+	Nights []AvailabilityUploadNight `json:"nights"`
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAvailabilityUpload_Validate(t *testing.T) {
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	single := []RoomType{"single"}
+
+	tests := []struct {
+		name          string
+		ranges        []AvailabilityRange
+		expectedError error
+	}{
+		{
+			name:   "valid ranges",
+			ranges: []AvailabilityRange{{RoomTypes: single, From: from, To: from.AddDate(0, 3, 0), Weekdays: []time.Weekday{time.Friday, time.Saturday}, Rooms: 5}},
+		},
+		{
+			name:          "no ranges",
+			expectedError: ErrInvalidAvailability,
+		},
+		{
+			name:          "no room types",
+			ranges:        []AvailabilityRange{{From: from, To: from, Rooms: 1}},
+			expectedError: ErrInvalidAvailability,
+		},
+		{
+			name:          "invalid date range",
+			ranges:        []AvailabilityRange{{RoomTypes: single, From: from, To: from.AddDate(0, 0, -1), Rooms: 1}},
+			expectedError: ErrInvalidAvailability,
+		},
+		{
+			name:          "too long range",
+			ranges:        []AvailabilityRange{{RoomTypes: single, From: from, To: from.AddDate(1, 0, 1), Rooms: 1}},
+			expectedError: ErrInvalidAvailability,
+		},
+		{
+			name:          "invalid weekday",
+			ranges:        []AvailabilityRange{{RoomTypes: single, From: from, To: from, Weekdays: []time.Weekday{7}, Rooms: 1}},
+			expectedError: ErrInvalidAvailability,
+		},
+		{
+			name:          "non-positive rooms",
+			ranges:        []AvailabilityRange{{RoomTypes: single, From: from, To: from}},
+			expectedError: ErrInvalidAvailability,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upload := AvailabilityUpload{HotelID: 1, Ranges: tt.ranges}
+
+			err := upload.Validate()
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAvailabilityUpload_Changes(t *testing.T) {
+	// Saturday
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	upload := AvailabilityUpload{
+		HotelID: 1,
+		Ranges: []AvailabilityRange{
+			{RoomTypes: []RoomType{"single", "double"}, From: from, To: from.AddDate(0, 0, 1), Rooms: 2},
+			{RoomTypes: []RoomType{"single"}, From: from, To: from.AddDate(0, 0, 13), Weekdays: []time.Weekday{time.Saturday}, Rooms: 1},
+		},
+	}
+
+	assert.Equal(t, []AvailabilityChange{
+		{HotelID: 1, RoomType: "double", Date: from, Delta: 2},
+		{HotelID: 1, RoomType: "double", Date: from.AddDate(0, 0, 1), Delta: 2},
+		{HotelID: 1, RoomType: "single", Date: from, Delta: 3},
+		{HotelID: 1, RoomType: "single", Date: from.AddDate(0, 0, 1), Delta: 2},
+		{HotelID: 1, RoomType: "single", Date: from.AddDate(0, 0, 7), Delta: 1},
+	}, upload.Changes())
+}
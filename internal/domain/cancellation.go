@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// CancellationPolicy is the charge for cancelling the booked rooms of the room type. Empty RoomType is the default
+// policy of the hotel. The cancellation is free until FreeDays days before the arrival, later PenaltyPercent
+// percent of the price or the price of the first PenaltyNights nights is charged. A non-refundable booking
+// is charged in full. The zero policy is free cancellation.
+type CancellationPolicy struct {
+	HotelID        HotelID  `json:"hotel_id"`
+	RoomType       RoomType `json:"room_type,omitempty"`
+	FreeDays       int      `json:"free_days"`
+	PenaltyPercent int      `json:"penalty_percent,omitempty"`
+	PenaltyNights  int      `json:"penalty_nights,omitempty"`
+	NonRefundable  bool     `json:"non_refundable,omitempty"`
+}
+
+func (p *CancellationPolicy) Validate() error {
+	if p.FreeDays < 0 || p.PenaltyPercent < 0 || p.PenaltyNights < 0 {
+		return fmt.Errorf("%w: days, percent and nights can't be negative", ErrInvalidPolicy)
+	}
+
+	if p.PenaltyPercent > 100 {
+		return fmt.Errorf("%w: percent can't be over 100", ErrInvalidPolicy)
+	}
+
+	if p.PenaltyPercent > 0 && p.PenaltyNights > 0 {
+		return fmt.Errorf("%w: penalty is either percent or nights", ErrInvalidPolicy)
+	}
+
+	if p.NonRefundable && (p.FreeDays > 0 || p.PenaltyPercent > 0 || p.PenaltyNights > 0) {
+		return fmt.Errorf("%w: non-refundable policy has no free days or penalty", ErrInvalidPolicy)
+	}
+
+	return nil
+}
+
+// Penalty returns the charge for cancelling the nights of the room type priced by lines on the date.
+func (p *CancellationPolicy) Penalty(lines []PriceLine, today time.Time) int64 {
+	if len(lines) == 0 {
+		return 0
+	}
+
+	var (
+		amount  int64
+		arrival = lines[0].Date
+		dates   []time.Time
+	)
+
+	for _, line := range lines {
+		amount += line.Amount.Amount
+
+		if line.Date.Before(arrival) {
+			arrival = line.Date
+		}
+
+		if !slices.ContainsFunc(dates, line.Date.Equal) {
+			dates = append(dates, line.Date)
+		}
+	}
+
+	if p.NonRefundable {
+		return amount
+	}
+
+	if !today.After(arrival.AddDate(0, 0, -p.FreeDays)) {
+		return 0
+	}
+
+	if p.PenaltyPercent > 0 {
+		return amount * int64(p.PenaltyPercent) / 100
+	}
+
+	slices.SortFunc(dates, func(a, b time.Time) int { return a.Compare(b) })
+
+	var penalty int64
+
+	for _, line := range lines {
+		if index := slices.IndexFunc(dates, line.Date.Equal); index < p.PenaltyNights {
+			penalty += line.Amount.Amount
+		}
+	}
+
+	return penalty
+}
+
+// CancellationCharge is what the cancellation of the order costs: the penalty kept by the hotels and the refund
+// of the rest of the order total.
+type CancellationCharge struct {
+	Penalty Money `json:"penalty"`
+	Refund  Money `json:"refund"`
+}
+
+// CancellationCharge returns the charge for cancelling the order by its policies. today is the current date
+// in each hotel of the order. The room types without a policy are cancelled for free. The penalty is taken
+// from the price the guest paid, so the discounts of the order lower it in the same proportion as the total.
+func (o *Order) CancellationCharge(today map[HotelID]time.Time) CancellationCharge {
+	type group struct {
+		hotelID  HotelID
+		roomType RoomType
+	}
+
+	lines := make(map[group][]PriceLine)
+
+	var groups []group
+
+	for _, line := range o.Lines {
+		g := group{hotelID: line.HotelID, roomType: line.RoomType}
+		if _, ok := lines[g]; !ok {
+			groups = append(groups, g)
+		}
+
+		lines[g] = append(lines[g], line)
+	}
+
+	var penalty int64
+
+	for _, g := range groups {
+		for _, policy := range o.CancellationPolicies {
+			if policy.HotelID == g.hotelID && policy.RoomType == g.roomType {
+				penalty += policy.Penalty(lines[g], today[g.hotelID])
+				break
+			}
+		}
+	}
+
+	if o.Subtotal.Amount > 0 {
+		penalty = penalty * o.Total.Amount / o.Subtotal.Amount
+	}
+
+	penalty = min(penalty, o.Total.Amount)
+
+	return CancellationCharge{
+		Penalty: Money{Amount: penalty, Currency: o.Total.Currency},
+		Refund:  Money{Amount: o.Total.Amount - penalty, Currency: o.Total.Currency},
+	}
+}
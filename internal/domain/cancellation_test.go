@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancellationPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        CancellationPolicy
+		expectedError string
+	}{
+		{name: "free cancellation", policy: CancellationPolicy{HotelID: 1}},
+		{name: "percent penalty", policy: CancellationPolicy{HotelID: 1, FreeDays: 3, PenaltyPercent: 50}},
+		{name: "first night penalty", policy: CancellationPolicy{HotelID: 1, RoomType: "single", FreeDays: 1, PenaltyNights: 1}},
+		{name: "non-refundable", policy: CancellationPolicy{HotelID: 1, NonRefundable: true}},
+		{
+			name:          "negative days",
+			policy:        CancellationPolicy{HotelID: 1, FreeDays: -1},
+			expectedError: "invalid cancellation policy: days, percent and nights can't be negative",
+		},
+		{
+			name:          "percent over 100",
+			policy:        CancellationPolicy{HotelID: 1, PenaltyPercent: 101},
+			expectedError: "invalid cancellation policy: percent can't be over 100",
+		},
+		{
+			name:          "percent and nights",
+			policy:        CancellationPolicy{HotelID: 1, PenaltyPercent: 50, PenaltyNights: 1},
+			expectedError: "invalid cancellation policy: penalty is either percent or nights",
+		},
+		{
+			name:          "non-refundable with free days",
+			policy:        CancellationPolicy{HotelID: 1, FreeDays: 3, NonRefundable: true},
+			expectedError: "invalid cancellation policy: non-refundable policy has no free days or penalty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+
+			if tt.expectedError != "" {
+				assert.ErrorIs(t, err, ErrInvalidPolicy)
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOrder_CancellationCharge(t *testing.T) {
+	arrival := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	rub := func(amount int64) Money { return Money{Amount: amount, Currency: "RUB"} }
+
+	// two nights of two singles and one night of a double in hotel 1, one night in hotel 2
+	lines := []PriceLine{
+		{HotelID: 1, RoomType: "single", Date: arrival.AddDate(0, 0, 1), RoomCount: 2, NightPrice: rub(1000), Amount: rub(2000)},
+		{HotelID: 1, RoomType: "single", Date: arrival, RoomCount: 2, NightPrice: rub(1500), Amount: rub(3000)},
+		{HotelID: 1, RoomType: "double", Date: arrival, RoomCount: 1, NightPrice: rub(4000), Amount: rub(4000)},
+		{HotelID: 2, RoomType: "single", Date: arrival, RoomCount: 1, NightPrice: rub(1000), Amount: rub(1000)},
+	}
+
+	tests := []struct {
+		name            string
+		policies        []CancellationPolicy
+		today           time.Time
+		discount        int64
+		expectedPenalty int64
+	}{
+		{
+			name:  "no policies",
+			today: arrival,
+		},
+		{
+			name: "free days left",
+			policies: []CancellationPolicy{
+				{HotelID: 1, RoomType: "single", FreeDays: 3, PenaltyPercent: 50},
+			},
+			today: arrival.AddDate(0, 0, -3),
+		},
+		{
+			name: "percent after free days",
+			policies: []CancellationPolicy{
+				{HotelID: 1, RoomType: "single", FreeDays: 3, PenaltyPercent: 50},
+			},
+			today:           arrival.AddDate(0, 0, -2),
+			expectedPenalty: 2500,
+		},
+		{
+			name: "first night after free days",
+			policies: []CancellationPolicy{
+				{HotelID: 1, RoomType: "single", FreeDays: 1, PenaltyNights: 1},
+				{HotelID: 2, RoomType: "single", FreeDays: 1, PenaltyNights: 1},
+			},
+			today:           arrival,
+			expectedPenalty: 4000,
+		},
+		{
+			name: "non-refundable",
+			policies: []CancellationPolicy{
+				{HotelID: 1, RoomType: "double", NonRefundable: true},
+			},
+			today:           arrival.AddDate(0, 0, -30),
+			expectedPenalty: 4000,
+		},
+		{
+			name: "discount lowers penalty",
+			policies: []CancellationPolicy{
+				{HotelID: 1, RoomType: "single", FreeDays: 3, PenaltyPercent: 50},
+			},
+			today:           arrival.AddDate(0, 0, -2),
+			discount:        1000,
+			expectedPenalty: 2250,
+		},
+		{
+			name: "discounted non-refundable order keeps what was paid for it",
+			policies: []CancellationPolicy{
+				{HotelID: 1, RoomType: "single", NonRefundable: true},
+				{HotelID: 1, RoomType: "double", NonRefundable: true},
+				{HotelID: 2, RoomType: "single", NonRefundable: true},
+			},
+			today:           arrival,
+			discount:        8000,
+			expectedPenalty: 2000,
+		},
+		{
+			name: "penalty of part of discounted order",
+			policies: []CancellationPolicy{
+				{HotelID: 1, RoomType: "single", NonRefundable: true},
+				{HotelID: 1, RoomType: "double", NonRefundable: true},
+			},
+			today:           arrival,
+			discount:        8000,
+			expectedPenalty: 1800,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := Order{
+				Lines:                lines,
+				Subtotal:             rub(10000),
+				Discounts:            []Discount{{Source: DiscountSourcePromo, Amount: rub(tt.discount)}},
+				CancellationPolicies: tt.policies,
+			}
+			order.RecalculateTotal()
+
+			charge := order.CancellationCharge(map[HotelID]time.Time{1: tt.today, 2: tt.today})
+
+			assert.Equal(t, CancellationCharge{
+				Penalty: rub(tt.expectedPenalty),
+				Refund:  rub(order.Total.Amount - tt.expectedPenalty),
+			}, charge)
+		})
+	}
+}
@@ -1,11 +1,120 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 var (
 	ErrHotelNotFound      = errors.New("hotel not found")
+	ErrHotelAlreadyExists = errors.New("hotel already exists")
+	ErrInvalidHotel       = errors.New("invalid hotel")
 	ErrRoomTypeNotFound   = errors.New("room type not found")
+	ErrInvalidRoomType    = errors.New("invalid room type")
 	ErrOrderNotFound      = errors.New("order not found")
 	ErrOrderAlreadyExists = errors.New("order already exists")
 	ErrRoomsNotAvailable  = errors.New("rooms not available")
+	ErrOrderNotModifiable = errors.New("order can't be modified")
+	ErrHoldNotFound       = errors.New("hold not found")
+	ErrHoldExpired        = errors.New("hold expired")
+	ErrRateNotFound       = errors.New("rate not found")
+	ErrPolicyNotFound     = errors.New("cancellation policy not found")
+	ErrCurrencyMismatch   = errors.New("currency mismatch")
+	ErrInvalidPrice       = errors.New("invalid price")
+	ErrInvalidPromo       = errors.New("invalid promo")
+	ErrPromoNotFound      = errors.New("promo code not found")
+	ErrPromoAlreadyExists = errors.New("promo code already exists")
+	ErrPromoNotApplicable = errors.New("promo code isn't applicable")
+	ErrPromoUsageLimit    = errors.New("promo code usage limit reached")
+	ErrInvalidPoints      = errors.New("invalid loyalty points")
+	ErrInsufficientPoints = errors.New("insufficient loyalty points")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrWebhookNotFound    = errors.New("webhook not found")
+	ErrInvalidWebhook     = errors.New("invalid webhook")
+	ErrPaymentDeclined    = errors.New("payment declined")
+	ErrPaymentTimeout     = errors.New("payment gateway timeout")
+	ErrPaymentNotFound    = errors.New("payment not found")
+
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	ErrRoomTypeAlreadyExists   = errors.New("room type already exists")
+	ErrInvalidQuery            = errors.New("invalid query")
+	ErrInvalidAvailability     = errors.New("invalid availability")
+	ErrCapacityBelowReserved   = errors.New("capacity is below reserved rooms")
+	ErrInvalidBooking          = errors.New("invalid booking")
+	ErrInvalidRestriction      = errors.New("invalid restriction")
+	ErrRestrictionViolated     = errors.New("restriction violated")
+	ErrInvalidOccupancy        = errors.New("invalid occupancy")
+	ErrOutsideStayDates        = errors.New("outside of the stay dates")
+	ErrInvalidPolicy           = errors.New("invalid cancellation policy")
+	ErrInvalidPaymentOperation = errors.New("invalid payment operation")
 )
+
+// StatusTransitionError is returned when an order can't be moved from its current status to the requested one.
+type StatusTransitionError struct {
+	From OrderStatus
+	To   OrderStatus
+}
+
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf("%s: from '%s' to '%s'", ErrInvalidStatusTransition, e.From, e.To)
+}
+
+func (e *StatusTransitionError) Unwrap() error {
+	return ErrInvalidStatusTransition
+}
+
+// CapacityBelowReservedError is returned when the capacity of the room type on the date would be less
+// than the rooms already reserved.
+type CapacityBelowReservedError struct {
+	RoomType RoomType
+	Date     time.Time
+	Capacity int
+	Reserved int
+}
+
+func (e *CapacityBelowReservedError) Error() string {
+	return fmt.Sprintf("%s: room '%s' has %d reserved rooms on %s, capacity %d requested",
+		ErrCapacityBelowReserved, e.RoomType, e.Reserved, e.Date.Format(time.DateOnly), e.Capacity)
+}
+
+func (e *CapacityBelowReservedError) Unwrap() error {
+	return ErrCapacityBelowReserved
+}
+
+// RestrictionError is returned when a booking breaks the rule of a restriction of its room type on the date.
+// Nights is the length of the stay and Limit is the stay limit of the min and max stay rules.
+type RestrictionError struct {
+	Rule     RestrictionRule
+	HotelID  HotelID
+	RoomType RoomType
+	Date     time.Time
+	Nights   int
+	Limit    int
+}
+
+func (e *RestrictionError) Error() string {
+	room := fmt.Sprintf("room '%s' in hotel id=%v", e.RoomType, e.HotelID)
+	date := e.Date.Format(time.DateOnly)
+
+	switch e.Rule {
+	case RuleStopSell:
+		return fmt.Sprintf("%s: %s: %s is closed for sale on %s", ErrRestrictionViolated, e.Rule, room, date)
+	case RuleClosedToArrival:
+		return fmt.Sprintf("%s: %s: %s is closed to arrival on %s", ErrRestrictionViolated, e.Rule, room, date)
+	case RuleClosedToDeparture:
+		return fmt.Sprintf("%s: %s: %s is closed to departure on %s", ErrRestrictionViolated, e.Rule, room, date)
+	case RuleMinStay:
+		return fmt.Sprintf("%s: %s: %s requires at least %d nights for arrival on %s, %d requested",
+			ErrRestrictionViolated, e.Rule, room, e.Limit, date, e.Nights)
+	case RuleMaxStay:
+		return fmt.Sprintf("%s: %s: %s allows at most %d nights for arrival on %s, %d requested",
+			ErrRestrictionViolated, e.Rule, room, e.Limit, date, e.Nights)
+	default:
+		return fmt.Sprintf("%s: %s: %s on %s", ErrRestrictionViolated, e.Rule, room, date)
+	}
+}
+
+func (e *RestrictionError) Unwrap() error {
+	return ErrRestrictionViolated
+}
@@ -0,0 +1,155 @@
+package domain
+
+import "time"
+
+type EventType string
+
+const (
+	EventOrderCreated        EventType = "order_created"
+	EventOrderCancelled      EventType = "order_cancelled"
+	EventOrderModified       EventType = "order_modified"
+	EventOrderStatusChanged  EventType = "order_status_changed"
+	EventOrderNoShow         EventType = "order_no_show"
+	EventAvailabilityChanged EventType = "availability_changed"
+)
+
+var eventTypes = []EventType{
+	EventOrderCreated,
+	EventOrderCancelled,
+	EventOrderModified,
+	EventOrderStatusChanged,
+	EventOrderNoShow,
+	EventAvailabilityChanged,
+}
+
+// Event is a change of the state recorded in the outbox. ID grows with every event,
+// so it's used as the delivery offset.
+type Event struct {
+	ID           int64                `json:"id"`
+	Type         EventType            `json:"type"`
+	CreatedAt    time.Time            `json:"created_at"`
+	Order        *Order               `json:"order,omitempty"`
+	Availability []AvailabilityChange `json:"availability,omitempty"`
+}
+
+// AvailabilityChange is the change of the available rooms of the room type for one night.
+type AvailabilityChange struct {
+	HotelID  HotelID   `json:"hotel_id"`
+	RoomType RoomType  `json:"room_type"`
+	Date     time.Time `json:"date"`
+	Delta    int       `json:"delta"`
+}
+
+// OrderEvents returns the events describing the change of the order. old is nil for a new order.
+func OrderEvents(old *Order, updated Order) []Event {
+	if old == nil {
+		return []Event{{Type: EventOrderCreated, Order: &updated}}
+	}
+
+	var events []Event
+
+	if diff := DiffBookings(old.Bookings, updated.Bookings); len(diff.Added) > 0 || len(diff.Removed) > 0 {
+		events = append(events, Event{Type: EventOrderModified, Order: &updated})
+	}
+
+	if old.Status != updated.Status {
+		eventType := EventOrderStatusChanged
+
+		switch updated.Status {
+		case OrderStatusCancelled:
+			eventType = EventOrderCancelled
+		case OrderStatusNoShow:
+			eventType = EventOrderNoShow
+		}
+
+		events = append(events, Event{Type: eventType, Order: &updated})
+	}
+
+	return events
+}
+
+// HotelIDs returns the hotels the event is about.
+func (e Event) HotelIDs() []HotelID {
+	var ids []HotelID
+
+	add := func(id HotelID) {
+		if !contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	if e.Order != nil {
+		for _, booking := range e.Order.Bookings {
+			add(booking.HotelID)
+		}
+	}
+
+	for _, change := range e.Availability {
+		add(change.HotelID)
+	}
+
+	return ids
+}
+
+// ForHotel returns the event as a partner hotel sees it: the order status and dates with only the bookings,
+// price lines, cancellation policies and availability changes of the hotel. The guest, the payment and
+// the order totals, which cover other hotels and discounts, aren't shared.
+func (e Event) ForHotel(hotelID HotelID) Event {
+	if e.Order != nil {
+		order := Order{
+			ID:           e.Order.ID,
+			Number:       e.Order.Number,
+			Status:       e.Order.Status,
+			CreatedAt:    e.Order.CreatedAt,
+			ModifiedAt:   e.Order.ModifiedAt,
+			CancelledAt:  e.Order.CancelledAt,
+			CheckedInAt:  e.Order.CheckedInAt,
+			CheckedOutAt: e.Order.CheckedOutAt,
+			NoShowAt:     e.Order.NoShowAt,
+		}
+
+		for _, booking := range e.Order.Bookings {
+			if booking.HotelID != hotelID {
+				continue
+			}
+
+			rooms := make([]RoomOccupancy, 0, len(booking.Rooms))
+			for _, room := range booking.Rooms {
+				rooms = append(rooms, RoomOccupancy{Adults: room.Adults, Children: room.Children})
+			}
+
+			if len(rooms) == 0 {
+				rooms = nil
+			}
+
+			booking.Rooms = rooms
+			order.Bookings = append(order.Bookings, booking)
+		}
+
+		for _, line := range e.Order.Lines {
+			if line.HotelID == hotelID {
+				order.Lines = append(order.Lines, line)
+			}
+		}
+
+		for _, policy := range e.Order.CancellationPolicies {
+			if policy.HotelID == hotelID {
+				order.CancellationPolicies = append(order.CancellationPolicies, policy)
+			}
+		}
+
+		e.Order = &order
+	}
+
+	var availability []AvailabilityChange
+
+	for _, change := range e.Availability {
+		if change.HotelID == hotelID {
+			availability = append(availability, change)
+		}
+	}
+
+	e.Availability = availability
+
+	return e
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_ForHotel(t *testing.T) {
+	testDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rub := func(amount int64) Money {
+		return Money{Amount: amount, Currency: "RUB"}
+	}
+
+	event := Event{
+		ID:   7,
+		Type: EventOrderCreated,
+		Order: &Order{
+			ID:        "order-1",
+			Number:    3,
+			UserID:    42,
+			Status:    OrderStatusConfirmed,
+			CreatedAt: testDate,
+			Bookings: []Booking{
+				{HotelID: 1, RoomType: RoomTypeSingle, From: testDate, To: testDate, RoomCount: 1,
+					Rooms: []RoomOccupancy{{Adults: 1, GuestNames: []string{"Ivan Petrov"}}}},
+				{HotelID: 2, RoomType: RoomTypeLux, From: testDate, To: testDate, RoomCount: 1},
+			},
+			PromoCode: "SALE",
+			Lines: []PriceLine{
+				{HotelID: 1, RoomType: RoomTypeSingle, Date: testDate, RoomCount: 1, NightPrice: rub(1000), Amount: rub(1000)},
+				{HotelID: 2, RoomType: RoomTypeLux, Date: testDate, RoomCount: 1, NightPrice: rub(5000), Amount: rub(5000)},
+			},
+			Subtotal:  rub(6000),
+			Discounts: []Discount{{Source: DiscountSourcePromo, PromoCode: "SALE", Amount: rub(600)}},
+			Total:     rub(5400),
+			CancellationPolicies: []CancellationPolicy{
+				{HotelID: 1, FreeDays: 3, PenaltyPercent: 50},
+				{HotelID: 2, NonRefundable: true},
+			},
+			Payments: []Payment{{ID: "fake-1", Amount: rub(5400)}},
+		},
+		Availability: []AvailabilityChange{
+			{HotelID: 1, RoomType: RoomTypeSingle, Date: testDate, Delta: -1},
+			{HotelID: 2, RoomType: RoomTypeLux, Date: testDate, Delta: -1},
+		},
+	}
+
+	expected := Event{
+		ID:   7,
+		Type: EventOrderCreated,
+		Order: &Order{
+			ID:        "order-1",
+			Number:    3,
+			Status:    OrderStatusConfirmed,
+			CreatedAt: testDate,
+			Bookings: []Booking{
+				{HotelID: 1, RoomType: RoomTypeSingle, From: testDate, To: testDate, RoomCount: 1,
+					Rooms: []RoomOccupancy{{Adults: 1}}},
+			},
+			Lines: []PriceLine{
+				{HotelID: 1, RoomType: RoomTypeSingle, Date: testDate, RoomCount: 1, NightPrice: rub(1000), Amount: rub(1000)},
+			},
+			CancellationPolicies: []CancellationPolicy{
+				{HotelID: 1, FreeDays: 3, PenaltyPercent: 50},
+			},
+		},
+		Availability: []AvailabilityChange{
+			{HotelID: 1, RoomType: RoomTypeSingle, Date: testDate, Delta: -1},
+		},
+	}
+
+	assert.Equal(t, expected, event.ForHotel(1))
+	assert.Equal(t, []string{"Ivan Petrov"}, event.Order.Bookings[0].Rooms[0].GuestNames, "the original event isn't changed")
+}
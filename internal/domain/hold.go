@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+type HoldToken string
+
+// Hold keeps rooms reserved for a limited time until it's confirmed as an order.
+type Hold struct {
+	Token     HoldToken   `json:"token"`
+	UserID    UserID      `json:"user_id"`
+	Bookings  []Booking   `json:"booking"`
+	Lines     []PriceLine `json:"lines"`
+	Total     Money       `json:"total"`
+	CreatedAt time.Time   `json:"created_at"`
+	ExpiresAt time.Time   `json:"expires_at"`
+	// CancellationPolicies are fixed with the price when the rooms are held.
+	CancellationPolicies []CancellationPolicy `json:"cancellation_policies,omitempty"`
+}
+
+func (h *Hold) IsExpired(now time.Time) bool {
+	return !now.Before(h.ExpiresAt)
+}
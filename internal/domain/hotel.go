@@ -1,29 +1,92 @@
 package domain
 
+import (
+	"fmt"
+	"time"
+)
+
 type HotelID int
 
+// TimeOfDayLayout is the layout of the check-in and check-out times.
+const TimeOfDayLayout = "15:04"
+
+// Hotel describes a hotel. Timezone is an IANA time zone name, the check-in, check-out and no-show cutoff
+// times are local times of the hotel. Zero StarRating means the hotel isn't rated.
 type Hotel struct {
-	ID   HotelID
-	Name string
+	ID           HotelID `json:"id"`
+	Name         string  `json:"name"`
+	Address      string  `json:"address,omitempty"`
+	City         string  `json:"city,omitempty"`
+	Timezone     string  `json:"timezone,omitempty"`
+	StarRating   int     `json:"star_rating,omitempty"`
+	CheckInTime  string  `json:"check_in_time,omitempty"`
+	CheckOutTime string  `json:"check_out_time,omitempty"`
+	NoShowCutoff string  `json:"no_show_cutoff,omitempty"`
+}
+
+func (h *Hotel) Validate() error {
+	if h.ID <= 0 {
+		return fmt.Errorf("%w: id must be positive", ErrInvalidHotel)
+	}
+
+	if h.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidHotel)
+	}
+
+	if _, err := h.Location(); err != nil {
+		return fmt.Errorf("%w: unknown timezone '%s'", ErrInvalidHotel, h.Timezone)
+	}
+
+	if h.StarRating < 0 || h.StarRating > 5 {
+		return fmt.Errorf("%w: star rating must be between 0 and 5", ErrInvalidHotel)
+	}
+
+	for _, t := range []string{h.CheckInTime, h.CheckOutTime, h.NoShowCutoff} {
+		if _, err := time.Parse(TimeOfDayLayout, t); t != "" && err != nil {
+			return fmt.Errorf("%w: time '%s' must be in HH:MM format", ErrInvalidHotel, t)
+		}
+	}
+
+	return nil
+}
+
+// Location returns the time zone of the hotel, UTC if it isn't set.
+func (h *Hotel) Location() (*time.Location, error) {
+	return time.LoadLocation(h.Timezone)
 }
 
+// RoomType is the code of a room type in the catalog of the hotel.
 type RoomType string
 
+// The room types of the fixture hotels.
 const (
 	RoomTypeSingle RoomType = "single"
 	RoomTypeDouble RoomType = "double"
 	RoomTypeLux    RoomType = "lux"
 )
 
-type RoomTypesEnum map[RoomType]struct{}
-
-var RoomTypes = RoomTypesEnum{
-	RoomTypeSingle: {},
-	RoomTypeDouble: {},
-	RoomTypeLux:    {},
+// HotelRoomType is a room type of the hotel catalog. Capacity is the maximum number of guests in a room.
+type HotelRoomType struct {
+	HotelID     HotelID  `json:"hotel_id"`
+	Code        RoomType `json:"code"`
+	Name        string   `json:"name"`
+	Capacity    int      `json:"capacity"`
+	Description string   `json:"description,omitempty"`
+	Amenities   []string `json:"amenities,omitempty"`
 }
 
-func (r RoomTypesEnum) Contains(value RoomType) bool {
-	_, ok := r[value]
-	return ok
+func (rt *HotelRoomType) Validate() error {
+	if rt.Code == "" {
+		return fmt.Errorf("%w: code is required", ErrInvalidRoomType)
+	}
+
+	if rt.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidRoomType)
+	}
+
+	if rt.Capacity <= 0 {
+		return fmt.Errorf("%w: capacity must be positive", ErrInvalidRoomType)
+	}
+
+	return nil
 }
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHotel_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		hotel         Hotel
+		expectedError error
+	}{
+		{
+			name: "valid hotel",
+			hotel: Hotel{ID: 1, Name: "Reddison", Address: "Europe Square, 2", City: "Moscow", Timezone: "Europe/Moscow",
+				StarRating: 4, CheckInTime: "14:00", CheckOutTime: "12:00"},
+		},
+		{
+			name:  "only required fields",
+			hotel: Hotel{ID: 1, Name: "Reddison"},
+		},
+		{
+			name:          "non-positive id",
+			hotel:         Hotel{ID: 0, Name: "Reddison"},
+			expectedError: ErrInvalidHotel,
+		},
+		{
+			name:          "empty name",
+			hotel:         Hotel{ID: 1},
+			expectedError: ErrInvalidHotel,
+		},
+		{
+			name:          "unknown timezone",
+			hotel:         Hotel{ID: 1, Name: "Reddison", Timezone: "Moscow"},
+			expectedError: ErrInvalidHotel,
+		},
+		{
+			name:          "star rating out of range",
+			hotel:         Hotel{ID: 1, Name: "Reddison", StarRating: 6},
+			expectedError: ErrInvalidHotel,
+		},
+		{
+			name:          "invalid check-in time",
+			hotel:         Hotel{ID: 1, Name: "Reddison", CheckInTime: "2pm"},
+			expectedError: ErrInvalidHotel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.hotel.Validate()
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHotelRoomType_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		roomType      HotelRoomType
+		expectedError error
+	}{
+		{
+			name:     "valid room type",
+			roomType: HotelRoomType{HotelID: 1, Code: "family", Name: "Family room", Capacity: 4, Amenities: []string{"crib"}},
+		},
+		{
+			name:          "empty code",
+			roomType:      HotelRoomType{HotelID: 1, Name: "Family room", Capacity: 4},
+			expectedError: ErrInvalidRoomType,
+		},
+		{
+			name:          "empty name",
+			roomType:      HotelRoomType{HotelID: 1, Code: "family", Capacity: 4},
+			expectedError: ErrInvalidRoomType,
+		},
+		{
+			name:          "non-positive capacity",
+			roomType:      HotelRoomType{HotelID: 1, Code: "dorm", Name: "Dorm bed"},
+			expectedError: ErrInvalidRoomType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.roomType.Validate()
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
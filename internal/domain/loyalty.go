@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+type LoyaltyEntryType string
+
+const (
+	LoyaltyEntryEarn     LoyaltyEntryType = "earn"
+	LoyaltyEntryRedeem   LoyaltyEntryType = "redeem"
+	LoyaltyEntryReversal LoyaltyEntryType = "reversal"
+)
+
+// LoyaltyEntry is a record of the append-only loyalty ledger. Points are positive when they are credited
+// and negative when they are debited.
+type LoyaltyEntry struct {
+	ID          int64            `json:"id"`
+	UserID      UserID           `json:"user_id"`
+	OrderNumber OrderNumber      `json:"order_number"`
+	Type        LoyaltyEntryType `json:"type"`
+	Points      int64            `json:"points"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+type LoyaltyAccount struct {
+	UserID  UserID         `json:"user_id"`
+	Balance int64          `json:"balance"`
+	History []LoyaltyEntry `json:"history"`
+}
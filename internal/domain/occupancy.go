@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RoomOccupancy describes who stays in a booked room. GuestNames may list fewer guests than stay in the room.
+type RoomOccupancy struct {
+	Adults     int      `json:"adults"`
+	Children   int      `json:"children"`
+	GuestNames []string `json:"guest_names,omitempty"`
+}
+
+// Guests returns the number of guests in the room.
+func (o RoomOccupancy) Guests() int {
+	return o.Adults + o.Children
+}
+
+// ValidateOccupancy checks the rooms of the booking against the room type: every booked room is described,
+// has at least one adult and doesn't exceed the room type capacity. A booking without described rooms is valid
+// if it books at least one room.
+func (b Booking) ValidateOccupancy(roomType HotelRoomType) error {
+	if b.RoomCount < 1 {
+		return fmt.Errorf("%w: room count of '%s' must be at least 1, got %d", ErrInvalidOccupancy, b.RoomType, b.RoomCount)
+	}
+
+	if len(b.Rooms) == 0 {
+		return nil
+	}
+
+	if len(b.Rooms) != b.RoomCount {
+		return fmt.Errorf("%w: %d rooms of '%s' booked, but %d described",
+			ErrInvalidOccupancy, b.RoomCount, b.RoomType, len(b.Rooms))
+	}
+
+	for i, room := range b.Rooms {
+		if room.Adults < 1 {
+			return fmt.Errorf("%w: room %d of '%s' must have at least one adult", ErrInvalidOccupancy, i+1, b.RoomType)
+		}
+
+		if room.Children < 0 {
+			return fmt.Errorf("%w: room %d of '%s' can't have negative children", ErrInvalidOccupancy, i+1, b.RoomType)
+		}
+
+		if room.Guests() > roomType.Capacity {
+			return fmt.Errorf("%w: room %d of '%s' has %d guests, but takes at most %d",
+				ErrInvalidOccupancy, i+1, b.RoomType, room.Guests(), roomType.Capacity)
+		}
+
+		if len(room.GuestNames) > room.Guests() {
+			return fmt.Errorf("%w: room %d of '%s' has %d guests, but %d names",
+				ErrInvalidOccupancy, i+1, b.RoomType, room.Guests(), len(room.GuestNames))
+		}
+
+		for _, name := range room.GuestNames {
+			if strings.TrimSpace(name) == "" {
+				return fmt.Errorf("%w: room %d of '%s' has an empty guest name", ErrInvalidOccupancy, i+1, b.RoomType)
+			}
+		}
+	}
+
+	return nil
+}
+
+func equalOccupancies(a, b []RoomOccupancy) bool {
+	return slices.EqualFunc(a, b, func(x, y RoomOccupancy) bool {
+		return x.Adults == y.Adults && x.Children == y.Children && slices.Equal(x.GuestNames, y.GuestNames)
+	})
+}
+
+// RoomingList is the list of the rooms of the order and their guests the hotels prepare the stay by.
+type RoomingList struct {
+	OrderNumber OrderNumber       `json:"order_number"`
+	Status      OrderStatus       `json:"status"`
+	Rooms       []RoomingListRoom `json:"rooms"`
+}
+
+// RoomingListRoom is a booked room. Departure is the morning after the last night.
+// Zero Adults means the guests of the room aren't known.
+type RoomingListRoom struct {
+	HotelID    HotelID   `json:"hotel_id"`
+	RoomType   RoomType  `json:"room_type"`
+	Arrival    time.Time `json:"arrival"`
+	Departure  time.Time `json:"departure"`
+	Nights     int       `json:"nights"`
+	Adults     int       `json:"adults"`
+	Children   int       `json:"children"`
+	GuestNames []string  `json:"guest_names"`
+}
+
+// RoomingList returns a room per each booked room of the order in the booking order.
+func (o *Order) RoomingList() RoomingList {
+	list := RoomingList{
+		OrderNumber: o.Number,
+		Status:      o.Status,
+		Rooms:       []RoomingListRoom{},
+	}
+
+	for _, booking := range o.Bookings {
+		for i := 0; i < booking.RoomCount; i++ {
+			room := RoomingListRoom{
+				HotelID:    booking.HotelID,
+				RoomType:   booking.RoomType,
+				Arrival:    booking.From,
+				Departure:  booking.Departure(),
+				Nights:     booking.Nights(),
+				GuestNames: []string{},
+			}
+
+			if i < len(booking.Rooms) {
+				room.Adults = booking.Rooms[i].Adults
+				room.Children = booking.Rooms[i].Children
+				room.GuestNames = append(room.GuestNames, booking.Rooms[i].GuestNames...)
+			}
+
+			list.Rooms = append(list.Rooms, room)
+		}
+	}
+
+	return list
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBooking_ValidateOccupancy(t *testing.T) {
+	double := HotelRoomType{HotelID: 1, Code: "double", Name: "Double", Capacity: 2}
+
+	tests := []struct {
+		name          string
+		rooms         []RoomOccupancy
+		expectedError string
+	}{
+		{
+			name: "guests aren't known",
+		},
+		{
+			name: "rooms within capacity",
+			rooms: []RoomOccupancy{
+				{Adults: 2, GuestNames: []string{"Ivan Petrov", "Anna Petrova"}},
+				{Adults: 1, Children: 1},
+			},
+		},
+		{
+			name:          "not every room described",
+			rooms:         []RoomOccupancy{{Adults: 1}},
+			expectedError: "invalid occupancy: 2 rooms of 'double' booked, but 1 described",
+		},
+		{
+			name:          "no adults",
+			rooms:         []RoomOccupancy{{Adults: 1}, {Children: 2}},
+			expectedError: "invalid occupancy: room 2 of 'double' must have at least one adult",
+		},
+		{
+			name:          "negative children",
+			rooms:         []RoomOccupancy{{Adults: 1}, {Adults: 1, Children: -1}},
+			expectedError: "invalid occupancy: room 2 of 'double' can't have negative children",
+		},
+		{
+			name:          "over capacity",
+			rooms:         []RoomOccupancy{{Adults: 2, Children: 1}, {Adults: 1}},
+			expectedError: "invalid occupancy: room 1 of 'double' has 3 guests, but takes at most 2",
+		},
+		{
+			name:          "more names than guests",
+			rooms:         []RoomOccupancy{{Adults: 1, GuestNames: []string{"Ivan Petrov", "Anna Petrova"}}, {Adults: 1}},
+			expectedError: "invalid occupancy: room 1 of 'double' has 1 guests, but 2 names",
+		},
+		{
+			name:          "empty name",
+			rooms:         []RoomOccupancy{{Adults: 1}, {Adults: 2, GuestNames: []string{" "}}},
+			expectedError: "invalid occupancy: room 2 of 'double' has an empty guest name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			booking := Booking{HotelID: 1, RoomType: "double", RoomCount: 2, Rooms: tt.rooms}
+
+			err := booking.ValidateOccupancy(double)
+
+			if tt.expectedError != "" {
+				assert.ErrorIs(t, err, ErrInvalidOccupancy)
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBooking_ValidateOccupancy_RoomCount(t *testing.T) {
+	double := HotelRoomType{HotelID: 1, Code: "double", Name: "Double", Capacity: 2}
+
+	for _, roomCount := range []int{0, -1} {
+		booking := Booking{HotelID: 1, RoomType: "double", RoomCount: roomCount}
+
+		err := booking.ValidateOccupancy(double)
+
+		assert.ErrorIs(t, err, ErrInvalidOccupancy)
+	}
+}
+
+func TestOrder_RoomingList(t *testing.T) {
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+
+	order := Order{
+		Number: 7,
+		Status: OrderStatusConfirmed,
+		Bookings: []Booking{
+			{HotelID: 1, RoomType: "double", From: from, To: to, RoomCount: 2, Rooms: []RoomOccupancy{
+				{Adults: 2, GuestNames: []string{"Ivan Petrov", "Anna Petrova"}},
+				{Adults: 1, Children: 1, GuestNames: []string{"Oleg Sidorov"}},
+			}},
+			{HotelID: 2, RoomType: "single", From: to, To: to, RoomCount: 1},
+		},
+	}
+
+	assert.Equal(t, RoomingList{
+		OrderNumber: 7,
+		Status:      OrderStatusConfirmed,
+		Rooms: []RoomingListRoom{
+			{HotelID: 1, RoomType: "double", Arrival: from, Departure: to.AddDate(0, 0, 1), Nights: 2,
+				Adults: 2, GuestNames: []string{"Ivan Petrov", "Anna Petrova"}},
+			{HotelID: 1, RoomType: "double", Arrival: from, Departure: to.AddDate(0, 0, 1), Nights: 2,
+				Adults: 1, Children: 1, GuestNames: []string{"Oleg Sidorov"}},
+			{HotelID: 2, RoomType: "single", Arrival: to, Departure: to.AddDate(0, 0, 1), Nights: 1,
+				GuestNames: []string{}},
+		},
+	}, order.RoomingList())
+}
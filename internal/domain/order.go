@@ -1,17 +1,145 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"slices"
+	"time"
+)
 
 type OrderNumber int64
 
 type OrderID string
 
+type OrderStatus string
+
+const (
+	OrderStatusPending    OrderStatus = "pending"
+	OrderStatusConfirmed  OrderStatus = "confirmed"
+	OrderStatusCancelled  OrderStatus = "cancelled"
+	OrderStatusCheckedIn  OrderStatus = "checked_in"
+	OrderStatusCheckedOut OrderStatus = "checked_out"
+	OrderStatusNoShow     OrderStatus = "no_show"
+)
+
+// orderStatusTransitions lists the statuses reachable from each status.
+// Cancelled, checked out and no-show orders are final.
+var orderStatusTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending:   {OrderStatusConfirmed, OrderStatusCancelled},
+	OrderStatusConfirmed: {OrderStatusCancelled, OrderStatusCheckedIn, OrderStatusNoShow},
+	OrderStatusCheckedIn: {OrderStatusCheckedOut},
+}
+
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, status := range orderStatusTransitions[s] {
+		if status == next {
+			return true
+		}
+	}
+
+	return false
+}
+
 type Order struct {
-	ID        OrderID     `json:"id"`
-	Number    OrderNumber `json:"number"`
-	UserID    UserID      `json:"user_id"`
-	CreatedAt time.Time   `json:"created_at"`
-	Bookings  []Booking   `json:"booking"`
+	ID            OrderID     `json:"id"`
+	Number        OrderNumber `json:"number"`
+	UserID        UserID      `json:"user_id"`
+	Status        OrderStatus `json:"status"`
+	CreatedAt     time.Time   `json:"created_at"`
+	ModifiedAt    *time.Time  `json:"modified_at,omitempty"`
+	CancelledAt   *time.Time  `json:"cancelled_at,omitempty"`
+	CheckedInAt   *time.Time  `json:"checked_in_at,omitempty"`
+	CheckedOutAt  *time.Time  `json:"checked_out_at,omitempty"`
+	NoShowAt      *time.Time  `json:"no_show_at,omitempty"`
+	Bookings      []Booking   `json:"booking"`
+	PromoCode     PromoCode   `json:"promo_code,omitempty"`
+	LoyaltyPoints int64       `json:"loyalty_points,omitempty"`
+	Lines         []PriceLine `json:"lines"`
+	Subtotal      Money       `json:"subtotal"`
+	Discounts     []Discount  `json:"discounts,omitempty"`
+	Total         Money       `json:"total"`
+	// CancellationPolicies are the policies of the booked room types at the moment the order was placed.
+	CancellationPolicies []CancellationPolicy `json:"cancellation_policies,omitempty"`
+	Cancellation         *CancellationCharge  `json:"cancellation,omitempty"`
+	// PaymentToken is the guest's card the order is paid with, it isn't stored.
+	PaymentToken PaymentToken `json:"-"`
+	Payments     []Payment    `json:"payments,omitempty"`
+	// Settlement is the work left after the last change of the order, nil when everything is done.
+	Settlement *Settlement `json:"settlement,omitempty"`
+}
+
+type DiscountSource string
+
+const (
+	DiscountSourcePromo   DiscountSource = "promo"
+	DiscountSourceLoyalty DiscountSource = "loyalty"
+)
+
+// Discount is a discount applied to the order.
+type Discount struct {
+	Source    DiscountSource `json:"source"`
+	PromoCode PromoCode      `json:"promo_code,omitempty"`
+	Points    int64          `json:"points,omitempty"`
+	Amount    Money          `json:"amount"`
+}
+
+// RecalculateTotal sets the total to the subtotal minus the discounts. The total can't be negative.
+func (o *Order) RecalculateTotal() {
+	o.Total = o.Subtotal
+
+	for _, discount := range o.Discounts {
+		o.Total.Amount -= discount.Amount.Amount
+	}
+
+	if o.Total.Amount < 0 {
+		o.Total.Amount = 0
+	}
+}
+
+// Clone returns a deep copy of the order, so the copy can be changed without changing the order.
+func (o *Order) Clone() Order {
+	clone := *o
+	clone.ModifiedAt = clonePtr(o.ModifiedAt)
+	clone.CancelledAt = clonePtr(o.CancelledAt)
+	clone.CheckedInAt = clonePtr(o.CheckedInAt)
+	clone.CheckedOutAt = clonePtr(o.CheckedOutAt)
+	clone.NoShowAt = clonePtr(o.NoShowAt)
+	clone.Bookings = cloneBookings(o.Bookings)
+	clone.Lines = slices.Clone(o.Lines)
+	clone.Discounts = slices.Clone(o.Discounts)
+	clone.CancellationPolicies = slices.Clone(o.CancellationPolicies)
+	clone.Cancellation = clonePtr(o.Cancellation)
+	clone.Payments = slices.Clone(o.Payments)
+
+	if o.Settlement != nil {
+		settlement := *o.Settlement
+		settlement.Release = cloneBookings(settlement.Release)
+		settlement.Refund = clonePtr(settlement.Refund)
+		clone.Settlement = &settlement
+	}
+
+	return clone
+}
+
+func cloneBookings(bookings []Booking) []Booking {
+	clone := slices.Clone(bookings)
+	for i := range clone {
+		clone[i].Rooms = slices.Clone(clone[i].Rooms)
+		for j := range clone[i].Rooms {
+			clone[i].Rooms[j].GuestNames = slices.Clone(clone[i].Rooms[j].GuestNames)
+		}
+	}
+
+	return clone
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+
+	v := *p
+
+	return &v
 }
 
 type Booking struct {
@@ -20,4 +148,89 @@ type Booking struct {
 	From      time.Time `json:"from"`
 	To        time.Time `json:"to"`
 	RoomCount int       `json:"room_count"`
+	// Rooms is the occupancy of each booked room, empty if the guests aren't known.
+	Rooms []RoomOccupancy `json:"rooms,omitempty"`
+}
+
+// ChangeStatus moves the order to the next status if the transition is allowed.
+func (o *Order) ChangeStatus(next OrderStatus) error {
+	if !o.Status.CanTransitionTo(next) {
+		return &StatusTransitionError{From: o.Status, To: next}
+	}
+
+	o.Status = next
+
+	return nil
+}
+
+// IsModifiable reports whether the bookings of the order can still be changed.
+func (o *Order) IsModifiable() bool {
+	return o.Status == OrderStatusPending || o.Status == OrderStatusConfirmed
+}
+
+// BookingsDiff describes how the bookings of an order were changed.
+type BookingsDiff struct {
+	Added   []Booking `json:"added"`
+	Removed []Booking `json:"removed"`
+}
+
+// DiffBookings returns the bookings that are present only in the new or only in the old list.
+func DiffBookings(old, new []Booking) BookingsDiff {
+	diff := BookingsDiff{
+		Added:   []Booking{},
+		Removed: []Booking{},
+	}
+
+	matched := make([]bool, len(old))
+
+	for _, newBooking := range new {
+		found := false
+
+		for i, oldBooking := range old {
+			if !matched[i] && oldBooking.Equal(newBooking) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			diff.Added = append(diff.Added, newBooking)
+		}
+	}
+
+	for i, oldBooking := range old {
+		if !matched[i] {
+			diff.Removed = append(diff.Removed, oldBooking)
+		}
+	}
+
+	return diff
+}
+
+// Validate checks that the booking has at least one room for at least one night.
+func (b Booking) Validate() error {
+	if b.RoomCount < 1 {
+		return fmt.Errorf("%w: room count of '%s' must be at least 1, got %d", ErrInvalidBooking, b.RoomType, b.RoomCount)
+	}
+
+	if b.To.Before(b.From) {
+		return fmt.Errorf("%w: date range of '%s' ends before it starts", ErrInvalidBooking, b.RoomType)
+	}
+
+	return nil
+}
+
+// Nights returns the number of nights of the booking. Both From and To nights are booked.
+func (b Booking) Nights() int {
+	return int(b.To.Sub(b.From).Hours()/24) + 1
+}
+
+func (b Booking) Equal(other Booking) bool {
+	return b.HotelID == other.HotelID &&
+		b.RoomType == other.RoomType &&
+		b.From.Equal(other.From) &&
+		b.To.Equal(other.To) &&
+		b.RoomCount == other.RoomCount &&
+		equalOccupancies(b.Rooms, other.Rooms)
 }
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrder_ChangeStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		from          OrderStatus
+		to            OrderStatus
+		expectedError error
+	}{
+		{name: "pending to confirmed", from: OrderStatusPending, to: OrderStatusConfirmed},
+		{name: "pending to cancelled", from: OrderStatusPending, to: OrderStatusCancelled},
+		{name: "confirmed to checked in", from: OrderStatusConfirmed, to: OrderStatusCheckedIn},
+		{name: "confirmed to no show", from: OrderStatusConfirmed, to: OrderStatusNoShow},
+		{name: "checked in to checked out", from: OrderStatusCheckedIn, to: OrderStatusCheckedOut},
+		{
+			name:          "pending to checked in",
+			from:          OrderStatusPending,
+			to:            OrderStatusCheckedIn,
+			expectedError: &StatusTransitionError{From: OrderStatusPending, To: OrderStatusCheckedIn},
+		},
+		{
+			name:          "checked in to cancelled",
+			from:          OrderStatusCheckedIn,
+			to:            OrderStatusCancelled,
+			expectedError: &StatusTransitionError{From: OrderStatusCheckedIn, To: OrderStatusCancelled},
+		},
+		{
+			name:          "cancelled is final",
+			from:          OrderStatusCancelled,
+			to:            OrderStatusConfirmed,
+			expectedError: &StatusTransitionError{From: OrderStatusCancelled, To: OrderStatusConfirmed},
+		},
+		{
+			name:          "no show is final",
+			from:          OrderStatusNoShow,
+			to:            OrderStatusCheckedIn,
+			expectedError: &StatusTransitionError{From: OrderStatusNoShow, To: OrderStatusCheckedIn},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := Order{Status: tt.from}
+
+			err := order.ChangeStatus(tt.to)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+				assert.Equal(t, tt.from, order.Status)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.to, order.Status)
+			}
+		})
+	}
+}
+
+func TestBooking_Validate(t *testing.T) {
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		booking       Booking
+		expectedError error
+	}{
+		{
+			name:    "one room for one night",
+			booking: Booking{HotelID: 1, RoomType: "single", From: from, To: from, RoomCount: 1},
+		},
+		{
+			name:          "no rooms",
+			booking:       Booking{HotelID: 1, RoomType: "single", From: from, To: from, RoomCount: 0},
+			expectedError: ErrInvalidBooking,
+		},
+		{
+			name:          "negative room count",
+			booking:       Booking{HotelID: 1, RoomType: "single", From: from, To: from, RoomCount: -2},
+			expectedError: ErrInvalidBooking,
+		},
+		{
+			name:          "date range ends before it starts",
+			booking:       Booking{HotelID: 1, RoomType: "single", From: from, To: from.AddDate(0, 0, -1), RoomCount: 1},
+			expectedError: ErrInvalidBooking,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.booking.Validate()
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
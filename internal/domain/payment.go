@@ -0,0 +1,65 @@
+package domain
+
+// PaymentToken identifies the guest's card at the payment gateway. The card details never reach the service.
+type PaymentToken string
+
+type PaymentID string
+
+// PaymentKey is the idempotency key of an authorization. It's unique per attempt, so the authorization
+// whose answer was lost can be found and voided.
+type PaymentKey string
+
+// Payment is the money taken from the guest for the order. An order is paid by several payments
+// if its total was raised.
+type Payment struct {
+	ID       PaymentID `json:"id"`
+	Amount   Money     `json:"amount"`
+	Refunded Money     `json:"refunded"`
+}
+
+// Refundable returns the amount up to limit that can still be refunded.
+func (p *Payment) Refundable(limit Money) Money {
+	return Money{
+		Amount:   min(limit.Amount, p.Amount.Amount-p.Refunded.Amount),
+		Currency: p.Amount.Currency,
+	}
+}
+
+// Paid returns the money taken for the order and not refunded yet.
+func (o *Order) Paid() Money {
+	paid := Money{Currency: o.Total.Currency}
+
+	for _, payment := range o.Payments {
+		paid.Amount += payment.Amount.Amount - payment.Refunded.Amount
+	}
+
+	return paid
+}
+
+// Overpaid returns the money taken over the order total, e.g. after the total was lowered.
+func (o *Order) Overpaid() Money {
+	overpaid := o.Paid()
+	overpaid.Amount = max(overpaid.Amount-o.Total.Amount, 0)
+
+	return overpaid
+}
+
+// Underpaid returns the part of the order total which isn't paid yet, e.g. after the total was raised.
+func (o *Order) Underpaid() Money {
+	underpaid := o.Total
+	underpaid.Amount = max(underpaid.Amount-o.Paid().Amount, 0)
+
+	return underpaid
+}
+
+// NextRefund returns the index of the latest payment which can be refunded and the part of amount refunded
+// from it. The refund is zero if nothing can be refunded.
+func (o *Order) NextRefund(amount Money) (int, Money) {
+	for i := len(o.Payments) - 1; i >= 0; i-- {
+		if refund := o.Payments[i].Refundable(amount); refund.Amount > 0 {
+			return i, refund
+		}
+	}
+
+	return -1, Money{Amount: 0, Currency: amount.Currency}
+}
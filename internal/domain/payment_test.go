@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrder_Payments(t *testing.T) {
+	rub := func(amount int64) Money {
+		return Money{Amount: amount, Currency: "RUB"}
+	}
+
+	tests := []struct {
+		name              string
+		total             Money
+		payments          []Payment
+		refund            Money
+		expectedPaid      Money
+		expectedOverpaid  Money
+		expectedUnderpaid Money
+		expectedPayment   int
+		expectedRefund    Money
+	}{
+		{
+			name:              "not paid",
+			total:             rub(1000),
+			refund:            rub(1000),
+			expectedPaid:      rub(0),
+			expectedOverpaid:  rub(0),
+			expectedUnderpaid: rub(1000),
+			expectedPayment:   -1,
+			expectedRefund:    rub(0),
+		},
+		{
+			name:              "lowered total",
+			total:             rub(600),
+			payments:          []Payment{{ID: "pay-1", Amount: rub(1000), Refunded: rub(0)}},
+			refund:            rub(400),
+			expectedPaid:      rub(1000),
+			expectedOverpaid:  rub(400),
+			expectedUnderpaid: rub(0),
+			expectedPayment:   0,
+			expectedRefund:    rub(400),
+		},
+		{
+			name:  "raised total",
+			total: rub(1500),
+			payments: []Payment{
+				{ID: "pay-1", Amount: rub(1000), Refunded: rub(100)},
+			},
+			refund:            rub(1500),
+			expectedPaid:      rub(900),
+			expectedOverpaid:  rub(0),
+			expectedUnderpaid: rub(600),
+			expectedPayment:   0,
+			expectedRefund:    rub(900),
+		},
+		{
+			name:  "refund starts from the latest payment",
+			total: rub(0),
+			payments: []Payment{
+				{ID: "pay-1", Amount: rub(1000), Refunded: rub(0)},
+				{ID: "pay-2", Amount: rub(500), Refunded: rub(0)},
+			},
+			refund:            rub(1500),
+			expectedPaid:      rub(1500),
+			expectedOverpaid:  rub(1500),
+			expectedUnderpaid: rub(0),
+			expectedPayment:   1,
+			expectedRefund:    rub(500),
+		},
+		{
+			name:  "refunded payments are skipped",
+			total: rub(0),
+			payments: []Payment{
+				{ID: "pay-1", Amount: rub(1000), Refunded: rub(0)},
+				{ID: "pay-2", Amount: rub(500), Refunded: rub(500)},
+			},
+			refund:            rub(1000),
+			expectedPaid:      rub(1000),
+			expectedOverpaid:  rub(1000),
+			expectedUnderpaid: rub(0),
+			expectedPayment:   0,
+			expectedRefund:    rub(1000),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := Order{Total: tt.total, Payments: tt.payments}
+
+			assert.Equal(t, tt.expectedPaid, order.Paid())
+			assert.Equal(t, tt.expectedOverpaid, order.Overpaid())
+			assert.Equal(t, tt.expectedUnderpaid, order.Underpaid())
+
+			payment, refund := order.NextRefund(tt.refund)
+			assert.Equal(t, tt.expectedPayment, payment)
+			assert.Equal(t, tt.expectedRefund, refund)
+		})
+	}
+}
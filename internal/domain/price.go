@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+type Currency string
+
+// Money is an amount in minor units of the currency, e.g. kopecks.
+type Money struct {
+	Amount   int64    `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+// Rate is the price of one room of the room type for one night.
+type Rate struct {
+	HotelID  HotelID   `json:"hotel_id"`
+	RoomType RoomType  `json:"room_type"`
+	Date     time.Time `json:"date"`
+	Price    Money     `json:"price"`
+}
+
+// PriceLine is the price of the booked rooms for one night.
+type PriceLine struct {
+	HotelID    HotelID   `json:"hotel_id"`
+	RoomType   RoomType  `json:"room_type"`
+	Date       time.Time `json:"date"`
+	RoomCount  int       `json:"room_count"`
+	NightPrice Money     `json:"night_price"`
+	Amount     Money     `json:"amount"`
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type PromoCode string
+
+type DiscountType string
+
+const (
+	DiscountTypePercent DiscountType = "percent"
+	DiscountTypeFixed   DiscountType = "fixed"
+)
+
+// Promo describes a discount given by a promo code. Empty HotelIDs and RoomTypes mean any hotel and room type,
+// zero limits mean no limit.
+type Promo struct {
+	Code           PromoCode    `json:"code"`
+	DiscountType   DiscountType `json:"discount_type"`
+	Value          int64        `json:"value"` // percent or amount in minor units
+	Currency       Currency     `json:"currency,omitempty"`
+	ValidFrom      time.Time    `json:"valid_from"`
+	ValidTo        time.Time    `json:"valid_to"`
+	MinNights      int          `json:"min_nights"`
+	HotelIDs       []HotelID    `json:"hotel_ids,omitempty"`
+	RoomTypes      []RoomType   `json:"room_types,omitempty"`
+	MaxUses        int          `json:"max_uses"`
+	MaxUsesPerUser int          `json:"max_uses_per_user"`
+}
+
+func (p *Promo) Validate() error {
+	switch p.DiscountType {
+	case DiscountTypePercent:
+		if p.Value <= 0 || p.Value > 100 {
+			return fmt.Errorf("%w: percent must be between 1 and 100", ErrInvalidPromo)
+		}
+	case DiscountTypeFixed:
+		if p.Value <= 0 || p.Currency == "" {
+			return fmt.Errorf("%w: fixed discount must be positive and have a currency", ErrInvalidPromo)
+		}
+	default:
+		return fmt.Errorf("%w: unknown discount type '%s'", ErrInvalidPromo, p.DiscountType)
+	}
+
+	if p.Code == "" {
+		return fmt.Errorf("%w: code is empty", ErrInvalidPromo)
+	}
+
+	if p.ValidTo.Before(p.ValidFrom) {
+		return fmt.Errorf("%w: invalid validity window", ErrInvalidPromo)
+	}
+
+	if p.MinNights < 0 || p.MaxUses < 0 || p.MaxUsesPerUser < 0 {
+		return fmt.Errorf("%w: limits can't be negative", ErrInvalidPromo)
+	}
+
+	return nil
+}
+
+// Discount calculates the discount for the priced order placed at the moment now.
+// Only the nights of the bookings in the promo scope are discounted. Each booking is matched with its own
+// price lines, so the nights of overlapping bookings are counted once per booking.
+func (p *Promo) Discount(order Order, now time.Time) (Money, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if today.Before(p.ValidFrom) || today.After(p.ValidTo) {
+		return Money{}, fmt.Errorf("%w: promo code '%s' isn't valid now", ErrPromoNotApplicable, p.Code)
+	}
+
+	base := Money{Currency: order.Subtotal.Currency}
+	used := make([]bool, len(order.Lines))
+
+	// the bookings out of the promo scope take their lines too, so an overlapping booking in the scope
+	// can't count them
+	for _, booking := range order.Bookings {
+		applies := p.covers(booking) && booking.Nights() >= p.MinNights
+
+		for date := booking.From; !date.After(booking.To); date = date.AddDate(0, 0, 1) {
+			i := bookingLine(order.Lines, used, booking, date)
+			if i < 0 {
+				continue
+			}
+
+			used[i] = true
+
+			if applies {
+				base.Amount += order.Lines[i].Amount.Amount
+			}
+		}
+	}
+
+	if base.Amount == 0 {
+		return Money{}, fmt.Errorf("%w: no bookings match promo code '%s' conditions", ErrPromoNotApplicable, p.Code)
+	}
+
+	switch p.DiscountType {
+	case DiscountTypePercent:
+		return Money{Amount: base.Amount * p.Value / 100, Currency: base.Currency}, nil
+	case DiscountTypeFixed:
+		if p.Currency != base.Currency {
+			return Money{}, fmt.Errorf("%w: promo code '%s' is in %s", ErrCurrencyMismatch, p.Code, p.Currency)
+		}
+
+		return Money{Amount: min(p.Value, base.Amount), Currency: base.Currency}, nil
+	default:
+		return Money{}, fmt.Errorf("%w: unknown discount type '%s'", ErrInvalidPromo, p.DiscountType)
+	}
+}
+
+// bookingLine returns the index of the first unused line of the booking's night on the date, or -1 if there is none.
+func bookingLine(lines []PriceLine, used []bool, booking Booking, date time.Time) int {
+	for i, line := range lines {
+		if !used[i] && line.HotelID == booking.HotelID && line.RoomType == booking.RoomType &&
+			line.RoomCount == booking.RoomCount && line.Date.Equal(date) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (p *Promo) covers(booking Booking) bool {
+	if len(p.HotelIDs) > 0 && !contains(p.HotelIDs, booking.HotelID) {
+		return false
+	}
+
+	if len(p.RoomTypes) > 0 && !contains(p.RoomTypes, booking.RoomType) {
+		return false
+	}
+
+	return true
+}
+
+func contains[T comparable](values []T, value T) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
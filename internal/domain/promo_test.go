@@ -0,0 +1,212 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromo_Discount(t *testing.T) {
+	testDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := testDate.Add(-48 * time.Hour)
+
+	rub := func(amount int64) Money {
+		return Money{Amount: amount, Currency: "RUB"}
+	}
+
+	order := Order{
+		Bookings: []Booking{
+			{HotelID: 1, RoomType: RoomTypeSingle, From: testDate, To: testDate.AddDate(0, 0, 1), RoomCount: 1},
+			{HotelID: 2, RoomType: RoomTypeLux, From: testDate, To: testDate, RoomCount: 1},
+		},
+		Lines: []PriceLine{
+			{HotelID: 1, RoomType: RoomTypeSingle, Date: testDate, RoomCount: 1, NightPrice: rub(1000), Amount: rub(1000)},
+			{HotelID: 1, RoomType: RoomTypeSingle, Date: testDate.AddDate(0, 0, 1), RoomCount: 1, NightPrice: rub(1000), Amount: rub(1000)},
+			{HotelID: 2, RoomType: RoomTypeLux, Date: testDate, RoomCount: 1, NightPrice: rub(5000), Amount: rub(5000)},
+		},
+		Subtotal: rub(7000),
+	}
+
+	promo := Promo{
+		Code:         "SALE",
+		DiscountType: DiscountTypePercent,
+		Value:        10,
+		ValidFrom:    now.AddDate(0, 0, -1),
+		ValidTo:      now.AddDate(0, 0, 1),
+	}
+
+	tests := []struct {
+		name           string
+		change         func(p *Promo)
+		expectedAmount Money
+		expectedError  error
+	}{
+		{
+			name:           "percent of the whole order",
+			change:         func(p *Promo) {},
+			expectedAmount: rub(700),
+		},
+		{
+			name: "fixed amount",
+			change: func(p *Promo) {
+				p.DiscountType = DiscountTypeFixed
+				p.Value = 1500
+				p.Currency = "RUB"
+			},
+			expectedAmount: rub(1500),
+		},
+		{
+			name: "fixed amount isn't bigger than the discounted nights",
+			change: func(p *Promo) {
+				p.DiscountType = DiscountTypeFixed
+				p.Value = 3000
+				p.Currency = "RUB"
+				p.HotelIDs = []HotelID{1}
+			},
+			expectedAmount: rub(2000),
+		},
+		{
+			name: "only bookings in hotel scope",
+			change: func(p *Promo) {
+				p.HotelIDs = []HotelID{2}
+			},
+			expectedAmount: rub(500),
+		},
+		{
+			name: "only bookings in room type scope",
+			change: func(p *Promo) {
+				p.RoomTypes = []RoomType{RoomTypeSingle}
+			},
+			expectedAmount: rub(200),
+		},
+		{
+			name: "only bookings with enough nights",
+			change: func(p *Promo) {
+				p.MinNights = 2
+			},
+			expectedAmount: rub(200),
+		},
+		{
+			name: "no bookings with enough nights",
+			change: func(p *Promo) {
+				p.MinNights = 3
+			},
+			expectedError: ErrPromoNotApplicable,
+		},
+		{
+			name: "not valid yet",
+			change: func(p *Promo) {
+				p.ValidFrom = now.AddDate(0, 0, 1)
+			},
+			expectedError: ErrPromoNotApplicable,
+		},
+		{
+			name: "expired",
+			change: func(p *Promo) {
+				p.ValidTo = now.AddDate(0, 0, -1)
+			},
+			expectedError: ErrPromoNotApplicable,
+		},
+		{
+			name: "fixed amount in another currency",
+			change: func(p *Promo) {
+				p.DiscountType = DiscountTypeFixed
+				p.Value = 10
+				p.Currency = "USD"
+			},
+			expectedError: ErrCurrencyMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := promo
+			tt.change(&p)
+
+			amount, err := p.Discount(order, now)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedAmount, amount)
+			}
+		})
+	}
+}
+
+func TestPromo_DiscountOverlappingBookings(t *testing.T) {
+	testDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := testDate.Add(-48 * time.Hour)
+
+	rub := func(amount int64) Money {
+		return Money{Amount: amount, Currency: "RUB"}
+	}
+
+	// two bookings of the same room type share the first night
+	order := Order{
+		Bookings: []Booking{
+			{HotelID: 1, RoomType: RoomTypeSingle, From: testDate, To: testDate.AddDate(0, 0, 1), RoomCount: 1},
+			{HotelID: 1, RoomType: RoomTypeSingle, From: testDate, To: testDate, RoomCount: 2},
+		},
+		Lines: []PriceLine{
+			{HotelID: 1, RoomType: RoomTypeSingle, Date: testDate, RoomCount: 1, NightPrice: rub(1000), Amount: rub(1000)},
+			{HotelID: 1, RoomType: RoomTypeSingle, Date: testDate.AddDate(0, 0, 1), RoomCount: 1, NightPrice: rub(1000), Amount: rub(1000)},
+			{HotelID: 1, RoomType: RoomTypeSingle, Date: testDate, RoomCount: 2, NightPrice: rub(1000), Amount: rub(2000)},
+		},
+		Subtotal: rub(4000),
+	}
+
+	tests := []struct {
+		name           string
+		minNights      int
+		bookings       []Booking
+		expectedAmount Money
+	}{
+		{
+			name:           "each night is counted once",
+			expectedAmount: rub(400),
+		},
+		{
+			name:           "only the nights of the booking with enough nights",
+			minNights:      2,
+			expectedAmount: rub(200),
+		},
+		{
+			name: "same bookings take their own lines",
+			bookings: []Booking{
+				{HotelID: 1, RoomType: RoomTypeSingle, From: testDate, To: testDate, RoomCount: 1},
+				{HotelID: 1, RoomType: RoomTypeSingle, From: testDate, To: testDate, RoomCount: 1},
+			},
+			expectedAmount: rub(200),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := order
+			if tt.bookings != nil {
+				o.Bookings = tt.bookings
+				o.Lines = []PriceLine{
+					{HotelID: 1, RoomType: RoomTypeSingle, Date: testDate, RoomCount: 1, NightPrice: rub(1000), Amount: rub(1000)},
+					{HotelID: 1, RoomType: RoomTypeSingle, Date: testDate, RoomCount: 1, NightPrice: rub(1000), Amount: rub(1000)},
+				}
+			}
+
+			promo := Promo{
+				Code:         "SALE",
+				DiscountType: DiscountTypePercent,
+				Value:        10,
+				ValidFrom:    now.AddDate(0, 0, -1),
+				ValidTo:      now.AddDate(0, 0, 1),
+				MinNights:    tt.minNights,
+			}
+
+			amount, err := promo.Discount(o, now)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedAmount, amount)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// RestrictionRule names a rule of the restriction.
+type RestrictionRule string
+
+const (
+	RuleStopSell          RestrictionRule = "stop_sell"
+	RuleClosedToArrival   RestrictionRule = "closed_to_arrival"
+	RuleClosedToDeparture RestrictionRule = "closed_to_departure"
+	RuleMinStay           RestrictionRule = "min_stay"
+	RuleMaxStay           RestrictionRule = "max_stay"
+)
+
+// Restriction limits the sale of the room type on the date without changing its availability.
+// StopSell closes the night of the date, ClosedToArrival and ClosedToDeparture forbid the stays starting
+// or ending on the date, the departure is the morning after the last night. MinStay and MaxStay limit
+// the nights of the stays arriving on the date, zero means no limit.
+type Restriction struct {
+	HotelID           HotelID   `json:"hotel_id"`
+	RoomType          RoomType  `json:"room_type"`
+	Date              time.Time `json:"date"`
+	StopSell          bool      `json:"stop_sell"`
+	ClosedToArrival   bool      `json:"closed_to_arrival"`
+	ClosedToDeparture bool      `json:"closed_to_departure"`
+	MinStay           int       `json:"min_stay"`
+	MaxStay           int       `json:"max_stay"`
+}
+
+func (r *Restriction) Validate() error {
+	if r.RoomType == "" {
+		return fmt.Errorf("%w: room type is required", ErrInvalidRestriction)
+	}
+
+	if r.Date.IsZero() {
+		return fmt.Errorf("%w: date is required", ErrInvalidRestriction)
+	}
+
+	if r.MinStay < 0 || r.MaxStay < 0 {
+		return fmt.Errorf("%w: stay limits can't be negative", ErrInvalidRestriction)
+	}
+
+	if r.MaxStay > 0 && r.MinStay > r.MaxStay {
+		return fmt.Errorf("%w: min stay is greater than max stay", ErrInvalidRestriction)
+	}
+
+	return nil
+}
+
+// IsEmpty reports whether the restriction has no rules, i.e. it's lifted.
+func (r *Restriction) IsEmpty() bool {
+	return !r.StopSell && !r.ClosedToArrival && !r.ClosedToDeparture && r.MinStay == 0 && r.MaxStay == 0
+}
+
+// CheckRestrictions checks the booking against the restrictions of its room type keyed by date
+// and returns a RestrictionError for the first violated rule.
+func (b Booking) CheckRestrictions(restrictions map[time.Time]Restriction) error {
+	violation := func(rule RestrictionRule, date time.Time, limit int) error {
+		return &RestrictionError{Rule: rule, HotelID: b.HotelID, RoomType: b.RoomType, Date: date, Nights: b.Nights(), Limit: limit}
+	}
+
+	arrival := restrictions[b.From]
+
+	if arrival.ClosedToArrival {
+		return violation(RuleClosedToArrival, b.From, 0)
+	}
+
+	if arrival.MinStay > 0 && b.Nights() < arrival.MinStay {
+		return violation(RuleMinStay, b.From, arrival.MinStay)
+	}
+
+	if arrival.MaxStay > 0 && b.Nights() > arrival.MaxStay {
+		return violation(RuleMaxStay, b.From, arrival.MaxStay)
+	}
+
+	for date := b.From; !date.After(b.To); date = date.AddDate(0, 0, 1) {
+		if restrictions[date].StopSell {
+			return violation(RuleStopSell, date, 0)
+		}
+	}
+
+	departure := b.To.AddDate(0, 0, 1)
+	if restrictions[departure].ClosedToDeparture {
+		return violation(RuleClosedToDeparture, departure, 0)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBooking_CheckRestrictions(t *testing.T) {
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+
+	booking := Booking{HotelID: 1, RoomType: "single", From: from, To: to, RoomCount: 1}
+
+	tests := []struct {
+		name         string
+		restrictions map[time.Time]Restriction
+		expectedErr  *RestrictionError
+	}{
+		{
+			name: "no restrictions",
+		},
+		{
+			name: "rules of other dates",
+			restrictions: map[time.Time]Restriction{
+				from.AddDate(0, 0, 1): {ClosedToArrival: true, ClosedToDeparture: true, MinStay: 5},
+				to:                    {ClosedToDeparture: true},
+				to.AddDate(0, 0, 1):   {StopSell: true},
+			},
+		},
+		{
+			name:         "stop sell",
+			restrictions: map[time.Time]Restriction{to: {StopSell: true}},
+			expectedErr:  &RestrictionError{Rule: RuleStopSell, HotelID: 1, RoomType: "single", Date: to, Nights: 3},
+		},
+		{
+			name:         "closed to arrival",
+			restrictions: map[time.Time]Restriction{from: {ClosedToArrival: true}},
+			expectedErr:  &RestrictionError{Rule: RuleClosedToArrival, HotelID: 1, RoomType: "single", Date: from, Nights: 3},
+		},
+		{
+			name:         "closed to departure",
+			restrictions: map[time.Time]Restriction{to.AddDate(0, 0, 1): {ClosedToDeparture: true}},
+			expectedErr: &RestrictionError{Rule: RuleClosedToDeparture, HotelID: 1, RoomType: "single",
+				Date: to.AddDate(0, 0, 1), Nights: 3},
+		},
+		{
+			name:         "min stay",
+			restrictions: map[time.Time]Restriction{from: {MinStay: 4}},
+			expectedErr:  &RestrictionError{Rule: RuleMinStay, HotelID: 1, RoomType: "single", Date: from, Nights: 3, Limit: 4},
+		},
+		{
+			name:         "max stay",
+			restrictions: map[time.Time]Restriction{from: {MaxStay: 2}},
+			expectedErr:  &RestrictionError{Rule: RuleMaxStay, HotelID: 1, RoomType: "single", Date: from, Nights: 3, Limit: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := booking.CheckRestrictions(tt.restrictions)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, ErrRestrictionViolated)
+				assert.Equal(t, tt.expectedErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRestrictionError_Error(t *testing.T) {
+	err := &RestrictionError{Rule: RuleMinStay, HotelID: 1, RoomType: "single",
+		Date: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), Nights: 1, Limit: 2}
+
+	assert.Equal(t, "restriction violated: min_stay: room 'single' in hotel id=1 requires at least 2 nights "+
+		"for arrival on 2025-02-01, 1 requested", err.Error())
+}
@@ -0,0 +1,28 @@
+package domain
+
+// Settlement is the work left after a change of the order is saved: the rooms to release, the promo code use
+// and the loyalty points to take back or credit and the money to refund. It's saved together with the change and cleared step by step,
+// so repeating the failed request finishes it without repeating the done steps.
+type Settlement struct {
+	Release       []Booking `json:"release,omitempty"`
+	RevertPromo   bool      `json:"revert_promo,omitempty"`
+	ReversePoints bool      `json:"reverse_points,omitempty"`
+	EarnPoints    bool      `json:"earn_points,omitempty"`
+	// Refund is the most that is paid back to the card.
+	Refund *Money `json:"refund,omitempty"`
+}
+
+// Settle sets the work left for the order, it's cleared if nothing is left.
+func (o *Order) Settle(settlement Settlement) {
+	if settlement.Refund != nil && settlement.Refund.Amount <= 0 {
+		settlement.Refund = nil
+	}
+
+	if len(settlement.Release) == 0 && !settlement.RevertPromo && !settlement.ReversePoints && !settlement.EarnPoints &&
+		settlement.Refund == nil {
+		o.Settlement = nil
+		return
+	}
+
+	o.Settlement = &settlement
+}
@@ -0,0 +1,210 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// LocalDate returns the date in the hotel at the moment. It's a UTC midnight like the nights of the bookings.
+func (h *Hotel) LocalDate(now time.Time) (time.Time, error) {
+	loc, err := h.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	year, month, day := now.In(loc).Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), nil
+}
+
+// Departure returns the date the guests leave, the day after the last night.
+func (b Booking) Departure() time.Time {
+	return b.To.AddDate(0, 0, 1)
+}
+
+// CheckIn marks the guests of the order as arrived at the moment. today is the current date in each hotel
+// of the order, the arrival must fall on one of the booked nights.
+func (o *Order) CheckIn(now time.Time, today map[HotelID]time.Time) error {
+	if !o.Status.CanTransitionTo(OrderStatusCheckedIn) {
+		return &StatusTransitionError{From: o.Status, To: OrderStatusCheckedIn}
+	}
+
+	if !o.staysOn(today, false) {
+		return fmt.Errorf("%w: check-in is possible from %s to %s hotel time",
+			ErrOutsideStayDates, o.arrival().Format(time.DateOnly), o.lastNight().Format(time.DateOnly))
+	}
+
+	o.Status = OrderStatusCheckedIn
+	o.CheckedInAt = &now
+
+	return nil
+}
+
+// CheckOut marks the guests of the order as departed at the moment. today is the current date in each hotel
+// of the order, the departure must fall on the booked nights or the departure day. The nights from today on
+// aren't used, so on an early departure they are cut from the bookings and the price of the order
+// and returned to be released.
+func (o *Order) CheckOut(now time.Time, today map[HotelID]time.Time) ([]Booking, error) {
+	if !o.Status.CanTransitionTo(OrderStatusCheckedOut) {
+		return nil, &StatusTransitionError{From: o.Status, To: OrderStatusCheckedOut}
+	}
+
+	if !o.staysOn(today, true) {
+		return nil, fmt.Errorf("%w: check-out is possible from %s to %s hotel time",
+			ErrOutsideStayDates, o.arrival().Format(time.DateOnly), o.lastNight().AddDate(0, 0, 1).Format(time.DateOnly))
+	}
+
+	kept, released := splitBookings(o.Bookings, func(booking Booking) (time.Time, bool) {
+		date, ok := today[booking.HotelID]
+		return date, ok
+	})
+
+	o.Bookings = kept
+	o.keepLines(func(line PriceLine) bool {
+		date, ok := today[line.HotelID]
+		return !ok || line.Date.Before(date)
+	})
+	o.Status = OrderStatusCheckedOut
+	o.CheckedOutAt = &now
+
+	return released, nil
+}
+
+// NoShowDeadline returns the moment the guests arriving on the date become a no-show: the cutoff time
+// of the hotel, or defaultCutoff if the hotel has none, on the next day in the hotel time.
+func (h *Hotel) NoShowDeadline(arrival time.Time, defaultCutoff string) (time.Time, error) {
+	cutoff := h.NoShowCutoff
+	if cutoff == "" {
+		cutoff = defaultCutoff
+	}
+
+	at, err := time.Parse(TimeOfDayLayout, cutoff)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid no-show cutoff '%s': %w", cutoff, err)
+	}
+
+	loc, err := h.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	year, month, day := arrival.Date()
+
+	return time.Date(year, month, day+1, at.Hour(), at.Minute(), 0, 0, loc), nil
+}
+
+// MarkNoShow marks the order whose guests didn't arrive as no-show at the moment. The first penaltyNights nights
+// from the arrival are the penalty: they are kept in the bookings and the price of the order is recalculated
+// for them. The other nights are cut from the bookings and returned to be released.
+func (o *Order) MarkNoShow(now time.Time, penaltyNights int) ([]Booking, error) {
+	if !o.Status.CanTransitionTo(OrderStatusNoShow) {
+		return nil, &StatusTransitionError{From: o.Status, To: OrderStatusNoShow}
+	}
+
+	cut := o.arrival().AddDate(0, 0, penaltyNights)
+
+	kept, released := splitBookings(o.Bookings, func(Booking) (time.Time, bool) {
+		return cut, true
+	})
+
+	o.Bookings = kept
+	o.keepLines(func(line PriceLine) bool {
+		return line.Date.Before(cut)
+	})
+	o.Status = OrderStatusNoShow
+	o.NoShowAt = &now
+
+	return released, nil
+}
+
+// keepLines keeps the price lines of the nights which are still charged and recalculates the total.
+func (o *Order) keepLines(keep func(line PriceLine) bool) {
+	lines := make([]PriceLine, 0, len(o.Lines))
+	subtotal := Money{Currency: o.Subtotal.Currency}
+
+	for _, line := range o.Lines {
+		if keep(line) {
+			lines = append(lines, line)
+			subtotal.Amount += line.Amount.Amount
+		}
+	}
+
+	o.Lines = lines
+	o.Subtotal = subtotal
+	o.RecalculateTotal()
+}
+
+// splitBookings cuts each booking at its date: the nights before the date are kept, the nights from the date on
+// are released. The bookings without a date are kept.
+func splitBookings(bookings []Booking, date func(Booking) (time.Time, bool)) ([]Booking, []Booking) {
+	var released []Booking
+
+	kept := make([]Booking, 0, len(bookings))
+
+	for _, booking := range bookings {
+		cut, ok := date(booking)
+
+		switch {
+		case !ok || cut.After(booking.To):
+			kept = append(kept, booking)
+		case !cut.After(booking.From):
+			released = append(released, booking)
+		default:
+			unused := booking
+			unused.From = cut
+			released = append(released, unused)
+
+			booking.To = cut.AddDate(0, 0, -1)
+			kept = append(kept, booking)
+		}
+	}
+
+	return kept, released
+}
+
+// staysOn reports whether today falls on the nights of a booking in its hotel, or on its departure day
+// if withDeparture is set.
+func (o *Order) staysOn(today map[HotelID]time.Time, withDeparture bool) bool {
+	for _, booking := range o.Bookings {
+		end := booking.To
+		if withDeparture {
+			end = booking.Departure()
+		}
+
+		date, ok := today[booking.HotelID]
+		if ok && !date.Before(booking.From) && !date.After(end) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Arrival returns the first arriving booking of the order.
+func (o *Order) Arrival() Booking {
+	var arrival Booking
+
+	for i, booking := range o.Bookings {
+		if i == 0 || booking.From.Before(arrival.From) {
+			arrival = booking
+		}
+	}
+
+	return arrival
+}
+
+func (o *Order) arrival() time.Time {
+	return o.Arrival().From
+}
+
+func (o *Order) lastNight() time.Time {
+	var last time.Time
+
+	for _, booking := range o.Bookings {
+		if booking.To.After(last) {
+			last = booking.To
+		}
+	}
+
+	return last
+}
@@ -0,0 +1,306 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHotel_LocalDate(t *testing.T) {
+	now := time.Date(2025, 2, 1, 22, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		timezone string
+		expected time.Time
+	}{
+		{name: "utc", expected: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "next day in the hotel", timezone: "Asia/Novosibirsk", expected: time.Date(2025, 2, 2, 0, 0, 0, 0, time.UTC)},
+		{name: "same day in the hotel", timezone: "America/New_York", expected: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hotel := Hotel{ID: 1, Name: "Reddison", Timezone: tt.timezone}
+
+			date, err := hotel.LocalDate(now)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, date)
+		})
+	}
+}
+
+func TestOrder_CheckIn(t *testing.T) {
+	now := time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+
+	tests := []struct {
+		name          string
+		status        OrderStatus
+		today         time.Time
+		expectedError string
+	}{
+		{name: "on arrival", status: OrderStatusConfirmed, today: from},
+		{name: "late arrival", status: OrderStatusConfirmed, today: to},
+		{
+			name:          "before arrival",
+			status:        OrderStatusConfirmed,
+			today:         from.AddDate(0, 0, -1),
+			expectedError: "outside of the stay dates: check-in is possible from 2025-02-01 to 2025-02-03 hotel time",
+		},
+		{
+			name:          "on departure",
+			status:        OrderStatusConfirmed,
+			today:         to.AddDate(0, 0, 1),
+			expectedError: "outside of the stay dates: check-in is possible from 2025-02-01 to 2025-02-03 hotel time",
+		},
+		{
+			name:          "cancelled",
+			status:        OrderStatusCancelled,
+			today:         from,
+			expectedError: "invalid order status transition: from 'cancelled' to 'checked_in'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := Order{
+				Status:   tt.status,
+				Bookings: []Booking{{HotelID: 1, RoomType: "single", From: from, To: to, RoomCount: 1}},
+			}
+
+			err := order.CheckIn(now, map[HotelID]time.Time{1: tt.today})
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Equal(t, tt.status, order.Status)
+				assert.Nil(t, order.CheckedInAt)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, OrderStatusCheckedIn, order.Status)
+				assert.Equal(t, &now, order.CheckedInAt)
+			}
+		})
+	}
+}
+
+func TestOrder_CheckOut(t *testing.T) {
+	now := time.Date(2025, 2, 3, 9, 0, 0, 0, time.UTC)
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 3)
+
+	first := Booking{HotelID: 1, RoomType: "single", From: from, To: to, RoomCount: 1}
+	second := Booking{HotelID: 2, RoomType: "double", From: to, To: to, RoomCount: 2}
+
+	rub := func(amount int64) Money {
+		return Money{Amount: amount, Currency: "RUB"}
+	}
+
+	var lines []PriceLine
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		lines = append(lines, PriceLine{HotelID: 1, RoomType: "single", Date: date, RoomCount: 1, NightPrice: rub(1000), Amount: rub(1000)})
+	}
+	lines = append(lines, PriceLine{HotelID: 2, RoomType: "double", Date: to, RoomCount: 2, NightPrice: rub(3000), Amount: rub(6000)})
+
+	tests := []struct {
+		name             string
+		today            map[HotelID]time.Time
+		expectedBookings []Booking
+		expectedReleased []Booking
+		expectedTotal    Money
+		expectedError    string
+	}{
+		{
+			name:             "on departure",
+			today:            map[HotelID]time.Time{1: to.AddDate(0, 0, 1), 2: to.AddDate(0, 0, 1)},
+			expectedBookings: []Booking{first, second},
+			expectedTotal:    rub(10000),
+		},
+		{
+			name:  "early departure",
+			today: map[HotelID]time.Time{1: from.AddDate(0, 0, 2), 2: from.AddDate(0, 0, 2)},
+			expectedBookings: []Booking{
+				{HotelID: 1, RoomType: "single", From: from, To: from.AddDate(0, 0, 1), RoomCount: 1},
+			},
+			expectedReleased: []Booking{
+				{HotelID: 1, RoomType: "single", From: from.AddDate(0, 0, 2), To: to, RoomCount: 1},
+				second,
+			},
+			expectedTotal: rub(2000),
+		},
+		{
+			name:             "departure on arrival day",
+			today:            map[HotelID]time.Time{1: from, 2: from},
+			expectedBookings: []Booking{},
+			expectedReleased: []Booking{first, second},
+			expectedTotal:    rub(0),
+		},
+		{
+			name:          "after departure",
+			today:         map[HotelID]time.Time{1: to.AddDate(0, 0, 2), 2: to.AddDate(0, 0, 2)},
+			expectedError: "outside of the stay dates: check-out is possible from 2025-02-01 to 2025-02-05 hotel time",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := Order{Status: OrderStatusCheckedIn, Bookings: []Booking{first, second}, Lines: lines,
+				Subtotal: rub(10000), Total: rub(10000)}
+
+			released, err := order.CheckOut(now, tt.today)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Equal(t, OrderStatusCheckedIn, order.Status)
+				assert.Equal(t, []Booking{first, second}, order.Bookings)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, OrderStatusCheckedOut, order.Status)
+				assert.Equal(t, &now, order.CheckedOutAt)
+				assert.Equal(t, tt.expectedBookings, order.Bookings)
+				assert.Equal(t, tt.expectedReleased, released)
+				assert.Equal(t, tt.expectedTotal, order.Total)
+			}
+		})
+	}
+
+	t.Run("not checked in", func(t *testing.T) {
+		order := Order{Status: OrderStatusConfirmed, Bookings: []Booking{first}}
+
+		_, err := order.CheckOut(now, map[HotelID]time.Time{1: to})
+
+		assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+	})
+}
+
+func TestHotel_NoShowDeadline(t *testing.T) {
+	arrival := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	novosibirsk, err := time.LoadLocation("Asia/Novosibirsk")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		hotel         Hotel
+		expected      time.Time
+		expectedError bool
+	}{
+		{
+			name:     "default cutoff",
+			hotel:    Hotel{ID: 1, Name: "Reddison"},
+			expected: time.Date(2025, 2, 2, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "hotel cutoff in the hotel time",
+			hotel:    Hotel{ID: 1, Name: "Reddison", Timezone: "Asia/Novosibirsk", NoShowCutoff: "12:00"},
+			expected: time.Date(2025, 2, 2, 12, 0, 0, 0, novosibirsk),
+		},
+		{
+			name:          "invalid cutoff",
+			hotel:         Hotel{ID: 1, Name: "Reddison", NoShowCutoff: "noon"},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deadline, err := tt.hotel.NoShowDeadline(arrival, "06:00")
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, tt.expected.Equal(deadline), "expected %s, got %s", tt.expected, deadline)
+			}
+		})
+	}
+}
+
+func TestOrder_MarkNoShow(t *testing.T) {
+	now := time.Date(2025, 2, 2, 6, 0, 0, 0, time.UTC)
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	rub := func(amount int64) Money { return Money{Amount: amount, Currency: "RUB"} }
+
+	order := Order{
+		Status: OrderStatusConfirmed,
+		Bookings: []Booking{
+			{HotelID: 1, RoomType: "single", From: from, To: from.AddDate(0, 0, 2), RoomCount: 1},
+			{HotelID: 2, RoomType: "double", From: from.AddDate(0, 0, 2), To: from.AddDate(0, 0, 2), RoomCount: 1},
+		},
+		Lines: []PriceLine{
+			{HotelID: 1, RoomType: "single", Date: from, RoomCount: 1, NightPrice: rub(1000), Amount: rub(1000)},
+			{HotelID: 1, RoomType: "single", Date: from.AddDate(0, 0, 1), RoomCount: 1, NightPrice: rub(1000), Amount: rub(1000)},
+			{HotelID: 1, RoomType: "single", Date: from.AddDate(0, 0, 2), RoomCount: 1, NightPrice: rub(1000), Amount: rub(1000)},
+			{HotelID: 2, RoomType: "double", Date: from.AddDate(0, 0, 2), RoomCount: 1, NightPrice: rub(2000), Amount: rub(2000)},
+		},
+		Subtotal:  rub(5000),
+		Discounts: []Discount{{Source: DiscountSourceLoyalty, Points: 3, Amount: rub(300)}},
+		Total:     rub(4700),
+	}
+
+	tests := []struct {
+		name             string
+		penaltyNights    int
+		expectedBookings []Booking
+		expectedReleased []Booking
+		expectedLines    int
+		expectedTotal    Money
+	}{
+		{
+			name:          "first night penalty",
+			penaltyNights: 1,
+			expectedBookings: []Booking{
+				{HotelID: 1, RoomType: "single", From: from, To: from, RoomCount: 1},
+			},
+			expectedReleased: []Booking{
+				{HotelID: 1, RoomType: "single", From: from.AddDate(0, 0, 1), To: from.AddDate(0, 0, 2), RoomCount: 1},
+				order.Bookings[1],
+			},
+			expectedLines: 1,
+			expectedTotal: rub(700),
+		},
+		{
+			name:             "no penalty",
+			penaltyNights:    0,
+			expectedBookings: []Booking{},
+			expectedReleased: order.Bookings,
+			expectedTotal:    rub(0),
+		},
+		{
+			name:             "penalty for the whole stay",
+			penaltyNights:    3,
+			expectedBookings: order.Bookings,
+			expectedLines:    4,
+			expectedTotal:    rub(4700),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			noShow := order
+
+			released, err := noShow.MarkNoShow(now, tt.penaltyNights)
+
+			assert.NoError(t, err)
+			assert.Equal(t, OrderStatusNoShow, noShow.Status)
+			assert.Equal(t, &now, noShow.NoShowAt)
+			assert.Equal(t, tt.expectedBookings, noShow.Bookings)
+			assert.Equal(t, tt.expectedReleased, released)
+			assert.Equal(t, tt.expectedTotal, noShow.Total)
+			assert.Len(t, noShow.Lines, tt.expectedLines)
+		})
+	}
+
+	t.Run("checked in", func(t *testing.T) {
+		checkedIn := order
+		checkedIn.Status = OrderStatusCheckedIn
+
+		_, err := checkedIn.MarkNoShow(now, 1)
+
+		assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+	})
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type WebhookID int64
+
+// Webhook is a subscription of a partner hotel to the events about its rooms.
+// The payloads are signed with Secret, empty EventTypes means all events.
+type Webhook struct {
+	ID         WebhookID   `json:"id"`
+	HotelID    HotelID     `json:"hotel_id"`
+	URL        string      `json:"url"`
+	Secret     string      `json:"secret"`
+	EventTypes []EventType `json:"event_types"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+// WebhookInfo is the webhook as it's shown by the api. The secret is known only to the partner.
+type WebhookInfo struct {
+	ID         WebhookID   `json:"id"`
+	HotelID    HotelID     `json:"hotel_id"`
+	URL        string      `json:"url"`
+	EventTypes []EventType `json:"event_types"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+func (w Webhook) Info() WebhookInfo {
+	return WebhookInfo{
+		ID:         w.ID,
+		HotelID:    w.HotelID,
+		URL:        w.URL,
+		EventTypes: w.EventTypes,
+		CreatedAt:  w.CreatedAt,
+	}
+}
+
+func (w Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http(s) url", ErrInvalidWebhook)
+	}
+
+	if w.Secret == "" {
+		return fmt.Errorf("%w: secret is required", ErrInvalidWebhook)
+	}
+
+	for _, eventType := range w.EventTypes {
+		if !contains(eventTypes, eventType) {
+			return fmt.Errorf("%w: unknown event type '%s'", ErrInvalidWebhook, eventType)
+		}
+	}
+
+	return nil
+}
+
+// Matches reports whether the event is about the hotel of the webhook and has the subscribed type.
+func (w Webhook) Matches(event Event) bool {
+	if len(w.EventTypes) > 0 && !contains(w.EventTypes, event.Type) {
+		return false
+	}
+
+	return contains(event.HotelIDs(), w.HotelID)
+}
+
+// DeadLetter is an event which couldn't be delivered to the webhook after all attempts.
+type DeadLetter struct {
+	ID        int64     `json:"id"`
+	WebhookID WebhookID `json:"webhook_id"`
+	Event     Event     `json:"event"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"last_error"`
+	FailedAt  time.Time `json:"failed_at"`
+}
@@ -8,24 +8,50 @@ import (
 )
 
 type hotelRepository interface {
+	GetHotel(ctx context.Context, hotelID domain.HotelID) (*domain.Hotel, error)
 	AddHotel(ctx context.Context, hotel domain.Hotel) error
+	AddRoomType(ctx context.Context, roomType domain.HotelRoomType) error
 	AddRoomAvailability(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error
 }
 
+// InitHotelData adds the hotels unless they are already in the store, e.g. restored from disk.
 func InitHotelData(store hotelRepository) error {
 	ctx := context.Background()
 
 	var err error
 
 	reddison := domain.Hotel{
-		ID:   1,
-		Name: "Reddison",
+		ID:           1,
+		Name:         "Reddison",
+		Address:      "Europe Square, 2",
+		City:         "Moscow",
+		Timezone:     "Europe/Moscow",
+		StarRating:   4,
+		CheckInTime:  "14:00",
+		CheckOutTime: "12:00",
+	}
+
+	if _, err = store.GetHotel(ctx, reddison.ID); err == nil {
+		return nil
 	}
 
 	if err = store.AddHotel(ctx, reddison); err != nil {
 		return err
 	}
 
+	roomTypes := []domain.HotelRoomType{
+		{HotelID: reddison.ID, Code: domain.RoomTypeSingle, Name: "Single", Capacity: 1, Amenities: []string{"wifi"}},
+		{HotelID: reddison.ID, Code: domain.RoomTypeDouble, Name: "Double", Capacity: 2, Amenities: []string{"wifi", "tv"}},
+		{HotelID: reddison.ID, Code: domain.RoomTypeLux, Name: "Lux", Capacity: 2,
+			Description: "Suite with a living room", Amenities: []string{"wifi", "tv", "minibar", "bathtub"}},
+	}
+
+	for _, roomType := range roomTypes {
+		if err = store.AddRoomType(ctx, roomType); err != nil {
+			return err
+		}
+	}
+
 	if err = store.AddRoomAvailability(ctx, reddison.ID, domain.RoomTypeSingle,
 		time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), 1); err != nil {
 		return err
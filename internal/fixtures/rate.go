@@ -0,0 +1,38 @@
+package fixtures
+
+import (
+	"context"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+)
+
+type rateRepository interface {
+	GetRate(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time) (*domain.Rate, error)
+	SetRate(ctx context.Context, rate domain.Rate) error
+}
+
+// InitRateData sets the prices unless they are already in the store, e.g. restored from disk.
+func InitRateData(store rateRepository) error {
+	ctx := context.Background()
+
+	if _, err := store.GetRate(ctx, 1, domain.RoomTypeSingle, date.Date(2025, 2, 1)); err == nil {
+		return nil
+	}
+
+	for day := 1; day <= 5; day++ {
+		rate := domain.Rate{
+			HotelID:  1,
+			RoomType: domain.RoomTypeSingle,
+			Date:     date.Date(2025, 2, day),
+			Price:    domain.Money{Amount: 500000, Currency: "RUB"},
+		}
+
+		if err := store.SetRate(ctx, rate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package fixtures
+
+import (
+	"context"
+
+	"applicationDesignTest/internal/domain"
+)
+
+type userRepository interface {
+	AddUser(ctx context.Context, user domain.User) error
+}
+
+func InitUserData(store userRepository) error {
+	return store.AddUser(context.Background(), domain.User{
+		ID:        1,
+		FirstName: "Ivan",
+		LastName:  "Ivanov",
+		Email:     "ivanov@example.com",
+	})
+}
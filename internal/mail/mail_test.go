@@ -0,0 +1,86 @@
+package mail
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"applicationDesignTest/internal/mail/mailtest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSMTPSender_Send(t *testing.T) {
+	server, err := mailtest.NewServer("127.0.0.1:0", nil)
+	if err != nil {
+		t.Fatalf("failed to start smtp server: %v", err)
+	}
+	defer server.Close()
+
+	sender := NewSMTPSender(server.Host, server.Port, "", "")
+
+	msg := Message{
+		From:    "booking@example.com",
+		To:      []string{"guest@example.com"},
+		Subject: "Заказ подтвержден",
+		Text:    "text body",
+		HTML:    "<p>html body</p>",
+	}
+
+	t.Run("delivers both versions of the body", func(t *testing.T) {
+		err := sender.Send(context.Background(), msg)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		messages := server.Messages()
+		if !assert.Len(t, messages, 1) {
+			return
+		}
+		assert.Equal(t, "booking@example.com", messages[0].From)
+		assert.Equal(t, []string{"guest@example.com"}, messages[0].To)
+
+		received, err := mail.ReadMessage(strings.NewReader(messages[0].Data))
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		subject, err := new(mime.WordDecoder).DecodeHeader(received.Header.Get("Subject"))
+		assert.NoError(t, err)
+		assert.Equal(t, msg.Subject, subject)
+
+		_, params, err := mime.ParseMediaType(received.Header.Get("Content-Type"))
+		assert.NoError(t, err)
+
+		parts := multipart.NewReader(received.Body, params["boundary"])
+
+		var bodies []string
+		for {
+			part, err := parts.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			body, err := io.ReadAll(part)
+			assert.NoError(t, err)
+			bodies = append(bodies, string(body))
+		}
+
+		assert.Equal(t, []string{msg.Text, msg.HTML}, bodies)
+	})
+
+	t.Run("reports server failure", func(t *testing.T) {
+		server.FailNext(1)
+
+		err := sender.Send(context.Background(), msg)
+		assert.Error(t, err)
+		assert.Len(t, server.Messages(), 1)
+	})
+}
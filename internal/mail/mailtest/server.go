@@ -0,0 +1,205 @@
+// Package mailtest provides a fake SMTP server which keeps the received messages in memory.
+// It is used in tests and as a local stand-in for a real mail server.
+package mailtest
+
+import (
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Message is an email received by the server.
+type Message struct {
+	From string
+	To   []string
+	Data string
+}
+
+type Server struct {
+	Host string
+	Port int
+
+	listener  net.Listener
+	onMessage func(Message)
+	messages  []Message
+	failures  int
+	mu        sync.Mutex
+	wg        sync.WaitGroup
+}
+
+// NewServer starts the server on addr, e.g. "127.0.0.1:0" for a random port.
+// onMessage, if not nil, is called for every received message.
+func NewServer(addr string, onMessage func(Message)) (*Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	tcpAddr := listener.Addr().(*net.TCPAddr)
+
+	s := &Server{
+		Host:      tcpAddr.IP.String(),
+		Port:      tcpAddr.Port,
+		listener:  listener,
+		onMessage: onMessage,
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+
+	return s, nil
+}
+
+func (s *Server) Addr() string {
+	return net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+}
+
+// FailNext makes the server reject the next n messages with a temporary error.
+func (s *Server) FailNext(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = n
+}
+
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Message(nil), s.messages...)
+}
+
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+
+			s.handle(textproto.NewConn(conn))
+		}()
+	}
+}
+
+func (s *Server) handle(conn *textproto.Conn) {
+	var msg Message
+
+	reply := func(code int, text string) bool {
+		return conn.PrintfLine("%d %s", code, text) == nil
+	}
+
+	if !reply(220, "mailtest ESMTP") {
+		return
+	}
+
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+
+		command, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(command) {
+		case "EHLO", "HELO":
+			if !reply(250, "mailtest") {
+				return
+			}
+		case "MAIL":
+			msg = Message{From: parseAddress(arg)}
+			if !reply(250, "OK") {
+				return
+			}
+		case "RCPT":
+			msg.To = append(msg.To, parseAddress(arg))
+			if !reply(250, "OK") {
+				return
+			}
+		case "DATA":
+			if s.takeFailure() {
+				if !reply(451, "temporary failure") {
+					return
+				}
+				continue
+			}
+
+			if !reply(354, "end data with <CR><LF>.<CR><LF>") {
+				return
+			}
+
+			data, err := conn.ReadDotBytes()
+			if err != nil {
+				return
+			}
+
+			msg.Data = string(data)
+			s.store(msg)
+
+			if !reply(250, "OK") {
+				return
+			}
+		case "RSET", "NOOP":
+			if !reply(250, "OK") {
+				return
+			}
+		case "QUIT":
+			reply(221, "bye")
+			return
+		default:
+			if !reply(502, "command not implemented") {
+				return
+			}
+		}
+	}
+}
+
+func (s *Server) takeFailure() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures == 0 {
+		return false
+	}
+
+	s.failures--
+
+	return true
+}
+
+func (s *Server) store(msg Message) {
+	s.mu.Lock()
+	s.messages = append(s.messages, msg)
+	s.mu.Unlock()
+
+	if s.onMessage != nil {
+		s.onMessage(msg)
+	}
+}
+
+// parseAddress extracts the address from "FROM:<user@example.com>" or "TO:<user@example.com>".
+func parseAddress(arg string) string {
+	_, address, _ := strings.Cut(arg, ":")
+	address = strings.TrimSpace(address)
+
+	if i := strings.IndexByte(address, ' '); i >= 0 {
+		address = address[:i]
+	}
+
+	return strings.Trim(address, "<>")
+}
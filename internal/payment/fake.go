@@ -0,0 +1,211 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"applicationDesignTest/internal/domain"
+)
+
+// Outcome is how the fake gateway answers the authorization of a card.
+type Outcome string
+
+const (
+	OutcomeSucceed Outcome = "succeed"
+	OutcomeDecline Outcome = "decline"
+	OutcomeTimeout Outcome = "timeout"
+	// OutcomeTimeoutAfterAuthorize makes the authorization, but its answer is lost.
+	OutcomeTimeoutAfterAuthorize Outcome = "timeout_after_authorize"
+)
+
+// The cards scripted by NewFakeGateway, any other card is authorized.
+const (
+	DeclineToken               domain.PaymentToken = "tok_decline"
+	TimeoutToken               domain.PaymentToken = "tok_timeout"
+	TimeoutAfterAuthorizeToken domain.PaymentToken = "tok_timeout_authorized"
+)
+
+type paymentStatus string
+
+const (
+	statusAuthorized paymentStatus = "authorized"
+	statusCaptured   paymentStatus = "captured"
+	statusVoided     paymentStatus = "voided"
+)
+
+type fakePayment struct {
+	status     paymentStatus
+	authorized domain.Money
+	captured   domain.Money
+	refunded   domain.Money
+}
+
+// FakeGateway is an in-process payment gateway for development and tests. The answer to the authorization
+// is scripted per card, the payments are numbered in the order they are authorized, so the gateway
+// behaves the same on every run.
+type FakeGateway struct {
+	mu       sync.Mutex
+	outcomes map[domain.PaymentToken]Outcome
+	payments map[domain.PaymentID]*fakePayment
+	keys     map[domain.PaymentKey]domain.PaymentID
+	seq      int
+}
+
+func NewFakeGateway() *FakeGateway {
+	return &FakeGateway{
+		outcomes: map[domain.PaymentToken]Outcome{
+			DeclineToken:               OutcomeDecline,
+			TimeoutToken:               OutcomeTimeout,
+			TimeoutAfterAuthorizeToken: OutcomeTimeoutAfterAuthorize,
+		},
+		payments: make(map[domain.PaymentID]*fakePayment),
+		keys:     make(map[domain.PaymentKey]domain.PaymentID),
+	}
+}
+
+// Script sets the answer to the authorization of the card.
+func (g *FakeGateway) Script(token domain.PaymentToken, outcome Outcome) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.outcomes[token] = outcome
+}
+
+// Authorize holds the amount on the card. Repeating the authorization with the same key returns the made one.
+// A timed out authorization isn't made unless the card is scripted to time out after it.
+func (g *FakeGateway) Authorize(ctx context.Context, key domain.PaymentKey, token domain.PaymentToken, amount domain.Money) (domain.PaymentID, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if id, ok := g.keys[key]; ok {
+		return id, nil
+	}
+
+	outcome := g.outcomes[token]
+
+	switch outcome {
+	case OutcomeDecline:
+		return "", fmt.Errorf("%w: card '%s'", domain.ErrPaymentDeclined, token)
+	case OutcomeTimeout:
+		return "", fmt.Errorf("%w: card '%s'", domain.ErrPaymentTimeout, token)
+	}
+
+	if amount.Amount <= 0 {
+		return "", fmt.Errorf("%w: amount must be positive", domain.ErrInvalidPaymentOperation)
+	}
+
+	g.seq++
+	id := domain.PaymentID(fmt.Sprintf("fake-%d", g.seq))
+
+	g.payments[id] = &fakePayment{
+		status:     statusAuthorized,
+		authorized: amount,
+		captured:   domain.Money{Currency: amount.Currency},
+		refunded:   domain.Money{Currency: amount.Currency},
+	}
+	g.keys[key] = id
+
+	if outcome == OutcomeTimeoutAfterAuthorize {
+		return "", fmt.Errorf("%w: card '%s'", domain.ErrPaymentTimeout, token)
+	}
+
+	return id, nil
+}
+
+// FindPayment returns the payment authorized with the key.
+func (g *FakeGateway) FindPayment(ctx context.Context, key domain.PaymentKey) (domain.PaymentID, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	id, ok := g.keys[key]
+	if !ok {
+		return "", fmt.Errorf("%w: key %s", domain.ErrPaymentNotFound, key)
+	}
+
+	return id, nil
+}
+
+// Capture takes the amount up to the authorized one, the rest of the authorization is released.
+func (g *FakeGateway) Capture(ctx context.Context, id domain.PaymentID, amount domain.Money) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	payment, err := g.payment(id, statusAuthorized)
+	if err != nil {
+		return err
+	}
+
+	if err := checkAmount(amount, payment.authorized); err != nil {
+		return err
+	}
+
+	payment.status = statusCaptured
+	payment.captured = amount
+
+	return nil
+}
+
+// Void releases the authorization without taking the money.
+func (g *FakeGateway) Void(ctx context.Context, id domain.PaymentID) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	payment, err := g.payment(id, statusAuthorized)
+	if err != nil {
+		return err
+	}
+
+	payment.status = statusVoided
+
+	return nil
+}
+
+// Refund returns the amount up to the captured one not refunded yet.
+func (g *FakeGateway) Refund(ctx context.Context, id domain.PaymentID, amount domain.Money) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	payment, err := g.payment(id, statusCaptured)
+	if err != nil {
+		return err
+	}
+
+	refundable := domain.Money{
+		Amount:   payment.captured.Amount - payment.refunded.Amount,
+		Currency: payment.captured.Currency,
+	}
+
+	if err := checkAmount(amount, refundable); err != nil {
+		return err
+	}
+
+	payment.refunded.Amount += amount.Amount
+
+	return nil
+}
+
+func (g *FakeGateway) payment(id domain.PaymentID, status paymentStatus) (*fakePayment, error) {
+	payment, ok := g.payments[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrPaymentNotFound, id)
+	}
+
+	if payment.status != status {
+		return nil, fmt.Errorf("%w: payment %s is %s", domain.ErrInvalidPaymentOperation, id, payment.status)
+	}
+
+	return payment, nil
+}
+
+func checkAmount(amount, limit domain.Money) error {
+	if amount.Currency != limit.Currency {
+		return fmt.Errorf("%w: %s and %s", domain.ErrCurrencyMismatch, amount.Currency, limit.Currency)
+	}
+
+	if amount.Amount <= 0 || amount.Amount > limit.Amount {
+		return fmt.Errorf("%w: amount must be positive and not over %d", domain.ErrInvalidPaymentOperation, limit.Amount)
+	}
+
+	return nil
+}
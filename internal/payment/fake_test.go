@@ -0,0 +1,101 @@
+package payment
+
+import (
+	"context"
+	"testing"
+
+	"applicationDesignTest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeGateway(t *testing.T) {
+	ctx := context.Background()
+	amount := domain.Money{Amount: 1000, Currency: "RUB"}
+
+	t.Run("scripted outcomes", func(t *testing.T) {
+		gateway := NewFakeGateway()
+		gateway.Script("tok_scripted", OutcomeDecline)
+
+		tests := []struct {
+			name          string
+			token         domain.PaymentToken
+			expectedID    domain.PaymentID
+			expectedError error
+		}{
+			{name: "any card is authorized", token: "tok_visa", expectedID: "fake-1"},
+			{name: "decline card", token: DeclineToken, expectedError: domain.ErrPaymentDeclined},
+			{name: "timeout card", token: TimeoutToken, expectedError: domain.ErrPaymentTimeout},
+			{name: "scripted card", token: "tok_scripted", expectedError: domain.ErrPaymentDeclined},
+			{name: "payments are numbered", token: "tok_visa", expectedID: "fake-2"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				id, err := gateway.Authorize(ctx, domain.PaymentKey(tt.name), tt.token, amount)
+
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Equal(t, tt.expectedID, id)
+			})
+		}
+	})
+
+	t.Run("capture and refund", func(t *testing.T) {
+		gateway := NewFakeGateway()
+
+		id, err := gateway.Authorize(ctx, "key-1", "tok_visa", amount)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.ErrorIs(t, gateway.Refund(ctx, id, amount), domain.ErrInvalidPaymentOperation)
+		assert.ErrorIs(t, gateway.Capture(ctx, id, domain.Money{Amount: 1001, Currency: "RUB"}), domain.ErrInvalidPaymentOperation)
+		assert.NoError(t, gateway.Capture(ctx, id, amount))
+		assert.ErrorIs(t, gateway.Void(ctx, id), domain.ErrInvalidPaymentOperation)
+		assert.NoError(t, gateway.Refund(ctx, id, domain.Money{Amount: 600, Currency: "RUB"}))
+		assert.ErrorIs(t, gateway.Refund(ctx, id, domain.Money{Amount: 600, Currency: "RUB"}), domain.ErrInvalidPaymentOperation)
+		assert.NoError(t, gateway.Refund(ctx, id, domain.Money{Amount: 400, Currency: "RUB"}))
+	})
+
+	t.Run("void", func(t *testing.T) {
+		gateway := NewFakeGateway()
+
+		id, err := gateway.Authorize(ctx, "key-1", "tok_visa", amount)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.NoError(t, gateway.Void(ctx, id))
+		assert.ErrorIs(t, gateway.Capture(ctx, id, amount), domain.ErrInvalidPaymentOperation)
+		assert.ErrorIs(t, gateway.Void(ctx, "fake-2"), domain.ErrPaymentNotFound)
+	})
+
+	t.Run("lost authorization is found by key", func(t *testing.T) {
+		gateway := NewFakeGateway()
+
+		_, err := gateway.Authorize(ctx, "key-1", TimeoutAfterAuthorizeToken, amount)
+		assert.ErrorIs(t, err, domain.ErrPaymentTimeout)
+
+		_, err = gateway.FindPayment(ctx, "key-2")
+		assert.ErrorIs(t, err, domain.ErrPaymentNotFound)
+
+		id, err := gateway.FindPayment(ctx, "key-1")
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, domain.PaymentID("fake-1"), id)
+		assert.NoError(t, gateway.Void(ctx, id))
+	})
+
+	t.Run("repeated authorization with the same key", func(t *testing.T) {
+		gateway := NewFakeGateway()
+
+		first, err := gateway.Authorize(ctx, "key-1", "tok_visa", amount)
+		assert.NoError(t, err)
+
+		second, err := gateway.Authorize(ctx, "key-1", "tok_visa", amount)
+		assert.NoError(t, err)
+		assert.Equal(t, first, second)
+	})
+}
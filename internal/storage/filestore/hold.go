@@ -0,0 +1,45 @@
+package filestore
+
+import (
+	"context"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+func (s *Store) AddHold(ctx context.Context, hold domain.Hold) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	if err := s.append(record{Op: opAddHold, Hold: &hold}); err != nil {
+		return err
+	}
+
+	return s.holds.AddHold(ctx, hold)
+}
+
+func (s *Store) GetHold(ctx context.Context, token domain.HoldToken) (*domain.Hold, error) {
+	return s.holds.GetHold(ctx, token)
+}
+
+// DeleteHold removes the hold and returns it, so only one caller can take it.
+func (s *Store) DeleteHold(ctx context.Context, token domain.HoldToken) (*domain.Hold, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	if _, err := s.holds.GetHold(ctx, token); err != nil {
+		return nil, err
+	}
+
+	if err := s.append(record{Op: opDeleteHold, HoldToken: token}); err != nil {
+		return nil, err
+	}
+
+	return s.holds.DeleteHold(ctx, token)
+}
+
+func (s *Store) GetExpiredHolds(ctx context.Context, now time.Time) ([]domain.Hold, error) {
+	return s.holds.GetExpiredHolds(ctx, now)
+}
@@ -0,0 +1,36 @@
+package filestore
+
+import (
+	"context"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+// AppendEntry is checked against the balance by the loyalty store and logged before the entry is added.
+// The creation time is logged, so the entry is restored as it was.
+func (s *Store) AppendEntry(ctx context.Context, entry domain.LoyaltyEntry) (*domain.LoyaltyEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+
+	return s.loyalty.AppendEntryLogged(ctx, entry, func() error {
+		return s.append(record{Op: opAppendLoyaltyEntry, LoyaltyEntry: &entry})
+	})
+}
+
+func (s *Store) GetBalance(ctx context.Context, userID domain.UserID) (int64, error) {
+	return s.loyalty.GetBalance(ctx, userID)
+}
+
+func (s *Store) GetUserEntries(ctx context.Context, userID domain.UserID) ([]domain.LoyaltyEntry, error) {
+	return s.loyalty.GetUserEntries(ctx, userID)
+}
+
+func (s *Store) GetOrderEntries(ctx context.Context, orderNumber domain.OrderNumber) ([]domain.LoyaltyEntry, error) {
+	return s.loyalty.GetOrderEntries(ctx, orderNumber)
+}
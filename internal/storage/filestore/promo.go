@@ -0,0 +1,54 @@
+package filestore
+
+import (
+	"context"
+
+	"applicationDesignTest/internal/domain"
+)
+
+func (s *Store) AddPromo(ctx context.Context, promo domain.Promo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	if _, err := s.promos.GetPromo(ctx, promo.Code); err == nil {
+		return domain.ErrPromoAlreadyExists
+	}
+
+	if err := s.append(record{Op: opAddPromo, Promo: &promo}); err != nil {
+		return err
+	}
+
+	return s.promos.AddPromo(ctx, promo)
+}
+
+func (s *Store) GetPromo(ctx context.Context, code domain.PromoCode) (*domain.Promo, error) {
+	return s.promos.GetPromo(ctx, code)
+}
+
+// Redeem is checked against the usage limits by the promo store and logged before the use is counted.
+func (s *Store) Redeem(ctx context.Context, code domain.PromoCode, userID domain.UserID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	return s.promos.RedeemLogged(ctx, code, userID, func() error {
+		return s.append(record{Op: opRedeemPromo, PromoCode: code, UserID: userID})
+	})
+}
+
+func (s *Store) Unredeem(ctx context.Context, code domain.PromoCode, userID domain.UserID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	if _, err := s.promos.GetPromo(ctx, code); err != nil {
+		return err
+	}
+
+	if err := s.append(record{Op: opUnredeemPromo, PromoCode: code, UserID: userID}); err != nil {
+		return err
+	}
+
+	return s.promos.Unredeem(ctx, code, userID)
+}
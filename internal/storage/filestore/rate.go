@@ -0,0 +1,40 @@
+package filestore
+
+import (
+	"context"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+func (s *Store) SetRate(ctx context.Context, rate domain.Rate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	if err := s.append(record{Op: opSetRate, Rate: &rate}); err != nil {
+		return err
+	}
+
+	return s.rates.SetRate(ctx, rate)
+}
+
+func (s *Store) GetRate(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time) (*domain.Rate, error) {
+	return s.rates.GetRate(ctx, hotelID, roomType, date)
+}
+
+func (s *Store) SetCancellationPolicy(ctx context.Context, policy domain.CancellationPolicy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	if err := s.append(record{Op: opSetCancellationPolicy, Policy: &policy}); err != nil {
+		return err
+	}
+
+	return s.rates.SetCancellationPolicy(ctx, policy)
+}
+
+func (s *Store) GetCancellationPolicy(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType) (*domain.CancellationPolicy, error) {
+	return s.rates.GetCancellationPolicy(ctx, hotelID, roomType)
+}
@@ -0,0 +1,25 @@
+package filestore
+
+import (
+	"context"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+func (s *Store) SetRestriction(ctx context.Context, restriction domain.Restriction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	if err := s.append(record{Op: opSetRestriction, Restriction: &restriction}); err != nil {
+		return err
+	}
+
+	return s.restrictions.SetRestriction(ctx, restriction)
+}
+
+func (s *Store) GetRestrictions(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType,
+	from, to time.Time) ([]domain.Restriction, error) {
+	return s.restrictions.GetRestrictions(ctx, hotelID, roomType, from, to)
+}
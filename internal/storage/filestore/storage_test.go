@@ -0,0 +1,35 @@
+package filestore
+
+import (
+	"testing"
+
+	"applicationDesignTest/internal/storage/storagetest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storagetest.Store {
+		store, err := Open(t.TempDir(), 5)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		t.Cleanup(func() { assert.NoError(t, store.Close()) })
+
+		return store
+	})
+}
+
+func TestStateConformance(t *testing.T) {
+	storagetest.RunState(t, func(t *testing.T) storagetest.StateStore {
+		store, err := Open(t.TempDir(), 5)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		t.Cleanup(func() { assert.NoError(t, store.Close()) })
+
+		return store
+	})
+}
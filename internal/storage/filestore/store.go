@@ -0,0 +1,724 @@
+// Package filestore is a durable store of hotels, orders, their outbox and the rest of the booking state: holds,
+// prices, promo codes, loyalty points, webhooks and sale restrictions. The state is kept in memory by memorystore
+// and every change is appended to a write-ahead log on disk before it's acknowledged. The log is
+// periodically compacted into a snapshot. On open the state is recovered from the snapshot and the log.
+package filestore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/internal/storage/memorystore"
+)
+
+const (
+	walFile      = "wal.log"
+	snapshotFile = "snapshot.json"
+)
+
+type snapshot struct {
+	Seq    uint64                      `json:"seq"`
+	Hotels []memorystore.HotelSnapshot `json:"hotels"`
+	Orders []domain.Order              `json:"orders"`
+	// LastOrderNumber is the last taken order number, it may be taken by an order which wasn't added.
+	LastOrderNumber domain.OrderNumber          `json:"last_order_number,omitempty"`
+	Outbox          memorystore.OutboxSnapshot  `json:"outbox"`
+	Holds           []domain.Hold               `json:"holds,omitempty"`
+	Rates           memorystore.RateSnapshot    `json:"rates"`
+	Promos          []memorystore.PromoSnapshot `json:"promos,omitempty"`
+	Loyalty         []domain.LoyaltyEntry       `json:"loyalty,omitempty"`
+	Webhooks        memorystore.WebhookSnapshot `json:"webhooks"`
+	Restrictions    []domain.Restriction        `json:"restrictions,omitempty"`
+}
+
+// logFile is the file the log is appended to.
+type logFile interface {
+	io.Writer
+	Sync() error
+	Truncate(size int64) error
+	Close() error
+}
+
+// Store implements the hotel, order, outbox, hold, rate, promo, loyalty, webhook and restriction repositories.
+// Changes are serialized, so the log has the same order as the changes of the state.
+type Store struct {
+	hotels       *memorystore.HotelStore
+	orders       *memorystore.OrderStore
+	outbox       *memorystore.Outbox
+	holds        *memorystore.HoldStore
+	rates        *memorystore.RateStore
+	promos       *memorystore.PromoStore
+	loyalty      *memorystore.LoyaltyStore
+	webhooks     *memorystore.WebhookStore
+	restrictions *memorystore.RestrictionStore
+
+	dir           string
+	wal           logFile
+	walSize       int64
+	seq           uint64
+	snapshotEvery int
+	sinceSnapshot int
+	// walErr is set when a failed write can't be cut off the log. The following records would be lost
+	// on recovery behind the torn one, so nothing is appended until a snapshot empties the log.
+	walErr error
+	mu     sync.Mutex
+}
+
+// Open recovers the store from dir. A snapshot is taken after every snapshotEvery records of the log.
+func Open(dir string, snapshotEvery int) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create store dir: %w", err)
+	}
+
+	s := &Store{
+		hotels:        memorystore.NewHotelStore(nil),
+		orders:        memorystore.NewOrderStore(nil),
+		outbox:        memorystore.NewOutbox(),
+		holds:         memorystore.NewHoldStore(),
+		rates:         memorystore.NewRateStore(),
+		promos:        memorystore.NewPromoStore(),
+		loyalty:       memorystore.NewLoyaltyStore(),
+		webhooks:      memorystore.NewWebhookStore(),
+		restrictions:  memorystore.NewRestrictionStore(),
+		dir:           dir,
+		snapshotEvery: snapshotEvery,
+	}
+
+	if err := s.recover(); err != nil {
+		return nil, fmt.Errorf("failed to recover store: %w", err)
+	}
+
+	wal, err := os.OpenFile(filepath.Join(dir, walFile), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log: %w", err)
+	}
+
+	info, err := wal.Stat()
+	if err != nil {
+		_ = wal.Close()
+		return nil, fmt.Errorf("failed to stat log: %w", err)
+	}
+
+	s.wal = wal
+	s.walSize = info.Size()
+
+	return s, nil
+}
+
+// Close takes a snapshot, so the next start doesn't replay the log, and closes the log.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshotErr := s.takeSnapshot()
+
+	if err := s.wal.Close(); err != nil {
+		return err
+	}
+
+	return snapshotErr
+}
+
+func (s *Store) GetHotel(ctx context.Context, hotelID domain.HotelID) (*domain.Hotel, error) {
+	return s.hotels.GetHotel(ctx, hotelID)
+}
+
+func (s *Store) GetHotels(ctx context.Context) ([]domain.Hotel, error) {
+	return s.hotels.GetHotels(ctx)
+}
+
+func (s *Store) AddHotel(ctx context.Context, hotel domain.Hotel) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	if _, err := s.hotels.GetHotel(ctx, hotel.ID); err == nil {
+		return domain.ErrHotelAlreadyExists
+	}
+
+	if err := s.append(record{Op: opAddHotel, Hotel: &hotel}); err != nil {
+		return err
+	}
+
+	return s.hotels.AddHotel(ctx, hotel)
+}
+
+func (s *Store) UpdateHotel(ctx context.Context, hotel domain.Hotel) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	if _, err := s.hotels.GetHotel(ctx, hotel.ID); err != nil {
+		return err
+	}
+
+	if err := s.append(record{Op: opUpdateHotel, Hotel: &hotel}); err != nil {
+		return err
+	}
+
+	return s.hotels.UpdateHotel(ctx, hotel)
+}
+
+func (s *Store) GetRoomTypes(ctx context.Context, hotelID domain.HotelID) ([]domain.HotelRoomType, error) {
+	return s.hotels.GetRoomTypes(ctx, hotelID)
+}
+
+func (s *Store) GetRoomType(ctx context.Context, hotelID domain.HotelID, code domain.RoomType) (*domain.HotelRoomType, error) {
+	return s.hotels.GetRoomType(ctx, hotelID, code)
+}
+
+func (s *Store) AddRoomType(ctx context.Context, roomType domain.HotelRoomType) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	if _, err := s.hotels.GetHotel(ctx, roomType.HotelID); err != nil {
+		return err
+	}
+
+	if _, err := s.hotels.GetRoomType(ctx, roomType.HotelID, roomType.Code); err == nil {
+		return domain.ErrRoomTypeAlreadyExists
+	}
+
+	if err := s.append(record{Op: opAddRoomType, HotelRoomType: &roomType}); err != nil {
+		return err
+	}
+
+	return s.hotels.AddRoomType(ctx, roomType)
+}
+
+func (s *Store) UpdateRoomType(ctx context.Context, roomType domain.HotelRoomType) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	if _, err := s.hotels.GetRoomType(ctx, roomType.HotelID, roomType.Code); err != nil {
+		return err
+	}
+
+	if err := s.append(record{Op: opUpdateRoomType, HotelRoomType: &roomType}); err != nil {
+		return err
+	}
+
+	return s.hotels.UpdateRoomType(ctx, roomType)
+}
+
+func (s *Store) GetRoomAvailability(ctx context.Context, hotelID domain.HotelID, from, to time.Time) ([]domain.RoomAvailability, error) {
+	return s.hotels.GetRoomAvailability(ctx, hotelID, from, to)
+}
+
+func (s *Store) AddRoomAvailability(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	if _, err := s.hotels.GetRoomType(ctx, hotelID, roomType); err != nil {
+		return err
+	}
+
+	if err := s.append(record{Op: opAddAvailability, HotelID: hotelID, RoomType: roomType, Date: date, Rooms: rooms}); err != nil {
+		return err
+	}
+
+	return s.hotels.AddRoomAvailability(ctx, hotelID, roomType, date, rooms)
+}
+
+func (s *Store) AddRoomAvailabilities(ctx context.Context, changes []domain.AvailabilityChange) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	for _, change := range changes {
+		if _, err := s.hotels.GetRoomType(ctx, change.HotelID, change.RoomType); err != nil {
+			return err
+		}
+	}
+
+	if err := s.append(record{Op: opAddAvailabilities, Availability: changes}); err != nil {
+		return err
+	}
+
+	return s.hotels.AddRoomAvailabilities(ctx, changes)
+}
+
+func (s *Store) SetRoomCapacity(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, capacity int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	rec := record{Op: opSetCapacity, HotelID: hotelID, RoomType: roomType, Date: date, Rooms: capacity}
+
+	return s.hotels.SetRoomCapacityLogged(ctx, hotelID, roomType, date, capacity, func() error {
+		return s.append(rec)
+	})
+}
+
+func (s *Store) ReduceRoomCapacity(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	rec := record{Op: opReduceCapacity, HotelID: hotelID, RoomType: roomType, Date: date, Rooms: rooms}
+
+	return s.hotels.ReduceRoomCapacityLogged(ctx, hotelID, roomType, date, rooms, func() error {
+		return s.append(rec)
+	})
+}
+
+func (s *Store) Reserve(ctx context.Context, bookings []domain.Booking) error {
+	return s.ReplaceReservation(ctx, nil, bookings)
+}
+
+func (s *Store) Release(ctx context.Context, bookings []domain.Booking) error {
+	return s.ReplaceReservation(ctx, bookings, nil)
+}
+
+// ReplaceReservation is checked by the hotel store, because only it knows if the rooms are available,
+// and logged before it's made.
+func (s *Store) ReplaceReservation(ctx context.Context, released, reserved []domain.Booking) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	return s.hotels.ReplaceReservationLogged(ctx, released, reserved, func() error {
+		return s.append(record{Op: opReplaceReservation, Released: released, Reserved: reserved})
+	})
+}
+
+// NextOrderNumber takes the next order number. The number is logged, so it isn't taken again after a restart.
+func (s *Store) NextOrderNumber(ctx context.Context) (domain.OrderNumber, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	number := s.orders.LastOrderNumber() + 1
+
+	if err := s.append(record{Op: opTakeOrderNumber, OrderNumber: number}); err != nil {
+		return 0, err
+	}
+
+	s.orders.RestoreOrderNumber(number)
+
+	return number, nil
+}
+
+func (s *Store) AddOrder(ctx context.Context, order domain.Order) (*domain.Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	if _, err := s.orders.GetOrderByID(ctx, order.ID); err == nil {
+		return nil, domain.ErrOrderAlreadyExists
+	}
+
+	// the number and the creation time are logged, so the order is restored as it was
+	if order.Number == 0 {
+		order.Number = s.orders.LastOrderNumber() + 1
+	}
+
+	if order.CreatedAt.IsZero() {
+		order.CreatedAt = time.Now().UTC()
+	}
+
+	if err := s.append(record{Op: opAddOrder, Order: &order}); err != nil {
+		return nil, err
+	}
+
+	return s.orders.AddOrder(ctx, order)
+}
+
+func (s *Store) GetOrderByID(ctx context.Context, id domain.OrderID) (*domain.Order, error) {
+	return s.orders.GetOrderByID(ctx, id)
+}
+
+func (s *Store) GetOrderByNumber(ctx context.Context, orderNumber domain.OrderNumber) (*domain.Order, error) {
+	return s.orders.GetOrderByNumber(ctx, orderNumber)
+}
+
+func (s *Store) GetOrders(ctx context.Context) ([]domain.Order, error) {
+	return s.orders.GetOrders(ctx)
+}
+
+// UpdateOrder applies update to a copy of the order, logs the result and stores it.
+func (s *Store) UpdateOrder(ctx context.Context, orderNumber domain.OrderNumber, update func(order *domain.Order) error) (*domain.Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	current, err := s.orders.GetOrderByNumber(ctx, orderNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := current.Clone()
+	if err := update(&updated); err != nil {
+		return nil, err
+	}
+
+	if err := s.append(record{Op: opPutOrder, Order: &updated}); err != nil {
+		return nil, err
+	}
+
+	return s.orders.UpdateOrder(ctx, orderNumber, func(order *domain.Order) error {
+		*order = updated
+		return nil
+	})
+}
+
+func (s *Store) GetEvents(ctx context.Context, afterID int64, limit int) ([]domain.Event, error) {
+	return s.outbox.GetEvents(ctx, afterID, limit)
+}
+
+// GetOffset logs the first offset of a new subscriber, so the events it hasn't got aren't trimmed
+// after a restart.
+func (s *Store) GetOffset(ctx context.Context, subscriber string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	if !s.outbox.Subscribed(subscriber) {
+		if err := s.append(record{Op: opSetOffset, Subscriber: subscriber}); err != nil {
+			return 0, err
+		}
+	}
+
+	return s.outbox.GetOffset(ctx, subscriber)
+}
+
+func (s *Store) SetOffset(ctx context.Context, subscriber string, offset int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	if err := s.append(record{Op: opSetOffset, Subscriber: subscriber, Offset: offset}); err != nil {
+		return err
+	}
+
+	return s.outbox.SetOffset(ctx, subscriber, offset)
+}
+
+// append writes the record to the log and flushes it to disk. A record which isn't written completely is cut off,
+// so the next record follows the last complete one and isn't lost behind a torn frame on recovery.
+func (s *Store) append(rec record) error {
+	if s.walErr != nil {
+		return s.walErr
+	}
+
+	rec.Seq = s.seq + 1
+
+	frame, err := encodeRecord(rec)
+	if err != nil {
+		return fmt.Errorf("failed to encode record: %w", err)
+	}
+
+	if _, err := s.wal.Write(frame); err != nil {
+		return s.cutOff(fmt.Errorf("failed to write log: %w", err))
+	}
+
+	if err := s.wal.Sync(); err != nil {
+		return s.cutOff(fmt.Errorf("failed to sync log: %w", err))
+	}
+
+	s.seq = rec.Seq
+	s.walSize += int64(len(frame))
+	s.sinceSnapshot++
+
+	return nil
+}
+
+// cutOff truncates the log to its last complete record after the write failed with err.
+// If the log can't be truncated, the store stops appending to it.
+func (s *Store) cutOff(err error) error {
+	truncateErr := s.wal.Truncate(s.walSize)
+	if truncateErr == nil {
+		truncateErr = s.wal.Sync()
+	}
+
+	if truncateErr != nil {
+		s.walErr = fmt.Errorf("log is damaged by a failed write: %w", truncateErr)
+	}
+
+	return err
+}
+
+// maybeSnapshot takes a snapshot if enough records are logged. It's called after the logged change
+// is applied to the state. The change is already durable, so a failed snapshot is retried later.
+func (s *Store) maybeSnapshot() {
+	if s.snapshotEvery > 0 && s.sinceSnapshot >= s.snapshotEvery {
+		_ = s.takeSnapshot()
+	}
+}
+
+// takeSnapshot writes the state to the snapshot file and empties the log.
+// The records of a log left after a crash between these steps are skipped on recovery by their Seq.
+func (s *Store) takeSnapshot() error {
+	ctx := context.Background()
+
+	orders, err := s.orders.GetOrders(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(snapshot{
+		Seq:    s.seq,
+		Hotels: s.hotels.Snapshot(ctx),
+		Orders: orders,
+
+		LastOrderNumber: s.orders.LastOrderNumber(),
+		Outbox:          s.outbox.Snapshot(),
+		Holds:           s.holds.Snapshot(),
+		Rates:           s.rates.Snapshot(),
+		Promos:          s.promos.Snapshot(),
+		Loyalty:         s.loyalty.Snapshot(),
+		Webhooks:        s.webhooks.Snapshot(),
+		Restrictions:    s.restrictions.Snapshot(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(filepath.Join(s.dir, snapshotFile), data); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	if err := s.wal.Truncate(0); err != nil {
+		return fmt.Errorf("failed to truncate log: %w", err)
+	}
+
+	if err := s.wal.Sync(); err != nil {
+		return fmt.Errorf("failed to sync log: %w", err)
+	}
+
+	s.walSize = 0
+	s.walErr = nil
+	s.sinceSnapshot = 0
+
+	return nil
+}
+
+// recover restores the snapshot and replays the log. The snapshot has the events of its state, so the outbox
+// is attached after it's restored and the replayed records add their events again. These events get the time
+// of the recovery.
+func (s *Store) recover() error {
+	data, err := os.ReadFile(filepath.Join(s.dir, snapshotFile))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	if err == nil {
+		var snap snapshot
+		if err := json.Unmarshal(data, &snap); err != nil {
+			return fmt.Errorf("failed to decode snapshot: %w", err)
+		}
+
+		if err := s.restoreSnapshot(snap); err != nil {
+			return err
+		}
+
+		s.outbox.Restore(snap.Outbox)
+	}
+
+	s.hotels.SetOutbox(s.outbox)
+	s.orders.SetOutbox(s.outbox)
+
+	return readLog(filepath.Join(s.dir, walFile), func(rec record) error {
+		if rec.Seq <= s.seq {
+			return nil
+		}
+
+		if err := s.replay(rec); err != nil {
+			return err
+		}
+
+		s.seq = rec.Seq
+		s.sinceSnapshot++
+
+		return nil
+	})
+}
+
+func (s *Store) restoreSnapshot(snap snapshot) error {
+	ctx := context.Background()
+
+	for _, hotel := range snap.Hotels {
+		if err := s.hotels.AddHotel(ctx, hotel.Hotel); err != nil {
+			return err
+		}
+
+		for _, roomType := range hotel.RoomTypes {
+			if err := s.hotels.AddRoomType(ctx, roomType); err != nil {
+				return err
+			}
+		}
+
+		for _, availability := range hotel.Availability {
+			err := s.hotels.RestoreRoomAvailability(ctx, hotel.Hotel.ID, availability.RoomType, availability.Date,
+				availability.Capacity, availability.Rooms)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, order := range snap.Orders {
+		if _, err := s.orders.AddOrder(ctx, order); err != nil {
+			return err
+		}
+	}
+
+	s.orders.RestoreOrderNumber(snap.LastOrderNumber)
+
+	for _, hold := range snap.Holds {
+		if err := s.holds.AddHold(ctx, hold); err != nil {
+			return err
+		}
+	}
+
+	for _, rate := range snap.Rates.Rates {
+		if err := s.rates.SetRate(ctx, rate); err != nil {
+			return err
+		}
+	}
+
+	for _, policy := range snap.Rates.Policies {
+		if err := s.rates.SetCancellationPolicy(ctx, policy); err != nil {
+			return err
+		}
+	}
+
+	for _, promo := range snap.Promos {
+		s.promos.RestorePromo(promo)
+	}
+
+	for _, entry := range snap.Loyalty {
+		if _, err := s.loyalty.AppendEntry(ctx, entry); err != nil {
+			return err
+		}
+	}
+
+	s.webhooks.Restore(snap.Webhooks)
+
+	for _, restriction := range snap.Restrictions {
+		if err := s.restrictions.SetRestriction(ctx, restriction); err != nil {
+			return err
+		}
+	}
+
+	s.seq = snap.Seq
+
+	return nil
+}
+
+func (s *Store) replay(rec record) error {
+	ctx := context.Background()
+
+	switch rec.Op {
+	case opAddHotel:
+		return s.hotels.AddHotel(ctx, *rec.Hotel)
+	case opUpdateHotel:
+		return s.hotels.UpdateHotel(ctx, *rec.Hotel)
+	case opAddRoomType:
+		return s.hotels.AddRoomType(ctx, *rec.HotelRoomType)
+	case opUpdateRoomType:
+		return s.hotels.UpdateRoomType(ctx, *rec.HotelRoomType)
+	case opAddAvailability:
+		return s.hotels.AddRoomAvailability(ctx, rec.HotelID, rec.RoomType, rec.Date, rec.Rooms)
+	case opAddAvailabilities:
+		return s.hotels.AddRoomAvailabilities(ctx, rec.Availability)
+	case opSetCapacity:
+		return s.hotels.SetRoomCapacity(ctx, rec.HotelID, rec.RoomType, rec.Date, rec.Rooms)
+	case opReduceCapacity:
+		return s.hotels.ReduceRoomCapacity(ctx, rec.HotelID, rec.RoomType, rec.Date, rec.Rooms)
+	case opReplaceReservation:
+		return s.hotels.ReplaceReservation(ctx, rec.Released, rec.Reserved)
+	case opTakeOrderNumber:
+		s.orders.RestoreOrderNumber(rec.OrderNumber)
+		return nil
+	case opAddOrder:
+		_, err := s.orders.AddOrder(ctx, *rec.Order)
+		return err
+	case opPutOrder:
+		_, err := s.orders.UpdateOrder(ctx, rec.Order.Number, func(order *domain.Order) error {
+			*order = *rec.Order
+			return nil
+		})
+		return err
+	case opSetOffset:
+		return s.outbox.SetOffset(ctx, rec.Subscriber, rec.Offset)
+	case opAddHold:
+		return s.holds.AddHold(ctx, *rec.Hold)
+	case opDeleteHold:
+		_, err := s.holds.DeleteHold(ctx, rec.HoldToken)
+		return err
+	case opSetRate:
+		return s.rates.SetRate(ctx, *rec.Rate)
+	case opSetCancellationPolicy:
+		return s.rates.SetCancellationPolicy(ctx, *rec.Policy)
+	case opAddPromo:
+		return s.promos.AddPromo(ctx, *rec.Promo)
+	case opRedeemPromo:
+		return s.promos.Redeem(ctx, rec.PromoCode, rec.UserID)
+	case opUnredeemPromo:
+		return s.promos.Unredeem(ctx, rec.PromoCode, rec.UserID)
+	case opAppendLoyaltyEntry:
+		_, err := s.loyalty.AppendEntry(ctx, *rec.LoyaltyEntry)
+		return err
+	case opAddWebhook:
+		_, err := s.webhooks.AddWebhook(ctx, *rec.Webhook)
+		return err
+	case opUpdateWebhook:
+		_, err := s.webhooks.UpdateWebhook(ctx, *rec.Webhook)
+		return err
+	case opDeleteWebhook:
+		return s.webhooks.DeleteWebhook(ctx, rec.WebhookID)
+	case opAddDeadLetter:
+		return s.webhooks.AddDeadLetter(ctx, *rec.DeadLetter)
+	case opSetRestriction:
+		return s.restrictions.SetRestriction(ctx, *rec.Restriction)
+	default:
+		return fmt.Errorf("unknown operation '%s'", rec.Op)
+	}
+}
+
+// writeFileAtomic replaces the file with data, so a crash leaves either the old or the new content.
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+
+	file, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	dir, err := os.Open(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+
+	return dir.Sync()
+}
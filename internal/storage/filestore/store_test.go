@@ -0,0 +1,360 @@
+package filestore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/internal/storage/memorystore"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testBooking = domain.Booking{HotelID: 1, RoomType: "single", From: date.Date(2025, 2, 1), To: date.Date(2025, 2, 2), RoomCount: 1}
+
+// fillStore makes every kind of change of the store.
+func fillStore(t *testing.T, store *Store) {
+	ctx := context.Background()
+
+	assert.NoError(t, store.AddHotel(ctx, domain.Hotel{ID: 1, Name: "Reddison"}))
+	assert.NoError(t, store.UpdateHotel(ctx, domain.Hotel{ID: 1, Name: "Reddison", City: "Moscow", Timezone: "Europe/Moscow"}))
+	assert.NoError(t, store.AddRoomType(ctx, domain.HotelRoomType{HotelID: 1, Code: "single", Name: "Single", Capacity: 1}))
+	assert.NoError(t, store.UpdateRoomType(ctx, domain.HotelRoomType{HotelID: 1, Code: "single", Name: "Single", Capacity: 2,
+		Amenities: []string{"wifi"}}))
+	assert.NoError(t, store.AddRoomAvailability(ctx, 1, "single", date.Date(2025, 2, 1), 3))
+	assert.NoError(t, store.AddRoomAvailability(ctx, 1, "single", date.Date(2025, 2, 2), 3))
+	assert.NoError(t, store.AddRoomAvailabilities(ctx, []domain.AvailabilityChange{
+		{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 2), Delta: 1},
+		{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 3), Delta: 2},
+	}))
+	assert.NoError(t, store.Reserve(ctx, []domain.Booking{testBooking}))
+	assert.NoError(t, store.SetRoomCapacity(ctx, 1, "single", date.Date(2025, 2, 3), 5))
+	assert.NoError(t, store.ReduceRoomCapacity(ctx, 1, "single", date.Date(2025, 2, 3), 1))
+
+	order, err := store.AddOrder(ctx, domain.Order{ID: "1", Status: domain.OrderStatusConfirmed, Bookings: []domain.Booking{testBooking}})
+	assert.NoError(t, err)
+
+	_, err = store.UpdateOrder(ctx, order.Number, func(order *domain.Order) error {
+		return order.ChangeStatus(domain.OrderStatusCheckedIn)
+	})
+	assert.NoError(t, err)
+
+	// the number of an order which wasn't added isn't taken again
+	_, err = store.NextOrderNumber(ctx)
+	assert.NoError(t, err)
+
+	// the events delivered to every subscriber are trimmed
+	_, err = store.GetOffset(ctx, "webhook")
+	assert.NoError(t, err)
+	_, err = store.GetOffset(ctx, "notification")
+	assert.NoError(t, err)
+	assert.NoError(t, store.SetOffset(ctx, "webhook", 2))
+	assert.NoError(t, store.SetOffset(ctx, "notification", 1))
+
+	// the rest of the booking state
+	hold := domain.Hold{Token: "hold-1", UserID: 1, Bookings: []domain.Booking{testBooking},
+		ExpiresAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	assert.NoError(t, store.AddHold(ctx, hold))
+	hold.Token = "hold-2"
+	assert.NoError(t, store.AddHold(ctx, hold))
+	_, err = store.DeleteHold(ctx, "hold-2")
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.SetRate(ctx, domain.Rate{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 1),
+		Price: domain.Money{Amount: 500000, Currency: "RUB"}}))
+	assert.NoError(t, store.SetCancellationPolicy(ctx, domain.CancellationPolicy{HotelID: 1, FreeDays: 3, PenaltyNights: 1}))
+
+	assert.NoError(t, store.AddPromo(ctx, domain.Promo{Code: "WINTER10", DiscountType: domain.DiscountTypePercent, Value: 10,
+		MaxUses: 2}))
+	assert.NoError(t, store.Redeem(ctx, "WINTER10", 1))
+	assert.NoError(t, store.Redeem(ctx, "WINTER10", 2))
+	assert.NoError(t, store.Unredeem(ctx, "WINTER10", 2))
+	assert.NoError(t, store.Redeem(ctx, "WINTER10", 3))
+
+	_, err = store.AppendEntry(ctx, domain.LoyaltyEntry{UserID: 1, OrderNumber: order.Number, Type: domain.LoyaltyEntryEarn, Points: 5})
+	assert.NoError(t, err)
+	_, err = store.AppendEntry(ctx, domain.LoyaltyEntry{UserID: 1, Type: domain.LoyaltyEntryRedeem, Points: -3})
+	assert.NoError(t, err)
+
+	webhook, err := store.AddWebhook(ctx, domain.Webhook{HotelID: 1, URL: "https://partner.example.com/hooks", Secret: "secret"})
+	assert.NoError(t, err)
+	webhook.URL = "https://partner.example.com/hooks/v2"
+	_, err = store.UpdateWebhook(ctx, *webhook)
+	assert.NoError(t, err)
+	deleted, err := store.AddWebhook(ctx, domain.Webhook{HotelID: 1, URL: "https://partner.example.com/old"})
+	assert.NoError(t, err)
+	assert.NoError(t, store.DeleteWebhook(ctx, deleted.ID))
+	assert.NoError(t, store.AddDeadLetter(ctx, domain.DeadLetter{WebhookID: webhook.ID, Attempts: 3, LastError: "timeout"}))
+
+	assert.NoError(t, store.SetRestriction(ctx, domain.Restriction{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 1),
+		MinStay: 2}))
+
+	// failed changes aren't logged
+	assert.ErrorIs(t, store.Redeem(ctx, "WINTER10", 2), domain.ErrPromoUsageLimit)
+	_, err = store.AppendEntry(ctx, domain.LoyaltyEntry{UserID: 1, Type: domain.LoyaltyEntryRedeem, Points: -10})
+	assert.ErrorIs(t, err, domain.ErrInsufficientPoints)
+	_, err = store.DeleteHold(ctx, "hold-2")
+	assert.ErrorIs(t, err, domain.ErrHoldNotFound)
+
+	assert.Error(t, store.Reserve(ctx, []domain.Booking{{HotelID: 1, RoomType: "single",
+		From: date.Date(2025, 2, 1), To: date.Date(2025, 2, 1), RoomCount: 10}}))
+	assert.Error(t, store.ReduceRoomCapacity(ctx, 1, "single", date.Date(2025, 2, 1), 10))
+}
+
+type state struct {
+	hotels       []memorystore.HotelSnapshot
+	orders       []domain.Order
+	outbox       memorystore.OutboxSnapshot
+	holds        []domain.Hold
+	rates        memorystore.RateSnapshot
+	promos       []memorystore.PromoSnapshot
+	loyalty      []domain.LoyaltyEntry
+	webhooks     memorystore.WebhookSnapshot
+	restrictions []domain.Restriction
+}
+
+func storeState(t *testing.T, store *Store) state {
+	orders, err := store.GetOrders(context.Background())
+	assert.NoError(t, err)
+
+	// the events replayed from the log get the time of the recovery
+	outbox := store.outbox.Snapshot()
+	for i := range outbox.Events {
+		outbox.Events[i].CreatedAt = time.Time{}
+	}
+
+	return state{
+		hotels:       store.hotels.Snapshot(context.Background()),
+		orders:       orders,
+		outbox:       outbox,
+		holds:        store.holds.Snapshot(),
+		rates:        store.rates.Snapshot(),
+		promos:       store.promos.Snapshot(),
+		loyalty:      store.loyalty.Snapshot(),
+		webhooks:     store.webhooks.Snapshot(),
+		restrictions: store.restrictions.Snapshot(),
+	}
+}
+
+func TestStore_Recover(t *testing.T) {
+	tests := []struct {
+		name          string
+		snapshotEvery int
+		close         bool
+	}{
+		{name: "from log", snapshotEvery: 0},
+		{name: "from snapshot and log", snapshotEvery: 2},
+		{name: "from snapshot taken on close", snapshotEvery: 0, close: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			store, err := Open(dir, tt.snapshotEvery)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			fillStore(t, store)
+			expected := storeState(t, store)
+
+			if tt.close {
+				assert.NoError(t, store.Close())
+			}
+
+			// the store isn't closed to imitate a crash
+			recovered, err := Open(dir, tt.snapshotEvery)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, expected, storeState(t, recovered))
+			assert.Equal(t, int64(1), expected.outbox.Trimmed)
+			assert.NotEmpty(t, expected.outbox.Events)
+			assert.Len(t, expected.holds, 1)
+			assert.Equal(t, 2, expected.promos[0].Uses)
+			assert.Len(t, expected.loyalty, 2)
+			assert.Equal(t, domain.WebhookID(2), expected.webhooks.LastID)
+
+			order, err := recovered.GetOrderByID(context.Background(), "1")
+			assert.NoError(t, err)
+			assert.Equal(t, domain.OrderStatusCheckedIn, order.Status)
+
+			// the next order gets the next untaken number
+			order, err = recovered.AddOrder(context.Background(), domain.Order{ID: "2"})
+			assert.NoError(t, err)
+			assert.Equal(t, domain.OrderNumber(3), order.Number)
+		})
+	}
+}
+
+func TestStore_RecoverTornRecord(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := Open(dir, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	fillStore(t, store)
+	expected := storeState(t, store)
+
+	// a crash in the middle of writing a record leaves a part of it
+	frame, err := encodeRecord(record{Seq: 100, Op: opReplaceReservation, Reserved: []domain.Booking{testBooking}})
+	assert.NoError(t, err)
+
+	wal, err := os.OpenFile(filepath.Join(dir, walFile), os.O_WRONLY|os.O_APPEND, 0o644)
+	assert.NoError(t, err)
+	_, err = wal.Write(frame[:len(frame)/2])
+	assert.NoError(t, err)
+	assert.NoError(t, wal.Close())
+
+	recovered, err := Open(dir, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, expected, storeState(t, recovered))
+
+	// the torn record is cut off, so the following records are readable
+	assert.NoError(t, recovered.Reserve(context.Background(), []domain.Booking{testBooking}))
+
+	expected = storeState(t, recovered)
+
+	recovered, err = Open(dir, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, expected, storeState(t, recovered))
+}
+
+// failingLog writes a part of the next record and fails, as a full disk does.
+type failingLog struct {
+	logFile
+	fail bool
+}
+
+func (l *failingLog) Write(p []byte) (int, error) {
+	if !l.fail {
+		return l.logFile.Write(p)
+	}
+
+	l.fail = false
+
+	n, _ := l.logFile.Write(p[:len(p)/2])
+
+	return n, errors.New("no space left on device")
+}
+
+func TestStore_RecoverAfterFailedWrite(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	store, err := Open(dir, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, store.AddHotel(ctx, domain.Hotel{ID: 1, Name: "Reddison"}))
+
+	store.wal = &failingLog{logFile: store.wal, fail: true}
+
+	assert.Error(t, store.AddHotel(ctx, domain.Hotel{ID: 2, Name: "Cosmos"}))
+	assert.NoError(t, store.AddHotel(ctx, domain.Hotel{ID: 3, Name: "Marriott"}))
+
+	// the store isn't closed to imitate a crash
+	recovered, err := Open(dir, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	hotels, err := recovered.GetHotels(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Hotel{{ID: 1, Name: "Reddison"}, {ID: 3, Name: "Marriott"}}, hotels)
+}
+
+const crashDirEnv = "FILESTORE_CRASH_DIR"
+
+// TestStore_CrashHelper books rooms until it's killed by TestStore_RecoverAfterKill.
+func TestStore_CrashHelper(t *testing.T) {
+	dir := os.Getenv(crashDirEnv)
+	if dir == "" {
+		t.Skip("run by TestStore_RecoverAfterKill")
+	}
+
+	store, err := Open(dir, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; ; i++ {
+		booking := domain.Booking{HotelID: 1, RoomType: "single", From: date.Date(2025, 2, 1), To: date.Date(2025, 2, 1), RoomCount: 1}
+
+		if err := store.Reserve(context.Background(), []domain.Booking{booking}); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := store.AddOrder(context.Background(), domain.Order{Bookings: []domain.Booking{booking}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestStore_RecoverAfterKill(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts a process")
+	}
+
+	const capacity = 1_000_000
+
+	dir := t.TempDir()
+
+	store, err := Open(dir, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, store.AddHotel(context.Background(), domain.Hotel{ID: 1}))
+	assert.NoError(t, store.AddRoomType(context.Background(), domain.HotelRoomType{HotelID: 1, Code: "single", Name: "Single", Capacity: 1}))
+	assert.NoError(t, store.AddRoomAvailability(context.Background(), 1, "single", date.Date(2025, 2, 1), capacity))
+	assert.NoError(t, store.Close())
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestStore_CrashHelper$")
+	cmd.Env = append(os.Environ(), crashDirEnv+"="+dir)
+
+	if !assert.NoError(t, cmd.Start()) {
+		return
+	}
+
+	time.Sleep(300 * time.Millisecond)
+
+	assert.NoError(t, cmd.Process.Kill())
+	_ = cmd.Wait()
+
+	recovered, err := Open(dir, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	orders, err := recovered.GetOrders(context.Background())
+	assert.NoError(t, err)
+	assert.NotEmpty(t, orders)
+
+	for i, order := range orders {
+		assert.Equal(t, domain.OrderNumber(i+1), order.Number)
+	}
+
+	snapshot := recovered.hotels.Snapshot(context.Background())
+	reserved := capacity - snapshot[0].Availability[0].Rooms
+
+	// the process may be killed between the reservation and the order
+	assert.Contains(t, []int{len(orders), len(orders) + 1}, reserved)
+}
@@ -0,0 +1,166 @@
+package filestore
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+type operation string
+
+const (
+	opAddHotel           operation = "add_hotel"
+	opUpdateHotel        operation = "update_hotel"
+	opAddRoomType        operation = "add_room_type"
+	opUpdateRoomType     operation = "update_room_type"
+	opAddAvailability    operation = "add_availability"
+	opAddAvailabilities  operation = "add_availabilities"
+	opSetCapacity        operation = "set_capacity"
+	opReduceCapacity     operation = "reduce_capacity"
+	opReplaceReservation operation = "replace_reservation"
+	opTakeOrderNumber    operation = "take_order_number"
+	opAddOrder           operation = "add_order"
+	opPutOrder           operation = "put_order"
+	opSetOffset          operation = "set_offset"
+
+	opAddHold               operation = "add_hold"
+	opDeleteHold            operation = "delete_hold"
+	opSetRate               operation = "set_rate"
+	opSetCancellationPolicy operation = "set_cancellation_policy"
+	opAddPromo              operation = "add_promo"
+	opRedeemPromo           operation = "redeem_promo"
+	opUnredeemPromo         operation = "unredeem_promo"
+	opAppendLoyaltyEntry    operation = "append_loyalty_entry"
+	opAddWebhook            operation = "add_webhook"
+	opUpdateWebhook         operation = "update_webhook"
+	opDeleteWebhook         operation = "delete_webhook"
+	opAddDeadLetter         operation = "add_dead_letter"
+	opSetRestriction        operation = "set_restriction"
+)
+
+// record is an entry of the write-ahead log. Seq grows with every record and is used to skip
+// the records already included in the snapshot.
+type record struct {
+	Seq           uint64                      `json:"seq"`
+	Op            operation                   `json:"op"`
+	Hotel         *domain.Hotel               `json:"hotel,omitempty"`
+	HotelRoomType *domain.HotelRoomType       `json:"hotel_room_type,omitempty"`
+	HotelID       domain.HotelID              `json:"hotel_id,omitempty"`
+	RoomType      domain.RoomType             `json:"room_type,omitempty"`
+	Date          time.Time                   `json:"date,omitempty"`
+	Rooms         int                         `json:"rooms,omitempty"`
+	Availability  []domain.AvailabilityChange `json:"availability,omitempty"`
+	Released      []domain.Booking            `json:"released,omitempty"`
+	Reserved      []domain.Booking            `json:"reserved,omitempty"`
+	OrderNumber   domain.OrderNumber          `json:"order_number,omitempty"`
+	Order         *domain.Order               `json:"order,omitempty"`
+	Subscriber    string                      `json:"subscriber,omitempty"`
+	Offset        int64                       `json:"offset,omitempty"`
+	Hold          *domain.Hold                `json:"hold,omitempty"`
+	HoldToken     domain.HoldToken            `json:"hold_token,omitempty"`
+	Rate          *domain.Rate                `json:"rate,omitempty"`
+	Policy        *domain.CancellationPolicy  `json:"policy,omitempty"`
+	Promo         *domain.Promo               `json:"promo,omitempty"`
+	PromoCode     domain.PromoCode            `json:"promo_code,omitempty"`
+	UserID        domain.UserID               `json:"user_id,omitempty"`
+	LoyaltyEntry  *domain.LoyaltyEntry        `json:"loyalty_entry,omitempty"`
+	Webhook       *domain.Webhook             `json:"webhook,omitempty"`
+	WebhookID     domain.WebhookID            `json:"webhook_id,omitempty"`
+	DeadLetter    *domain.DeadLetter          `json:"dead_letter,omitempty"`
+	Restriction   *domain.Restriction         `json:"restriction,omitempty"`
+}
+
+// frameHeaderSize is the size of the record length and its checksum preceding the record.
+const frameHeaderSize = 8
+
+var errTornRecord = errors.New("torn record")
+
+// encodeRecord frames the JSON of the record with its length and CRC32, so a record
+// partially written before a crash is detected on recovery.
+func encodeRecord(rec record) ([]byte, error) {
+	payload, err := json.Marshal(rec)
+	if err != nil {
+		return nil, err
+	}
+
+	frame := make([]byte, frameHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(frame[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(frame[4:8], crc32.ChecksumIEEE(payload))
+	copy(frame[frameHeaderSize:], payload)
+
+	return frame, nil
+}
+
+// readRecord reads the next record. It returns io.EOF at the end of the log
+// and errTornRecord if the rest of the log is incomplete or corrupted.
+func readRecord(r *bufio.Reader) (record, int, error) {
+	var rec record
+
+	header := make([]byte, frameHeaderSize)
+
+	n, err := io.ReadFull(r, header)
+	if err == io.EOF {
+		return rec, 0, io.EOF
+	}
+	if err != nil {
+		return rec, 0, errTornRecord
+	}
+
+	payload := make([]byte, binary.BigEndian.Uint32(header[0:4]))
+
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return rec, 0, errTornRecord
+	}
+
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(header[4:8]) {
+		return rec, 0, errTornRecord
+	}
+
+	if err := json.Unmarshal(payload, &rec); err != nil {
+		return rec, 0, errTornRecord
+	}
+
+	return rec, n + len(payload), nil
+}
+
+// readLog calls apply for every complete record of the log and cuts off the torn tail left by a crash.
+func readLog(path string, apply func(rec record) error) error {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	r := bufio.NewReader(file)
+
+	var offset int64
+
+	for {
+		rec, n, err := readRecord(r)
+		if err == io.EOF {
+			return nil
+		}
+
+		if errors.Is(err, errTornRecord) {
+			if err := file.Truncate(offset); err != nil {
+				return fmt.Errorf("failed to cut off torn record: %w", err)
+			}
+
+			return file.Sync()
+		}
+
+		if err := apply(rec); err != nil {
+			return fmt.Errorf("failed to apply record %d: %w", rec.Seq, err)
+		}
+
+		offset += int64(n)
+	}
+}
@@ -0,0 +1,82 @@
+package filestore
+
+import (
+	"context"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+// AddWebhook logs the creation time of the webhook, so it's restored as it was. The ID is given again
+// on recovery in the same order.
+func (s *Store) AddWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	if webhook.CreatedAt.IsZero() {
+		webhook.CreatedAt = time.Now().UTC()
+	}
+
+	if err := s.append(record{Op: opAddWebhook, Webhook: &webhook}); err != nil {
+		return nil, err
+	}
+
+	return s.webhooks.AddWebhook(ctx, webhook)
+}
+
+func (s *Store) GetWebhook(ctx context.Context, id domain.WebhookID) (*domain.Webhook, error) {
+	return s.webhooks.GetWebhook(ctx, id)
+}
+
+func (s *Store) GetWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	return s.webhooks.GetWebhooks(ctx)
+}
+
+func (s *Store) UpdateWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	if _, err := s.webhooks.GetWebhook(ctx, webhook.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.append(record{Op: opUpdateWebhook, Webhook: &webhook}); err != nil {
+		return nil, err
+	}
+
+	return s.webhooks.UpdateWebhook(ctx, webhook)
+}
+
+func (s *Store) DeleteWebhook(ctx context.Context, id domain.WebhookID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	if _, err := s.webhooks.GetWebhook(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.append(record{Op: opDeleteWebhook, WebhookID: id}); err != nil {
+		return err
+	}
+
+	return s.webhooks.DeleteWebhook(ctx, id)
+}
+
+func (s *Store) AddDeadLetter(ctx context.Context, deadLetter domain.DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.maybeSnapshot()
+
+	if err := s.append(record{Op: opAddDeadLetter, DeadLetter: &deadLetter}); err != nil {
+		return err
+	}
+
+	return s.webhooks.AddDeadLetter(ctx, deadLetter)
+}
+
+func (s *Store) GetDeadLetters(ctx context.Context) ([]domain.DeadLetter, error) {
+	return s.webhooks.GetDeadLetters(ctx)
+}
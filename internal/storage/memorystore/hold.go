@@ -0,0 +1,92 @@
+package memorystore
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+type HoldStore struct {
+	holds map[domain.HoldToken]*domain.Hold
+	mu    sync.Mutex
+}
+
+func NewHoldStore() *HoldStore {
+	return &HoldStore{
+		holds: make(map[domain.HoldToken]*domain.Hold),
+	}
+}
+
+func (s *HoldStore) AddHold(ctx context.Context, hold domain.Hold) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.holds[hold.Token] = &hold
+
+	return nil
+}
+
+func (s *HoldStore) GetHold(ctx context.Context, token domain.HoldToken) (*domain.Hold, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hold, ok := s.holds[token]
+	if !ok {
+		return nil, domain.ErrHoldNotFound
+	}
+
+	result := *hold
+
+	return &result, nil
+}
+
+// DeleteHold removes the hold and returns it, so only one caller can take it.
+func (s *HoldStore) DeleteHold(ctx context.Context, token domain.HoldToken) (*domain.Hold, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hold, ok := s.holds[token]
+	if !ok {
+		return nil, domain.ErrHoldNotFound
+	}
+
+	delete(s.holds, token)
+
+	return hold, nil
+}
+
+// Snapshot returns a copy of the holds ordered by token.
+func (s *HoldStore) Snapshot() []domain.Hold {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	holds := make([]domain.Hold, 0, len(s.holds))
+	for _, hold := range s.holds {
+		holds = append(holds, *hold)
+	}
+
+	sort.Slice(holds, func(i, j int) bool {
+		return holds[i].Token < holds[j].Token
+	})
+
+	return holds
+}
+
+// GetExpiredHolds returns the holds expired at the moment now.
+func (s *HoldStore) GetExpiredHolds(ctx context.Context, now time.Time) ([]domain.Hold, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var expired []domain.Hold
+
+	for _, hold := range s.holds {
+		if hold.IsExpired(now) {
+			expired = append(expired, *hold)
+		}
+	}
+
+	return expired, nil
+}
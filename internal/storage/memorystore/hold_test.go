@@ -0,0 +1,41 @@
+package memorystore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHoldStore_GetExpiredHolds(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	store := NewHoldStore()
+
+	holds := []domain.Hold{
+		{Token: "expired", ExpiresAt: now.Add(-time.Second)},
+		{Token: "expires now", ExpiresAt: now},
+		{Token: "active", ExpiresAt: now.Add(time.Second)},
+	}
+	for _, hold := range holds {
+		assert.NoError(t, store.AddHold(context.Background(), hold))
+	}
+
+	expired, err := store.GetExpiredHolds(context.Background(), now)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, holds[:2], expired)
+
+	deleted, err := store.DeleteHold(context.Background(), "expired")
+	assert.NoError(t, err)
+	assert.Equal(t, holds[0], *deleted)
+
+	_, err = store.GetHold(context.Background(), "expired")
+	assert.ErrorIs(t, err, domain.ErrHoldNotFound)
+
+	active, err := store.DeleteHold(context.Background(), "active")
+	assert.NoError(t, err)
+	assert.Equal(t, holds[2], *active)
+}
@@ -15,137 +15,650 @@ import (
 type HotelStore struct {
 	roomAvailability map[domain.HotelID]*HotelWrapper
 	mu               sync.RWMutex // lock for addition new hotel
+	outbox           *Outbox
 }
 
 type HotelWrapper struct {
 	Hotel          *domain.Hotel
-	RoomCategories map[domain.RoomType]*RoomCategory // RoomType -> RoomCategory
+	RoomTypes      map[domain.RoomType]domain.HotelRoomType // the catalog of the hotel
+	RoomCategories map[domain.RoomType]*RoomCategory        // RoomType -> RoomCategory
 	mu             sync.Mutex
 }
 
+// RoomCategory tracks the rooms added to the availability separately from the free ones,
+// the difference is reserved.
 type RoomCategory struct {
 	availability map[time.Time]int // Date -> Available Rooms
+	capacity     map[time.Time]int // Date -> Added Rooms
 	mu           sync.Mutex
 }
 
-type reservedCategories struct {
-	category  *RoomCategory
-	from      time.Time
-	to        time.Time
-	roomCount int
+func newRoomCategory() *RoomCategory {
+	return &RoomCategory{
+		availability: make(map[time.Time]int),
+		capacity:     make(map[time.Time]int),
+	}
+}
+
+// addRooms adds free rooms to the capacity of the date.
+func (c *RoomCategory) addRooms(date time.Time, capacity, rooms int) {
+	if c.capacity == nil {
+		c.capacity = make(map[time.Time]int)
+	}
+
+	c.capacity[date] += capacity
+	c.availability[date] += rooms
+}
+
+type categoryKey struct {
+	hotelID  domain.HotelID
+	roomType domain.RoomType
 }
 
-func NewHotelStore() *HotelStore {
+// NewHotelStore creates the store which records the availability changes in the outbox. The outbox may be nil.
+func NewHotelStore(outbox *Outbox) *HotelStore {
 	return &HotelStore{
 		roomAvailability: make(map[domain.HotelID]*HotelWrapper),
+		outbox:           outbox,
 	}
 }
 
-func (s *HotelStore) GetHotel(ctx context.Context, hotelID domain.HotelID) (*domain.Hotel, error) {
+// HotelSnapshot is a copy of the hotel, its room types and availability.
+type HotelSnapshot struct {
+	Hotel        domain.Hotel           `json:"hotel"`
+	RoomTypes    []domain.HotelRoomType `json:"room_types"`
+	Availability []RoomAvailability     `json:"availability"`
+}
+
+type RoomAvailability struct {
+	RoomType domain.RoomType `json:"room_type"`
+	Date     time.Time       `json:"date"`
+	Rooms    int             `json:"rooms"`
+	Capacity int             `json:"capacity"`
+}
+
+// SetOutbox replaces the outbox of the store. It's used by the stores which rebuild the state
+// from their own log and mustn't record the restored changes as new events.
+// It must be called before the store is used concurrently.
+func (s *HotelStore) SetOutbox(outbox *Outbox) {
+	s.outbox = outbox
+}
+
+// Snapshot returns a copy of the hotels, their room types and availability ordered by hotel, room type and date.
+func (s *HotelStore) Snapshot(ctx context.Context) []HotelSnapshot {
+	type hotelEntry struct {
+		hotel   domain.Hotel
+		wrapper *HotelWrapper
+	}
+
 	s.mu.RLock()
-	hotelWrapper, ok := s.roomAvailability[hotelID]
+	hotels := make([]hotelEntry, 0, len(s.roomAvailability))
+	for _, hotelWrapper := range s.roomAvailability {
+		hotels = append(hotels, hotelEntry{hotel: *hotelWrapper.Hotel, wrapper: hotelWrapper})
+	}
 	s.mu.RUnlock()
 
+	sort.Slice(hotels, func(i, j int) bool {
+		return hotels[i].hotel.ID < hotels[j].hotel.ID
+	})
+
+	snapshot := make([]HotelSnapshot, 0, len(hotels))
+
+	for _, entry := range hotels {
+		hotelSnapshot := HotelSnapshot{Hotel: entry.hotel}
+		hotelWrapper := entry.wrapper
+
+		hotelWrapper.mu.Lock()
+		hotelSnapshot.RoomTypes = sortedRoomTypes(hotelWrapper.RoomTypes)
+		for roomType, category := range hotelWrapper.RoomCategories {
+			category.mu.Lock()
+			for date, rooms := range category.availability {
+				hotelSnapshot.Availability = append(hotelSnapshot.Availability, RoomAvailability{
+					RoomType: roomType,
+					Date:     date,
+					Rooms:    rooms,
+					Capacity: category.capacity[date],
+				})
+			}
+			category.mu.Unlock()
+		}
+		hotelWrapper.mu.Unlock()
+
+		sort.Slice(hotelSnapshot.Availability, func(i, j int) bool {
+			a, b := hotelSnapshot.Availability[i], hotelSnapshot.Availability[j]
+			if a.RoomType != b.RoomType {
+				return a.RoomType < b.RoomType
+			}
+			return a.Date.Before(b.Date)
+		})
+
+		snapshot = append(snapshot, hotelSnapshot)
+	}
+
+	return snapshot
+}
+
+func (s *HotelStore) GetHotel(ctx context.Context, hotelID domain.HotelID) (*domain.Hotel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	hotelWrapper, ok := s.roomAvailability[hotelID]
 	if !ok {
 		return nil, domain.ErrHotelNotFound
 	}
 
-	return hotelWrapper.Hotel, nil
+	hotel := *hotelWrapper.Hotel
+
+	return &hotel, nil
+}
+
+// GetHotels returns all hotels ordered by id.
+func (s *HotelStore) GetHotels(ctx context.Context) ([]domain.Hotel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	hotels := make([]domain.Hotel, 0, len(s.roomAvailability))
+	for _, hotelWrapper := range s.roomAvailability {
+		hotels = append(hotels, *hotelWrapper.Hotel)
+	}
+
+	sort.Slice(hotels, func(i, j int) bool {
+		return hotels[i].ID < hotels[j].ID
+	})
+
+	return hotels, nil
 }
 
 func (s *HotelStore) AddHotel(ctx context.Context, hotel domain.Hotel) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, ok := s.roomAvailability[hotel.ID]; !ok {
-		s.roomAvailability[hotel.ID] = &HotelWrapper{
-			Hotel:          &hotel,
-			RoomCategories: make(map[domain.RoomType]*RoomCategory),
-		}
+	if _, ok := s.roomAvailability[hotel.ID]; ok {
+		return domain.ErrHotelAlreadyExists
+	}
+
+	s.roomAvailability[hotel.ID] = &HotelWrapper{
+		Hotel:          &hotel,
+		RoomTypes:      make(map[domain.RoomType]domain.HotelRoomType),
+		RoomCategories: make(map[domain.RoomType]*RoomCategory),
 	}
 
 	return nil
 }
 
-func (s *HotelStore) AddRoomAvailability(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error {
-	s.mu.RLock()
-	hotelWrapper, ok := s.roomAvailability[hotelID]
-	s.mu.RUnlock()
+// UpdateHotel replaces the hotel description, the availability of the hotel is kept.
+func (s *HotelStore) UpdateHotel(ctx context.Context, hotel domain.Hotel) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
+	hotelWrapper, ok := s.roomAvailability[hotel.ID]
 	if !ok {
 		return domain.ErrHotelNotFound
 	}
 
-	roomCat, ok := hotelWrapper.RoomCategories[roomType]
+	hotelWrapper.Hotel = &hotel
+
+	return nil
+}
+
+func (s *HotelStore) getHotelWrapper(hotelID domain.HotelID) (*HotelWrapper, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	hotelWrapper, ok := s.roomAvailability[hotelID]
 	if !ok {
-		hotelWrapper.mu.Lock()
-		hotelWrapper.RoomCategories[roomType] = &RoomCategory{
-			availability: map[time.Time]int{date: rooms},
+		return nil, domain.ErrHotelNotFound
+	}
+
+	return hotelWrapper, nil
+}
+
+// GetRoomTypes returns the room type catalog of the hotel ordered by code.
+func (s *HotelStore) GetRoomTypes(ctx context.Context, hotelID domain.HotelID) ([]domain.HotelRoomType, error) {
+	hotelWrapper, err := s.getHotelWrapper(hotelID)
+	if err != nil {
+		return nil, err
+	}
+
+	hotelWrapper.mu.Lock()
+	defer hotelWrapper.mu.Unlock()
+
+	return sortedRoomTypes(hotelWrapper.RoomTypes), nil
+}
+
+func (s *HotelStore) GetRoomType(ctx context.Context, hotelID domain.HotelID, code domain.RoomType) (*domain.HotelRoomType, error) {
+	hotelWrapper, err := s.getHotelWrapper(hotelID)
+	if err != nil {
+		return nil, err
+	}
+
+	hotelWrapper.mu.Lock()
+	defer hotelWrapper.mu.Unlock()
+
+	roomType, ok := hotelWrapper.RoomTypes[code]
+	if !ok {
+		return nil, domain.ErrRoomTypeNotFound
+	}
+
+	return copyRoomType(roomType), nil
+}
+
+// AddRoomType adds the room type to the catalog of its hotel.
+func (s *HotelStore) AddRoomType(ctx context.Context, roomType domain.HotelRoomType) error {
+	hotelWrapper, err := s.getHotelWrapper(roomType.HotelID)
+	if err != nil {
+		return err
+	}
+
+	hotelWrapper.mu.Lock()
+	defer hotelWrapper.mu.Unlock()
+
+	if _, ok := hotelWrapper.RoomTypes[roomType.Code]; ok {
+		return domain.ErrRoomTypeAlreadyExists
+	}
+
+	hotelWrapper.RoomTypes[roomType.Code] = *copyRoomType(roomType)
+
+	return nil
+}
+
+// UpdateRoomType replaces the description of the room type, its availability is kept.
+func (s *HotelStore) UpdateRoomType(ctx context.Context, roomType domain.HotelRoomType) error {
+	hotelWrapper, err := s.getHotelWrapper(roomType.HotelID)
+	if err != nil {
+		return err
+	}
+
+	hotelWrapper.mu.Lock()
+	defer hotelWrapper.mu.Unlock()
+
+	if _, ok := hotelWrapper.RoomTypes[roomType.Code]; !ok {
+		return domain.ErrRoomTypeNotFound
+	}
+
+	hotelWrapper.RoomTypes[roomType.Code] = *copyRoomType(roomType)
+
+	return nil
+}
+
+func copyRoomType(roomType domain.HotelRoomType) *domain.HotelRoomType {
+	roomType.Amenities = append([]string(nil), roomType.Amenities...)
+	return &roomType
+}
+
+func sortedRoomTypes(catalog map[domain.RoomType]domain.HotelRoomType) []domain.HotelRoomType {
+	roomTypes := make([]domain.HotelRoomType, 0, len(catalog))
+	for _, roomType := range catalog {
+		roomTypes = append(roomTypes, *copyRoomType(roomType))
+	}
+
+	sort.Slice(roomTypes, func(i, j int) bool {
+		return roomTypes[i].Code < roomTypes[j].Code
+	})
+
+	return roomTypes
+}
+
+// GetRoomAvailability returns the availability of the hotel from one date to another inclusive
+// ordered by room type and date. The dates without availability are omitted.
+func (s *HotelStore) GetRoomAvailability(ctx context.Context, hotelID domain.HotelID, from, to time.Time) ([]domain.RoomAvailability, error) {
+	hotelWrapper, err := s.getHotelWrapper(hotelID)
+	if err != nil {
+		return nil, err
+	}
+
+	hotelWrapper.mu.Lock()
+	categories := make(map[domain.RoomType]*RoomCategory, len(hotelWrapper.RoomCategories))
+	for roomType, category := range hotelWrapper.RoomCategories {
+		categories[roomType] = category
+	}
+	hotelWrapper.mu.Unlock()
+
+	availability := []domain.RoomAvailability{}
+
+	for roomType, category := range categories {
+		category.mu.Lock()
+		for date, rooms := range category.availability {
+			if !date.Before(from) && !date.After(to) {
+				availability = append(availability, domain.RoomAvailability{
+					HotelID:  hotelID,
+					RoomType: roomType,
+					Date:     date,
+					Rooms:    rooms,
+					Capacity: category.capacity[date],
+				})
+			}
+		}
+		category.mu.Unlock()
+	}
+
+	sort.Slice(availability, func(i, j int) bool {
+		if availability[i].RoomType != availability[j].RoomType {
+			return availability[i].RoomType < availability[j].RoomType
 		}
+		return availability[i].Date.Before(availability[j].Date)
+	})
+
+	return availability, nil
+}
+
+// AddRoomAvailability adds rooms of the room type from the catalog of the hotel.
+func (s *HotelStore) AddRoomAvailability(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error {
+	hotelWrapper, err := s.getHotelWrapper(hotelID)
+	if err != nil {
+		return err
+	}
+
+	change := domain.Event{
+		Type:         domain.EventAvailabilityChanged,
+		Availability: []domain.AvailabilityChange{{HotelID: hotelID, RoomType: roomType, Date: date, Delta: rooms}},
+	}
+
+	hotelWrapper.mu.Lock()
+
+	if _, ok := hotelWrapper.RoomTypes[roomType]; !ok {
 		hotelWrapper.mu.Unlock()
+		return domain.ErrRoomTypeNotFound
+	}
 
-		return nil
+	roomCat, ok := hotelWrapper.RoomCategories[roomType]
+	if !ok {
+		roomCat = newRoomCategory()
+		hotelWrapper.RoomCategories[roomType] = roomCat
 	}
 
+	hotelWrapper.mu.Unlock()
+
 	roomCat.mu.Lock()
-	roomCat.availability[date] += rooms
+	roomCat.addRooms(date, rooms, rooms)
+	s.outbox.append(change)
 	roomCat.mu.Unlock()
 
 	return nil
 }
 
+// AddRoomAvailabilities adds the rooms of the changes at once. If a hotel or a room type isn't found,
+// nothing is changed.
+func (s *HotelStore) AddRoomAvailabilities(ctx context.Context, changes []domain.AvailabilityChange) error {
+	keys := make([]domain.Booking, 0, len(changes))
+	for _, change := range changes {
+		keys = append(keys, domain.Booking{HotelID: change.HotelID, RoomType: change.RoomType})
+	}
+
+	categories, unlock, err := s.lockCategories(keys)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	for _, change := range changes {
+		categories[categoryKey{hotelID: change.HotelID, roomType: change.RoomType}].addRooms(change.Date, change.Delta, change.Delta)
+	}
+
+	if len(changes) > 0 {
+		changes = append([]domain.AvailabilityChange(nil), changes...)
+		sortAvailabilityChanges(changes)
+		s.outbox.append(domain.Event{Type: domain.EventAvailabilityChanged, Availability: changes})
+	}
+
+	return nil
+}
+
+// SetRoomCapacity sets the number of rooms of the room type on the date. The reserved rooms are kept,
+// so the capacity can't be less than them.
+func (s *HotelStore) SetRoomCapacity(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, capacity int) error {
+	return s.SetRoomCapacityLogged(ctx, hotelID, roomType, date, capacity, nil)
+}
+
+// SetRoomCapacityLogged is SetRoomCapacity which calls log once the change is checked and makes it only if log
+// succeeds. It's used by the stores which log the changes before they're made.
+func (s *HotelStore) SetRoomCapacityLogged(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time,
+	capacity int, log func() error) error {
+	return s.changeCapacity(hotelID, roomType, date, func(int) int {
+		return capacity
+	}, log)
+}
+
+// ReduceRoomCapacity takes the rooms of the room type on the date out of the capacity. Only free rooms can be taken.
+func (s *HotelStore) ReduceRoomCapacity(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error {
+	return s.ReduceRoomCapacityLogged(ctx, hotelID, roomType, date, rooms, nil)
+}
+
+// ReduceRoomCapacityLogged is ReduceRoomCapacity which calls log once the change is checked and makes it only
+// if log succeeds.
+func (s *HotelStore) ReduceRoomCapacityLogged(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time,
+	rooms int, log func() error) error {
+	return s.changeCapacity(hotelID, roomType, date, func(capacity int) int {
+		return capacity - rooms
+	}, log)
+}
+
+// changeCapacity replaces the capacity of the date with the one returned by newCapacity keeping the reserved rooms.
+// log, if any, is called before the change is made.
+func (s *HotelStore) changeCapacity(hotelID domain.HotelID, roomType domain.RoomType, date time.Time, newCapacity func(capacity int) int,
+	log func() error) error {
+	categories, unlock, err := s.lockCategories([]domain.Booking{{HotelID: hotelID, RoomType: roomType}})
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	category := categories[categoryKey{hotelID: hotelID, roomType: roomType}]
+
+	capacity := newCapacity(category.capacity[date])
+	reserved := max(category.capacity[date]-category.availability[date], 0)
+
+	if capacity < reserved {
+		return &domain.CapacityBelowReservedError{RoomType: roomType, Date: date, Capacity: capacity, Reserved: reserved}
+	}
+
+	if log != nil {
+		if err := log(); err != nil {
+			return err
+		}
+	}
+
+	delta := capacity - reserved - category.availability[date]
+	category.addRooms(date, capacity-category.capacity[date], delta)
+
+	if delta != 0 {
+		s.outbox.append(domain.Event{
+			Type:         domain.EventAvailabilityChanged,
+			Availability: []domain.AvailabilityChange{{HotelID: hotelID, RoomType: roomType, Date: date, Delta: delta}},
+		})
+	}
+
+	return nil
+}
+
+// RestoreRoomAvailability sets the capacity and the free rooms of the room type on the date
+// without recording an event. It's used by the stores which rebuild the state from their own snapshot.
+func (s *HotelStore) RestoreRoomAvailability(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, capacity, rooms int) error {
+	categories, unlock, err := s.lockCategories([]domain.Booking{{HotelID: hotelID, RoomType: roomType}})
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	category := categories[categoryKey{hotelID: hotelID, roomType: roomType}]
+	category.capacity[date] = capacity
+	category.availability[date] = rooms
+
+	return nil
+}
+
 func (s *HotelStore) Reserve(ctx context.Context, bookings []domain.Booking) error {
-	var lockedCategories []reservedCategories
+	return s.ReplaceReservation(ctx, nil, bookings)
+}
+
+// Release returns the nights of the bookings back to the availability. It's the inverse of Reserve.
+func (s *HotelStore) Release(ctx context.Context, bookings []domain.Booking) error {
+	return s.ReplaceReservation(ctx, bookings, nil)
+}
+
+// ReplaceReservation releases the nights of the released bookings and reserves the nights of the reserved ones
+// in one critical section. If the reserved bookings don't fit, nothing is changed.
+func (s *HotelStore) ReplaceReservation(ctx context.Context, released, reserved []domain.Booking) error {
+	return s.ReplaceReservationLogged(ctx, released, reserved, nil)
+}
+
+// ReplaceReservationLogged is ReplaceReservation which calls log once the reserved bookings are known to fit
+// and makes the change only if log succeeds. It's used by the stores which log the changes before they're made.
+func (s *HotelStore) ReplaceReservationLogged(ctx context.Context, released, reserved []domain.Booking, log func() error) error {
+	all := make([]domain.Booking, 0, len(released)+len(reserved))
+	all = append(all, released...)
+	all = append(all, reserved...)
+
+	categories, unlock, err := s.lockCategories(all)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
-	defer func() {
-		for _, reserve := range lockedCategories {
-			reserve.category.mu.Unlock()
+	demand := bookingDemand(reserved)
+	for key, dates := range bookingDemand(released) {
+		if _, ok := demand[key]; !ok {
+			demand[key] = make(map[time.Time]int)
 		}
-	}()
 
-	// resolving deadlocks
-	sort.Slice(bookings, func(i, j int) bool {
-		return bookings[i].HotelID < bookings[j].HotelID
-	})
+		for date, rooms := range dates {
+			demand[key][date] -= rooms
+		}
+	}
 
 	// checking availability
+	for key, dates := range demand {
+		category := categories[key]
+
+		for date, rooms := range dates {
+			if rooms > 0 && category.availability[date] < rooms {
+				return fmt.Errorf("%w: room '%s' not available in hotel id=%v for all requested dates",
+					domain.ErrRoomsNotAvailable, key.roomType, key.hotelID)
+			}
+		}
+	}
+
+	if log != nil {
+		if err := log(); err != nil {
+			return err
+		}
+	}
+
+	// change availability
+	var changes []domain.AvailabilityChange
+
+	for key, dates := range demand {
+		for date, rooms := range dates {
+			if rooms != 0 {
+				categories[key].availability[date] -= rooms
+
+				changes = append(changes, domain.AvailabilityChange{
+					HotelID:  key.hotelID,
+					RoomType: key.roomType,
+					Date:     date,
+					Delta:    -rooms,
+				})
+			}
+		}
+	}
+
+	if len(changes) > 0 {
+		sortAvailabilityChanges(changes)
+		s.outbox.append(domain.Event{Type: domain.EventAvailabilityChanged, Availability: changes})
+	}
+
+	return nil
+}
+
+func sortAvailabilityChanges(changes []domain.AvailabilityChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].HotelID != changes[j].HotelID {
+			return changes[i].HotelID < changes[j].HotelID
+		}
+		if changes[i].RoomType != changes[j].RoomType {
+			return changes[i].RoomType < changes[j].RoomType
+		}
+		return changes[i].Date.Before(changes[j].Date)
+	})
+}
+
+// lockCategories locks the room categories of the bookings in a stable order to avoid deadlocks.
+// The returned function unlocks them.
+func (s *HotelStore) lockCategories(bookings []domain.Booking) (map[categoryKey]*RoomCategory, func(), error) {
+	keys := make([]categoryKey, 0, len(bookings))
 	for _, booking := range bookings {
+		keys = append(keys, categoryKey{hotelID: booking.HotelID, roomType: booking.RoomType})
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].hotelID != keys[j].hotelID {
+			return keys[i].hotelID < keys[j].hotelID
+		}
+		return keys[i].roomType < keys[j].roomType
+	})
+
+	categories := make(map[categoryKey]*RoomCategory, len(keys))
+	locked := make([]*RoomCategory, 0, len(keys))
+
+	unlock := func() {
+		for _, category := range locked {
+			category.mu.Unlock()
+		}
+	}
+
+	for _, key := range keys {
+		if _, ok := categories[key]; ok {
+			continue
+		}
+
 		s.mu.RLock()
-		hotelWrapper, ok := s.roomAvailability[booking.HotelID]
+		hotelWrapper, ok := s.roomAvailability[key.hotelID]
 		s.mu.RUnlock()
 
 		if !ok {
-			return domain.ErrHotelNotFound
+			unlock()
+			return nil, nil, domain.ErrHotelNotFound
 		}
 
-		category, ok := hotelWrapper.RoomCategories[booking.RoomType]
+		hotelWrapper.mu.Lock()
+		category, ok := hotelWrapper.RoomCategories[key.roomType]
+		if _, inCatalog := hotelWrapper.RoomTypes[key.roomType]; !ok && inCatalog {
+			// the room type has no availability yet
+			category = newRoomCategory()
+			hotelWrapper.RoomCategories[key.roomType] = category
+			ok = true
+		}
+		hotelWrapper.mu.Unlock()
+
 		if !ok {
-			return domain.ErrRoomTypeNotFound
+			unlock()
+			return nil, nil, domain.ErrRoomTypeNotFound
 		}
 
 		category.mu.Lock()
 
-		lockedCategories = append(lockedCategories, reservedCategories{
-			category:  category,
-			from:      booking.From,
-			to:        booking.To,
-			roomCount: booking.RoomCount,
-		})
+		categories[key] = category
+		locked = append(locked, category)
+	}
 
-		for date := booking.From; !date.After(booking.To); date = date.AddDate(0, 0, 1) {
-			if category.availability[date] < booking.RoomCount {
-				return fmt.Errorf("%w: room '%s' not available in hotel id=%v for all requested dates",
-					domain.ErrRoomsNotAvailable, booking.RoomType, booking.HotelID)
-			}
+	return categories, unlock, nil
+}
+
+// bookingDemand sums the requested rooms per category and date,
+// so several bookings of the same category are checked together.
+func bookingDemand(bookings []domain.Booking) map[categoryKey]map[time.Time]int {
+	demand := make(map[categoryKey]map[time.Time]int)
+
+	for _, booking := range bookings {
+		key := categoryKey{hotelID: booking.HotelID, roomType: booking.RoomType}
+		if _, ok := demand[key]; !ok {
+			demand[key] = make(map[time.Time]int)
 		}
-	}
 
-	// decrease availability
-	for _, reserve := range lockedCategories {
-		for date := reserve.from; !date.After(reserve.to); date = date.AddDate(0, 0, 1) {
-			reserve.category.availability[date] -= reserve.roomCount
+		for date := booking.From; !date.After(booking.To); date = date.AddDate(0, 0, 1) {
+			demand[key][date] += booking.RoomCount
 		}
 	}
 
-	return nil
+	return demand
 }
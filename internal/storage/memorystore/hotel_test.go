@@ -244,11 +244,49 @@ func TestHotelStore_Reserve(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "several bookings of the same room type are checked together",
+			roomType: "single",
+			setupBookings: func(roomType domain.RoomType) []domain.Booking {
+				return []domain.Booking{
+					{HotelID: 1, RoomType: roomType, From: testDate, To: testDate, RoomCount: 1},
+					{HotelID: 1, RoomType: roomType, From: testDate, To: testDate, RoomCount: 2},
+				}
+			},
+			expectedError: fmt.Errorf("room 'single' not available in hotel id=1 for all requested dates"),
+			setupHotelStore: func(s *HotelStore) {
+				hotel := &domain.Hotel{
+					ID:   1,
+					Name: "Hotel A",
+				}
+				roomCategory := &RoomCategory{
+					availability: map[time.Time]int{
+						testDate: 2,
+					},
+				}
+				hotelWrapper := &HotelWrapper{
+					Hotel: hotel,
+					RoomCategories: map[domain.RoomType]*RoomCategory{
+						"single": roomCategory,
+					},
+				}
+				s.roomAvailability[1] = hotelWrapper
+			},
+			expectedAvailability: []availability{
+				{
+					rooms: map[time.Time]int{
+						testDate: 2,
+					},
+					roomType: "single",
+					hotelID:  1,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			store := NewHotelStore()
+			store := NewHotelStore(nil)
 
 			tt.setupHotelStore(store)
 
@@ -273,3 +311,191 @@ func TestHotelStore_Reserve(t *testing.T) {
 		})
 	}
 }
+
+func TestHotelStore_Release(t *testing.T) {
+	testDate := date.Date(2025, 1, 1)
+
+	tests := []struct {
+		name                 string
+		bookings             []domain.Booking
+		expectedError        error
+		expectedAvailability map[time.Time]int
+	}{
+		{
+			name: "successfully release",
+			bookings: []domain.Booking{
+				{HotelID: 1, RoomType: "single", From: testDate, To: testDate.AddDate(0, 0, 1), RoomCount: 2},
+			},
+			expectedAvailability: map[time.Time]int{
+				testDate:                  2,
+				testDate.AddDate(0, 0, 1): 3,
+			},
+		},
+		{
+			name: "several bookings of the same room type",
+			bookings: []domain.Booking{
+				{HotelID: 1, RoomType: "single", From: testDate, To: testDate, RoomCount: 1},
+				{HotelID: 1, RoomType: "single", From: testDate, To: testDate.AddDate(0, 0, 1), RoomCount: 1},
+			},
+			expectedAvailability: map[time.Time]int{
+				testDate:                  2,
+				testDate.AddDate(0, 0, 1): 2,
+			},
+		},
+		{
+			name: "hotel is not found",
+			bookings: []domain.Booking{
+				{HotelID: 1, RoomType: "single", From: testDate, To: testDate, RoomCount: 1},
+				{HotelID: 2, RoomType: "single", From: testDate, To: testDate, RoomCount: 1},
+			},
+			expectedError: domain.ErrHotelNotFound,
+			expectedAvailability: map[time.Time]int{
+				testDate:                  0,
+				testDate.AddDate(0, 0, 1): 1,
+			},
+		},
+		{
+			name: "room type is not found",
+			bookings: []domain.Booking{
+				{HotelID: 1, RoomType: "single", From: testDate, To: testDate, RoomCount: 1},
+				{HotelID: 1, RoomType: "double", From: testDate, To: testDate, RoomCount: 1},
+			},
+			expectedError: domain.ErrRoomTypeNotFound,
+			expectedAvailability: map[time.Time]int{
+				testDate:                  0,
+				testDate.AddDate(0, 0, 1): 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewHotelStore(nil)
+
+			category := &RoomCategory{
+				availability: map[time.Time]int{
+					testDate:                  0,
+					testDate.AddDate(0, 0, 1): 1,
+				},
+			}
+			store.roomAvailability[1] = &HotelWrapper{
+				Hotel: &domain.Hotel{ID: 1, Name: "Hotel A"},
+				RoomCategories: map[domain.RoomType]*RoomCategory{
+					"single": category,
+				},
+			}
+
+			err := store.Release(context.Background(), tt.bookings)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectedAvailability, category.availability)
+		})
+	}
+}
+
+func TestHotelStore_ReplaceReservation(t *testing.T) {
+	testDate := date.Date(2025, 1, 1)
+
+	tests := []struct {
+		name                 string
+		released             []domain.Booking
+		reserved             []domain.Booking
+		expectedError        error
+		expectedAvailability map[time.Time]int
+	}{
+		{
+			name: "shift stay by a day",
+			released: []domain.Booking{
+				{HotelID: 1, RoomType: "single", From: testDate, To: testDate.AddDate(0, 0, 1), RoomCount: 1},
+			},
+			reserved: []domain.Booking{
+				{HotelID: 1, RoomType: "single", From: testDate.AddDate(0, 0, 1), To: testDate.AddDate(0, 0, 2), RoomCount: 1},
+			},
+			expectedAvailability: map[time.Time]int{
+				testDate:                  1,
+				testDate.AddDate(0, 0, 1): 0,
+				testDate.AddDate(0, 0, 2): 0,
+			},
+		},
+		{
+			name: "released nights can be reserved again",
+			released: []domain.Booking{
+				{HotelID: 1, RoomType: "single", From: testDate, To: testDate.AddDate(0, 0, 1), RoomCount: 1},
+			},
+			reserved: []domain.Booking{
+				{HotelID: 1, RoomType: "single", From: testDate.AddDate(0, 0, 1), To: testDate.AddDate(0, 0, 1), RoomCount: 1},
+			},
+			expectedAvailability: map[time.Time]int{
+				testDate:                  1,
+				testDate.AddDate(0, 0, 1): 0,
+				testDate.AddDate(0, 0, 2): 1,
+			},
+		},
+		{
+			name: "add one more room",
+			released: []domain.Booking{
+				{HotelID: 1, RoomType: "single", From: testDate, To: testDate, RoomCount: 1},
+			},
+			reserved: []domain.Booking{
+				{HotelID: 1, RoomType: "single", From: testDate, To: testDate, RoomCount: 2},
+			},
+			expectedError: fmt.Errorf("room 'single' not available in hotel id=1 for all requested dates"),
+			expectedAvailability: map[time.Time]int{
+				testDate:                  0,
+				testDate.AddDate(0, 0, 1): 0,
+				testDate.AddDate(0, 0, 2): 1,
+			},
+		},
+		{
+			name: "new room type is not found",
+			released: []domain.Booking{
+				{HotelID: 1, RoomType: "single", From: testDate, To: testDate, RoomCount: 1},
+			},
+			reserved: []domain.Booking{
+				{HotelID: 1, RoomType: "double", From: testDate, To: testDate, RoomCount: 1},
+			},
+			expectedError: domain.ErrRoomTypeNotFound,
+			expectedAvailability: map[time.Time]int{
+				testDate:                  0,
+				testDate.AddDate(0, 0, 1): 0,
+				testDate.AddDate(0, 0, 2): 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewHotelStore(nil)
+
+			category := &RoomCategory{
+				availability: map[time.Time]int{
+					testDate:                  0,
+					testDate.AddDate(0, 0, 1): 0,
+					testDate.AddDate(0, 0, 2): 1,
+				},
+			}
+			store.roomAvailability[1] = &HotelWrapper{
+				Hotel: &domain.Hotel{ID: 1, Name: "Hotel A"},
+				RoomCategories: map[domain.RoomType]*RoomCategory{
+					"single": category,
+				},
+			}
+
+			err := store.ReplaceReservation(context.Background(), tt.released, tt.reserved)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectedAvailability, category.availability)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package memorystore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+// LoyaltyStore is an append-only ledger of loyalty points. Balances are derived from the entries.
+type LoyaltyStore struct {
+	entries  []domain.LoyaltyEntry
+	balances map[domain.UserID]int64
+	mu       sync.RWMutex
+}
+
+func NewLoyaltyStore() *LoyaltyStore {
+	return &LoyaltyStore{
+		balances: make(map[domain.UserID]int64),
+	}
+}
+
+// AppendEntry adds the entry to the ledger. A redeem entry is rejected if the user hasn't enough points.
+// The creation time is set unless it's already known, e.g. when the entry is restored.
+func (s *LoyaltyStore) AppendEntry(ctx context.Context, entry domain.LoyaltyEntry) (*domain.LoyaltyEntry, error) {
+	return s.AppendEntryLogged(ctx, entry, nil)
+}
+
+// AppendEntryLogged is AppendEntry which calls log once the balance is checked and adds the entry only
+// if log succeeds. It's used by the stores which log the changes before they're made.
+func (s *LoyaltyStore) AppendEntryLogged(ctx context.Context, entry domain.LoyaltyEntry, log func() error) (*domain.LoyaltyEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	balance := s.balances[entry.UserID]
+
+	if entry.Type == domain.LoyaltyEntryRedeem && balance+entry.Points < 0 {
+		return nil, fmt.Errorf("%w: balance is %d, requested %d", domain.ErrInsufficientPoints, balance, -entry.Points)
+	}
+
+	if log != nil {
+		if err := log(); err != nil {
+			return nil, err
+		}
+	}
+
+	entry.ID = int64(len(s.entries) + 1)
+
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	s.entries = append(s.entries, entry)
+	s.balances[entry.UserID] = balance + entry.Points
+
+	return &entry, nil
+}
+
+func (s *LoyaltyStore) GetBalance(ctx context.Context, userID domain.UserID) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.balances[userID], nil
+}
+
+func (s *LoyaltyStore) GetUserEntries(ctx context.Context, userID domain.UserID) ([]domain.LoyaltyEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := []domain.LoyaltyEntry{}
+
+	for _, entry := range s.entries {
+		if entry.UserID == userID {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+func (s *LoyaltyStore) GetOrderEntries(ctx context.Context, orderNumber domain.OrderNumber) ([]domain.LoyaltyEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var entries []domain.LoyaltyEntry
+
+	for _, entry := range s.entries {
+		if entry.OrderNumber == orderNumber {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// Snapshot returns a copy of the ledger in the order of the entries.
+func (s *LoyaltyStore) Snapshot() []domain.LoyaltyEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]domain.LoyaltyEntry{}, s.entries...)
+}
@@ -0,0 +1,68 @@
+package memorystore
+
+import (
+	"context"
+	"testing"
+
+	"applicationDesignTest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoyaltyStore_AppendEntry(t *testing.T) {
+	tests := []struct {
+		name            string
+		entries         []domain.LoyaltyEntry
+		expectedErrors  []error
+		expectedBalance int64
+	}{
+		{
+			name: "earn and redeem",
+			entries: []domain.LoyaltyEntry{
+				{UserID: 1, OrderNumber: 1, Type: domain.LoyaltyEntryEarn, Points: 10},
+				{UserID: 1, OrderNumber: 2, Type: domain.LoyaltyEntryRedeem, Points: -4},
+			},
+			expectedErrors:  []error{nil, nil},
+			expectedBalance: 6,
+		},
+		{
+			name: "insufficient points",
+			entries: []domain.LoyaltyEntry{
+				{UserID: 1, OrderNumber: 1, Type: domain.LoyaltyEntryEarn, Points: 3},
+				{UserID: 1, OrderNumber: 2, Type: domain.LoyaltyEntryRedeem, Points: -4},
+			},
+			expectedErrors:  []error{nil, domain.ErrInsufficientPoints},
+			expectedBalance: 3,
+		},
+		{
+			name: "reversal of redeemed points",
+			entries: []domain.LoyaltyEntry{
+				{UserID: 1, OrderNumber: 1, Type: domain.LoyaltyEntryEarn, Points: 5},
+				{UserID: 1, OrderNumber: 2, Type: domain.LoyaltyEntryRedeem, Points: -5},
+				{UserID: 1, OrderNumber: 2, Type: domain.LoyaltyEntryReversal, Points: 5},
+			},
+			expectedErrors:  []error{nil, nil, nil},
+			expectedBalance: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewLoyaltyStore()
+
+			for i, entry := range tt.entries {
+				_, err := store.AppendEntry(context.Background(), entry)
+
+				if tt.expectedErrors[i] != nil {
+					assert.ErrorIs(t, err, tt.expectedErrors[i])
+				} else {
+					assert.NoError(t, err)
+				}
+			}
+
+			balance, err := store.GetBalance(context.Background(), 1)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedBalance, balance)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package memorystore
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -17,28 +18,74 @@ type OrderStore struct {
 	numMu          sync.RWMutex
 
 	maxOrderNumber atomic.Int64
+
+	outbox *Outbox
 }
 
-func NewOrderStore() *OrderStore {
+// NewOrderStore creates the store which records the order events in the outbox. The outbox may be nil.
+func NewOrderStore(outbox *Outbox) *OrderStore {
 	return &OrderStore{
 		ordersByID:     make(map[domain.OrderID]*domain.Order),
 		ordersByNumber: make(map[domain.OrderNumber]*domain.Order),
+		outbox:         outbox,
 	}
 }
 
-func (s *OrderStore) AddOrder(ctx context.Context, order domain.Order) (*domain.Order, error) {
-	order.Number = domain.OrderNumber(s.maxOrderNumber.Add(1))
-	order.CreatedAt = time.Now()
+// SetOutbox replaces the outbox of the store. It's used by the stores which rebuild the state
+// from their own log and mustn't record the restored changes as new events.
+// It must be called before the store is used concurrently.
+func (s *OrderStore) SetOutbox(outbox *Outbox) {
+	s.outbox = outbox
+}
 
+// NextOrderNumber takes the next order number, so the order can be referred to before it's added.
+func (s *OrderStore) NextOrderNumber(ctx context.Context) (domain.OrderNumber, error) {
+	return domain.OrderNumber(s.maxOrderNumber.Add(1)), nil
+}
+
+// LastOrderNumber returns the last taken order number.
+func (s *OrderStore) LastOrderNumber() domain.OrderNumber {
+	return domain.OrderNumber(s.maxOrderNumber.Load())
+}
+
+// RestoreOrderNumber marks the numbers up to number as taken, e.g. when the store is restored.
+func (s *OrderStore) RestoreOrderNumber(number domain.OrderNumber) {
+	for {
+		last := s.maxOrderNumber.Load()
+		if int64(number) <= last || s.maxOrderNumber.CompareAndSwap(last, int64(number)) {
+			return
+		}
+	}
+}
+
+// AddOrder keeps the number taken by NextOrderNumber or assigns the next one to the order. The creation
+// time is set unless it's already known, e.g. when the order is restored.
+func (s *OrderStore) AddOrder(ctx context.Context, order domain.Order) (*domain.Order, error) {
 	s.idMu.Lock()
 	defer s.idMu.Unlock()
 
+	if _, ok := s.ordersByID[order.ID]; ok {
+		return nil, domain.ErrOrderAlreadyExists
+	}
+
+	if order.Number == 0 {
+		order.Number = domain.OrderNumber(s.maxOrderNumber.Add(1))
+	} else {
+		s.RestoreOrderNumber(order.Number)
+	}
+
+	if order.CreatedAt.IsZero() {
+		order.CreatedAt = time.Now()
+	}
+
 	s.numMu.Lock()
 	defer s.numMu.Unlock()
 
 	s.ordersByID[order.ID] = &order
 	s.ordersByNumber[order.Number] = &order
 
+	s.outbox.append(domain.OrderEvents(nil, order)...)
+
 	return &order, nil
 }
 
@@ -65,3 +112,51 @@ func (s *OrderStore) GetOrderByNumber(ctx context.Context, orderNumber domain.Or
 
 	return order, nil
 }
+
+// GetOrders returns all orders ordered by number.
+func (s *OrderStore) GetOrders(ctx context.Context) ([]domain.Order, error) {
+	s.numMu.RLock()
+	defer s.numMu.RUnlock()
+
+	orders := make([]domain.Order, 0, len(s.ordersByNumber))
+	for _, order := range s.ordersByNumber {
+		orders = append(orders, *order)
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].Number < orders[j].Number
+	})
+
+	return orders, nil
+}
+
+// UpdateOrder applies update to a copy of the order and stores the result if update succeeds.
+// The order is locked while update runs, so it can be used for check-and-set changes.
+func (s *OrderStore) UpdateOrder(ctx context.Context, orderNumber domain.OrderNumber, update func(order *domain.Order) error) (*domain.Order, error) {
+	s.idMu.Lock()
+	defer s.idMu.Unlock()
+
+	s.numMu.Lock()
+	defer s.numMu.Unlock()
+
+	current, ok := s.ordersByNumber[orderNumber]
+	if !ok {
+		return nil, domain.ErrOrderNotFound
+	}
+
+	updated := current.Clone()
+	if err := update(&updated); err != nil {
+		return nil, err
+	}
+
+	// identifiers can't be changed
+	updated.ID = current.ID
+	updated.Number = current.Number
+
+	s.ordersByID[updated.ID] = &updated
+	s.ordersByNumber[updated.Number] = &updated
+
+	s.outbox.append(domain.OrderEvents(current, updated)...)
+
+	return &updated, nil
+}
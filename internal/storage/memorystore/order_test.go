@@ -2,6 +2,7 @@ package memorystore
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -11,6 +12,8 @@ import (
 )
 
 func TestOrderStore_AddOrder(t *testing.T) {
+	now := time.Now()
+
 	tests := []struct {
 		name          string
 		orders        []domain.Order
@@ -23,7 +26,7 @@ func TestOrderStore_AddOrder(t *testing.T) {
 				{
 					ID: "1",
 					Bookings: []domain.Booking{
-						{HotelID: 101, RoomType: "single", From: time.Now(), To: time.Now().Add(2 * time.Hour), RoomCount: 1},
+						{HotelID: 101, RoomType: "single", From: now, To: now.Add(2 * time.Hour), RoomCount: 1},
 					},
 				},
 			},
@@ -31,7 +34,7 @@ func TestOrderStore_AddOrder(t *testing.T) {
 				ID:     "1",
 				Number: 1,
 				Bookings: []domain.Booking{
-					{HotelID: 101, RoomType: "single", From: time.Now(), To: time.Now().Add(2 * time.Hour), RoomCount: 1},
+					{HotelID: 101, RoomType: "single", From: now, To: now.Add(2 * time.Hour), RoomCount: 1},
 				},
 			},
 			expectedError: nil,
@@ -42,13 +45,13 @@ func TestOrderStore_AddOrder(t *testing.T) {
 				{
 					ID: "1",
 					Bookings: []domain.Booking{
-						{HotelID: 101, RoomType: "single", From: time.Now(), To: time.Now().Add(2 * time.Hour), RoomCount: 1},
+						{HotelID: 101, RoomType: "single", From: now, To: now.Add(2 * time.Hour), RoomCount: 1},
 					},
 				},
 				{
 					ID: "2",
 					Bookings: []domain.Booking{
-						{HotelID: 102, RoomType: "double", From: time.Now(), To: time.Now().Add(2 * time.Hour), RoomCount: 2},
+						{HotelID: 102, RoomType: "double", From: now, To: now.Add(2 * time.Hour), RoomCount: 2},
 					},
 				},
 			},
@@ -56,7 +59,7 @@ func TestOrderStore_AddOrder(t *testing.T) {
 				ID:     "2",
 				Number: 2,
 				Bookings: []domain.Booking{
-					{HotelID: 102, RoomType: "double", From: time.Now(), To: time.Now().Add(2 * time.Hour), RoomCount: 2},
+					{HotelID: 102, RoomType: "double", From: now, To: now.Add(2 * time.Hour), RoomCount: 2},
 				},
 			},
 			expectedError: nil,
@@ -65,7 +68,7 @@ func TestOrderStore_AddOrder(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			store := NewOrderStore()
+			store := NewOrderStore(nil)
 
 			var (
 				result *domain.Order
@@ -98,3 +101,86 @@ func TestOrderStore_AddOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestOrderStore_UpdateOrder(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name           string
+		orderNumber    domain.OrderNumber
+		update         func(order *domain.Order) error
+		expectedStatus domain.OrderStatus
+		expectedError  error
+	}{
+		{
+			name:        "should update order",
+			orderNumber: 1,
+			update: func(order *domain.Order) error {
+				order.Status = domain.OrderStatusCancelled
+				return nil
+			},
+			expectedStatus: domain.OrderStatusCancelled,
+		},
+		{
+			name:        "should keep order unchanged if update fails",
+			orderNumber: 1,
+			update: func(order *domain.Order) error {
+				order.Status = domain.OrderStatusCancelled
+				return errors.New("update failed")
+			},
+			expectedStatus: domain.OrderStatusConfirmed,
+			expectedError:  errors.New("update failed"),
+		},
+		{
+			name:        "should not change order identifiers",
+			orderNumber: 1,
+			update: func(order *domain.Order) error {
+				order.ID = "2"
+				order.Number = 2
+				return nil
+			},
+			expectedStatus: domain.OrderStatusConfirmed,
+		},
+		{
+			name:        "order not found",
+			orderNumber: 2,
+			update: func(order *domain.Order) error {
+				return nil
+			},
+			expectedStatus: domain.OrderStatusConfirmed,
+			expectedError:  domain.ErrOrderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewOrderStore(nil)
+
+			_, err := store.AddOrder(context.Background(), domain.Order{
+				ID:     "1",
+				Status: domain.OrderStatusConfirmed,
+				Bookings: []domain.Booking{
+					{HotelID: 101, RoomType: "single", From: now, To: now.Add(2 * time.Hour), RoomCount: 1},
+				},
+			})
+			assert.NoError(t, err)
+
+			_, err = store.UpdateOrder(context.Background(), tt.orderNumber, tt.update)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			byID, err := store.GetOrderByID(context.Background(), "1")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, byID.Status)
+
+			byNumber, err := store.GetOrderByNumber(context.Background(), 1)
+			assert.NoError(t, err)
+			assert.Equal(t, byID, byNumber)
+		})
+	}
+}
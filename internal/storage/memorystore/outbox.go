@@ -0,0 +1,157 @@
+package memorystore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+// Outbox keeps the events of the state changes and the delivery offsets of their subscribers.
+// Stores append the events while they hold the lock of the change, so an event is recorded
+// if and only if the change is made. A nil Outbox drops the events. The events delivered to every
+// subscriber are trimmed.
+type Outbox struct {
+	events []domain.Event
+	// trimmed is the number of the trimmed events, i.e. the ID of the last one
+	trimmed int64
+	offsets map[string]int64
+	mu      sync.RWMutex
+}
+
+func NewOutbox() *Outbox {
+	return &Outbox{
+		offsets: make(map[string]int64),
+	}
+}
+
+// OutboxSnapshot is a copy of the kept events and the delivery offsets.
+type OutboxSnapshot struct {
+	Events  []domain.Event   `json:"events"`
+	Trimmed int64            `json:"trimmed"`
+	Offsets map[string]int64 `json:"offsets"`
+}
+
+// Snapshot returns a copy of the kept events and the delivery offsets.
+func (o *Outbox) Snapshot() OutboxSnapshot {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	offsets := make(map[string]int64, len(o.offsets))
+	for subscriber, offset := range o.offsets {
+		offsets[subscriber] = offset
+	}
+
+	return OutboxSnapshot{
+		Events:  append([]domain.Event(nil), o.events...),
+		Trimmed: o.trimmed,
+		Offsets: offsets,
+	}
+}
+
+// Restore replaces the events and the delivery offsets with the ones of the snapshot.
+func (o *Outbox) Restore(snap OutboxSnapshot) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.events = append([]domain.Event(nil), snap.Events...)
+	o.trimmed = snap.Trimmed
+	o.offsets = make(map[string]int64, len(snap.Offsets))
+
+	for subscriber, offset := range snap.Offsets {
+		o.offsets[subscriber] = offset
+	}
+}
+
+// Subscribed reports if the subscriber has got its offset, so the events it hasn't got yet are kept.
+func (o *Outbox) Subscribed(subscriber string) bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	_, ok := o.offsets[subscriber]
+
+	return ok
+}
+
+func (o *Outbox) append(events ...domain.Event) {
+	if o == nil || len(events) == 0 {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	now := time.Now()
+
+	for _, event := range events {
+		event.ID = o.trimmed + int64(len(o.events)) + 1
+		event.CreatedAt = now
+
+		o.events = append(o.events, event)
+	}
+}
+
+// GetEvents returns up to limit events following the event with afterID.
+func (o *Outbox) GetEvents(ctx context.Context, afterID int64, limit int) ([]domain.Event, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	// the event IDs are their positions in the list starting from 1 after the trimmed events
+	start := afterID - o.trimmed
+	if start < 0 {
+		start = 0
+	}
+
+	if start >= int64(len(o.events)) {
+		return nil, nil
+	}
+
+	events := o.events[start:]
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	return append([]domain.Event(nil), events...), nil
+}
+
+// GetOffset returns the ID of the last event delivered to the subscriber. The events aren't trimmed
+// until the subscriber gets them.
+func (o *Outbox) GetOffset(ctx context.Context, subscriber string) (int64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	offset, ok := o.offsets[subscriber]
+	if !ok {
+		o.offsets[subscriber] = 0
+	}
+
+	return offset, nil
+}
+
+// SetOffset records the delivery to the subscriber and trims the events delivered to every subscriber.
+func (o *Outbox) SetOffset(ctx context.Context, subscriber string, offset int64) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.offsets[subscriber] = offset
+	o.trim()
+
+	return nil
+}
+
+func (o *Outbox) trim() {
+	delivered := o.trimmed + int64(len(o.events))
+
+	for _, offset := range o.offsets {
+		delivered = min(delivered, offset)
+	}
+
+	if delivered <= o.trimmed {
+		return
+	}
+
+	// the kept events are copied, so the trimmed ones can be collected
+	o.events = append([]domain.Event(nil), o.events[delivered-o.trimmed:]...)
+	o.trimmed = delivered
+}
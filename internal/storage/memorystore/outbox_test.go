@@ -0,0 +1,121 @@
+package memorystore
+
+import (
+	"context"
+	"testing"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutbox_RecordsStoreChanges(t *testing.T) {
+	ctx := context.Background()
+
+	outbox := NewOutbox()
+	hotelStore := NewHotelStore(outbox)
+	orderStore := NewOrderStore(outbox)
+
+	booking := domain.Booking{HotelID: 1, RoomType: "single", From: date.Date(2025, 2, 1), To: date.Date(2025, 2, 2), RoomCount: 1}
+
+	assert.NoError(t, hotelStore.AddHotel(ctx, domain.Hotel{ID: 1}))
+	assert.NoError(t, hotelStore.AddRoomType(ctx, domain.HotelRoomType{HotelID: 1, Code: "single", Name: "Single", Capacity: 1}))
+	assert.NoError(t, hotelStore.AddRoomAvailability(ctx, 1, "single", date.Date(2025, 2, 1), 2))
+	assert.NoError(t, hotelStore.AddRoomAvailability(ctx, 1, "single", date.Date(2025, 2, 2), 2))
+	assert.NoError(t, hotelStore.Reserve(ctx, []domain.Booking{booking}))
+
+	// failed changes aren't recorded
+	assert.Error(t, hotelStore.Reserve(ctx, []domain.Booking{{HotelID: 1, RoomType: "single",
+		From: date.Date(2025, 2, 1), To: date.Date(2025, 2, 1), RoomCount: 5}}))
+
+	order, err := orderStore.AddOrder(ctx, domain.Order{ID: "1", Status: domain.OrderStatusConfirmed, Bookings: []domain.Booking{booking}})
+	assert.NoError(t, err)
+
+	_, err = orderStore.UpdateOrder(ctx, order.Number, func(order *domain.Order) error {
+		return order.ChangeStatus(domain.OrderStatusCancelled)
+	})
+	assert.NoError(t, err)
+
+	_, err = orderStore.UpdateOrder(ctx, order.Number, func(order *domain.Order) error {
+		return order.ChangeStatus(domain.OrderStatusConfirmed)
+	})
+	assert.Error(t, err)
+
+	events, err := outbox.GetEvents(ctx, 0, 100)
+	assert.NoError(t, err)
+
+	var types []domain.EventType
+	for i, event := range events {
+		assert.Equal(t, int64(i+1), event.ID)
+		types = append(types, event.Type)
+	}
+
+	assert.Equal(t, []domain.EventType{
+		domain.EventAvailabilityChanged,
+		domain.EventAvailabilityChanged,
+		domain.EventAvailabilityChanged,
+		domain.EventOrderCreated,
+		domain.EventOrderCancelled,
+	}, types)
+
+	assert.Equal(t, []domain.AvailabilityChange{
+		{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 1), Delta: -1},
+		{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 2), Delta: -1},
+	}, events[2].Availability)
+
+	assert.Equal(t, domain.OrderStatusCancelled, events[4].Order.Status)
+
+	// reading from the offset
+	events, err = outbox.GetEvents(ctx, 3, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, domain.EventOrderCreated, events[0].Type)
+	}
+}
+
+func TestOutbox_TrimsDeliveredEvents(t *testing.T) {
+	ctx := context.Background()
+
+	outbox := NewOutbox()
+
+	for i := 0; i < 5; i++ {
+		outbox.append(domain.Event{Type: domain.EventOrderCreated})
+	}
+
+	// a subscriber which hasn't got any event keeps them
+	_, err := outbox.GetOffset(ctx, "webhook")
+	assert.NoError(t, err)
+
+	assert.NoError(t, outbox.SetOffset(ctx, "notification", 3))
+	assert.Len(t, outbox.events, 5)
+
+	assert.NoError(t, outbox.SetOffset(ctx, "webhook", 2))
+	assert.Len(t, outbox.events, 3)
+
+	// the IDs continue after the trimmed events
+	outbox.append(domain.Event{Type: domain.EventOrderCancelled})
+
+	events, err := outbox.GetEvents(ctx, 2, 100)
+	assert.NoError(t, err)
+
+	var ids []int64
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+	assert.Equal(t, []int64{3, 4, 5, 6}, ids)
+
+	events, err = outbox.GetEvents(ctx, 5, 100)
+	assert.NoError(t, err)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, domain.EventOrderCancelled, events[0].Type)
+	}
+
+	assert.NoError(t, outbox.SetOffset(ctx, "notification", 6))
+	assert.NoError(t, outbox.SetOffset(ctx, "webhook", 6))
+	assert.Empty(t, outbox.events)
+
+	events, err = outbox.GetEvents(ctx, 6, 100)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+}
@@ -0,0 +1,155 @@
+package memorystore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"applicationDesignTest/internal/domain"
+)
+
+type PromoStore struct {
+	promos map[domain.PromoCode]*promoUsage
+	mu     sync.Mutex
+}
+
+type promoUsage struct {
+	promo  domain.Promo
+	uses   int
+	byUser map[domain.UserID]int
+}
+
+// PromoSnapshot is a copy of the promo and its uses.
+type PromoSnapshot struct {
+	Promo  domain.Promo          `json:"promo"`
+	Uses   int                   `json:"uses"`
+	ByUser map[domain.UserID]int `json:"by_user"`
+}
+
+func NewPromoStore() *PromoStore {
+	return &PromoStore{
+		promos: make(map[domain.PromoCode]*promoUsage),
+	}
+}
+
+func (s *PromoStore) AddPromo(ctx context.Context, promo domain.Promo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.promos[promo.Code]; ok {
+		return domain.ErrPromoAlreadyExists
+	}
+
+	s.promos[promo.Code] = &promoUsage{
+		promo:  promo,
+		byUser: make(map[domain.UserID]int),
+	}
+
+	return nil
+}
+
+func (s *PromoStore) GetPromo(ctx context.Context, code domain.PromoCode) (*domain.Promo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	usage, ok := s.promos[code]
+	if !ok {
+		return nil, domain.ErrPromoNotFound
+	}
+
+	promo := usage.promo
+
+	return &promo, nil
+}
+
+// Redeem counts one more use of the promo code by the user if the usage limits allow it.
+func (s *PromoStore) Redeem(ctx context.Context, code domain.PromoCode, userID domain.UserID) error {
+	return s.RedeemLogged(ctx, code, userID, nil)
+}
+
+// RedeemLogged is Redeem which calls log once the limits are checked and counts the use only if log succeeds.
+// It's used by the stores which log the changes before they're made.
+func (s *PromoStore) RedeemLogged(ctx context.Context, code domain.PromoCode, userID domain.UserID, log func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	usage, ok := s.promos[code]
+	if !ok {
+		return domain.ErrPromoNotFound
+	}
+
+	if usage.promo.MaxUses > 0 && usage.uses >= usage.promo.MaxUses {
+		return fmt.Errorf("%w: promo code '%s' is used %d times", domain.ErrPromoUsageLimit, code, usage.uses)
+	}
+
+	if usage.promo.MaxUsesPerUser > 0 && usage.byUser[userID] >= usage.promo.MaxUsesPerUser {
+		return fmt.Errorf("%w: promo code '%s' is used %d times by the user",
+			domain.ErrPromoUsageLimit, code, usage.byUser[userID])
+	}
+
+	if log != nil {
+		if err := log(); err != nil {
+			return err
+		}
+	}
+
+	usage.uses++
+	usage.byUser[userID]++
+
+	return nil
+}
+
+// Unredeem takes back a use of the promo code counted by Redeem.
+func (s *PromoStore) Unredeem(ctx context.Context, code domain.PromoCode, userID domain.UserID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	usage, ok := s.promos[code]
+	if !ok {
+		return domain.ErrPromoNotFound
+	}
+
+	if usage.byUser[userID] > 0 {
+		usage.uses--
+		usage.byUser[userID]--
+	}
+
+	return nil
+}
+
+// Snapshot returns a copy of the promos and their uses ordered by code.
+func (s *PromoStore) Snapshot() []PromoSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	promos := make([]PromoSnapshot, 0, len(s.promos))
+
+	for _, usage := range s.promos {
+		byUser := make(map[domain.UserID]int, len(usage.byUser))
+		for userID, uses := range usage.byUser {
+			byUser[userID] = uses
+		}
+
+		promos = append(promos, PromoSnapshot{Promo: usage.promo, Uses: usage.uses, ByUser: byUser})
+	}
+
+	sort.Slice(promos, func(i, j int) bool {
+		return promos[i].Promo.Code < promos[j].Promo.Code
+	})
+
+	return promos
+}
+
+// RestorePromo replaces the promo and its uses with the ones of the snapshot.
+func (s *PromoStore) RestorePromo(snap PromoSnapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byUser := make(map[domain.UserID]int, len(snap.ByUser))
+	for userID, uses := range snap.ByUser {
+		byUser[userID] = uses
+	}
+
+	s.promos[snap.Promo.Code] = &promoUsage{promo: snap.Promo, uses: snap.Uses, byUser: byUser}
+}
@@ -0,0 +1,76 @@
+package memorystore
+
+import (
+	"context"
+	"testing"
+
+	"applicationDesignTest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromoStore_Redeem(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxUses        int
+		maxUsesPerUser int
+		redeems        []domain.UserID
+		expectedErrors []error
+	}{
+		{
+			name:           "no limits",
+			redeems:        []domain.UserID{1, 1, 2},
+			expectedErrors: []error{nil, nil, nil},
+		},
+		{
+			name:           "global limit",
+			maxUses:        2,
+			redeems:        []domain.UserID{1, 2, 3},
+			expectedErrors: []error{nil, nil, domain.ErrPromoUsageLimit},
+		},
+		{
+			name:           "per user limit",
+			maxUsesPerUser: 1,
+			redeems:        []domain.UserID{1, 2, 1},
+			expectedErrors: []error{nil, nil, domain.ErrPromoUsageLimit},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewPromoStore()
+
+			err := store.AddPromo(context.Background(), domain.Promo{
+				Code:           "SALE",
+				MaxUses:        tt.maxUses,
+				MaxUsesPerUser: tt.maxUsesPerUser,
+			})
+			assert.NoError(t, err)
+
+			for i, userID := range tt.redeems {
+				err := store.Redeem(context.Background(), "SALE", userID)
+
+				if tt.expectedErrors[i] != nil {
+					assert.ErrorIs(t, err, tt.expectedErrors[i])
+				} else {
+					assert.NoError(t, err)
+				}
+			}
+		})
+	}
+}
+
+func TestPromoStore_Unredeem(t *testing.T) {
+	store := NewPromoStore()
+
+	err := store.AddPromo(context.Background(), domain.Promo{Code: "SALE", MaxUses: 1})
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.Redeem(context.Background(), "SALE", 1))
+	assert.ErrorIs(t, store.Redeem(context.Background(), "SALE", 2), domain.ErrPromoUsageLimit)
+
+	assert.NoError(t, store.Unredeem(context.Background(), "SALE", 1))
+	assert.NoError(t, store.Redeem(context.Background(), "SALE", 2))
+
+	assert.ErrorIs(t, store.Redeem(context.Background(), "UNKNOWN", 1), domain.ErrPromoNotFound)
+}
@@ -0,0 +1,131 @@
+package memorystore
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+type RateStore struct {
+	rates    map[rateKey]domain.Money
+	policies map[policyKey]domain.CancellationPolicy
+	mu       sync.RWMutex
+}
+
+type rateKey struct {
+	hotelID  domain.HotelID
+	roomType domain.RoomType
+	date     time.Time
+}
+
+type policyKey struct {
+	hotelID  domain.HotelID
+	roomType domain.RoomType
+}
+
+// RateSnapshot is a copy of the prices and the cancellation policies.
+type RateSnapshot struct {
+	Rates    []domain.Rate               `json:"rates"`
+	Policies []domain.CancellationPolicy `json:"policies"`
+}
+
+func NewRateStore() *RateStore {
+	return &RateStore{
+		rates:    make(map[rateKey]domain.Money),
+		policies: make(map[policyKey]domain.CancellationPolicy),
+	}
+}
+
+// SetRate sets the price of the room type for the date, replacing the previous one.
+func (s *RateStore) SetRate(ctx context.Context, rate domain.Rate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rates[rateKey{hotelID: rate.HotelID, roomType: rate.RoomType, date: rate.Date}] = rate.Price
+
+	return nil
+}
+
+func (s *RateStore) GetRate(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time) (*domain.Rate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	price, ok := s.rates[rateKey{hotelID: hotelID, roomType: roomType, date: date}]
+	if !ok {
+		return nil, domain.ErrRateNotFound
+	}
+
+	return &domain.Rate{
+		HotelID:  hotelID,
+		RoomType: roomType,
+		Date:     date,
+		Price:    price,
+	}, nil
+}
+
+// SetCancellationPolicy sets the cancellation policy of the room type, or the default one of the hotel
+// if the room type is empty, replacing the previous one.
+func (s *RateStore) SetCancellationPolicy(ctx context.Context, policy domain.CancellationPolicy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.policies[policyKey{hotelID: policy.HotelID, roomType: policy.RoomType}] = policy
+
+	return nil
+}
+
+func (s *RateStore) GetCancellationPolicy(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType) (*domain.CancellationPolicy, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	policy, ok := s.policies[policyKey{hotelID: hotelID, roomType: roomType}]
+	if !ok {
+		return nil, domain.ErrPolicyNotFound
+	}
+
+	return &policy, nil
+}
+
+// Snapshot returns a copy of the prices ordered by hotel, room type and date and the cancellation policies
+// ordered by hotel and room type.
+func (s *RateStore) Snapshot() RateSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snap := RateSnapshot{
+		Rates:    make([]domain.Rate, 0, len(s.rates)),
+		Policies: make([]domain.CancellationPolicy, 0, len(s.policies)),
+	}
+
+	for key, price := range s.rates {
+		snap.Rates = append(snap.Rates, domain.Rate{HotelID: key.hotelID, RoomType: key.roomType, Date: key.date, Price: price})
+	}
+
+	for _, policy := range s.policies {
+		snap.Policies = append(snap.Policies, policy)
+	}
+
+	sort.Slice(snap.Rates, func(i, j int) bool {
+		a, b := snap.Rates[i], snap.Rates[j]
+		if a.HotelID != b.HotelID {
+			return a.HotelID < b.HotelID
+		}
+		if a.RoomType != b.RoomType {
+			return a.RoomType < b.RoomType
+		}
+		return a.Date.Before(b.Date)
+	})
+
+	sort.Slice(snap.Policies, func(i, j int) bool {
+		a, b := snap.Policies[i], snap.Policies[j]
+		if a.HotelID != b.HotelID {
+			return a.HotelID < b.HotelID
+		}
+		return a.RoomType < b.RoomType
+	})
+
+	return snap
+}
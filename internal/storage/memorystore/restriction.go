@@ -0,0 +1,95 @@
+package memorystore
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+type RestrictionStore struct {
+	restrictions map[restrictionKey]domain.Restriction
+	mu           sync.RWMutex
+}
+
+type restrictionKey struct {
+	hotelID  domain.HotelID
+	roomType domain.RoomType
+	date     time.Time
+}
+
+func NewRestrictionStore() *RestrictionStore {
+	return &RestrictionStore{
+		restrictions: make(map[restrictionKey]domain.Restriction),
+	}
+}
+
+// SetRestriction replaces the restriction of the room type on the date. A restriction without rules is removed.
+func (s *RestrictionStore) SetRestriction(ctx context.Context, restriction domain.Restriction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := restrictionKey{hotelID: restriction.HotelID, roomType: restriction.RoomType, date: restriction.Date}
+
+	if restriction.IsEmpty() {
+		delete(s.restrictions, key)
+		return nil
+	}
+
+	s.restrictions[key] = restriction
+
+	return nil
+}
+
+// GetRestrictions returns the restrictions of the hotel from one date to another inclusive ordered by room type
+// and date. Empty roomType matches any room type.
+func (s *RestrictionStore) GetRestrictions(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType,
+	from, to time.Time) ([]domain.Restriction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	restrictions := []domain.Restriction{}
+
+	for key, restriction := range s.restrictions {
+		if key.hotelID != hotelID || (roomType != "" && key.roomType != roomType) || key.date.Before(from) || key.date.After(to) {
+			continue
+		}
+
+		restrictions = append(restrictions, restriction)
+	}
+
+	sort.Slice(restrictions, func(i, j int) bool {
+		if restrictions[i].RoomType != restrictions[j].RoomType {
+			return restrictions[i].RoomType < restrictions[j].RoomType
+		}
+		return restrictions[i].Date.Before(restrictions[j].Date)
+	})
+
+	return restrictions, nil
+}
+
+// Snapshot returns a copy of the restrictions ordered by hotel, room type and date.
+func (s *RestrictionStore) Snapshot() []domain.Restriction {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	restrictions := make([]domain.Restriction, 0, len(s.restrictions))
+	for _, restriction := range s.restrictions {
+		restrictions = append(restrictions, restriction)
+	}
+
+	sort.Slice(restrictions, func(i, j int) bool {
+		a, b := restrictions[i], restrictions[j]
+		if a.HotelID != b.HotelID {
+			return a.HotelID < b.HotelID
+		}
+		if a.RoomType != b.RoomType {
+			return a.RoomType < b.RoomType
+		}
+		return a.Date.Before(b.Date)
+	})
+
+	return restrictions
+}
@@ -0,0 +1,40 @@
+package memorystore
+
+import (
+	"testing"
+
+	"applicationDesignTest/internal/storage/storagetest"
+)
+
+type store struct {
+	*HotelStore
+	*OrderStore
+}
+
+type stateStore struct {
+	*HoldStore
+	*RateStore
+	*PromoStore
+	*LoyaltyStore
+	*WebhookStore
+	*RestrictionStore
+}
+
+func TestConformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storagetest.Store {
+		return store{HotelStore: NewHotelStore(nil), OrderStore: NewOrderStore(nil)}
+	})
+}
+
+func TestStateConformance(t *testing.T) {
+	storagetest.RunState(t, func(t *testing.T) storagetest.StateStore {
+		return stateStore{
+			HoldStore:        NewHoldStore(),
+			RateStore:        NewRateStore(),
+			PromoStore:       NewPromoStore(),
+			LoyaltyStore:     NewLoyaltyStore(),
+			WebhookStore:     NewWebhookStore(),
+			RestrictionStore: NewRestrictionStore(),
+		}
+	})
+}
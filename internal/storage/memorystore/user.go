@@ -0,0 +1,40 @@
+package memorystore
+
+import (
+	"context"
+	"sync"
+
+	"applicationDesignTest/internal/domain"
+)
+
+type UserStore struct {
+	users map[domain.UserID]domain.User
+	mu    sync.RWMutex
+}
+
+func NewUserStore() *UserStore {
+	return &UserStore{
+		users: make(map[domain.UserID]domain.User),
+	}
+}
+
+func (s *UserStore) AddUser(ctx context.Context, user domain.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.users[user.ID] = user
+
+	return nil
+}
+
+func (s *UserStore) GetUser(ctx context.Context, id domain.UserID) (*domain.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,151 @@
+package memorystore
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+type WebhookStore struct {
+	webhooks    map[domain.WebhookID]domain.Webhook
+	lastID      domain.WebhookID
+	deadLetters []domain.DeadLetter
+	mu          sync.RWMutex
+}
+
+// WebhookSnapshot is a copy of the webhooks, the last given ID and the dead letters.
+type WebhookSnapshot struct {
+	Webhooks    []domain.Webhook    `json:"webhooks"`
+	LastID      domain.WebhookID    `json:"last_id"`
+	DeadLetters []domain.DeadLetter `json:"dead_letters"`
+}
+
+func NewWebhookStore() *WebhookStore {
+	return &WebhookStore{
+		webhooks: make(map[domain.WebhookID]domain.Webhook),
+	}
+}
+
+// AddWebhook gives the webhook the next ID. The creation time is set unless it's already known,
+// e.g. when the webhook is restored.
+func (s *WebhookStore) AddWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastID++
+	webhook.ID = s.lastID
+
+	if webhook.CreatedAt.IsZero() {
+		webhook.CreatedAt = time.Now()
+	}
+
+	s.webhooks[webhook.ID] = webhook
+
+	return &webhook, nil
+}
+
+func (s *WebhookStore) GetWebhook(ctx context.Context, id domain.WebhookID) (*domain.Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	webhook, ok := s.webhooks[id]
+	if !ok {
+		return nil, domain.ErrWebhookNotFound
+	}
+
+	return &webhook, nil
+}
+
+// GetWebhooks returns the webhooks ordered by ID.
+func (s *WebhookStore) GetWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	webhooks := make([]domain.Webhook, 0, len(s.webhooks))
+	for _, webhook := range s.webhooks {
+		webhooks = append(webhooks, webhook)
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].ID < webhooks[j].ID
+	})
+
+	return webhooks, nil
+}
+
+// UpdateWebhook replaces the webhook keeping its creation time.
+func (s *WebhookStore) UpdateWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.webhooks[webhook.ID]
+	if !ok {
+		return nil, domain.ErrWebhookNotFound
+	}
+
+	webhook.CreatedAt = current.CreatedAt
+	s.webhooks[webhook.ID] = webhook
+
+	return &webhook, nil
+}
+
+func (s *WebhookStore) DeleteWebhook(ctx context.Context, id domain.WebhookID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.webhooks[id]; !ok {
+		return domain.ErrWebhookNotFound
+	}
+
+	delete(s.webhooks, id)
+
+	return nil
+}
+
+func (s *WebhookStore) AddDeadLetter(ctx context.Context, deadLetter domain.DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deadLetter.ID = int64(len(s.deadLetters) + 1)
+	s.deadLetters = append(s.deadLetters, deadLetter)
+
+	return nil
+}
+
+func (s *WebhookStore) GetDeadLetters(ctx context.Context) ([]domain.DeadLetter, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]domain.DeadLetter{}, s.deadLetters...), nil
+}
+
+// Snapshot returns a copy of the webhooks ordered by ID, the last given ID and the dead letters.
+func (s *WebhookStore) Snapshot() WebhookSnapshot {
+	webhooks, _ := s.GetWebhooks(context.Background())
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return WebhookSnapshot{
+		Webhooks:    webhooks,
+		LastID:      s.lastID,
+		DeadLetters: append([]domain.DeadLetter{}, s.deadLetters...),
+	}
+}
+
+// Restore replaces the webhooks and the dead letters with the ones of the snapshot.
+func (s *WebhookStore) Restore(snap WebhookSnapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.webhooks = make(map[domain.WebhookID]domain.Webhook, len(snap.Webhooks))
+	for _, webhook := range snap.Webhooks {
+		s.webhooks[webhook.ID] = webhook
+	}
+
+	s.lastID = snap.LastID
+	s.deadLetters = append([]domain.DeadLetter(nil), snap.DeadLetters...)
+}
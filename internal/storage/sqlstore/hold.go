@@ -0,0 +1,96 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+func (s *Store) AddHold(ctx context.Context, hold domain.Hold) error {
+	data, err := json.Marshal(hold)
+	if err != nil {
+		return fmt.Errorf("failed to encode hold: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `INSERT INTO holds (token, expires_at, data) VALUES (?, ?, ?)
+		ON CONFLICT (token) DO UPDATE SET expires_at = excluded.expires_at, data = excluded.data`,
+		hold.Token, hold.ExpiresAt.UnixNano(), data)
+
+	return err
+}
+
+func (s *Store) GetHold(ctx context.Context, token domain.HoldToken) (*domain.Hold, error) {
+	return getHold(ctx, s.db, token)
+}
+
+// DeleteHold removes the hold and returns it, so only one caller can take it.
+func (s *Store) DeleteHold(ctx context.Context, token domain.HoldToken) (*domain.Hold, error) {
+	var hold *domain.Hold
+
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		if hold, err = getHold(ctx, tx, token); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `DELETE FROM holds WHERE token = ?`, token)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// GetExpiredHolds returns the holds expired at the moment now.
+func (s *Store) GetExpiredHolds(ctx context.Context, now time.Time) ([]domain.Hold, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT data FROM holds WHERE expires_at <= ? ORDER BY token`, now.UnixNano())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holds []domain.Hold
+
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		var hold domain.Hold
+		if err := json.Unmarshal(data, &hold); err != nil {
+			return nil, fmt.Errorf("failed to decode hold: %w", err)
+		}
+
+		holds = append(holds, hold)
+	}
+
+	return holds, rows.Err()
+}
+
+func getHold(ctx context.Context, q queryer, token domain.HoldToken) (*domain.Hold, error) {
+	var data []byte
+
+	err := q.QueryRowContext(ctx, `SELECT data FROM holds WHERE token = ?`, token).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrHoldNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var hold domain.Hold
+	if err := json.Unmarshal(data, &hold); err != nil {
+		return nil, fmt.Errorf("failed to decode hold: %w", err)
+	}
+
+	return &hold, nil
+}
@@ -0,0 +1,100 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+const loyaltyEntryColumns = `id, user_id, order_number, type, points, created_at`
+
+// AppendEntry adds the entry to the ledger. A redeem entry is rejected if the user hasn't enough points.
+// The balance is checked in the transaction of the change, so concurrent redeems don't overdraw it.
+func (s *Store) AppendEntry(ctx context.Context, entry domain.LoyaltyEntry) (*domain.LoyaltyEntry, error) {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		balance, err := getBalance(ctx, tx, entry.UserID)
+		if err != nil {
+			return err
+		}
+
+		if entry.Type == domain.LoyaltyEntryRedeem && balance+entry.Points < 0 {
+			return fmt.Errorf("%w: balance is %d, requested %d", domain.ErrInsufficientPoints, balance, -entry.Points)
+		}
+
+		result, err := tx.ExecContext(ctx, `INSERT INTO loyalty_entries (user_id, order_number, type, points, created_at)
+			VALUES (?, ?, ?, ?, ?)`, entry.UserID, entry.OrderNumber, entry.Type, entry.Points, entry.CreatedAt.Format(time.RFC3339Nano))
+		if err != nil {
+			return err
+		}
+
+		entry.ID, err = result.LastInsertId()
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (s *Store) GetBalance(ctx context.Context, userID domain.UserID) (int64, error) {
+	return getBalance(ctx, s.db, userID)
+}
+
+func (s *Store) GetUserEntries(ctx context.Context, userID domain.UserID) ([]domain.LoyaltyEntry, error) {
+	entries, err := s.getLoyaltyEntries(ctx, `SELECT `+loyaltyEntryColumns+` FROM loyalty_entries WHERE user_id = ? ORDER BY id`, userID)
+	if entries == nil && err == nil {
+		entries = []domain.LoyaltyEntry{}
+	}
+
+	return entries, err
+}
+
+func (s *Store) GetOrderEntries(ctx context.Context, orderNumber domain.OrderNumber) ([]domain.LoyaltyEntry, error) {
+	return s.getLoyaltyEntries(ctx, `SELECT `+loyaltyEntryColumns+` FROM loyalty_entries WHERE order_number = ? ORDER BY id`, orderNumber)
+}
+
+func (s *Store) getLoyaltyEntries(ctx context.Context, query string, arg any) ([]domain.LoyaltyEntry, error) {
+	rows, err := s.db.QueryContext(ctx, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []domain.LoyaltyEntry
+
+	for rows.Next() {
+		var (
+			entry     domain.LoyaltyEntry
+			createdAt string
+		)
+
+		if err := rows.Scan(&entry.ID, &entry.UserID, &entry.OrderNumber, &entry.Type, &entry.Points, &createdAt); err != nil {
+			return nil, err
+		}
+
+		if entry.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+			return nil, fmt.Errorf("failed to decode loyalty entry time: %w", err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func getBalance(ctx context.Context, q queryer, userID domain.UserID) (int64, error) {
+	var balance int64
+
+	err := q.QueryRowContext(ctx, `SELECT COALESCE(SUM(points), 0) FROM loyalty_entries WHERE user_id = ?`, userID).Scan(&balance)
+
+	return balance, err
+}
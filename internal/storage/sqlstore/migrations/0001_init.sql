@@ -0,0 +1,28 @@
+CREATE TABLE hotels (
+    id   INTEGER PRIMARY KEY,
+    name TEXT    NOT NULL
+);
+
+CREATE TABLE room_availability (
+    hotel_id  INTEGER NOT NULL REFERENCES hotels (id),
+    room_type TEXT    NOT NULL,
+    date      TEXT    NOT NULL,
+    rooms     INTEGER NOT NULL CHECK (rooms >= 0),
+    PRIMARY KEY (hotel_id, room_type, date)
+);
+
+CREATE TABLE orders (
+    number INTEGER PRIMARY KEY AUTOINCREMENT,
+    id     TEXT    NOT NULL UNIQUE,
+    data   TEXT    NOT NULL
+);
+
+CREATE TABLE outbox_events (
+    id   INTEGER PRIMARY KEY AUTOINCREMENT,
+    data TEXT    NOT NULL
+);
+
+CREATE TABLE outbox_offsets (
+    subscriber TEXT    PRIMARY KEY,
+    event_id   INTEGER NOT NULL
+);
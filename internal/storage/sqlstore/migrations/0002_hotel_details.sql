@@ -0,0 +1,6 @@
+ALTER TABLE hotels ADD COLUMN address        TEXT    NOT NULL DEFAULT '';
+ALTER TABLE hotels ADD COLUMN city           TEXT    NOT NULL DEFAULT '';
+ALTER TABLE hotels ADD COLUMN timezone       TEXT    NOT NULL DEFAULT '';
+ALTER TABLE hotels ADD COLUMN star_rating    INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE hotels ADD COLUMN check_in_time  TEXT    NOT NULL DEFAULT '';
+ALTER TABLE hotels ADD COLUMN check_out_time TEXT    NOT NULL DEFAULT '';
@@ -0,0 +1,13 @@
+CREATE TABLE room_types (
+    hotel_id    INTEGER NOT NULL REFERENCES hotels (id),
+    code        TEXT    NOT NULL,
+    name        TEXT    NOT NULL,
+    capacity    INTEGER NOT NULL,
+    description TEXT    NOT NULL DEFAULT '',
+    amenities   TEXT    NOT NULL DEFAULT '[]',
+    PRIMARY KEY (hotel_id, code)
+);
+
+-- the room types which already have availability become the catalog of their hotels
+INSERT INTO room_types (hotel_id, code, name, capacity)
+SELECT DISTINCT hotel_id, room_type, room_type, 1 FROM room_availability;
@@ -0,0 +1,5 @@
+ALTER TABLE room_availability ADD COLUMN capacity INTEGER NOT NULL DEFAULT 0;
+
+-- the reservations made before the capacity was tracked can't be told apart from it,
+-- so the free rooms become the capacity
+UPDATE room_availability SET capacity = rooms;
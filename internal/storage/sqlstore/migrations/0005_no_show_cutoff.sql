@@ -0,0 +1 @@
+ALTER TABLE hotels ADD COLUMN no_show_cutoff TEXT NOT NULL DEFAULT '';
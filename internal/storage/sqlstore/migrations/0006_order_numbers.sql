@@ -0,0 +1,5 @@
+CREATE TABLE order_numbers (
+    number INTEGER PRIMARY KEY AUTOINCREMENT
+);
+
+INSERT INTO order_numbers (number) SELECT number FROM orders;
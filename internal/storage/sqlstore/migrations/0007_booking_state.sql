@@ -0,0 +1,68 @@
+-- the rest of the booking state, which was kept in memory
+
+CREATE TABLE holds (
+    token      TEXT    PRIMARY KEY,
+    expires_at INTEGER NOT NULL,
+    data       TEXT    NOT NULL
+);
+
+CREATE INDEX holds_expires_at ON holds (expires_at);
+
+CREATE TABLE rates (
+    hotel_id  INTEGER NOT NULL,
+    room_type TEXT    NOT NULL,
+    date      TEXT    NOT NULL,
+    amount    INTEGER NOT NULL,
+    currency  TEXT    NOT NULL,
+    PRIMARY KEY (hotel_id, room_type, date)
+);
+
+CREATE TABLE cancellation_policies (
+    hotel_id  INTEGER NOT NULL,
+    room_type TEXT    NOT NULL,
+    data      TEXT    NOT NULL,
+    PRIMARY KEY (hotel_id, room_type)
+);
+
+CREATE TABLE promos (
+    code TEXT    PRIMARY KEY,
+    data TEXT    NOT NULL,
+    uses INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE promo_uses (
+    code    TEXT    NOT NULL REFERENCES promos (code),
+    user_id INTEGER NOT NULL,
+    uses    INTEGER NOT NULL,
+    PRIMARY KEY (code, user_id)
+);
+
+CREATE TABLE loyalty_entries (
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id      INTEGER NOT NULL,
+    order_number INTEGER NOT NULL,
+    type         TEXT    NOT NULL,
+    points       INTEGER NOT NULL,
+    created_at   TEXT    NOT NULL
+);
+
+CREATE INDEX loyalty_entries_user_id ON loyalty_entries (user_id);
+CREATE INDEX loyalty_entries_order_number ON loyalty_entries (order_number);
+
+CREATE TABLE webhooks (
+    id   INTEGER PRIMARY KEY AUTOINCREMENT,
+    data TEXT    NOT NULL
+);
+
+CREATE TABLE dead_letters (
+    id   INTEGER PRIMARY KEY AUTOINCREMENT,
+    data TEXT    NOT NULL
+);
+
+CREATE TABLE restrictions (
+    hotel_id  INTEGER NOT NULL,
+    room_type TEXT    NOT NULL,
+    date      TEXT    NOT NULL,
+    data      TEXT    NOT NULL,
+    PRIMARY KEY (hotel_id, room_type, date)
+);
@@ -0,0 +1,119 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"applicationDesignTest/internal/domain"
+)
+
+func (s *Store) AddPromo(ctx context.Context, promo domain.Promo) error {
+	data, err := json.Marshal(promo)
+	if err != nil {
+		return fmt.Errorf("failed to encode promo: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `INSERT INTO promos (code, data) VALUES (?, ?)`, promo.Code, data)
+	if isUniqueViolation(err) {
+		return domain.ErrPromoAlreadyExists
+	}
+
+	return err
+}
+
+func (s *Store) GetPromo(ctx context.Context, code domain.PromoCode) (*domain.Promo, error) {
+	promo, _, err := getPromo(ctx, s.db, code)
+
+	return promo, err
+}
+
+// Redeem counts one more use of the promo code by the user if the usage limits allow it. The limits are
+// checked in the transaction of the change, so concurrent uses don't exceed them.
+func (s *Store) Redeem(ctx context.Context, code domain.PromoCode, userID domain.UserID) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		promo, uses, err := getPromo(ctx, tx, code)
+		if err != nil {
+			return err
+		}
+
+		userUses, err := getPromoUses(ctx, tx, code, userID)
+		if err != nil {
+			return err
+		}
+
+		if promo.MaxUses > 0 && uses >= promo.MaxUses {
+			return fmt.Errorf("%w: promo code '%s' is used %d times", domain.ErrPromoUsageLimit, code, uses)
+		}
+
+		if promo.MaxUsesPerUser > 0 && userUses >= promo.MaxUsesPerUser {
+			return fmt.Errorf("%w: promo code '%s' is used %d times by the user", domain.ErrPromoUsageLimit, code, userUses)
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE promos SET uses = uses + 1 WHERE code = ?`, code); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `INSERT INTO promo_uses (code, user_id, uses) VALUES (?, ?, 1)
+			ON CONFLICT (code, user_id) DO UPDATE SET uses = uses + 1`, code, userID)
+
+		return err
+	})
+}
+
+// Unredeem takes back a use of the promo code counted by Redeem.
+func (s *Store) Unredeem(ctx context.Context, code domain.PromoCode, userID domain.UserID) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		if _, _, err := getPromo(ctx, tx, code); err != nil {
+			return err
+		}
+
+		userUses, err := getPromoUses(ctx, tx, code, userID)
+		if err != nil || userUses == 0 {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE promos SET uses = uses - 1 WHERE code = ?`, code); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE promo_uses SET uses = uses - 1 WHERE code = ? AND user_id = ?`, code, userID)
+
+		return err
+	})
+}
+
+func getPromo(ctx context.Context, q queryer, code domain.PromoCode) (*domain.Promo, int, error) {
+	var (
+		data []byte
+		uses int
+	)
+
+	err := q.QueryRowContext(ctx, `SELECT data, uses FROM promos WHERE code = ?`, code).Scan(&data, &uses)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, 0, domain.ErrPromoNotFound
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var promo domain.Promo
+	if err := json.Unmarshal(data, &promo); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode promo: %w", err)
+	}
+
+	return &promo, uses, nil
+}
+
+func getPromoUses(ctx context.Context, q queryer, code domain.PromoCode, userID domain.UserID) (int, error) {
+	var uses int
+
+	err := q.QueryRowContext(ctx, `SELECT uses FROM promo_uses WHERE code = ? AND user_id = ?`, code, userID).Scan(&uses)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+
+	return uses, err
+}
@@ -0,0 +1,70 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+// SetRate sets the price of the room type for the date, replacing the previous one.
+func (s *Store) SetRate(ctx context.Context, rate domain.Rate) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO rates (hotel_id, room_type, date, amount, currency) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (hotel_id, room_type, date) DO UPDATE SET amount = excluded.amount, currency = excluded.currency`,
+		rate.HotelID, rate.RoomType, rate.Date.Format(dateLayout), rate.Price.Amount, rate.Price.Currency)
+
+	return err
+}
+
+func (s *Store) GetRate(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time) (*domain.Rate, error) {
+	rate := domain.Rate{HotelID: hotelID, RoomType: roomType, Date: date}
+
+	err := s.db.QueryRowContext(ctx, `SELECT amount, currency FROM rates WHERE hotel_id = ? AND room_type = ? AND date = ?`,
+		hotelID, roomType, date.Format(dateLayout)).Scan(&rate.Price.Amount, &rate.Price.Currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrRateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &rate, nil
+}
+
+// SetCancellationPolicy sets the cancellation policy of the room type, or the default one of the hotel
+// if the room type is empty, replacing the previous one.
+func (s *Store) SetCancellationPolicy(ctx context.Context, policy domain.CancellationPolicy) error {
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return fmt.Errorf("failed to encode cancellation policy: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `INSERT INTO cancellation_policies (hotel_id, room_type, data) VALUES (?, ?, ?)
+		ON CONFLICT (hotel_id, room_type) DO UPDATE SET data = excluded.data`, policy.HotelID, policy.RoomType, data)
+
+	return err
+}
+
+func (s *Store) GetCancellationPolicy(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType) (*domain.CancellationPolicy, error) {
+	var data []byte
+
+	err := s.db.QueryRowContext(ctx, `SELECT data FROM cancellation_policies WHERE hotel_id = ? AND room_type = ?`,
+		hotelID, roomType).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrPolicyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var policy domain.CancellationPolicy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to decode cancellation policy: %w", err)
+	}
+
+	return &policy, nil
+}
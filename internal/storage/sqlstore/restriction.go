@@ -0,0 +1,64 @@
+package sqlstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+// SetRestriction replaces the restriction of the room type on the date. A restriction without rules is removed.
+func (s *Store) SetRestriction(ctx context.Context, restriction domain.Restriction) error {
+	date := restriction.Date.Format(dateLayout)
+
+	if restriction.IsEmpty() {
+		_, err := s.db.ExecContext(ctx, `DELETE FROM restrictions WHERE hotel_id = ? AND room_type = ? AND date = ?`,
+			restriction.HotelID, restriction.RoomType, date)
+
+		return err
+	}
+
+	data, err := json.Marshal(restriction)
+	if err != nil {
+		return fmt.Errorf("failed to encode restriction: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `INSERT INTO restrictions (hotel_id, room_type, date, data) VALUES (?, ?, ?, ?)
+		ON CONFLICT (hotel_id, room_type, date) DO UPDATE SET data = excluded.data`,
+		restriction.HotelID, restriction.RoomType, date, data)
+
+	return err
+}
+
+// GetRestrictions returns the restrictions of the hotel from one date to another inclusive ordered by room type
+// and date. Empty roomType matches any room type.
+func (s *Store) GetRestrictions(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType,
+	from, to time.Time) ([]domain.Restriction, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT data FROM restrictions
+		WHERE hotel_id = ? AND (? = '' OR room_type = ?) AND date >= ? AND date <= ?
+		ORDER BY room_type, date`, hotelID, roomType, roomType, from.Format(dateLayout), to.Format(dateLayout))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	restrictions := []domain.Restriction{}
+
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		var restriction domain.Restriction
+		if err := json.Unmarshal(data, &restriction); err != nil {
+			return nil, fmt.Errorf("failed to decode restriction: %w", err)
+		}
+
+		restrictions = append(restrictions, restriction)
+	}
+
+	return restrictions, rows.Err()
+}
@@ -0,0 +1,36 @@
+package sqlstore
+
+import (
+	"path/filepath"
+	"testing"
+
+	"applicationDesignTest/internal/storage/storagetest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storagetest.Store {
+		store, err := Open(filepath.Join(t.TempDir(), "booking.db"))
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		t.Cleanup(func() { assert.NoError(t, store.Close()) })
+
+		return store
+	})
+}
+
+func TestStateConformance(t *testing.T) {
+	storagetest.RunState(t, func(t *testing.T) storagetest.StateStore {
+		store, err := Open(filepath.Join(t.TempDir(), "booking.db"))
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		t.Cleanup(func() { assert.NoError(t, store.Close()) })
+
+		return store
+	})
+}
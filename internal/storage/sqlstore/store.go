@@ -0,0 +1,805 @@
+// Package sqlstore is a database/sql store of hotels, availability, orders and the rest of the booking state:
+// holds, prices, promo codes, loyalty points, webhooks and sale restrictions. The queries are written
+// for SQLite. The order and availability events are written to the outbox table in the transaction
+// of the change, so the store is also the outbox of the events.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+const dateLayout = "2006-01-02"
+
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens the SQLite database at path and migrates it. Write transactions take the database lock
+// when they begin and wait for it, so concurrent writes are serialized instead of failing.
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?_busy_timeout=5000&_txlock=immediate&_foreign_keys=on", path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	store, err := New(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// New creates the store on the opened database and applies the migrations.
+func New(db *sql.DB) (*Store, error) {
+	if err := migrate(context.Background(), db); err != nil {
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+const hotelColumns = `id, name, address, city, timezone, star_rating, check_in_time, check_out_time, no_show_cutoff`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanHotel(row rowScanner) (*domain.Hotel, error) {
+	var hotel domain.Hotel
+
+	err := row.Scan(&hotel.ID, &hotel.Name, &hotel.Address, &hotel.City, &hotel.Timezone, &hotel.StarRating,
+		&hotel.CheckInTime, &hotel.CheckOutTime, &hotel.NoShowCutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hotel, nil
+}
+
+func (s *Store) GetHotel(ctx context.Context, hotelID domain.HotelID) (*domain.Hotel, error) {
+	hotel, err := scanHotel(s.db.QueryRowContext(ctx, `SELECT `+hotelColumns+` FROM hotels WHERE id = ?`, hotelID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrHotelNotFound
+	}
+
+	return hotel, err
+}
+
+// GetHotels returns all hotels ordered by id.
+func (s *Store) GetHotels(ctx context.Context) ([]domain.Hotel, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+hotelColumns+` FROM hotels ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hotels := []domain.Hotel{}
+
+	for rows.Next() {
+		hotel, err := scanHotel(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		hotels = append(hotels, *hotel)
+	}
+
+	return hotels, rows.Err()
+}
+
+func (s *Store) AddHotel(ctx context.Context, hotel domain.Hotel) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO hotels (`+hotelColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		hotel.ID, hotel.Name, hotel.Address, hotel.City, hotel.Timezone, hotel.StarRating, hotel.CheckInTime, hotel.CheckOutTime,
+		hotel.NoShowCutoff)
+	if isUniqueViolation(err) {
+		return domain.ErrHotelAlreadyExists
+	}
+
+	return err
+}
+
+func (s *Store) UpdateHotel(ctx context.Context, hotel domain.Hotel) error {
+	result, err := s.db.ExecContext(ctx, `UPDATE hotels SET name = ?, address = ?, city = ?, timezone = ?, star_rating = ?,
+		check_in_time = ?, check_out_time = ?, no_show_cutoff = ? WHERE id = ?`,
+		hotel.Name, hotel.Address, hotel.City, hotel.Timezone, hotel.StarRating, hotel.CheckInTime, hotel.CheckOutTime,
+		hotel.NoShowCutoff, hotel.ID)
+	if err != nil {
+		return err
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if updated == 0 {
+		return domain.ErrHotelNotFound
+	}
+
+	return nil
+}
+
+const roomTypeColumns = `hotel_id, code, name, capacity, description, amenities`
+
+func scanRoomType(row rowScanner) (*domain.HotelRoomType, error) {
+	var (
+		roomType  domain.HotelRoomType
+		amenities []byte
+	)
+
+	err := row.Scan(&roomType.HotelID, &roomType.Code, &roomType.Name, &roomType.Capacity, &roomType.Description, &amenities)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(amenities, &roomType.Amenities); err != nil {
+		return nil, fmt.Errorf("failed to decode amenities: %w", err)
+	}
+
+	return &roomType, nil
+}
+
+// GetRoomTypes returns the room type catalog of the hotel ordered by code.
+func (s *Store) GetRoomTypes(ctx context.Context, hotelID domain.HotelID) ([]domain.HotelRoomType, error) {
+	if _, err := s.GetHotel(ctx, hotelID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+roomTypeColumns+` FROM room_types WHERE hotel_id = ? ORDER BY code`, hotelID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roomTypes := []domain.HotelRoomType{}
+
+	for rows.Next() {
+		roomType, err := scanRoomType(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		roomTypes = append(roomTypes, *roomType)
+	}
+
+	return roomTypes, rows.Err()
+}
+
+func (s *Store) GetRoomType(ctx context.Context, hotelID domain.HotelID, code domain.RoomType) (*domain.HotelRoomType, error) {
+	roomType, err := scanRoomType(s.db.QueryRowContext(ctx,
+		`SELECT `+roomTypeColumns+` FROM room_types WHERE hotel_id = ? AND code = ?`, hotelID, code))
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, err := s.GetHotel(ctx, hotelID); err != nil {
+			return nil, err
+		}
+
+		return nil, domain.ErrRoomTypeNotFound
+	}
+
+	return roomType, err
+}
+
+func (s *Store) AddRoomType(ctx context.Context, roomType domain.HotelRoomType) error {
+	amenities, err := encodeAmenities(roomType.Amenities)
+	if err != nil {
+		return err
+	}
+
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		if err := checkHotel(ctx, tx, roomType.HotelID); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, `INSERT INTO room_types (`+roomTypeColumns+`) VALUES (?, ?, ?, ?, ?, ?)`,
+			roomType.HotelID, roomType.Code, roomType.Name, roomType.Capacity, roomType.Description, amenities)
+		if isUniqueViolation(err) {
+			return domain.ErrRoomTypeAlreadyExists
+		}
+
+		return err
+	})
+}
+
+func (s *Store) UpdateRoomType(ctx context.Context, roomType domain.HotelRoomType) error {
+	amenities, err := encodeAmenities(roomType.Amenities)
+	if err != nil {
+		return err
+	}
+
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		if err := checkHotel(ctx, tx, roomType.HotelID); err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `UPDATE room_types SET name = ?, capacity = ?, description = ?, amenities = ?
+			WHERE hotel_id = ? AND code = ?`,
+			roomType.Name, roomType.Capacity, roomType.Description, amenities, roomType.HotelID, roomType.Code)
+		if err != nil {
+			return err
+		}
+
+		updated, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if updated == 0 {
+			return domain.ErrRoomTypeNotFound
+		}
+
+		return nil
+	})
+}
+
+func encodeAmenities(amenities []string) ([]byte, error) {
+	if amenities == nil {
+		amenities = []string{}
+	}
+
+	data, err := json.Marshal(amenities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode amenities: %w", err)
+	}
+
+	return data, nil
+}
+
+// GetRoomAvailability returns the availability of the hotel from one date to another inclusive
+// ordered by room type and date. The dates without availability are omitted.
+func (s *Store) GetRoomAvailability(ctx context.Context, hotelID domain.HotelID, from, to time.Time) ([]domain.RoomAvailability, error) {
+	if _, err := s.GetHotel(ctx, hotelID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT room_type, date, rooms, capacity FROM room_availability
+		WHERE hotel_id = ? AND date BETWEEN ? AND ? ORDER BY room_type, date`,
+		hotelID, from.Format(dateLayout), to.Format(dateLayout))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	availability := []domain.RoomAvailability{}
+
+	for rows.Next() {
+		var (
+			item domain.RoomAvailability
+			date string
+		)
+
+		if err := rows.Scan(&item.RoomType, &date, &item.Rooms, &item.Capacity); err != nil {
+			return nil, err
+		}
+
+		item.HotelID = hotelID
+		if item.Date, err = time.Parse(dateLayout, date); err != nil {
+			return nil, fmt.Errorf("failed to parse date: %w", err)
+		}
+
+		availability = append(availability, item)
+	}
+
+	return availability, rows.Err()
+}
+
+// AddRoomAvailability adds rooms of the room type from the catalog of the hotel.
+func (s *Store) AddRoomAvailability(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		if err := checkRoomType(ctx, tx, hotelID, roomType); err != nil {
+			return err
+		}
+
+		if err := addRooms(ctx, tx, hotelID, roomType, date, rooms, rooms); err != nil {
+			return err
+		}
+
+		return insertEvents(ctx, tx, domain.Event{
+			Type:         domain.EventAvailabilityChanged,
+			Availability: []domain.AvailabilityChange{{HotelID: hotelID, RoomType: roomType, Date: date, Delta: rooms}},
+		})
+	})
+}
+
+// AddRoomAvailabilities adds the rooms of the changes in one transaction.
+func (s *Store) AddRoomAvailabilities(ctx context.Context, changes []domain.AvailabilityChange) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		for _, change := range changes {
+			if err := checkRoomType(ctx, tx, change.HotelID, change.RoomType); err != nil {
+				return err
+			}
+
+			if err := addRooms(ctx, tx, change.HotelID, change.RoomType, change.Date, change.Delta, change.Delta); err != nil {
+				return err
+			}
+		}
+
+		if len(changes) == 0 {
+			return nil
+		}
+
+		return insertEvents(ctx, tx, domain.Event{Type: domain.EventAvailabilityChanged, Availability: changes})
+	})
+}
+
+// SetRoomCapacity sets the number of rooms of the room type on the date. The reserved rooms are kept,
+// so the capacity can't be less than them.
+func (s *Store) SetRoomCapacity(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, capacity int) error {
+	return s.changeCapacity(ctx, hotelID, roomType, date, func(int) int {
+		return capacity
+	})
+}
+
+// ReduceRoomCapacity takes the rooms of the room type on the date out of the capacity. Only free rooms can be taken.
+func (s *Store) ReduceRoomCapacity(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error {
+	return s.changeCapacity(ctx, hotelID, roomType, date, func(capacity int) int {
+		return capacity - rooms
+	})
+}
+
+// changeCapacity replaces the capacity of the date with the one returned by newCapacity keeping the reserved rooms.
+// The transactions take the write lock when they begin, so the row isn't changed between the read and the update.
+func (s *Store) changeCapacity(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time,
+	newCapacity func(capacity int) int) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		if err := checkRoomType(ctx, tx, hotelID, roomType); err != nil {
+			return err
+		}
+
+		var capacity, rooms int
+
+		err := tx.QueryRowContext(ctx, `SELECT capacity, rooms FROM room_availability
+			WHERE hotel_id = ? AND room_type = ? AND date = ?`,
+			hotelID, roomType, date.Format(dateLayout)).Scan(&capacity, &rooms)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		changed := newCapacity(capacity)
+		reserved := max(capacity-rooms, 0)
+
+		if changed < reserved {
+			return &domain.CapacityBelowReservedError{RoomType: roomType, Date: date, Capacity: changed, Reserved: reserved}
+		}
+
+		_, err = tx.ExecContext(ctx, `INSERT INTO room_availability (hotel_id, room_type, date, capacity, rooms) VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (hotel_id, room_type, date) DO UPDATE SET capacity = excluded.capacity, rooms = excluded.rooms`,
+			hotelID, roomType, date.Format(dateLayout), changed, changed-reserved)
+		if err != nil {
+			return err
+		}
+
+		delta := changed - reserved - rooms
+
+		if delta == 0 {
+			return nil
+		}
+
+		return insertEvents(ctx, tx, domain.Event{
+			Type:         domain.EventAvailabilityChanged,
+			Availability: []domain.AvailabilityChange{{HotelID: hotelID, RoomType: roomType, Date: date, Delta: delta}},
+		})
+	})
+}
+
+func (s *Store) Reserve(ctx context.Context, bookings []domain.Booking) error {
+	return s.ReplaceReservation(ctx, nil, bookings)
+}
+
+func (s *Store) Release(ctx context.Context, bookings []domain.Booking) error {
+	return s.ReplaceReservation(ctx, bookings, nil)
+}
+
+// ReplaceReservation releases the nights of the released bookings and reserves the nights of the reserved ones
+// in one transaction. Every night is reserved by a conditional update of its row, so the rooms can't be
+// oversold by concurrent transactions. If the reserved bookings don't fit, nothing is changed.
+func (s *Store) ReplaceReservation(ctx context.Context, released, reserved []domain.Booking) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		for _, booking := range append(append([]domain.Booking{}, released...), reserved...) {
+			if err := checkRoomType(ctx, tx, booking.HotelID, booking.RoomType); err != nil {
+				return err
+			}
+		}
+
+		changes := availabilityChanges(released, reserved)
+
+		for _, change := range changes {
+			if change.Delta > 0 {
+				// the released rooms are free again, the capacity is kept
+				if err := addRooms(ctx, tx, change.HotelID, change.RoomType, change.Date, 0, change.Delta); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			result, err := tx.ExecContext(ctx, `UPDATE room_availability SET rooms = rooms - ?
+				WHERE hotel_id = ? AND room_type = ? AND date = ? AND rooms >= ?`,
+				-change.Delta, change.HotelID, change.RoomType, change.Date.Format(dateLayout), -change.Delta)
+			if err != nil {
+				return err
+			}
+
+			updated, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+
+			if updated == 0 {
+				return fmt.Errorf("%w: room '%s' not available in hotel id=%v for all requested dates",
+					domain.ErrRoomsNotAvailable, change.RoomType, change.HotelID)
+			}
+		}
+
+		if len(changes) == 0 {
+			return nil
+		}
+
+		return insertEvents(ctx, tx, domain.Event{Type: domain.EventAvailabilityChanged, Availability: changes})
+	})
+}
+
+// NextOrderNumber takes the next order number, so the order can be referred to before it's added.
+func (s *Store) NextOrderNumber(ctx context.Context) (domain.OrderNumber, error) {
+	return takeOrderNumber(ctx, s.db)
+}
+
+// AddOrder keeps the number taken by NextOrderNumber or takes the next one for the order.
+func (s *Store) AddOrder(ctx context.Context, order domain.Order) (*domain.Order, error) {
+	if order.CreatedAt.IsZero() {
+		order.CreatedAt = time.Now().UTC()
+	}
+
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		if order.Number == 0 {
+			number, err := takeOrderNumber(ctx, tx)
+			if err != nil {
+				return err
+			}
+
+			order.Number = number
+		}
+
+		_, err := tx.ExecContext(ctx, `INSERT INTO orders (number, id, data) VALUES (?, ?, '{}')`, order.Number, order.ID)
+		if isUniqueViolation(err) {
+			return domain.ErrOrderAlreadyExists
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := putOrder(ctx, tx, order); err != nil {
+			return err
+		}
+
+		return insertEvents(ctx, tx, domain.OrderEvents(nil, order)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+func (s *Store) GetOrderByID(ctx context.Context, id domain.OrderID) (*domain.Order, error) {
+	return getOrder(ctx, s.db, `SELECT data FROM orders WHERE id = ?`, id)
+}
+
+func (s *Store) GetOrderByNumber(ctx context.Context, orderNumber domain.OrderNumber) (*domain.Order, error) {
+	return getOrder(ctx, s.db, `SELECT data FROM orders WHERE number = ?`, orderNumber)
+}
+
+// GetOrders returns all orders ordered by number.
+func (s *Store) GetOrders(ctx context.Context) ([]domain.Order, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT data FROM orders ORDER BY number`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := []domain.Order{}
+
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		var order domain.Order
+		if err := json.Unmarshal(data, &order); err != nil {
+			return nil, fmt.Errorf("failed to decode order: %w", err)
+		}
+
+		orders = append(orders, order)
+	}
+
+	return orders, rows.Err()
+}
+
+// UpdateOrder applies update to the order in a transaction and stores the result if update succeeds.
+func (s *Store) UpdateOrder(ctx context.Context, orderNumber domain.OrderNumber, update func(order *domain.Order) error) (*domain.Order, error) {
+	var updated domain.Order
+
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		current, err := getOrder(ctx, tx, `SELECT data FROM orders WHERE number = ?`, orderNumber)
+		if err != nil {
+			return err
+		}
+
+		updated = *current
+		if err := update(&updated); err != nil {
+			return err
+		}
+
+		// identifiers can't be changed
+		updated.ID = current.ID
+		updated.Number = current.Number
+
+		if err := putOrder(ctx, tx, updated); err != nil {
+			return err
+		}
+
+		return insertEvents(ctx, tx, domain.OrderEvents(current, updated)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// GetEvents returns up to limit outbox events following the event with afterID.
+func (s *Store) GetEvents(ctx context.Context, afterID int64, limit int) ([]domain.Event, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, data FROM outbox_events WHERE id > ? ORDER BY id LIMIT ?`, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []domain.Event
+
+	for rows.Next() {
+		var (
+			id   int64
+			data []byte
+		)
+
+		if err := rows.Scan(&id, &data); err != nil {
+			return nil, err
+		}
+
+		var event domain.Event
+		if err := json.Unmarshal(data, &event); err != nil {
+			return nil, fmt.Errorf("failed to decode event: %w", err)
+		}
+
+		event.ID = id
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// GetOffset returns the ID of the last event delivered to the subscriber.
+func (s *Store) GetOffset(ctx context.Context, subscriber string) (int64, error) {
+	var offset int64
+
+	err := s.db.QueryRowContext(ctx, `SELECT event_id FROM outbox_offsets WHERE subscriber = ?`, subscriber).Scan(&offset)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+
+	return offset, err
+}
+
+func (s *Store) SetOffset(ctx context.Context, subscriber string, offset int64) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO outbox_offsets (subscriber, event_id) VALUES (?, ?)
+		ON CONFLICT (subscriber) DO UPDATE SET event_id = excluded.event_id`, subscriber, offset)
+
+	return err
+}
+
+func (s *Store) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func isUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+
+	return errors.As(err, &sqliteErr) &&
+		(sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey)
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func takeOrderNumber(ctx context.Context, e execer) (domain.OrderNumber, error) {
+	result, err := e.ExecContext(ctx, `INSERT INTO order_numbers DEFAULT VALUES`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to take order number: %w", err)
+	}
+
+	number, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return domain.OrderNumber(number), nil
+}
+
+type queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func getOrder(ctx context.Context, q queryer, query string, arg any) (*domain.Order, error) {
+	var data []byte
+
+	err := q.QueryRowContext(ctx, query, arg).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var order domain.Order
+	if err := json.Unmarshal(data, &order); err != nil {
+		return nil, fmt.Errorf("failed to decode order: %w", err)
+	}
+
+	return &order, nil
+}
+
+func putOrder(ctx context.Context, tx *sql.Tx, order domain.Order) error {
+	data, err := json.Marshal(order)
+	if err != nil {
+		return fmt.Errorf("failed to encode order: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE orders SET data = ? WHERE number = ?`, data, order.Number)
+
+	return err
+}
+
+func insertEvents(ctx context.Context, tx *sql.Tx, events ...domain.Event) error {
+	now := time.Now().UTC()
+
+	for _, event := range events {
+		event.CreatedAt = now
+
+		data, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `INSERT INTO outbox_events (data) VALUES (?)`, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkHotel(ctx context.Context, tx *sql.Tx, hotelID domain.HotelID) error {
+	var exists bool
+
+	err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM hotels WHERE id = ?)`, hotelID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return domain.ErrHotelNotFound
+	}
+
+	return nil
+}
+
+// checkRoomType checks that the room type is in the catalog of the hotel.
+func checkRoomType(ctx context.Context, tx *sql.Tx, hotelID domain.HotelID, roomType domain.RoomType) error {
+	if err := checkHotel(ctx, tx, hotelID); err != nil {
+		return err
+	}
+
+	var exists bool
+
+	err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM room_types WHERE hotel_id = ? AND code = ?)`,
+		hotelID, roomType).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return domain.ErrRoomTypeNotFound
+	}
+
+	return nil
+}
+
+// addRooms adds free rooms and capacity to the date.
+func addRooms(ctx context.Context, tx *sql.Tx, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, capacity, rooms int) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO room_availability (hotel_id, room_type, date, capacity, rooms) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (hotel_id, room_type, date) DO UPDATE
+		SET capacity = capacity + excluded.capacity, rooms = rooms + excluded.rooms`,
+		hotelID, roomType, date.Format(dateLayout), capacity, rooms)
+
+	return err
+}
+
+// availabilityChanges sums the nights of the bookings per room type and date: released nights are added
+// and reserved ones are subtracted. The changes are ordered by hotel, room type and date.
+func availabilityChanges(released, reserved []domain.Booking) []domain.AvailabilityChange {
+	type night struct {
+		hotelID  domain.HotelID
+		roomType domain.RoomType
+		date     time.Time
+	}
+
+	deltas := make(map[night]int)
+
+	add := func(bookings []domain.Booking, sign int) {
+		for _, booking := range bookings {
+			for date := booking.From; !date.After(booking.To); date = date.AddDate(0, 0, 1) {
+				deltas[night{hotelID: booking.HotelID, roomType: booking.RoomType, date: date}] += sign * booking.RoomCount
+			}
+		}
+	}
+
+	add(released, 1)
+	add(reserved, -1)
+
+	changes := make([]domain.AvailabilityChange, 0, len(deltas))
+
+	for n, delta := range deltas {
+		if delta != 0 {
+			changes = append(changes, domain.AvailabilityChange{HotelID: n.hotelID, RoomType: n.roomType, Date: n.date, Delta: delta})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].HotelID != changes[j].HotelID {
+			return changes[i].HotelID < changes[j].HotelID
+		}
+		if changes[i].RoomType != changes[j].RoomType {
+			return changes[i].RoomType < changes[j].RoomType
+		}
+		return changes[i].Date.Before(changes[j].Date)
+	})
+
+	return changes
+}
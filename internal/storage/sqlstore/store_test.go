@@ -0,0 +1,67 @@
+package sqlstore
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func openStore(t *testing.T) *Store {
+	store, err := Open(filepath.Join(t.TempDir(), "booking.db"))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	t.Cleanup(func() { store.Close() })
+
+	ctx := context.Background()
+	assert.NoError(t, store.AddHotel(ctx, domain.Hotel{ID: 1, Name: "Reddison"}))
+	assert.NoError(t, store.AddRoomType(ctx, domain.HotelRoomType{HotelID: 1, Code: "single", Name: "Single", Capacity: 1}))
+	assert.NoError(t, store.AddRoomAvailability(ctx, 1, "single", date.Date(2025, 2, 1), 3))
+	assert.NoError(t, store.AddRoomAvailability(ctx, 1, "single", date.Date(2025, 2, 2), 1))
+
+	return store
+}
+
+func TestStore_Outbox(t *testing.T) {
+	ctx := context.Background()
+	store := openStore(t)
+
+	booking := domain.Booking{HotelID: 1, RoomType: "single", From: date.Date(2025, 2, 1), To: date.Date(2025, 2, 1), RoomCount: 1}
+
+	assert.NoError(t, store.Reserve(ctx, []domain.Booking{booking}))
+	_, err := store.AddOrder(ctx, domain.Order{ID: "1", Status: domain.OrderStatusConfirmed, Bookings: []domain.Booking{booking}})
+	assert.NoError(t, err)
+
+	// failed changes emit no events
+	assert.Error(t, store.Reserve(ctx, []domain.Booking{{HotelID: 1, RoomType: "single",
+		From: date.Date(2025, 2, 1), To: date.Date(2025, 2, 1), RoomCount: 10}}))
+
+	events, err := store.GetEvents(ctx, 2, 10)
+	assert.NoError(t, err)
+
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, int64(3), events[0].ID)
+		assert.Equal(t, domain.EventAvailabilityChanged, events[0].Type)
+		assert.Equal(t, []domain.AvailabilityChange{{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 1), Delta: -1}},
+			events[0].Availability)
+
+		assert.Equal(t, int64(4), events[1].ID)
+		assert.Equal(t, domain.EventOrderCreated, events[1].Type)
+		assert.Equal(t, domain.OrderID("1"), events[1].Order.ID)
+	}
+
+	offset, err := store.GetOffset(ctx, "webhook")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), offset)
+
+	assert.NoError(t, store.SetOffset(ctx, "webhook", 4))
+	offset, err = store.GetOffset(ctx, "webhook")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), offset)
+}
@@ -0,0 +1,186 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+// AddWebhook gives the webhook the next ID. The creation time is set unless it's already known.
+func (s *Store) AddWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error) {
+	if webhook.CreatedAt.IsZero() {
+		webhook.CreatedAt = time.Now().UTC()
+	}
+
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `INSERT INTO webhooks (data) VALUES ('{}')`)
+		if err != nil {
+			return err
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		webhook.ID = domain.WebhookID(id)
+
+		return putWebhook(ctx, tx, webhook)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (s *Store) GetWebhook(ctx context.Context, id domain.WebhookID) (*domain.Webhook, error) {
+	return getWebhook(ctx, s.db, id)
+}
+
+// GetWebhooks returns the webhooks ordered by ID.
+func (s *Store) GetWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT data FROM webhooks ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []domain.Webhook{}
+
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		var webhook domain.Webhook
+		if err := json.Unmarshal(data, &webhook); err != nil {
+			return nil, fmt.Errorf("failed to decode webhook: %w", err)
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// UpdateWebhook replaces the webhook keeping its creation time.
+func (s *Store) UpdateWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error) {
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		current, err := getWebhook(ctx, tx, webhook.ID)
+		if err != nil {
+			return err
+		}
+
+		webhook.CreatedAt = current.CreatedAt
+
+		return putWebhook(ctx, tx, webhook)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (s *Store) DeleteWebhook(ctx context.Context, id domain.WebhookID) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (s *Store) AddDeadLetter(ctx context.Context, deadLetter domain.DeadLetter) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `INSERT INTO dead_letters (data) VALUES ('{}')`)
+		if err != nil {
+			return err
+		}
+
+		if deadLetter.ID, err = result.LastInsertId(); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(deadLetter)
+		if err != nil {
+			return fmt.Errorf("failed to encode dead letter: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE dead_letters SET data = ? WHERE id = ?`, data, deadLetter.ID)
+
+		return err
+	})
+}
+
+func (s *Store) GetDeadLetters(ctx context.Context) ([]domain.DeadLetter, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT data FROM dead_letters ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deadLetters := []domain.DeadLetter{}
+
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		var deadLetter domain.DeadLetter
+		if err := json.Unmarshal(data, &deadLetter); err != nil {
+			return nil, fmt.Errorf("failed to decode dead letter: %w", err)
+		}
+
+		deadLetters = append(deadLetters, deadLetter)
+	}
+
+	return deadLetters, rows.Err()
+}
+
+func getWebhook(ctx context.Context, q queryer, id domain.WebhookID) (*domain.Webhook, error) {
+	var data []byte
+
+	err := q.QueryRowContext(ctx, `SELECT data FROM webhooks WHERE id = ?`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var webhook domain.Webhook
+	if err := json.Unmarshal(data, &webhook); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook: %w", err)
+	}
+
+	return &webhook, nil
+}
+
+func putWebhook(ctx context.Context, tx *sql.Tx, webhook domain.Webhook) error {
+	data, err := json.Marshal(webhook)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE webhooks SET data = ? WHERE id = ?`, data, webhook.ID)
+
+	return err
+}
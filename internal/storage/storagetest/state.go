@@ -0,0 +1,319 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// StateStore is the store of the rest of the booking state under test: holds, prices, promo codes,
+// loyalty points, webhooks and sale restrictions.
+type StateStore interface {
+	AddHold(ctx context.Context, hold domain.Hold) error
+	GetHold(ctx context.Context, token domain.HoldToken) (*domain.Hold, error)
+	DeleteHold(ctx context.Context, token domain.HoldToken) (*domain.Hold, error)
+	GetExpiredHolds(ctx context.Context, now time.Time) ([]domain.Hold, error)
+
+	SetRate(ctx context.Context, rate domain.Rate) error
+	GetRate(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time) (*domain.Rate, error)
+	SetCancellationPolicy(ctx context.Context, policy domain.CancellationPolicy) error
+	GetCancellationPolicy(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType) (*domain.CancellationPolicy, error)
+
+	AddPromo(ctx context.Context, promo domain.Promo) error
+	GetPromo(ctx context.Context, code domain.PromoCode) (*domain.Promo, error)
+	Redeem(ctx context.Context, code domain.PromoCode, userID domain.UserID) error
+	Unredeem(ctx context.Context, code domain.PromoCode, userID domain.UserID) error
+
+	AppendEntry(ctx context.Context, entry domain.LoyaltyEntry) (*domain.LoyaltyEntry, error)
+	GetBalance(ctx context.Context, userID domain.UserID) (int64, error)
+	GetUserEntries(ctx context.Context, userID domain.UserID) ([]domain.LoyaltyEntry, error)
+	GetOrderEntries(ctx context.Context, orderNumber domain.OrderNumber) ([]domain.LoyaltyEntry, error)
+
+	AddWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error)
+	GetWebhook(ctx context.Context, id domain.WebhookID) (*domain.Webhook, error)
+	GetWebhooks(ctx context.Context) ([]domain.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id domain.WebhookID) error
+	AddDeadLetter(ctx context.Context, deadLetter domain.DeadLetter) error
+	GetDeadLetters(ctx context.Context) ([]domain.DeadLetter, error)
+
+	SetRestriction(ctx context.Context, restriction domain.Restriction) error
+	GetRestrictions(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, from, to time.Time) ([]domain.Restriction, error)
+}
+
+// StateFactory creates an empty state store. The store is released by the factory with t.Cleanup.
+type StateFactory func(t *testing.T) StateStore
+
+// RunState runs the conformance suite against the state stores created by newStore.
+func RunState(t *testing.T, newStore StateFactory) {
+	t.Run("holds", func(t *testing.T) { testHolds(t, newStore(t)) })
+	t.Run("rates", func(t *testing.T) { testRates(t, newStore(t)) })
+	t.Run("promos", func(t *testing.T) { testPromos(t, newStore(t)) })
+	t.Run("loyalty", func(t *testing.T) { testLoyalty(t, newStore(t)) })
+	t.Run("webhooks", func(t *testing.T) { testWebhooks(t, newStore(t)) })
+	t.Run("restrictions", func(t *testing.T) { testRestrictions(t, newStore(t)) })
+}
+
+func testHolds(t *testing.T, store StateStore) {
+	ctx := context.Background()
+	expiresAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	_, err := store.GetHold(ctx, "hold-1")
+	assert.ErrorIs(t, err, domain.ErrHoldNotFound)
+
+	hold := domain.Hold{Token: "hold-1", UserID: 1, Bookings: []domain.Booking{booking("single", 1, 2, 1)},
+		Total: domain.Money{Amount: 1000, Currency: "RUB"}, ExpiresAt: expiresAt}
+	later := hold
+	later.Token = "hold-2"
+	later.ExpiresAt = expiresAt.Add(time.Hour)
+
+	assert.NoError(t, store.AddHold(ctx, hold))
+	assert.NoError(t, store.AddHold(ctx, later))
+
+	stored, err := store.GetHold(ctx, "hold-1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, hold, *stored)
+	}
+
+	expired, err := store.GetExpiredHolds(ctx, expiresAt.Add(-time.Second))
+	assert.NoError(t, err)
+	assert.Empty(t, expired)
+
+	expired, err = store.GetExpiredHolds(ctx, expiresAt)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Hold{hold}, expired)
+
+	// only one caller takes the hold
+	deleted, err := store.DeleteHold(ctx, "hold-1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, hold, *deleted)
+	}
+
+	_, err = store.DeleteHold(ctx, "hold-1")
+	assert.ErrorIs(t, err, domain.ErrHoldNotFound)
+}
+
+func testRates(t *testing.T, store StateStore) {
+	ctx := context.Background()
+
+	_, err := store.GetRate(ctx, 1, "single", date.Date(2025, 2, 1))
+	assert.ErrorIs(t, err, domain.ErrRateNotFound)
+
+	assert.NoError(t, store.SetRate(ctx, domain.Rate{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 1),
+		Price: domain.Money{Amount: 1000, Currency: "RUB"}}))
+	assert.NoError(t, store.SetRate(ctx, domain.Rate{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 1),
+		Price: domain.Money{Amount: 1200, Currency: "RUB"}}))
+
+	rate, err := store.GetRate(ctx, 1, "single", date.Date(2025, 2, 1))
+	if assert.NoError(t, err) {
+		assert.Equal(t, domain.Money{Amount: 1200, Currency: "RUB"}, rate.Price)
+		assert.Equal(t, date.Date(2025, 2, 1), rate.Date)
+	}
+
+	_, err = store.GetRate(ctx, 1, "single", date.Date(2025, 2, 2))
+	assert.ErrorIs(t, err, domain.ErrRateNotFound)
+
+	_, err = store.GetCancellationPolicy(ctx, 1, "")
+	assert.ErrorIs(t, err, domain.ErrPolicyNotFound)
+
+	hotelPolicy := domain.CancellationPolicy{HotelID: 1, FreeDays: 3, PenaltyNights: 1}
+	roomPolicy := domain.CancellationPolicy{HotelID: 1, RoomType: "single", NonRefundable: true}
+
+	assert.NoError(t, store.SetCancellationPolicy(ctx, hotelPolicy))
+	assert.NoError(t, store.SetCancellationPolicy(ctx, roomPolicy))
+
+	policy, err := store.GetCancellationPolicy(ctx, 1, "")
+	if assert.NoError(t, err) {
+		assert.Equal(t, hotelPolicy, *policy)
+	}
+
+	policy, err = store.GetCancellationPolicy(ctx, 1, "single")
+	if assert.NoError(t, err) {
+		assert.Equal(t, roomPolicy, *policy)
+	}
+}
+
+func testPromos(t *testing.T, store StateStore) {
+	ctx := context.Background()
+
+	promo := domain.Promo{Code: "WINTER10", DiscountType: domain.DiscountTypePercent, Value: 10,
+		ValidFrom: date.Date(2025, 1, 1), ValidTo: date.Date(2025, 2, 28), MaxUses: 2, MaxUsesPerUser: 1}
+
+	_, err := store.GetPromo(ctx, promo.Code)
+	assert.ErrorIs(t, err, domain.ErrPromoNotFound)
+	assert.ErrorIs(t, store.Redeem(ctx, promo.Code, 1), domain.ErrPromoNotFound)
+
+	assert.NoError(t, store.AddPromo(ctx, promo))
+	assert.ErrorIs(t, store.AddPromo(ctx, promo), domain.ErrPromoAlreadyExists)
+
+	stored, err := store.GetPromo(ctx, promo.Code)
+	if assert.NoError(t, err) {
+		assert.Equal(t, promo, *stored)
+	}
+
+	assert.NoError(t, store.Redeem(ctx, promo.Code, 1))
+	assert.ErrorIs(t, store.Redeem(ctx, promo.Code, 1), domain.ErrPromoUsageLimit)
+	assert.NoError(t, store.Redeem(ctx, promo.Code, 2))
+	assert.ErrorIs(t, store.Redeem(ctx, promo.Code, 3), domain.ErrPromoUsageLimit)
+
+	// a taken back use frees both limits, a use which wasn't counted isn't taken back
+	assert.NoError(t, store.Unredeem(ctx, promo.Code, 3))
+	assert.ErrorIs(t, store.Redeem(ctx, promo.Code, 3), domain.ErrPromoUsageLimit)
+	assert.NoError(t, store.Unredeem(ctx, promo.Code, 1))
+	assert.NoError(t, store.Redeem(ctx, promo.Code, 1))
+}
+
+func testLoyalty(t *testing.T, store StateStore) {
+	ctx := context.Background()
+
+	balance, err := store.GetBalance(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), balance)
+
+	entries, err := store.GetUserEntries(ctx, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	_, err = store.AppendEntry(ctx, domain.LoyaltyEntry{UserID: 1, OrderNumber: 1, Type: domain.LoyaltyEntryRedeem, Points: -1})
+	assert.ErrorIs(t, err, domain.ErrInsufficientPoints)
+
+	earned, err := store.AppendEntry(ctx, domain.LoyaltyEntry{UserID: 1, OrderNumber: 1, Type: domain.LoyaltyEntryEarn, Points: 5})
+	if assert.NoError(t, err) {
+		assert.NotZero(t, earned.ID)
+		assert.False(t, earned.CreatedAt.IsZero())
+	}
+
+	redeemed, err := store.AppendEntry(ctx, domain.LoyaltyEntry{UserID: 1, OrderNumber: 2, Type: domain.LoyaltyEntryRedeem, Points: -3})
+	assert.NoError(t, err)
+
+	_, err = store.AppendEntry(ctx, domain.LoyaltyEntry{UserID: 2, OrderNumber: 3, Type: domain.LoyaltyEntryEarn, Points: 7})
+	assert.NoError(t, err)
+
+	_, err = store.AppendEntry(ctx, domain.LoyaltyEntry{UserID: 1, OrderNumber: 4, Type: domain.LoyaltyEntryRedeem, Points: -3})
+	assert.ErrorIs(t, err, domain.ErrInsufficientPoints)
+
+	balance, err = store.GetBalance(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), balance)
+
+	entries, err = store.GetUserEntries(ctx, 1)
+	if assert.NoError(t, err) && assert.Len(t, entries, 2) {
+		assert.Equal(t, earned.ID, entries[0].ID)
+		assert.True(t, earned.CreatedAt.Equal(entries[0].CreatedAt))
+		assert.Equal(t, redeemed.ID, entries[1].ID)
+		assert.Equal(t, int64(-3), entries[1].Points)
+	}
+
+	entries, err = store.GetOrderEntries(ctx, 2)
+	if assert.NoError(t, err) && assert.Len(t, entries, 1) {
+		assert.Equal(t, domain.LoyaltyEntryRedeem, entries[0].Type)
+	}
+}
+
+func testWebhooks(t *testing.T, store StateStore) {
+	ctx := context.Background()
+
+	_, err := store.GetWebhook(ctx, 1)
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+
+	first, err := store.AddWebhook(ctx, domain.Webhook{HotelID: 1, URL: "https://partner.example.com/hooks", Secret: "secret",
+		EventTypes: []domain.EventType{domain.EventOrderCreated}})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.False(t, first.CreatedAt.IsZero())
+
+	second, err := store.AddWebhook(ctx, domain.Webhook{HotelID: 2, URL: "https://other.example.com/hooks"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Greater(t, second.ID, first.ID)
+
+	// the creation time is kept
+	updated := *first
+	updated.URL = "https://partner.example.com/hooks/v2"
+	updated.CreatedAt = time.Time{}
+
+	_, err = store.UpdateWebhook(ctx, updated)
+	assert.NoError(t, err)
+
+	stored, err := store.GetWebhook(ctx, first.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "https://partner.example.com/hooks/v2", stored.URL)
+		assert.Equal(t, "secret", stored.Secret)
+		assert.True(t, first.CreatedAt.Equal(stored.CreatedAt))
+	}
+
+	_, err = store.UpdateWebhook(ctx, domain.Webhook{ID: 100})
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+
+	assert.NoError(t, store.DeleteWebhook(ctx, second.ID))
+	assert.ErrorIs(t, store.DeleteWebhook(ctx, second.ID), domain.ErrWebhookNotFound)
+
+	// the IDs of the deleted webhooks aren't given again
+	third, err := store.AddWebhook(ctx, domain.Webhook{HotelID: 2, URL: "https://other.example.com/hooks"})
+	if assert.NoError(t, err) {
+		assert.Greater(t, third.ID, second.ID)
+	}
+
+	webhooks, err := store.GetWebhooks(ctx)
+	if assert.NoError(t, err) && assert.Len(t, webhooks, 2) {
+		assert.Equal(t, first.ID, webhooks[0].ID)
+		assert.Equal(t, third.ID, webhooks[1].ID)
+	}
+
+	deadLetters, err := store.GetDeadLetters(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, deadLetters)
+
+	assert.NoError(t, store.AddDeadLetter(ctx, domain.DeadLetter{WebhookID: first.ID, Attempts: 3, LastError: "timeout",
+		Event: domain.Event{ID: 1, Type: domain.EventOrderCreated}}))
+	assert.NoError(t, store.AddDeadLetter(ctx, domain.DeadLetter{WebhookID: first.ID, Attempts: 3, LastError: "refused"}))
+
+	deadLetters, err = store.GetDeadLetters(ctx)
+	if assert.NoError(t, err) && assert.Len(t, deadLetters, 2) {
+		assert.Equal(t, int64(1), deadLetters[0].ID)
+		assert.Equal(t, domain.EventOrderCreated, deadLetters[0].Event.Type)
+		assert.Equal(t, int64(2), deadLetters[1].ID)
+		assert.Equal(t, "refused", deadLetters[1].LastError)
+	}
+}
+
+func testRestrictions(t *testing.T, store StateStore) {
+	ctx := context.Background()
+
+	restrictions, err := store.GetRestrictions(ctx, 1, "", date.Date(2025, 2, 1), date.Date(2025, 2, 28))
+	assert.NoError(t, err)
+	assert.Empty(t, restrictions)
+
+	stopSell := domain.Restriction{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 2), StopSell: true}
+	minStay := domain.Restriction{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 1), MinStay: 2}
+	double := domain.Restriction{HotelID: 1, RoomType: "double", Date: date.Date(2025, 2, 3), ClosedToArrival: true}
+	otherHotel := domain.Restriction{HotelID: 2, RoomType: "single", Date: date.Date(2025, 2, 1), MaxStay: 5}
+
+	for _, restriction := range []domain.Restriction{stopSell, minStay, double, otherHotel} {
+		assert.NoError(t, store.SetRestriction(ctx, restriction))
+	}
+
+	restrictions, err = store.GetRestrictions(ctx, 1, "", date.Date(2025, 2, 1), date.Date(2025, 2, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Restriction{double, minStay, stopSell}, restrictions)
+
+	restrictions, err = store.GetRestrictions(ctx, 1, "single", date.Date(2025, 2, 2), date.Date(2025, 2, 28))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Restriction{stopSell}, restrictions)
+
+	// a restriction without rules lifts the previous one
+	assert.NoError(t, store.SetRestriction(ctx, domain.Restriction{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 2)}))
+
+	restrictions, err = store.GetRestrictions(ctx, 1, "single", date.Date(2025, 2, 1), date.Date(2025, 2, 28))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Restriction{minStay}, restrictions)
+}
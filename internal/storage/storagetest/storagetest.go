@@ -0,0 +1,592 @@
+// Package storagetest is the conformance test suite of the stores. A store backend is validated by calling
+// Run with a factory of empty hotel and order stores and RunState with a factory of empty stores of the rest
+// of the booking state.
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Store is the hotel and order store under test.
+type Store interface {
+	GetHotel(ctx context.Context, hotelID domain.HotelID) (*domain.Hotel, error)
+	GetHotels(ctx context.Context) ([]domain.Hotel, error)
+	AddHotel(ctx context.Context, hotel domain.Hotel) error
+	UpdateHotel(ctx context.Context, hotel domain.Hotel) error
+	GetRoomTypes(ctx context.Context, hotelID domain.HotelID) ([]domain.HotelRoomType, error)
+	GetRoomType(ctx context.Context, hotelID domain.HotelID, code domain.RoomType) (*domain.HotelRoomType, error)
+	AddRoomType(ctx context.Context, roomType domain.HotelRoomType) error
+	UpdateRoomType(ctx context.Context, roomType domain.HotelRoomType) error
+	GetRoomAvailability(ctx context.Context, hotelID domain.HotelID, from, to time.Time) ([]domain.RoomAvailability, error)
+	AddRoomAvailability(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error
+	AddRoomAvailabilities(ctx context.Context, changes []domain.AvailabilityChange) error
+	SetRoomCapacity(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, capacity int) error
+	ReduceRoomCapacity(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error
+	Reserve(ctx context.Context, bookings []domain.Booking) error
+	Release(ctx context.Context, bookings []domain.Booking) error
+	ReplaceReservation(ctx context.Context, released, reserved []domain.Booking) error
+
+	NextOrderNumber(ctx context.Context) (domain.OrderNumber, error)
+	AddOrder(ctx context.Context, order domain.Order) (*domain.Order, error)
+	GetOrderByID(ctx context.Context, id domain.OrderID) (*domain.Order, error)
+	GetOrderByNumber(ctx context.Context, orderNumber domain.OrderNumber) (*domain.Order, error)
+	UpdateOrder(ctx context.Context, orderNumber domain.OrderNumber, update func(order *domain.Order) error) (*domain.Order, error)
+}
+
+// Factory creates an empty store. The store is released by the factory with t.Cleanup.
+type Factory func(t *testing.T) Store
+
+// Run runs the conformance suite against the stores created by newStore.
+func Run(t *testing.T, newStore Factory) {
+	t.Run("hotels", func(t *testing.T) { testHotels(t, newStore) })
+	t.Run("room types", func(t *testing.T) { testRoomTypes(t, newStore) })
+	t.Run("room availability", func(t *testing.T) { testRoomAvailability(t, newStore) })
+	t.Run("room capacity", func(t *testing.T) { testRoomCapacity(t, newStore) })
+	t.Run("room availabilities", func(t *testing.T) { testRoomAvailabilities(t, newStore) })
+	t.Run("set room capacity", func(t *testing.T) { testSetRoomCapacity(t, newStore) })
+	t.Run("reduce room capacity", func(t *testing.T) { testReduceRoomCapacity(t, newStore) })
+	t.Run("reserve", func(t *testing.T) { testReserve(t, newStore) })
+	t.Run("release", func(t *testing.T) { testRelease(t, newStore) })
+	t.Run("replace reservation", func(t *testing.T) { testReplaceReservation(t, newStore) })
+	t.Run("concurrent reserve", func(t *testing.T) { testConcurrentReserve(t, newStore) })
+	t.Run("order numbers", func(t *testing.T) { testOrderNumbers(t, newStore) })
+	t.Run("orders", func(t *testing.T) { testOrders(t, newStore) })
+}
+
+func booking(roomType domain.RoomType, from, to, rooms int) domain.Booking {
+	return domain.Booking{HotelID: 1, RoomType: roomType, From: date.Date(2025, 2, from), To: date.Date(2025, 2, to), RoomCount: rooms}
+}
+
+// newHotel creates a store with hotel 1 which has single and double room types, 3 single rooms on February 1
+// and 1 on February 2.
+func newHotel(t *testing.T, newStore Factory) Store {
+	ctx := context.Background()
+	store := newStore(t)
+
+	assert.NoError(t, store.AddHotel(ctx, domain.Hotel{ID: 1, Name: "Reddison"}))
+	assert.NoError(t, store.AddRoomType(ctx, domain.HotelRoomType{HotelID: 1, Code: "single", Name: "Single", Capacity: 1}))
+	assert.NoError(t, store.AddRoomType(ctx, domain.HotelRoomType{HotelID: 1, Code: "double", Name: "Double", Capacity: 2}))
+	assert.NoError(t, store.AddRoomAvailability(ctx, 1, "single", date.Date(2025, 2, 1), 3))
+	assert.NoError(t, store.AddRoomAvailability(ctx, 1, "single", date.Date(2025, 2, 2), 1))
+
+	return store
+}
+
+// assertRooms checks the rooms available on February 1 and 2 by reserving them all and releasing them back.
+func assertRooms(t *testing.T, store Store, first, second int) {
+	ctx := context.Background()
+
+	for day, rooms := range map[int]int{1: first, 2: second} {
+		if rooms > 0 {
+			if assert.NoError(t, store.Reserve(ctx, []domain.Booking{booking("single", day, day, rooms)}), "February %d", day) {
+				assert.NoError(t, store.Release(ctx, []domain.Booking{booking("single", day, day, rooms)}))
+			}
+		}
+
+		assert.ErrorIs(t, store.Reserve(ctx, []domain.Booking{booking("single", day, day, rooms+1)}),
+			domain.ErrRoomsNotAvailable, "February %d", day)
+	}
+}
+
+func testHotels(t *testing.T, newStore Factory) {
+	ctx := context.Background()
+	store := newStore(t)
+
+	_, err := store.GetHotel(ctx, 1)
+	assert.ErrorIs(t, err, domain.ErrHotelNotFound)
+
+	hotels, err := store.GetHotels(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, hotels)
+
+	assert.NoError(t, store.AddHotel(ctx, domain.Hotel{ID: 2, Name: "Cosmos"}))
+	assert.NoError(t, store.AddHotel(ctx, domain.Hotel{ID: 1, Name: "Reddison"}))
+	assert.ErrorIs(t, store.AddHotel(ctx, domain.Hotel{ID: 1, Name: "Duplicate"}), domain.ErrHotelAlreadyExists)
+
+	hotel, err := store.GetHotel(ctx, 1)
+	if assert.NoError(t, err) {
+		assert.Equal(t, domain.Hotel{ID: 1, Name: "Reddison"}, *hotel)
+	}
+
+	updated := domain.Hotel{ID: 1, Name: "Reddison Slavyanskaya", Address: "Europe Square, 2", City: "Moscow",
+		Timezone: "Europe/Moscow", StarRating: 5, CheckInTime: "14:00", CheckOutTime: "12:00",
+		NoShowCutoff: "06:00"}
+
+	assert.NoError(t, store.UpdateHotel(ctx, updated))
+	assert.ErrorIs(t, store.UpdateHotel(ctx, domain.Hotel{ID: 3, Name: "Unknown"}), domain.ErrHotelNotFound)
+
+	hotel, err = store.GetHotel(ctx, 1)
+	if assert.NoError(t, err) {
+		assert.Equal(t, updated, *hotel)
+	}
+
+	hotels, err = store.GetHotels(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Hotel{updated, {ID: 2, Name: "Cosmos"}}, hotels)
+}
+
+func testRoomTypes(t *testing.T, newStore Factory) {
+	ctx := context.Background()
+	store := newStore(t)
+
+	suite := domain.HotelRoomType{HotelID: 1, Code: "suite", Name: "Suite", Capacity: 3,
+		Description: "Two rooms with a view", Amenities: []string{"minibar", "bathtub"}}
+
+	assert.ErrorIs(t, store.AddRoomType(ctx, suite), domain.ErrHotelNotFound)
+
+	_, err := store.GetRoomTypes(ctx, 1)
+	assert.ErrorIs(t, err, domain.ErrHotelNotFound)
+
+	assert.NoError(t, store.AddHotel(ctx, domain.Hotel{ID: 1, Name: "Reddison"}))
+
+	roomTypes, err := store.GetRoomTypes(ctx, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, roomTypes)
+
+	dorm := domain.HotelRoomType{HotelID: 1, Code: "dorm", Name: "Dorm bed", Capacity: 1}
+
+	assert.NoError(t, store.AddRoomType(ctx, suite))
+	assert.NoError(t, store.AddRoomType(ctx, dorm))
+	assert.ErrorIs(t, store.AddRoomType(ctx, suite), domain.ErrRoomTypeAlreadyExists)
+
+	_, err = store.GetRoomType(ctx, 1, "family")
+	assert.ErrorIs(t, err, domain.ErrRoomTypeNotFound)
+
+	suite.Capacity = 4
+	suite.Amenities = nil
+
+	assert.NoError(t, store.UpdateRoomType(ctx, suite))
+	assert.ErrorIs(t, store.UpdateRoomType(ctx, domain.HotelRoomType{HotelID: 1, Code: "family", Name: "Family", Capacity: 4}),
+		domain.ErrRoomTypeNotFound)
+
+	roomType, err := store.GetRoomType(ctx, 1, "suite")
+	if assert.NoError(t, err) {
+		assert.Equal(t, suite.Capacity, roomType.Capacity)
+		assert.Empty(t, roomType.Amenities)
+	}
+
+	roomTypes, err = store.GetRoomTypes(ctx, 1)
+	if assert.NoError(t, err) && assert.Len(t, roomTypes, 2) {
+		assert.Equal(t, dorm.Code, roomTypes[0].Code)
+		assert.Equal(t, suite.Code, roomTypes[1].Code)
+	}
+}
+
+func testRoomAvailability(t *testing.T, newStore Factory) {
+	ctx := context.Background()
+	store := newHotel(t, newStore)
+
+	assert.ErrorIs(t, store.AddRoomAvailability(ctx, 2, "single", date.Date(2025, 2, 1), 1), domain.ErrHotelNotFound)
+	assert.ErrorIs(t, store.AddRoomAvailability(ctx, 1, "suite", date.Date(2025, 2, 1), 1), domain.ErrRoomTypeNotFound)
+
+	// availability is added to the existing one
+	assert.NoError(t, store.AddRoomAvailability(ctx, 1, "single", date.Date(2025, 2, 2), 2))
+	assertRooms(t, store, 3, 3)
+
+	_, err := store.GetRoomAvailability(ctx, 2, date.Date(2025, 2, 1), date.Date(2025, 2, 2))
+	assert.ErrorIs(t, err, domain.ErrHotelNotFound)
+
+	assert.NoError(t, store.AddRoomAvailability(ctx, 1, "double", date.Date(2025, 2, 3), 1))
+
+	availability, err := store.GetRoomAvailability(ctx, 1, date.Date(2025, 2, 2), date.Date(2025, 2, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.RoomAvailability{
+		{HotelID: 1, RoomType: "double", Date: date.Date(2025, 2, 3), Rooms: 1, Capacity: 1},
+		{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 2), Rooms: 3, Capacity: 3},
+	}, availability)
+
+	// the hotel update keeps the availability
+	assert.NoError(t, store.UpdateHotel(ctx, domain.Hotel{ID: 1, Name: "Reddison", City: "Moscow"}))
+	assertRooms(t, store, 3, 3)
+}
+
+func testRoomCapacity(t *testing.T, newStore Factory) {
+	ctx := context.Background()
+	store := newHotel(t, newStore)
+
+	assert.NoError(t, store.Reserve(ctx, []domain.Booking{booking("single", 1, 2, 1)}))
+	// the rooms added after the reservation are free
+	assert.NoError(t, store.AddRoomAvailability(ctx, 1, "single", date.Date(2025, 2, 1), 2))
+
+	availability, err := store.GetRoomAvailability(ctx, 1, date.Date(2025, 2, 1), date.Date(2025, 2, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.RoomAvailability{
+		{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 1), Rooms: 4, Capacity: 5},
+		{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 2), Rooms: 0, Capacity: 1},
+	}, availability)
+
+	// the released rooms are free again, the capacity is kept
+	assert.NoError(t, store.Release(ctx, []domain.Booking{booking("single", 1, 2, 1)}))
+
+	availability, err = store.GetRoomAvailability(ctx, 1, date.Date(2025, 2, 1), date.Date(2025, 2, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.RoomAvailability{
+		{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 1), Rooms: 5, Capacity: 5},
+		{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 2), Rooms: 1, Capacity: 1},
+	}, availability)
+}
+
+func testRoomAvailabilities(t *testing.T, newStore Factory) {
+	ctx := context.Background()
+	store := newHotel(t, newStore)
+
+	// nothing is added if one of the room types isn't found
+	assert.ErrorIs(t, store.AddRoomAvailabilities(ctx, []domain.AvailabilityChange{
+		{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 1), Delta: 1},
+		{HotelID: 1, RoomType: "suite", Date: date.Date(2025, 2, 1), Delta: 1},
+	}), domain.ErrRoomTypeNotFound)
+	assertRooms(t, store, 3, 1)
+
+	assert.ErrorIs(t, store.AddRoomAvailabilities(ctx, []domain.AvailabilityChange{
+		{HotelID: 2, RoomType: "single", Date: date.Date(2025, 2, 1), Delta: 1},
+	}), domain.ErrHotelNotFound)
+
+	assert.NoError(t, store.AddRoomAvailabilities(ctx, []domain.AvailabilityChange{
+		{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 1), Delta: 1},
+		{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 3), Delta: 2},
+		{HotelID: 1, RoomType: "double", Date: date.Date(2025, 2, 1), Delta: 1},
+	}))
+
+	availability, err := store.GetRoomAvailability(ctx, 1, date.Date(2025, 2, 1), date.Date(2025, 2, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.RoomAvailability{
+		{HotelID: 1, RoomType: "double", Date: date.Date(2025, 2, 1), Rooms: 1, Capacity: 1},
+		{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 1), Rooms: 4, Capacity: 4},
+		{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 2), Rooms: 1, Capacity: 1},
+		{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, 3), Rooms: 2, Capacity: 2},
+	}, availability)
+}
+
+// assertCapacity checks the capacity and the free rooms of single rooms on the date of February.
+func assertCapacity(t *testing.T, store Store, day, capacity, rooms int) {
+	availability, err := store.GetRoomAvailability(context.Background(), 1, date.Date(2025, 2, day), date.Date(2025, 2, day))
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.RoomAvailability{
+		{HotelID: 1, RoomType: "single", Date: date.Date(2025, 2, day), Rooms: rooms, Capacity: capacity},
+	}, availability, "February %d", day)
+}
+
+func testSetRoomCapacity(t *testing.T, newStore Factory) {
+	ctx := context.Background()
+	store := newHotel(t, newStore)
+
+	assert.NoError(t, store.Reserve(ctx, []domain.Booking{booking("single", 1, 1, 2)}))
+
+	assert.NoError(t, store.SetRoomCapacity(ctx, 1, "single", date.Date(2025, 2, 1), 5))
+	assertCapacity(t, store, 1, 5, 3)
+
+	err := store.SetRoomCapacity(ctx, 1, "single", date.Date(2025, 2, 1), 1)
+	assert.ErrorIs(t, err, domain.ErrCapacityBelowReserved)
+
+	var capacityErr *domain.CapacityBelowReservedError
+	if assert.ErrorAs(t, err, &capacityErr) {
+		assert.Equal(t, 2, capacityErr.Reserved)
+		assert.Equal(t, 1, capacityErr.Capacity)
+	}
+
+	assertCapacity(t, store, 1, 5, 3)
+
+	// all free rooms are taken out
+	assert.NoError(t, store.SetRoomCapacity(ctx, 1, "single", date.Date(2025, 2, 1), 2))
+	assertCapacity(t, store, 1, 2, 0)
+
+	// a date without availability
+	assert.NoError(t, store.SetRoomCapacity(ctx, 1, "single", date.Date(2025, 2, 3), 4))
+	assertCapacity(t, store, 3, 4, 4)
+
+	assert.ErrorIs(t, store.SetRoomCapacity(ctx, 1, "suite", date.Date(2025, 2, 1), 1), domain.ErrRoomTypeNotFound)
+	assert.ErrorIs(t, store.SetRoomCapacity(ctx, 2, "single", date.Date(2025, 2, 1), 1), domain.ErrHotelNotFound)
+}
+
+func testReduceRoomCapacity(t *testing.T, newStore Factory) {
+	ctx := context.Background()
+	store := newHotel(t, newStore)
+
+	assert.NoError(t, store.Reserve(ctx, []domain.Booking{booking("single", 1, 1, 2)}))
+
+	assert.NoError(t, store.ReduceRoomCapacity(ctx, 1, "single", date.Date(2025, 2, 1), 1))
+	assertCapacity(t, store, 1, 2, 0)
+
+	// the reserved rooms can't be taken
+	assert.ErrorIs(t, store.ReduceRoomCapacity(ctx, 1, "single", date.Date(2025, 2, 1), 1), domain.ErrCapacityBelowReserved)
+	assertCapacity(t, store, 1, 2, 0)
+
+	assert.NoError(t, store.ReduceRoomCapacity(ctx, 1, "single", date.Date(2025, 2, 2), 1))
+	assertCapacity(t, store, 2, 0, 0)
+	assertRooms(t, store, 0, 0)
+}
+
+func testReserve(t *testing.T, newStore Factory) {
+	tests := []struct {
+		name          string
+		bookings      []domain.Booking
+		expectedRooms [2]int
+		expectedError error
+	}{
+		{
+			name:          "should reserve rooms for all nights",
+			bookings:      []domain.Booking{booking("single", 1, 2, 1)},
+			expectedRooms: [2]int{2, 0},
+		},
+		{
+			name:          "should sum bookings of the same room type",
+			bookings:      []domain.Booking{booking("single", 1, 1, 2), booking("single", 1, 1, 1)},
+			expectedRooms: [2]int{0, 1},
+		},
+		{
+			name:          "should reserve nothing if one night is not available",
+			bookings:      []domain.Booking{booking("single", 1, 2, 2)},
+			expectedRooms: [2]int{3, 1},
+			expectedError: domain.ErrRoomsNotAvailable,
+		},
+		{
+			name:          "should reserve nothing if one booking is not available",
+			bookings:      []domain.Booking{booking("single", 1, 1, 1), booking("single", 2, 2, 2)},
+			expectedRooms: [2]int{3, 1},
+			expectedError: domain.ErrRoomsNotAvailable,
+		},
+		{
+			name:          "night without availability",
+			bookings:      []domain.Booking{booking("single", 3, 3, 1)},
+			expectedRooms: [2]int{3, 1},
+			expectedError: domain.ErrRoomsNotAvailable,
+		},
+		{
+			name:          "room type without availability",
+			bookings:      []domain.Booking{booking("single", 1, 1, 1), booking("double", 1, 1, 1)},
+			expectedRooms: [2]int{3, 1},
+			expectedError: domain.ErrRoomsNotAvailable,
+		},
+		{
+			name:          "room type not found",
+			bookings:      []domain.Booking{booking("single", 1, 1, 1), booking("suite", 1, 1, 1)},
+			expectedRooms: [2]int{3, 1},
+			expectedError: domain.ErrRoomTypeNotFound,
+		},
+		{
+			name: "hotel not found",
+			bookings: []domain.Booking{
+				{HotelID: 2, RoomType: "single", From: date.Date(2025, 2, 1), To: date.Date(2025, 2, 1), RoomCount: 1},
+			},
+			expectedRooms: [2]int{3, 1},
+			expectedError: domain.ErrHotelNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newHotel(t, newStore)
+
+			err := store.Reserve(context.Background(), tt.bookings)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assertRooms(t, store, tt.expectedRooms[0], tt.expectedRooms[1])
+		})
+	}
+}
+
+func testRelease(t *testing.T, newStore Factory) {
+	ctx := context.Background()
+	store := newHotel(t, newStore)
+
+	assert.NoError(t, store.Reserve(ctx, []domain.Booking{booking("single", 1, 2, 1)}))
+	assert.NoError(t, store.Release(ctx, []domain.Booking{booking("single", 1, 2, 1)}))
+	assertRooms(t, store, 3, 1)
+}
+
+func testReplaceReservation(t *testing.T, newStore Factory) {
+	ctx := context.Background()
+	store := newHotel(t, newStore)
+
+	assert.NoError(t, store.Reserve(ctx, []domain.Booking{booking("single", 1, 2, 1)}))
+
+	// the released night is available to the reserved bookings
+	assert.NoError(t, store.ReplaceReservation(ctx,
+		[]domain.Booking{booking("single", 1, 2, 1)}, []domain.Booking{booking("single", 2, 2, 1)}))
+	assertRooms(t, store, 3, 0)
+
+	// the reservation is kept if the new bookings don't fit
+	assert.ErrorIs(t, store.ReplaceReservation(ctx,
+		[]domain.Booking{booking("single", 2, 2, 1)}, []domain.Booking{booking("single", 1, 1, 4)}), domain.ErrRoomsNotAvailable)
+	assertRooms(t, store, 3, 0)
+}
+
+func testConcurrentReserve(t *testing.T, newStore Factory) {
+	store := newHotel(t, newStore)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		reserved int
+	)
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			err := store.Reserve(context.Background(), []domain.Booking{booking("single", 1, 1, 1)})
+			if err != nil {
+				assert.ErrorIs(t, err, domain.ErrRoomsNotAvailable)
+				return
+			}
+
+			mu.Lock()
+			reserved++
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 3, reserved)
+	assertRooms(t, store, 0, 1)
+}
+
+func testOrderNumbers(t *testing.T, newStore Factory) {
+	ctx := context.Background()
+	store := newStore(t)
+
+	first, err := store.AddOrder(ctx, domain.Order{ID: "first"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		numbers = make(map[domain.OrderNumber]bool)
+	)
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			order, err := store.AddOrder(ctx, domain.Order{ID: domain.OrderID(fmt.Sprint(i))})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			mu.Lock()
+			assert.False(t, numbers[order.Number], "duplicate number %d", order.Number)
+			numbers[order.Number] = true
+			mu.Unlock()
+		}(i)
+	}
+
+	wg.Wait()
+
+	for number := range numbers {
+		assert.Greater(t, number, first.Number)
+	}
+
+	taken, err := store.NextOrderNumber(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	last, err := store.AddOrder(ctx, domain.Order{ID: "last"})
+	if assert.NoError(t, err) {
+		for number := range numbers {
+			assert.Less(t, number, last.Number)
+		}
+
+		assert.Greater(t, last.Number, taken, "taken number is reused")
+	}
+
+	// the order keeps the number taken for it
+	order, err := store.AddOrder(ctx, domain.Order{ID: "taken", Number: taken})
+	if assert.NoError(t, err) {
+		assert.Equal(t, taken, order.Number)
+	}
+
+	byNumber, err := store.GetOrderByNumber(ctx, taken)
+	if assert.NoError(t, err) {
+		assert.Equal(t, domain.OrderID("taken"), byNumber.ID)
+	}
+}
+
+func testOrders(t *testing.T, newStore Factory) {
+	ctx := context.Background()
+	store := newStore(t)
+
+	_, err := store.GetOrderByID(ctx, "1")
+	assert.ErrorIs(t, err, domain.ErrOrderNotFound)
+
+	_, err = store.GetOrderByNumber(ctx, 1)
+	assert.ErrorIs(t, err, domain.ErrOrderNotFound)
+
+	_, err = store.UpdateOrder(ctx, 1, func(order *domain.Order) error { return nil })
+	assert.ErrorIs(t, err, domain.ErrOrderNotFound)
+
+	// the order is built anew for each comparison, so the stored order doesn't share the slices with the expected one
+	newBookings := func() []domain.Booking {
+		withGuests := booking("single", 1, 2, 1)
+		withGuests.Rooms = []domain.RoomOccupancy{{Adults: 1, GuestNames: []string{"Ivan Petrov"}}}
+
+		return []domain.Booking{withGuests}
+	}
+	newPayments := func() []domain.Payment {
+		return []domain.Payment{{ID: "p1", Amount: domain.Money{Amount: 1000, Currency: "RUB"},
+			Refunded: domain.Money{Currency: "RUB"}}}
+	}
+
+	added, err := store.AddOrder(ctx, domain.Order{ID: "1", Status: domain.OrderStatusConfirmed,
+		Bookings: newBookings(), Payments: newPayments()})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.False(t, added.CreatedAt.IsZero())
+
+	_, err = store.AddOrder(ctx, domain.Order{ID: "1", Status: domain.OrderStatusConfirmed})
+	assert.ErrorIs(t, err, domain.ErrOrderAlreadyExists)
+
+	_, err = store.UpdateOrder(ctx, added.Number, func(order *domain.Order) error {
+		order.ID = "2"
+		return order.ChangeStatus(domain.OrderStatusCheckedIn)
+	})
+	assert.NoError(t, err)
+
+	_, err = store.UpdateOrder(ctx, added.Number, func(order *domain.Order) error {
+		order.Status = domain.OrderStatusCancelled
+		order.Payments[0].Refunded.Amount += 500
+		order.Bookings[0].Rooms[0].GuestNames[0] = "Petr Ivanov"
+		return errors.New("update failed")
+	})
+	assert.Error(t, err)
+
+	byID, err := store.GetOrderByID(ctx, "1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, added.Number, byID.Number)
+		assert.Equal(t, domain.OrderStatusCheckedIn, byID.Status)
+		assert.Equal(t, newBookings(), byID.Bookings)
+		assert.Equal(t, newPayments(), byID.Payments)
+	}
+
+	byNumber, err := store.GetOrderByNumber(ctx, added.Number)
+	if assert.NoError(t, err) {
+		assert.Equal(t, byID, byNumber)
+	}
+
+	_, err = store.GetOrderByID(ctx, "2")
+	assert.ErrorIs(t, err, domain.ErrOrderNotFound)
+}
@@ -4,32 +4,86 @@ package booking
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
 
 	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/keylock"
 )
 
 type hotelRepository interface {
+	GetHotel(ctx context.Context, id domain.HotelID) (*domain.Hotel, error)
 	Reserve(ctx context.Context, bookings []domain.Booking) error
-	AddRoomAvailability(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error
+	Release(ctx context.Context, bookings []domain.Booking) error
+	ReplaceReservation(ctx context.Context, released, reserved []domain.Booking) error
 }
 
 type orderService interface {
+	NextOrderNumber(ctx context.Context) (domain.OrderNumber, error)
 	AddOrder(ctx context.Context, order domain.Order) (*domain.Order, error)
 	GetOrderByID(ctx context.Context, id domain.OrderID) (*domain.Order, error)
+	GetOrderByNumber(ctx context.Context, orderNumber domain.OrderNumber) (*domain.Order, error)
+	UpdateOrder(ctx context.Context, orderNumber domain.OrderNumber, update func(order *domain.Order) error) (*domain.Order, error)
+}
+
+type pricingService interface {
+	Quote(ctx context.Context, bookings []domain.Booking) ([]domain.PriceLine, domain.Money, error)
+	CancellationPolicies(ctx context.Context, bookings []domain.Booking) ([]domain.CancellationPolicy, error)
+}
+
+type promoService interface {
+	ApplyPromo(ctx context.Context, code domain.PromoCode, order domain.Order) (*domain.Discount, error)
+	RedeemPromo(ctx context.Context, code domain.PromoCode, userID domain.UserID) error
+	RevertPromo(ctx context.Context, code domain.PromoCode, userID domain.UserID) error
+}
+
+type loyaltyService interface {
+	RedeemPoints(ctx context.Context, order domain.Order) (*domain.Discount, error)
+	EarnPoints(ctx context.Context, order domain.Order) error
+	ReverseOrder(ctx context.Context, order domain.Order) error
+}
+
+type restrictionService interface {
+	CheckRestrictions(ctx context.Context, bookings []domain.Booking) error
+}
+
+// paymentProvider is the payment gateway taking the money from the guest's card.
+type paymentProvider interface {
+	Authorize(ctx context.Context, key domain.PaymentKey, token domain.PaymentToken, amount domain.Money) (domain.PaymentID, error)
+	FindPayment(ctx context.Context, key domain.PaymentKey) (domain.PaymentID, error)
+	Capture(ctx context.Context, id domain.PaymentID, amount domain.Money) error
+	Void(ctx context.Context, id domain.PaymentID) error
+	Refund(ctx context.Context, id domain.PaymentID, amount domain.Money) error
 }
 
 type BookingService struct {
-	hotelStore   hotelRepository
-	orderService orderService
+	hotelStore         hotelRepository
+	orderService       orderService
+	pricingService     pricingService
+	promoService       promoService
+	loyaltyService     loyaltyService
+	restrictionService restrictionService
+	paymentProvider    paymentProvider
+	orderLocks         *keylock.Locks[domain.OrderNumber]
+	now                func() time.Time
 }
 
-func NewBookingService(hotelStore hotelRepository, orderService orderService) *BookingService {
+func NewBookingService(hotelStore hotelRepository, orderService orderService, pricingService pricingService,
+	promoService promoService, loyaltyService loyaltyService, restrictionService restrictionService,
+	paymentProvider paymentProvider) *BookingService {
 	return &BookingService{
-		hotelStore:   hotelStore,
-		orderService: orderService,
+		hotelStore:         hotelStore,
+		orderService:       orderService,
+		pricingService:     pricingService,
+		promoService:       promoService,
+		loyaltyService:     loyaltyService,
+		restrictionService: restrictionService,
+		paymentProvider:    paymentProvider,
+		orderLocks:         keylock.New[domain.OrderNumber](),
+		now:                time.Now,
 	}
 }
 
@@ -46,13 +100,675 @@ func (bs *BookingService) CreateOrder(ctx context.Context, order domain.Order) (
 		return existOrder, domain.ErrOrderAlreadyExists
 	}
 
+	if err := bs.restrictionService.CheckRestrictions(ctx, order.Bookings); err != nil {
+		return nil, err
+	}
+
+	order.Lines, order.Subtotal, err = bs.pricingService.Quote(ctx, order.Bookings)
+	if err != nil {
+		return nil, err
+	}
+
+	order.CancellationPolicies, err = bs.pricingService.CancellationPolicies(ctx, order.Bookings)
+	if err != nil {
+		return nil, err
+	}
+
+	order.Discounts = nil
+
+	// the discounts are known before the reservation, the loyalty points are debited before it
+	// and the promo code use is counted after it, everything is taken back if the order isn't placed
+	if order.PromoCode != "" {
+		discount, err := bs.promoService.ApplyPromo(ctx, order.PromoCode, order)
+		if err != nil {
+			return nil, err
+		}
+
+		order.Discounts = append(order.Discounts, *discount)
+	}
+
+	var done placement
+
+	if order.LoyaltyPoints > 0 {
+		// the ledger keeps the points by the order number, so it's taken before the points are redeemed
+		order.Number, err = bs.orderService.NextOrderNumber(ctx)
+		if err != nil {
+			return nil, bs.rollback(ctx, order, done, err)
+		}
+
+		order.RecalculateTotal()
+
+		discount, err := bs.loyaltyService.RedeemPoints(ctx, order)
+		if err != nil {
+			return nil, bs.rollback(ctx, order, done, err)
+		}
+
+		order.Discounts = append(order.Discounts, *discount)
+	}
+
 	if err := bs.hotelStore.Reserve(ctx, order.Bookings); err != nil {
+		return nil, bs.rollback(ctx, order, done, err)
+	}
+
+	done.reserved = true
+
+	if order.PromoCode != "" {
+		if err := bs.promoService.RedeemPromo(ctx, order.PromoCode, order.UserID); err != nil {
+			return nil, bs.rollback(ctx, order, done, err)
+		}
+
+		done.promoRedeemed = true
+	}
+
+	placedOrder, err := bs.PlaceReservedOrder(ctx, order)
+	if err != nil {
+		err = bs.rollback(ctx, order, done, err)
+
+		// idempotency: a concurrent request with the same id has placed the order first
+		if errors.Is(err, domain.ErrOrderAlreadyExists) {
+			if existOrder, getErr := bs.orderService.GetOrderByID(ctx, order.ID); getErr == nil {
+				return existOrder, err
+			}
+		}
+
+		return nil, err
+	}
+
+	return placedOrder, nil
+}
+
+// PlaceReservedOrder takes the payment for the order whose rooms are already reserved, e.g. by a hold,
+// and stores the order. The payment is refunded if the order isn't stored.
+func (bs *BookingService) PlaceReservedOrder(ctx context.Context, order domain.Order) (*domain.Order, error) {
+	order.Status = domain.OrderStatusConfirmed
+	order.RecalculateTotal()
+
+	// the money is taken before the order is confirmed
+	payment, err := bs.takePayment(ctx, order.PaymentToken, order.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	order.Payments = nil
+	if payment != nil {
+		order.Payments = append(order.Payments, *payment)
+	}
+
+	placedOrder, err := bs.orderService.AddOrder(ctx, order)
+	if err != nil {
+		if payment != nil {
+			if refundErr := bs.paymentProvider.Refund(ctx, payment.ID, payment.Amount); refundErr != nil {
+				return nil, errors.Join(err, fmt.Errorf("failed to refund payment: %w", refundErr))
+			}
+		}
+
+		return nil, err
+	}
+
+	return placedOrder, nil
+}
+
+// takePayment authorizes the amount on the guest's card and captures it. The authorization is voided
+// if the capture fails or if the gateway timed out after making it. Nothing is taken for a free order.
+func (bs *BookingService) takePayment(ctx context.Context, token domain.PaymentToken, amount domain.Money) (*domain.Payment, error) {
+	if amount.Amount == 0 {
+		return nil, nil
+	}
+
+	key, err := newPaymentKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate payment key: %w", err)
+	}
+
+	id, err := bs.paymentProvider.Authorize(ctx, key, token, amount)
+	if err != nil {
+		err = fmt.Errorf("failed to authorize payment: %w", err)
+
+		if errors.Is(err, domain.ErrPaymentTimeout) {
+			return nil, bs.voidLost(ctx, key, err)
+		}
+
+		return nil, err
+	}
+
+	if err := bs.paymentProvider.Capture(ctx, id, amount); err != nil {
+		err = fmt.Errorf("failed to capture payment: %w", err)
+
+		if voidErr := bs.paymentProvider.Void(ctx, id); voidErr != nil {
+			return nil, errors.Join(err, fmt.Errorf("failed to void payment: %w", voidErr))
+		}
+
+		return nil, err
+	}
+
+	return &domain.Payment{
+		ID:       id,
+		Amount:   amount,
+		Refunded: domain.Money{Currency: amount.Currency},
+	}, nil
+}
+
+// voidLost voids the authorization made with the key whose answer was lost and reports the cause.
+func (bs *BookingService) voidLost(ctx context.Context, key domain.PaymentKey, cause error) error {
+	id, err := bs.paymentProvider.FindPayment(ctx, key)
+	if errors.Is(err, domain.ErrPaymentNotFound) {
+		return cause
+	}
+	if err != nil {
+		return errors.Join(cause, fmt.Errorf("failed to find payment: %w", err))
+	}
+
+	if err := bs.paymentProvider.Void(ctx, id); err != nil {
+		return errors.Join(cause, fmt.Errorf("failed to void payment: %w", err))
+	}
+
+	return cause
+}
+
+func newPaymentKey() (domain.PaymentKey, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return domain.PaymentKey(hex.EncodeToString(b)), nil
+}
+
+// placement is the progress of the order creation, the loyalty points are reversed by the ledger,
+// so they aren't tracked.
+type placement struct {
+	reserved      bool
+	promoRedeemed bool
+}
+
+// rollback undoes the steps of the order creation made before the failure and returns the failure cause.
+func (bs *BookingService) rollback(ctx context.Context, order domain.Order, done placement, cause error) error {
+	var errs []error
+
+	if done.reserved {
+		if err := bs.hotelStore.Release(ctx, order.Bookings); err != nil {
+			errs = append(errs, fmt.Errorf("failed to release rooms: %w", err))
+		}
+	}
+
+	if done.promoRedeemed {
+		if err := bs.promoService.RevertPromo(ctx, order.PromoCode, order.UserID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to revert promo code: %w", err))
+		}
+	}
+
+	if order.LoyaltyPoints > 0 {
+		if err := bs.loyaltyService.ReverseOrder(ctx, order); err != nil {
+			errs = append(errs, fmt.Errorf("failed to reverse loyalty points: %w", err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to rollback order: %w", errors.Join(append([]error{cause}, errs...)...))
+	}
+
+	return cause
+}
+
+// CancelOrder marks the order as cancelled and returns its rooms to the hotels. The penalty and the refund
+// are calculated by the cancellation policies of the order in the hotel time, the refund is paid back to the card.
+// The release, the reversal of the promo code use and the points and the refund are saved with the cancellation
+// and made after it, so cancelling an already cancelled order finishes them if they failed, otherwise returns
+// it unchanged.
+func (bs *BookingService) CancelOrder(ctx context.Context, orderNumber domain.OrderNumber) (*domain.Order, error) {
+	unlock := bs.orderLocks.Lock(orderNumber)
+	defer unlock()
+
+	order, err := bs.orderService.GetOrderByNumber(ctx, orderNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	now := bs.now()
+
+	today, err := bs.hotelDates(ctx, order.Bookings, now)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelledOrder, err := bs.orderService.UpdateOrder(ctx, orderNumber, func(order *domain.Order) error {
+		// idempotency
+		if order.Status == domain.OrderStatusCancelled {
+			return nil
+		}
+
+		if err := order.ChangeStatus(domain.OrderStatusCancelled); err != nil {
+			return err
+		}
+
+		charge := order.CancellationCharge(today)
+		order.Cancellation = &charge
+		order.CancelledAt = &now
+
+		settlement := domain.Settlement{
+			Release:       order.Bookings,
+			RevertPromo:   order.PromoCode != "",
+			ReversePoints: order.LoyaltyPoints > 0,
+		}
+
+		if len(order.Payments) > 0 {
+			settlement.Refund = &charge.Refund
+		}
+
+		order.Settle(settlement)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bs.settle(ctx, cancelledOrder)
+}
+
+// settle makes the settlement steps of the order one by one. Each done step is cleared in the stored order,
+// so a failed step is retried by the next request without repeating the done ones.
+func (bs *BookingService) settle(ctx context.Context, order *domain.Order) (*domain.Order, error) {
+	for order.Settlement != nil {
+		left := *order.Settlement
+
+		var (
+			payment int
+			refund  domain.Money
+		)
+
+		switch {
+		case len(left.Release) > 0:
+			if err := bs.hotelStore.Release(ctx, left.Release); err != nil {
+				return nil, fmt.Errorf("failed to release rooms: %w", err)
+			}
+
+			left.Release = nil
+		case left.RevertPromo:
+			if err := bs.promoService.RevertPromo(ctx, order.PromoCode, order.UserID); err != nil {
+				return nil, fmt.Errorf("failed to revert promo code: %w", err)
+			}
+
+			left.RevertPromo = false
+		case left.ReversePoints:
+			if err := bs.loyaltyService.ReverseOrder(ctx, *order); err != nil {
+				return nil, fmt.Errorf("failed to reverse loyalty points: %w", err)
+			}
+
+			left.ReversePoints = false
+		case left.EarnPoints:
+			if err := bs.loyaltyService.EarnPoints(ctx, *order); err != nil {
+				return nil, fmt.Errorf("failed to earn loyalty points: %w", err)
+			}
+
+			left.EarnPoints = false
+		case left.Refund != nil:
+			// the refund is paid back from the latest payments, one payment per step
+			payment, refund = order.NextRefund(*left.Refund)
+			if refund.Amount == 0 {
+				left.Refund = nil
+				break
+			}
+
+			if err := bs.paymentProvider.Refund(ctx, order.Payments[payment].ID, refund); err != nil {
+				return nil, fmt.Errorf("failed to refund payment: %w", err)
+			}
+
+			rest := *left.Refund
+			rest.Amount -= refund.Amount
+			left.Refund = &rest
+		}
+
+		var err error
+
+		order, err = bs.orderService.UpdateOrder(ctx, order.Number, func(order *domain.Order) error {
+			if refund.Amount > 0 {
+				order.Payments[payment].Refunded.Amount += refund.Amount
+			}
+
+			order.Settle(left)
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to save settlement: %w", err)
+		}
+	}
+
+	return order, nil
+}
+
+// ChangeOrderStatus moves the order to the status if the transition is allowed. The statuses with their own flow
+// are changed by it: cancelling, check-in and check-out. No-show is only marked by the no-show processing.
+func (bs *BookingService) ChangeOrderStatus(ctx context.Context, orderNumber domain.OrderNumber, status domain.OrderStatus) (*domain.Order, error) {
+	switch status {
+	case domain.OrderStatusCancelled:
+		return bs.CancelOrder(ctx, orderNumber)
+	case domain.OrderStatusCheckedIn:
+		return bs.CheckIn(ctx, orderNumber)
+	case domain.OrderStatusCheckedOut:
+		order, _, err := bs.CheckOut(ctx, orderNumber)
+		return order, err
+	case domain.OrderStatusNoShow:
+		return nil, fmt.Errorf("%w: '%s' is marked by the no-show processing", domain.ErrInvalidStatusTransition, status)
+	}
+
+	unlock := bs.orderLocks.Lock(orderNumber)
+	defer unlock()
+
+	return bs.orderService.UpdateOrder(ctx, orderNumber, func(order *domain.Order) error {
+		return order.ChangeStatus(status)
+	})
+}
+
+// CheckIn marks the guests of the order as arrived. It's possible on the booked nights in the hotel time.
+func (bs *BookingService) CheckIn(ctx context.Context, orderNumber domain.OrderNumber) (*domain.Order, error) {
+	unlock := bs.orderLocks.Lock(orderNumber)
+	defer unlock()
+
+	order, err := bs.orderService.GetOrderByNumber(ctx, orderNumber)
+	if err != nil {
 		return nil, err
 	}
 
-	return bs.orderService.AddOrder(ctx, order)
+	now := bs.now()
+
+	today, err := bs.hotelDates(ctx, order.Bookings, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return bs.orderService.UpdateOrder(ctx, orderNumber, func(order *domain.Order) error {
+		return order.CheckIn(now, today)
+	})
+}
+
+// CheckOut marks the guests of the order as departed. It's possible on the booked nights and the departure day
+// in the hotel time. On an early departure the nights from today on are released and their price is refunded.
+// The release, the points earning and the refund are saved with the check-out and made after it, repeating
+// the check-out finishes them if they failed. The released bookings are returned.
+func (bs *BookingService) CheckOut(ctx context.Context, orderNumber domain.OrderNumber) (*domain.Order, []domain.Booking, error) {
+	unlock := bs.orderLocks.Lock(orderNumber)
+	defer unlock()
+
+	order, err := bs.orderService.GetOrderByNumber(ctx, orderNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := bs.now()
+
+	today, err := bs.hotelDates(ctx, order.Bookings, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the discounts are recalculated for the nights which are still charged, the check-out is made on a copy
+	// to know them, the order is locked, so the stored one is checked out the same way
+	var discounts []domain.Discount
+
+	stay := order.Clone()
+	if _, err := stay.CheckOut(now, today); err == nil {
+		discounts, err = bs.rediscount(ctx, stay)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var released []domain.Booking
+
+	checkedOutOrder, err := bs.orderService.UpdateOrder(ctx, orderNumber, func(order *domain.Order) error {
+		if order.Status == domain.OrderStatusCheckedOut && order.Settlement != nil {
+			released = order.Settlement.Release
+			return nil
+		}
+
+		var err error
+
+		released, err = order.CheckOut(now, today)
+		if err != nil {
+			return err
+		}
+
+		order.Discounts = discounts
+		order.RecalculateTotal()
+
+		refund := order.Overpaid()
+		order.Settle(domain.Settlement{Release: released, EarnPoints: true, Refund: &refund})
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	settledOrder, err := bs.settle(ctx, checkedOutOrder)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return settledOrder, released, nil
 }
 
-func (bs *BookingService) AddRoomAvailability(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error {
-	return bs.hotelStore.AddRoomAvailability(ctx, hotelID, roomType, date, rooms)
+// MarkNoShow marks the order whose guests didn't arrive as no-show. The nights after the first penaltyNights
+// ones are released and their price is refunded. The release and the refund are saved with the mark and made
+// after it, marking the no-show order again finishes them if they failed. The released bookings are returned.
+func (bs *BookingService) MarkNoShow(ctx context.Context, orderNumber domain.OrderNumber, penaltyNights int) (*domain.Order, []domain.Booking, error) {
+	unlock := bs.orderLocks.Lock(orderNumber)
+	defer unlock()
+
+	order, err := bs.orderService.GetOrderByNumber(ctx, orderNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := bs.now()
+
+	// the discounts are recalculated for the penalty nights like on an early check-out
+	var discounts []domain.Discount
+
+	stay := order.Clone()
+	if _, err := stay.MarkNoShow(now, penaltyNights); err == nil {
+		discounts, err = bs.rediscount(ctx, stay)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var released []domain.Booking
+
+	markedOrder, err := bs.orderService.UpdateOrder(ctx, orderNumber, func(order *domain.Order) error {
+		if order.Status == domain.OrderStatusNoShow && order.Settlement != nil {
+			released = order.Settlement.Release
+			return nil
+		}
+
+		var err error
+
+		released, err = order.MarkNoShow(now, penaltyNights)
+		if err != nil {
+			return err
+		}
+
+		order.Discounts = discounts
+		order.RecalculateTotal()
+
+		refund := order.Overpaid()
+		order.Settle(domain.Settlement{Release: released, Refund: &refund})
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	settledOrder, err := bs.settle(ctx, markedOrder)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return settledOrder, released, nil
+}
+
+// hotelDates returns the current date in each hotel of the bookings.
+func (bs *BookingService) hotelDates(ctx context.Context, bookings []domain.Booking, now time.Time) (map[domain.HotelID]time.Time, error) {
+	dates := make(map[domain.HotelID]time.Time)
+
+	for _, booking := range bookings {
+		if _, ok := dates[booking.HotelID]; ok {
+			continue
+		}
+
+		hotel, err := bs.hotelStore.GetHotel(ctx, booking.HotelID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get hotel: %w", err)
+		}
+
+		date, err := hotel.LocalDate(now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get hotel date: %w", err)
+		}
+
+		dates[booking.HotelID] = date
+	}
+
+	return dates, nil
+}
+
+// ModifyOrder replaces the bookings of the order. The old nights are released and the new ones are reserved
+// at once, so if the new bookings can't be reserved the order keeps its original reservation. The discounts
+// are recalculated for the new nights. A raised total is charged to the card before the order is changed,
+// a lowered one is refunded after it.
+func (bs *BookingService) ModifyOrder(ctx context.Context, orderNumber domain.OrderNumber, bookings []domain.Booking,
+	paymentToken domain.PaymentToken) (*domain.Order, *domain.BookingsDiff, error) {
+	unlock := bs.orderLocks.Lock(orderNumber)
+	defer unlock()
+
+	order, err := bs.orderService.GetOrderByNumber(ctx, orderNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !order.IsModifiable() {
+		return nil, nil, fmt.Errorf("%w: order is %s", domain.ErrOrderNotModifiable, order.Status)
+	}
+
+	oldBookings := order.Bookings
+
+	if err := bs.restrictionService.CheckRestrictions(ctx, bookings); err != nil {
+		return nil, nil, err
+	}
+
+	lines, subtotal, err := bs.pricingService.Quote(ctx, bookings)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the new bookings are placed on the current terms
+	policies, err := bs.pricingService.CancellationPolicies(ctx, bookings)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	repriced := order.Clone()
+	repriced.Bookings = bookings
+	repriced.Lines = lines
+	repriced.Subtotal = subtotal
+
+	repriced.Discounts, err = bs.rediscount(ctx, repriced)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	repriced.RecalculateTotal()
+
+	if err := bs.hotelStore.ReplaceReservation(ctx, oldBookings, bookings); err != nil {
+		return nil, nil, err
+	}
+
+	payment, err := bs.takePayment(ctx, paymentToken, repriced.Underpaid())
+	if err != nil {
+		return nil, nil, bs.rollbackReservation(ctx, bookings, oldBookings, err)
+	}
+
+	modifiedOrder, err := bs.orderService.UpdateOrder(ctx, orderNumber, func(order *domain.Order) error {
+		now := bs.now()
+		order.Bookings = bookings
+		order.Lines = lines
+		order.Subtotal = subtotal
+		order.Discounts = repriced.Discounts
+		order.CancellationPolicies = policies
+		order.RecalculateTotal()
+		order.ModifiedAt = &now
+
+		if payment != nil {
+			order.Payments = append(order.Payments, *payment)
+		}
+
+		refund := order.Overpaid()
+		order.Settle(domain.Settlement{Refund: &refund})
+
+		return nil
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to update order: %w", err)
+
+		if payment != nil {
+			if refundErr := bs.paymentProvider.Refund(ctx, payment.ID, payment.Amount); refundErr != nil {
+				err = errors.Join(err, fmt.Errorf("failed to refund payment: %w", refundErr))
+			}
+		}
+
+		return nil, nil, bs.rollbackReservation(ctx, bookings, oldBookings, err)
+	}
+
+	settledOrder, err := bs.settle(ctx, modifiedOrder)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	diff := domain.DiffBookings(oldBookings, bookings)
+
+	return settledOrder, &diff, nil
+}
+
+// rediscount recalculates the discounts of the order whose nights were changed. The promo code is checked again,
+// its discount is dropped if the bookings are no longer in its scope or shorter than its minimum stay.
+// No discount exceeds the price left after the previous ones, the points of a lowered loyalty discount
+// aren't given back.
+func (bs *BookingService) rediscount(ctx context.Context, order domain.Order) ([]domain.Discount, error) {
+	var discounts []domain.Discount
+
+	left := order.Subtotal.Amount
+
+	for _, discount := range order.Discounts {
+		if discount.Source == domain.DiscountSourcePromo {
+			applied, err := bs.promoService.ApplyPromo(ctx, discount.PromoCode, order)
+			if errors.Is(err, domain.ErrPromoNotApplicable) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to apply promo code: %w", err)
+			}
+
+			discount = *applied
+		}
+
+		discount.Amount.Amount = min(discount.Amount.Amount, left)
+		left -= discount.Amount.Amount
+
+		discounts = append(discounts, discount)
+	}
+
+	return discounts, nil
+}
+
+// rollbackReservation puts the old reservation of the order back after a failed modification
+// and returns the failure cause.
+func (bs *BookingService) rollbackReservation(ctx context.Context, reserved, released []domain.Booking, cause error) error {
+	if err := bs.hotelStore.ReplaceReservation(ctx, reserved, released); err != nil {
+		return fmt.Errorf("failed to rollback reservation: %w", errors.Join(cause, err))
+	}
+
+	return cause
 }
@@ -1,8 +1,10 @@
 package booking
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"slices"
 	"testing"
 	"time"
 
@@ -19,16 +21,82 @@ func TestBookingService_CreateOrder(t *testing.T) {
 
 	mockHotelRepo := mocks.NewMockhotelRepository(ctrl)
 	mockOrderService := mocks.NewMockorderService(ctrl)
+	mockPricingService := mocks.NewMockpricingService(ctrl)
+	mockPromoService := mocks.NewMockpromoService(ctrl)
+	mockLoyaltyService := mocks.NewMockloyaltyService(ctrl)
+	mockRestrictionService := mocks.NewMockrestrictionService(ctrl)
+	mockPaymentProvider := mocks.NewMockpaymentProvider(ctrl)
 
-	bs := NewBookingService(mockHotelRepo, mockOrderService)
+	bs := NewBookingService(mockHotelRepo, mockOrderService, mockPricingService, mockPromoService, mockLoyaltyService,
+		mockRestrictionService, mockPaymentProvider)
 
 	testOrder := domain.Order{
 		ID: domain.OrderID("1-test-0"),
 		Bookings: []domain.Booking{
 			{HotelID: 101, RoomType: "single", From: time.Now(), To: time.Now().Add(2 * time.Hour), RoomCount: 1},
 		},
+		PaymentToken: "tok_visa",
 	}
 
+	testLines := []domain.PriceLine{
+		{
+			HotelID:    101,
+			RoomType:   "single",
+			Date:       testOrder.Bookings[0].From,
+			RoomCount:  1,
+			NightPrice: domain.Money{Amount: 1000, Currency: "RUB"},
+			Amount:     domain.Money{Amount: 1000, Currency: "RUB"},
+		},
+	}
+	testTotal := domain.Money{Amount: 1000, Currency: "RUB"}
+
+	createdOrder := testOrder
+	createdOrder.Status = domain.OrderStatusConfirmed
+	createdOrder.Lines = testLines
+	createdOrder.Subtotal = testTotal
+	createdOrder.Total = testTotal
+	createdOrder.Payments = []domain.Payment{{ID: "pay-1", Amount: testTotal, Refunded: domain.Money{Currency: "RUB"}}}
+
+	promoOrder := testOrder
+	promoOrder.PromoCode = "SALE"
+
+	pricedPromoOrder := promoOrder
+	pricedPromoOrder.Lines = testLines
+	pricedPromoOrder.Subtotal = testTotal
+
+	testDiscount := domain.Discount{
+		Source:    domain.DiscountSourcePromo,
+		PromoCode: "SALE",
+		Amount:    domain.Money{Amount: 100, Currency: "RUB"},
+	}
+
+	createdPromoOrder := pricedPromoOrder
+	createdPromoOrder.Status = domain.OrderStatusConfirmed
+	createdPromoOrder.Discounts = []domain.Discount{testDiscount}
+	createdPromoOrder.Total = domain.Money{Amount: 900, Currency: "RUB"}
+	createdPromoOrder.Payments = []domain.Payment{{ID: "pay-1", Amount: createdPromoOrder.Total, Refunded: domain.Money{Currency: "RUB"}}}
+
+	pointsOrder := testOrder
+	pointsOrder.LoyaltyPoints = 3
+
+	pricedPointsOrder := pointsOrder
+	pricedPointsOrder.Number = 7
+	pricedPointsOrder.Lines = testLines
+	pricedPointsOrder.Subtotal = testTotal
+	pricedPointsOrder.Total = testTotal
+
+	pointsDiscount := domain.Discount{
+		Source: domain.DiscountSourceLoyalty,
+		Points: 3,
+		Amount: domain.Money{Amount: 300, Currency: "RUB"},
+	}
+
+	createdPointsOrder := pricedPointsOrder
+	createdPointsOrder.Status = domain.OrderStatusConfirmed
+	createdPointsOrder.Discounts = []domain.Discount{pointsDiscount}
+	createdPointsOrder.Total = domain.Money{Amount: 700, Currency: "RUB"}
+	createdPointsOrder.Payments = []domain.Payment{{ID: "pay-1", Amount: createdPointsOrder.Total, Refunded: domain.Money{Currency: "RUB"}}}
+
 	tests := []struct {
 		name           string
 		order          domain.Order
@@ -41,10 +109,15 @@ func TestBookingService_CreateOrder(t *testing.T) {
 			order: testOrder,
 			mockSetup: func() {
 				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(nil, domain.ErrOrderNotFound)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), testOrder.Bookings).Return(testLines, testTotal, nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), testOrder.Bookings).Return(nil, nil)
 				mockHotelRepo.EXPECT().Reserve(gomock.Any(), testOrder.Bookings).Return(nil)
-				mockOrderService.EXPECT().AddOrder(gomock.Any(), testOrder).Return(&testOrder, nil)
+				mockPaymentProvider.EXPECT().Authorize(gomock.Any(), gomock.Any(), testOrder.PaymentToken, testTotal).Return(domain.PaymentID("pay-1"), nil)
+				mockPaymentProvider.EXPECT().Capture(gomock.Any(), domain.PaymentID("pay-1"), testTotal).Return(nil)
+				mockOrderService.EXPECT().AddOrder(gomock.Any(), createdOrder).Return(&createdOrder, nil)
 			},
-			expectedResult: &testOrder,
+			expectedResult: &createdOrder,
 			expectedError:  nil,
 		},
 		{
@@ -65,11 +138,36 @@ func TestBookingService_CreateOrder(t *testing.T) {
 			expectedResult: nil,
 			expectedError:  errors.New("failed to get order by id: getting order failed"),
 		},
+		{
+			name:  "restriction violated",
+			order: testOrder,
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(nil, domain.ErrOrderNotFound)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), testOrder.Bookings).
+					Return(&domain.RestrictionError{Rule: domain.RuleStopSell, HotelID: 101, RoomType: "single"})
+			},
+			expectedResult: nil,
+			expectedError:  domain.ErrRestrictionViolated,
+		},
+		{
+			name:  "pricing error",
+			order: testOrder,
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(nil, domain.ErrOrderNotFound)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), testOrder.Bookings).Return(nil, domain.Money{}, domain.ErrRateNotFound)
+			},
+			expectedResult: nil,
+			expectedError:  domain.ErrRateNotFound,
+		},
 		{
 			name:  "reserve error",
 			order: testOrder,
 			mockSetup: func() {
 				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(nil, domain.ErrOrderNotFound)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), testOrder.Bookings).Return(testLines, testTotal, nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), testOrder.Bookings).Return(nil, nil)
 				mockHotelRepo.EXPECT().Reserve(gomock.Any(), testOrder.Bookings).Return(errors.New("reservation failed"))
 			},
 			expectedResult: nil,
@@ -80,12 +178,256 @@ func TestBookingService_CreateOrder(t *testing.T) {
 			order: testOrder,
 			mockSetup: func() {
 				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(nil, domain.ErrOrderNotFound)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), testOrder.Bookings).Return(testLines, testTotal, nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), testOrder.Bookings).Return(nil, nil)
 				mockHotelRepo.EXPECT().Reserve(gomock.Any(), testOrder.Bookings).Return(nil)
-				mockOrderService.EXPECT().AddOrder(gomock.Any(), testOrder).Return(nil, errors.New("addition order failed"))
+				mockPaymentProvider.EXPECT().Authorize(gomock.Any(), gomock.Any(), testOrder.PaymentToken, testTotal).Return(domain.PaymentID("pay-1"), nil)
+				mockPaymentProvider.EXPECT().Capture(gomock.Any(), domain.PaymentID("pay-1"), testTotal).Return(nil)
+				mockOrderService.EXPECT().AddOrder(gomock.Any(), createdOrder).Return(nil, errors.New("addition order failed"))
+				mockPaymentProvider.EXPECT().Refund(gomock.Any(), domain.PaymentID("pay-1"), testTotal).Return(nil)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), testOrder.Bookings).Return(nil)
 			},
 			expectedResult: nil,
 			expectedError:  errors.New("addition order failed"),
 		},
+		{
+			name:  "concurrent order with the same id is returned",
+			order: testOrder,
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(nil, domain.ErrOrderNotFound)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), testOrder.Bookings).Return(testLines, testTotal, nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), testOrder.Bookings).Return(nil, nil)
+				mockHotelRepo.EXPECT().Reserve(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPaymentProvider.EXPECT().Authorize(gomock.Any(), gomock.Any(), testOrder.PaymentToken, testTotal).Return(domain.PaymentID("pay-1"), nil)
+				mockPaymentProvider.EXPECT().Capture(gomock.Any(), domain.PaymentID("pay-1"), testTotal).Return(nil)
+				mockOrderService.EXPECT().AddOrder(gomock.Any(), createdOrder).Return(nil, domain.ErrOrderAlreadyExists)
+				mockPaymentProvider.EXPECT().Refund(gomock.Any(), domain.PaymentID("pay-1"), testTotal).Return(nil)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(&testOrder, nil)
+			},
+			expectedResult: &testOrder,
+			expectedError:  domain.ErrOrderAlreadyExists,
+		},
+		{
+			name:  "declined payment releases rooms",
+			order: testOrder,
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(nil, domain.ErrOrderNotFound)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), testOrder.Bookings).Return(testLines, testTotal, nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), testOrder.Bookings).Return(nil, nil)
+				mockHotelRepo.EXPECT().Reserve(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPaymentProvider.EXPECT().Authorize(gomock.Any(), gomock.Any(), testOrder.PaymentToken, testTotal).Return(domain.PaymentID(""), domain.ErrPaymentDeclined)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), testOrder.Bookings).Return(nil)
+			},
+			expectedResult: nil,
+			expectedError:  domain.ErrPaymentDeclined,
+		},
+		{
+			name:  "authorization lost on timeout is voided and rooms released",
+			order: testOrder,
+			mockSetup: func() {
+				var key domain.PaymentKey
+
+				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(nil, domain.ErrOrderNotFound)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), testOrder.Bookings).Return(testLines, testTotal, nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), testOrder.Bookings).Return(nil, nil)
+				mockHotelRepo.EXPECT().Reserve(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPaymentProvider.EXPECT().Authorize(gomock.Any(), gomock.Any(), testOrder.PaymentToken, testTotal).
+					DoAndReturn(func(_ context.Context, k domain.PaymentKey, _ domain.PaymentToken, _ domain.Money) (domain.PaymentID, error) {
+						key = k
+						return "", domain.ErrPaymentTimeout
+					})
+				mockPaymentProvider.EXPECT().FindPayment(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, k domain.PaymentKey) (domain.PaymentID, error) {
+						assert.Equal(t, key, k)
+						return "pay-1", nil
+					})
+				mockPaymentProvider.EXPECT().Void(gomock.Any(), domain.PaymentID("pay-1")).Return(nil)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), testOrder.Bookings).Return(nil)
+			},
+			expectedResult: nil,
+			expectedError:  domain.ErrPaymentTimeout,
+		},
+		{
+			name:  "timeout before authorization releases rooms",
+			order: testOrder,
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(nil, domain.ErrOrderNotFound)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), testOrder.Bookings).Return(testLines, testTotal, nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), testOrder.Bookings).Return(nil, nil)
+				mockHotelRepo.EXPECT().Reserve(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPaymentProvider.EXPECT().Authorize(gomock.Any(), gomock.Any(), testOrder.PaymentToken, testTotal).
+					Return(domain.PaymentID(""), domain.ErrPaymentTimeout)
+				mockPaymentProvider.EXPECT().FindPayment(gomock.Any(), gomock.Any()).Return(domain.PaymentID(""), domain.ErrPaymentNotFound)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), testOrder.Bookings).Return(nil)
+			},
+			expectedResult: nil,
+			expectedError:  domain.ErrPaymentTimeout,
+		},
+		{
+			name:  "capture error voids authorization and releases rooms",
+			order: testOrder,
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(nil, domain.ErrOrderNotFound)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), testOrder.Bookings).Return(testLines, testTotal, nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), testOrder.Bookings).Return(nil, nil)
+				mockHotelRepo.EXPECT().Reserve(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPaymentProvider.EXPECT().Authorize(gomock.Any(), gomock.Any(), testOrder.PaymentToken, testTotal).Return(domain.PaymentID("pay-1"), nil)
+				mockPaymentProvider.EXPECT().Capture(gomock.Any(), domain.PaymentID("pay-1"), testTotal).Return(domain.ErrPaymentTimeout)
+				mockPaymentProvider.EXPECT().Void(gomock.Any(), domain.PaymentID("pay-1")).Return(nil)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), testOrder.Bookings).Return(nil)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("failed to capture payment: payment gateway timeout"),
+		},
+		{
+			name:  "successfully create with promo code",
+			order: promoOrder,
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(nil, domain.ErrOrderNotFound)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), testOrder.Bookings).Return(testLines, testTotal, nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), testOrder.Bookings).Return(nil, nil)
+				mockPromoService.EXPECT().ApplyPromo(gomock.Any(), promoOrder.PromoCode, pricedPromoOrder).Return(&testDiscount, nil)
+				mockHotelRepo.EXPECT().Reserve(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPromoService.EXPECT().RedeemPromo(gomock.Any(), promoOrder.PromoCode, promoOrder.UserID).Return(nil)
+				mockPaymentProvider.EXPECT().Authorize(gomock.Any(), gomock.Any(), testOrder.PaymentToken, createdPromoOrder.Total).Return(domain.PaymentID("pay-1"), nil)
+				mockPaymentProvider.EXPECT().Capture(gomock.Any(), domain.PaymentID("pay-1"), createdPromoOrder.Total).Return(nil)
+				mockOrderService.EXPECT().AddOrder(gomock.Any(), createdPromoOrder).Return(&createdPromoOrder, nil)
+			},
+			expectedResult: &createdPromoOrder,
+			expectedError:  nil,
+		},
+		{
+			name:  "promo code isn't applicable",
+			order: promoOrder,
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(nil, domain.ErrOrderNotFound)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), testOrder.Bookings).Return(testLines, testTotal, nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), testOrder.Bookings).Return(nil, nil)
+				mockPromoService.EXPECT().ApplyPromo(gomock.Any(), promoOrder.PromoCode, pricedPromoOrder).Return(nil, domain.ErrPromoNotApplicable)
+			},
+			expectedResult: nil,
+			expectedError:  domain.ErrPromoNotApplicable,
+		},
+		{
+			name:  "promo code use isn't counted if rooms aren't available",
+			order: promoOrder,
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(nil, domain.ErrOrderNotFound)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), testOrder.Bookings).Return(testLines, testTotal, nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), testOrder.Bookings).Return(nil, nil)
+				mockPromoService.EXPECT().ApplyPromo(gomock.Any(), promoOrder.PromoCode, pricedPromoOrder).Return(&testDiscount, nil)
+				mockHotelRepo.EXPECT().Reserve(gomock.Any(), testOrder.Bookings).Return(domain.ErrRoomsNotAvailable)
+			},
+			expectedResult: nil,
+			expectedError:  domain.ErrRoomsNotAvailable,
+		},
+		{
+			name:  "promo code usage limit releases rooms",
+			order: promoOrder,
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(nil, domain.ErrOrderNotFound)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), testOrder.Bookings).Return(testLines, testTotal, nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), testOrder.Bookings).Return(nil, nil)
+				mockPromoService.EXPECT().ApplyPromo(gomock.Any(), promoOrder.PromoCode, pricedPromoOrder).Return(&testDiscount, nil)
+				mockHotelRepo.EXPECT().Reserve(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPromoService.EXPECT().RedeemPromo(gomock.Any(), promoOrder.PromoCode, promoOrder.UserID).Return(domain.ErrPromoUsageLimit)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), testOrder.Bookings).Return(nil)
+			},
+			expectedResult: nil,
+			expectedError:  domain.ErrPromoUsageLimit,
+		},
+		{
+			name:  "declined payment reverts promo code",
+			order: promoOrder,
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(nil, domain.ErrOrderNotFound)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), testOrder.Bookings).Return(testLines, testTotal, nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), testOrder.Bookings).Return(nil, nil)
+				mockPromoService.EXPECT().ApplyPromo(gomock.Any(), promoOrder.PromoCode, pricedPromoOrder).Return(&testDiscount, nil)
+				mockHotelRepo.EXPECT().Reserve(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPromoService.EXPECT().RedeemPromo(gomock.Any(), promoOrder.PromoCode, promoOrder.UserID).Return(nil)
+				mockPaymentProvider.EXPECT().Authorize(gomock.Any(), gomock.Any(), testOrder.PaymentToken, createdPromoOrder.Total).Return(domain.PaymentID(""), domain.ErrPaymentDeclined)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPromoService.EXPECT().RevertPromo(gomock.Any(), promoOrder.PromoCode, promoOrder.UserID).Return(nil)
+			},
+			expectedResult: nil,
+			expectedError:  domain.ErrPaymentDeclined,
+		},
+		{
+			name:  "successfully create with loyalty points",
+			order: pointsOrder,
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(nil, domain.ErrOrderNotFound)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), testOrder.Bookings).Return(testLines, testTotal, nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), testOrder.Bookings).Return(nil, nil)
+				mockOrderService.EXPECT().NextOrderNumber(gomock.Any()).Return(domain.OrderNumber(7), nil)
+				mockLoyaltyService.EXPECT().RedeemPoints(gomock.Any(), pricedPointsOrder).Return(&pointsDiscount, nil)
+				mockHotelRepo.EXPECT().Reserve(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPaymentProvider.EXPECT().Authorize(gomock.Any(), gomock.Any(), testOrder.PaymentToken, createdPointsOrder.Total).Return(domain.PaymentID("pay-1"), nil)
+				mockPaymentProvider.EXPECT().Capture(gomock.Any(), domain.PaymentID("pay-1"), createdPointsOrder.Total).Return(nil)
+				mockOrderService.EXPECT().AddOrder(gomock.Any(), createdPointsOrder).Return(&createdPointsOrder, nil)
+			},
+			expectedResult: &createdPointsOrder,
+			expectedError:  nil,
+		},
+		{
+			name:  "insufficient loyalty points",
+			order: pointsOrder,
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(nil, domain.ErrOrderNotFound)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), testOrder.Bookings).Return(testLines, testTotal, nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), testOrder.Bookings).Return(nil, nil)
+				mockOrderService.EXPECT().NextOrderNumber(gomock.Any()).Return(domain.OrderNumber(7), nil)
+				mockLoyaltyService.EXPECT().RedeemPoints(gomock.Any(), pricedPointsOrder).Return(nil, domain.ErrInsufficientPoints)
+				mockLoyaltyService.EXPECT().ReverseOrder(gomock.Any(), pricedPointsOrder).Return(nil)
+			},
+			expectedResult: nil,
+			expectedError:  domain.ErrInsufficientPoints,
+		},
+		{
+			name:  "order number error",
+			order: pointsOrder,
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(nil, domain.ErrOrderNotFound)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), testOrder.Bookings).Return(testLines, testTotal, nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), testOrder.Bookings).Return(nil, nil)
+				mockOrderService.EXPECT().NextOrderNumber(gomock.Any()).Return(domain.OrderNumber(0), errors.New("log failed"))
+				mockLoyaltyService.EXPECT().ReverseOrder(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedResult: nil,
+			expectedError:  errors.New("log failed"),
+		},
+		{
+			name:  "reserve error reverses loyalty points",
+			order: pointsOrder,
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(nil, domain.ErrOrderNotFound)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), testOrder.Bookings).Return(testLines, testTotal, nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), testOrder.Bookings).Return(nil, nil)
+				mockOrderService.EXPECT().NextOrderNumber(gomock.Any()).Return(domain.OrderNumber(7), nil)
+				mockLoyaltyService.EXPECT().RedeemPoints(gomock.Any(), pricedPointsOrder).Return(&pointsDiscount, nil)
+				mockHotelRepo.EXPECT().Reserve(gomock.Any(), testOrder.Bookings).Return(domain.ErrRoomsNotAvailable)
+				mockLoyaltyService.EXPECT().ReverseOrder(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedResult: nil,
+			expectedError:  domain.ErrRoomsNotAvailable,
+		},
 	}
 
 	for _, tt := range tests {
@@ -97,6 +439,9 @@ func TestBookingService_CreateOrder(t *testing.T) {
 			if tt.expectedError != nil {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedError.Error())
+				if tt.expectedResult != nil {
+					assert.Equal(t, tt.expectedResult, result)
+				}
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.expectedResult, result)
@@ -104,3 +449,1156 @@ func TestBookingService_CreateOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestBookingService_CancelOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelRepo := mocks.NewMockhotelRepository(ctrl)
+	mockOrderService := mocks.NewMockorderService(ctrl)
+	mockPricingService := mocks.NewMockpricingService(ctrl)
+	mockPromoService := mocks.NewMockpromoService(ctrl)
+	mockLoyaltyService := mocks.NewMockloyaltyService(ctrl)
+	mockPaymentProvider := mocks.NewMockpaymentProvider(ctrl)
+
+	bs := NewBookingService(mockHotelRepo, mockOrderService, mockPricingService, mockPromoService, mockLoyaltyService, nil,
+		mockPaymentProvider)
+
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	bs.now = func() time.Time { return time.Date(2025, 1, 30, 12, 0, 0, 0, time.UTC) }
+
+	testOrder := domain.Order{
+		ID:     domain.OrderID("1-test-0"),
+		Number: 1,
+		Status: domain.OrderStatusConfirmed,
+		Bookings: []domain.Booking{
+			{HotelID: 101, RoomType: "single", From: from, To: from.AddDate(0, 0, 1), RoomCount: 1},
+		},
+		Lines: []domain.PriceLine{
+			{HotelID: 101, RoomType: "single", Date: from, RoomCount: 1, Amount: domain.Money{Amount: 1000, Currency: "RUB"}},
+			{HotelID: 101, RoomType: "single", Date: from.AddDate(0, 0, 1), RoomCount: 1, Amount: domain.Money{Amount: 1200, Currency: "RUB"}},
+		},
+		Total: domain.Money{Amount: 2200, Currency: "RUB"},
+	}
+
+	policyOrder := testOrder
+	policyOrder.CancellationPolicies = []domain.CancellationPolicy{
+		{HotelID: 101, RoomType: "single", FreeDays: 3, PenaltyNights: 1},
+	}
+
+	paidOrder := policyOrder
+	paidOrder.Payments = []domain.Payment{{ID: "pay-1", Amount: paidOrder.Total, Refunded: domain.Money{Currency: "RUB"}}}
+
+	pointsOrder := testOrder
+	pointsOrder.LoyaltyPoints = 10
+
+	promoOrder := testOrder
+	promoOrder.PromoCode = "WINTER10"
+	promoOrder.UserID = 1
+
+	cancelledAt := time.Now()
+	cancelledOrder := testOrder
+	cancelledOrder.Status = domain.OrderStatusCancelled
+	cancelledOrder.CancelledAt = &cancelledAt
+
+	// the refund failed after the rooms were released
+	unrefundedOrder := paidOrder
+	unrefundedOrder.Status = domain.OrderStatusCancelled
+	unrefundedOrder.CancelledAt = &cancelledAt
+	unrefundedOrder.Cancellation = &domain.CancellationCharge{
+		Penalty: domain.Money{Amount: 1000, Currency: "RUB"},
+		Refund:  domain.Money{Amount: 1200, Currency: "RUB"},
+	}
+	unrefundedOrder.Settlement = &domain.Settlement{Refund: &domain.Money{Amount: 1200, Currency: "RUB"}}
+
+	checkedInOrder := testOrder
+	checkedInOrder.Status = domain.OrderStatusCheckedIn
+
+	hotel := &domain.Hotel{ID: 101, Name: "Reddison"}
+
+	// storedOrder emulates the store keeping its own copy of the order between the updates
+	storedOrder := func(stored domain.Order) func(context.Context, domain.OrderNumber, func(*domain.Order) error) (*domain.Order, error) {
+		stored.Payments = slices.Clone(stored.Payments)
+
+		return func(_ context.Context, _ domain.OrderNumber, update func(*domain.Order) error) (*domain.Order, error) {
+			if err := update(&stored); err != nil {
+				return nil, err
+			}
+			updated := stored
+			updated.Payments = slices.Clone(stored.Payments)
+			return &updated, nil
+		}
+	}
+
+	tests := []struct {
+		name            string
+		mockSetup       func()
+		expectedStatus  domain.OrderStatus
+		expectedCharge  *domain.CancellationCharge
+		expectedPayment []domain.Payment
+		expectedError   error
+	}{
+		{
+			name: "successfully cancel",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&testOrder, nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(testOrder)).Times(2)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), testOrder.Bookings).Return(nil)
+			},
+			expectedStatus: domain.OrderStatusCancelled,
+			expectedCharge: &domain.CancellationCharge{
+				Penalty: domain.Money{Amount: 0, Currency: "RUB"},
+				Refund:  domain.Money{Amount: 2200, Currency: "RUB"},
+			},
+		},
+		{
+			name: "penalty of the first night after the free days",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&policyOrder, nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(policyOrder)).Times(2)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), testOrder.Bookings).Return(nil)
+			},
+			expectedStatus: domain.OrderStatusCancelled,
+			expectedCharge: &domain.CancellationCharge{
+				Penalty: domain.Money{Amount: 1000, Currency: "RUB"},
+				Refund:  domain.Money{Amount: 1200, Currency: "RUB"},
+			},
+		},
+		{
+			name: "redeemed points are reversed",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&pointsOrder, nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(pointsOrder)).Times(3)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockLoyaltyService.EXPECT().ReverseOrder(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedStatus: domain.OrderStatusCancelled,
+		},
+		{
+			name: "promo code use is taken back",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&promoOrder, nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(promoOrder)).Times(3)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPromoService.EXPECT().RevertPromo(gomock.Any(), promoOrder.PromoCode, promoOrder.UserID).Return(nil)
+			},
+			expectedStatus: domain.OrderStatusCancelled,
+		},
+		{
+			name: "refund is paid back to the card",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&paidOrder, nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(paidOrder)).Times(3)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPaymentProvider.EXPECT().Refund(gomock.Any(), domain.PaymentID("pay-1"), domain.Money{Amount: 1200, Currency: "RUB"}).Return(nil)
+			},
+			expectedStatus: domain.OrderStatusCancelled,
+			expectedCharge: &domain.CancellationCharge{
+				Penalty: domain.Money{Amount: 1000, Currency: "RUB"},
+				Refund:  domain.Money{Amount: 1200, Currency: "RUB"},
+			},
+			expectedPayment: []domain.Payment{{
+				ID:       "pay-1",
+				Amount:   domain.Money{Amount: 2200, Currency: "RUB"},
+				Refunded: domain.Money{Amount: 1200, Currency: "RUB"},
+			}},
+		},
+		{
+			name: "refund error",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&paidOrder, nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(paidOrder)).Times(2)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), testOrder.Bookings).Return(nil)
+				mockPaymentProvider.EXPECT().Refund(gomock.Any(), domain.PaymentID("pay-1"), domain.Money{Amount: 1200, Currency: "RUB"}).
+					Return(domain.ErrPaymentTimeout)
+			},
+			expectedError: errors.New("failed to refund payment: payment gateway timeout"),
+		},
+		{
+			name: "repeated cancel finishes the refund without releasing rooms again",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&unrefundedOrder, nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(unrefundedOrder)).Times(2)
+				mockPaymentProvider.EXPECT().Refund(gomock.Any(), domain.PaymentID("pay-1"), domain.Money{Amount: 1200, Currency: "RUB"}).Return(nil)
+			},
+			expectedStatus: domain.OrderStatusCancelled,
+			expectedPayment: []domain.Payment{{
+				ID:       "pay-1",
+				Amount:   domain.Money{Amount: 2200, Currency: "RUB"},
+				Refunded: domain.Money{Amount: 1200, Currency: "RUB"},
+			}},
+		},
+		{
+			name: "already cancelled order doesn't release rooms again",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&cancelledOrder, nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(cancelledOrder))
+			},
+			expectedStatus: domain.OrderStatusCancelled,
+		},
+		{
+			name: "checked in order can't be cancelled",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&checkedInOrder, nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(checkedInOrder))
+			},
+			expectedError: &domain.StatusTransitionError{From: domain.OrderStatusCheckedIn, To: domain.OrderStatusCancelled},
+		},
+		{
+			name: "order not found",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(nil, domain.ErrOrderNotFound)
+			},
+			expectedError: domain.ErrOrderNotFound,
+		},
+		{
+			name: "release error",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&testOrder, nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(testOrder))
+				mockHotelRepo.EXPECT().Release(gomock.Any(), testOrder.Bookings).Return(domain.ErrHotelNotFound)
+			},
+			expectedError: errors.New("failed to release rooms: hotel not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			result, err := bs.CancelOrder(context.Background(), testOrder.Number)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, result.Status)
+				assert.NotNil(t, result.CancelledAt)
+				assert.Nil(t, result.Settlement)
+				if tt.expectedCharge != nil {
+					assert.Equal(t, tt.expectedCharge, result.Cancellation)
+				}
+				if tt.expectedPayment != nil {
+					assert.Equal(t, tt.expectedPayment, result.Payments)
+				}
+			}
+		})
+	}
+}
+
+func TestBookingService_ModifyOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelRepo := mocks.NewMockhotelRepository(ctrl)
+	mockOrderService := mocks.NewMockorderService(ctrl)
+	mockPricingService := mocks.NewMockpricingService(ctrl)
+	mockPromoService := mocks.NewMockpromoService(ctrl)
+	mockLoyaltyService := mocks.NewMockloyaltyService(ctrl)
+	mockRestrictionService := mocks.NewMockrestrictionService(ctrl)
+	mockPaymentProvider := mocks.NewMockpaymentProvider(ctrl)
+
+	bs := NewBookingService(mockHotelRepo, mockOrderService, mockPricingService, mockPromoService, mockLoyaltyService,
+		mockRestrictionService, mockPaymentProvider)
+
+	testDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rub := func(amount int64) domain.Money {
+		return domain.Money{Amount: amount, Currency: "RUB"}
+	}
+
+	oldBookings := []domain.Booking{
+		{HotelID: 101, RoomType: "single", From: testDate, To: testDate.AddDate(0, 0, 1), RoomCount: 1},
+		{HotelID: 102, RoomType: "double", From: testDate, To: testDate, RoomCount: 1},
+	}
+	newBookings := []domain.Booking{
+		{HotelID: 101, RoomType: "single", From: testDate.AddDate(0, 0, 1), To: testDate.AddDate(0, 0, 2), RoomCount: 1},
+		{HotelID: 102, RoomType: "double", From: testDate, To: testDate, RoomCount: 1},
+	}
+
+	testOrder := domain.Order{
+		ID:       domain.OrderID("1-test-0"),
+		Number:   1,
+		Status:   domain.OrderStatusConfirmed,
+		Bookings: oldBookings,
+		Subtotal: rub(2000),
+		Total:    rub(2000),
+		Payments: []domain.Payment{{ID: "pay-1", Amount: rub(2000), Refunded: rub(0)}},
+	}
+
+	cancelledOrder := testOrder
+	cancelledOrder.Status = domain.OrderStatusCancelled
+
+	// the order placed with a promo code and loyalty points
+	discountedOrder := testOrder
+	discountedOrder.PromoCode = "WINTER10"
+	discountedOrder.LoyaltyPoints = 300
+	discountedOrder.Discounts = []domain.Discount{
+		{Source: domain.DiscountSourcePromo, PromoCode: "WINTER10", Amount: rub(200)},
+		{Source: domain.DiscountSourceLoyalty, Points: 300, Amount: rub(300)},
+	}
+	discountedOrder.Total = rub(1500)
+	discountedOrder.Payments = []domain.Payment{{ID: "pay-1", Amount: rub(1500), Refunded: rub(0)}}
+
+	newLines := []domain.PriceLine{
+		{HotelID: 101, RoomType: "single", Date: testDate.AddDate(0, 0, 1), RoomCount: 1, NightPrice: rub(400), Amount: rub(400)},
+		{HotelID: 101, RoomType: "single", Date: testDate.AddDate(0, 0, 2), RoomCount: 1, NightPrice: rub(400), Amount: rub(400)},
+		{HotelID: 102, RoomType: "double", Date: testDate, RoomCount: 1, NightPrice: rub(200), Amount: rub(200)},
+	}
+
+	// storedOrder emulates the store keeping its own copy of the order between the updates
+	storedOrder := func(stored domain.Order) func(context.Context, domain.OrderNumber, func(*domain.Order) error) (*domain.Order, error) {
+		stored.Payments = slices.Clone(stored.Payments)
+
+		return func(_ context.Context, _ domain.OrderNumber, update func(*domain.Order) error) (*domain.Order, error) {
+			if err := update(&stored); err != nil {
+				return nil, err
+			}
+			updated := stored
+			updated.Payments = slices.Clone(stored.Payments)
+			return &updated, nil
+		}
+	}
+
+	tests := []struct {
+		name              string
+		mockSetup         func()
+		expectedDiff      *domain.BookingsDiff
+		expectedTotal     domain.Money
+		expectedDiscounts []domain.Discount
+		expectedPayments  []domain.Payment
+		expectedError     error
+	}{
+		{
+			name: "successfully modify",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&testOrder, nil)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), newBookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), newBookings).Return(nil, rub(2000), nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), newBookings).Return(nil, nil)
+				mockHotelRepo.EXPECT().ReplaceReservation(gomock.Any(), oldBookings, newBookings).Return(nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(testOrder))
+			},
+			expectedDiff: &domain.BookingsDiff{
+				Added:   []domain.Booking{newBookings[0]},
+				Removed: []domain.Booking{oldBookings[0]},
+			},
+			expectedTotal:    rub(2000),
+			expectedPayments: testOrder.Payments,
+		},
+		{
+			name: "raised total is charged to the card",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&testOrder, nil)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), newBookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), newBookings).Return(nil, rub(2600), nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), newBookings).Return(nil, nil)
+				mockHotelRepo.EXPECT().ReplaceReservation(gomock.Any(), oldBookings, newBookings).Return(nil)
+				mockPaymentProvider.EXPECT().Authorize(gomock.Any(), gomock.Any(), domain.PaymentToken("tok_visa"), rub(600)).Return(domain.PaymentID("pay-2"), nil)
+				mockPaymentProvider.EXPECT().Capture(gomock.Any(), domain.PaymentID("pay-2"), rub(600)).Return(nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(testOrder))
+			},
+			expectedDiff: &domain.BookingsDiff{
+				Added:   []domain.Booking{newBookings[0]},
+				Removed: []domain.Booking{oldBookings[0]},
+			},
+			expectedTotal: rub(2600),
+			expectedPayments: []domain.Payment{
+				{ID: "pay-1", Amount: rub(2000), Refunded: rub(0)},
+				{ID: "pay-2", Amount: rub(600), Refunded: rub(0)},
+			},
+		},
+		{
+			name: "lowered total is refunded",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&testOrder, nil)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), newBookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), newBookings).Return(nil, rub(1500), nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), newBookings).Return(nil, nil)
+				mockHotelRepo.EXPECT().ReplaceReservation(gomock.Any(), oldBookings, newBookings).Return(nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(testOrder)).Times(2)
+				mockPaymentProvider.EXPECT().Refund(gomock.Any(), domain.PaymentID("pay-1"), rub(500)).Return(nil)
+			},
+			expectedDiff: &domain.BookingsDiff{
+				Added:   []domain.Booking{newBookings[0]},
+				Removed: []domain.Booking{oldBookings[0]},
+			},
+			expectedTotal: rub(1500),
+			expectedPayments: []domain.Payment{
+				{ID: "pay-1", Amount: rub(2000), Refunded: rub(500)},
+			},
+		},
+		{
+			name: "promo discount is recalculated for the new nights",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&discountedOrder, nil)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), newBookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), newBookings).Return(newLines, rub(1000), nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), newBookings).Return(nil, nil)
+				mockPromoService.EXPECT().ApplyPromo(gomock.Any(), domain.PromoCode("WINTER10"), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ domain.PromoCode, order domain.Order) (*domain.Discount, error) {
+						assert.Equal(t, newBookings, order.Bookings)
+						assert.Equal(t, newLines, order.Lines)
+						return &domain.Discount{Source: domain.DiscountSourcePromo, PromoCode: "WINTER10", Amount: rub(100)}, nil
+					})
+				mockHotelRepo.EXPECT().ReplaceReservation(gomock.Any(), oldBookings, newBookings).Return(nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(discountedOrder)).Times(2)
+				mockPaymentProvider.EXPECT().Refund(gomock.Any(), domain.PaymentID("pay-1"), rub(900)).Return(nil)
+			},
+			expectedDiff: &domain.BookingsDiff{
+				Added:   []domain.Booking{newBookings[0]},
+				Removed: []domain.Booking{oldBookings[0]},
+			},
+			expectedTotal: rub(600),
+			expectedDiscounts: []domain.Discount{
+				{Source: domain.DiscountSourcePromo, PromoCode: "WINTER10", Amount: rub(100)},
+				{Source: domain.DiscountSourceLoyalty, Points: 300, Amount: rub(300)},
+			},
+			expectedPayments: []domain.Payment{{ID: "pay-1", Amount: rub(1500), Refunded: rub(900)}},
+		},
+		{
+			name: "shortened stay loses the promo discount and caps the loyalty discount",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&discountedOrder, nil)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), newBookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), newBookings).Return(newLines, rub(200), nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), newBookings).Return(nil, nil)
+				mockPromoService.EXPECT().ApplyPromo(gomock.Any(), domain.PromoCode("WINTER10"), gomock.Any()).
+					Return(nil, domain.ErrPromoNotApplicable)
+				mockHotelRepo.EXPECT().ReplaceReservation(gomock.Any(), oldBookings, newBookings).Return(nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(discountedOrder)).Times(2)
+				mockPaymentProvider.EXPECT().Refund(gomock.Any(), domain.PaymentID("pay-1"), rub(1500)).Return(nil)
+			},
+			expectedDiff: &domain.BookingsDiff{
+				Added:   []domain.Booking{newBookings[0]},
+				Removed: []domain.Booking{oldBookings[0]},
+			},
+			expectedTotal: rub(0),
+			expectedDiscounts: []domain.Discount{
+				{Source: domain.DiscountSourceLoyalty, Points: 300, Amount: rub(200)},
+			},
+			expectedPayments: []domain.Payment{{ID: "pay-1", Amount: rub(1500), Refunded: rub(1500)}},
+		},
+		{
+			name: "promo error keeps the reservation",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&discountedOrder, nil)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), newBookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), newBookings).Return(newLines, rub(1000), nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), newBookings).Return(nil, nil)
+				mockPromoService.EXPECT().ApplyPromo(gomock.Any(), domain.PromoCode("WINTER10"), gomock.Any()).
+					Return(nil, domain.ErrPromoNotFound)
+			},
+			expectedError: domain.ErrPromoNotFound,
+		},
+		{
+			name: "declined charge rolls back reservation",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&testOrder, nil)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), newBookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), newBookings).Return(nil, rub(2600), nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), newBookings).Return(nil, nil)
+				mockHotelRepo.EXPECT().ReplaceReservation(gomock.Any(), oldBookings, newBookings).Return(nil)
+				mockPaymentProvider.EXPECT().Authorize(gomock.Any(), gomock.Any(), domain.PaymentToken("tok_visa"), rub(600)).
+					Return(domain.PaymentID(""), domain.ErrPaymentDeclined)
+				mockHotelRepo.EXPECT().ReplaceReservation(gomock.Any(), newBookings, oldBookings).Return(nil)
+			},
+			expectedError: domain.ErrPaymentDeclined,
+		},
+		{
+			name: "order not found",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(nil, domain.ErrOrderNotFound)
+			},
+			expectedError: domain.ErrOrderNotFound,
+		},
+		{
+			name: "cancelled order can't be modified",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&cancelledOrder, nil)
+			},
+			expectedError: domain.ErrOrderNotModifiable,
+		},
+		{
+			name: "new bookings violate restriction",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&testOrder, nil)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), newBookings).
+					Return(&domain.RestrictionError{Rule: domain.RuleMinStay, HotelID: 101, RoomType: "single", Nights: 1, Limit: 2})
+			},
+			expectedError: domain.ErrRestrictionViolated,
+		},
+		{
+			name: "new rooms not available",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&testOrder, nil)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), newBookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), newBookings).Return(nil, rub(2000), nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), newBookings).Return(nil, nil)
+				mockHotelRepo.EXPECT().ReplaceReservation(gomock.Any(), oldBookings, newBookings).Return(domain.ErrRoomsNotAvailable)
+			},
+			expectedError: domain.ErrRoomsNotAvailable,
+		},
+		{
+			name: "update error rolls back reservation",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&testOrder, nil)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), newBookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), newBookings).Return(nil, rub(2000), nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), newBookings).Return(nil, nil)
+				mockHotelRepo.EXPECT().ReplaceReservation(gomock.Any(), oldBookings, newBookings).Return(nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).Return(nil, errors.New("update failed"))
+				mockHotelRepo.EXPECT().ReplaceReservation(gomock.Any(), newBookings, oldBookings).Return(nil)
+			},
+			expectedError: errors.New("failed to update order: update failed"),
+		},
+		{
+			name: "update error refunds the charge",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&testOrder, nil)
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), newBookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), newBookings).Return(nil, rub(2600), nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), newBookings).Return(nil, nil)
+				mockHotelRepo.EXPECT().ReplaceReservation(gomock.Any(), oldBookings, newBookings).Return(nil)
+				mockPaymentProvider.EXPECT().Authorize(gomock.Any(), gomock.Any(), domain.PaymentToken("tok_visa"), rub(600)).Return(domain.PaymentID("pay-2"), nil)
+				mockPaymentProvider.EXPECT().Capture(gomock.Any(), domain.PaymentID("pay-2"), rub(600)).Return(nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).Return(nil, errors.New("update failed"))
+				mockPaymentProvider.EXPECT().Refund(gomock.Any(), domain.PaymentID("pay-2"), rub(600)).Return(nil)
+				mockHotelRepo.EXPECT().ReplaceReservation(gomock.Any(), newBookings, oldBookings).Return(nil)
+			},
+			expectedError: errors.New("failed to update order: update failed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			result, diff, err := bs.ModifyOrder(context.Background(), testOrder.Number, newBookings, "tok_visa")
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, newBookings, result.Bookings)
+				assert.Equal(t, tt.expectedTotal, result.Total)
+				assert.Equal(t, tt.expectedDiscounts, result.Discounts)
+				assert.Equal(t, tt.expectedPayments, result.Payments)
+				assert.Nil(t, result.Settlement)
+				assert.NotNil(t, result.ModifiedAt)
+				assert.Equal(t, tt.expectedDiff, diff)
+			}
+		})
+	}
+}
+
+func TestBookingService_ChangeOrderStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelRepo := mocks.NewMockhotelRepository(ctrl)
+	mockOrderService := mocks.NewMockorderService(ctrl)
+	mockLoyaltyService := mocks.NewMockloyaltyService(ctrl)
+
+	bs := NewBookingService(mockHotelRepo, mockOrderService, nil, nil, mockLoyaltyService, nil, nil)
+
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	testOrder := domain.Order{
+		ID:     domain.OrderID("1-test-0"),
+		Number: 1,
+		Status: domain.OrderStatusPending,
+		Bookings: []domain.Booking{
+			{HotelID: 101, RoomType: "single", From: from, To: from, RoomCount: 1},
+		},
+	}
+
+	hotel := &domain.Hotel{ID: 101, Name: "Reddison"}
+
+	withStatus := func(status domain.OrderStatus) *domain.Order {
+		order := testOrder
+		order.Status = status
+		return &order
+	}
+
+	storedOrder := func(stored domain.Order) func(context.Context, domain.OrderNumber, func(*domain.Order) error) (*domain.Order, error) {
+		return func(_ context.Context, _ domain.OrderNumber, update func(*domain.Order) error) (*domain.Order, error) {
+			if err := update(&stored); err != nil {
+				return nil, err
+			}
+			updated := stored
+			return &updated, nil
+		}
+	}
+
+	tests := []struct {
+		name           string
+		status         domain.OrderStatus
+		now            time.Time
+		mockSetup      func()
+		expectedStatus domain.OrderStatus
+		expectedError  error
+	}{
+		{
+			name:   "pending order is confirmed",
+			status: domain.OrderStatusConfirmed,
+			mockSetup: func() {
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(testOrder))
+			},
+			expectedStatus: domain.OrderStatusConfirmed,
+		},
+		{
+			name:   "check-in checks the stay dates",
+			status: domain.OrderStatusCheckedIn,
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(withStatus(domain.OrderStatusConfirmed), nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).
+					DoAndReturn(storedOrder(*withStatus(domain.OrderStatusConfirmed)))
+			},
+			expectedStatus: domain.OrderStatusCheckedIn,
+		},
+		{
+			name:   "check-out earns points",
+			status: domain.OrderStatusCheckedOut,
+			// the departure day
+			now: from.AddDate(0, 0, 1).Add(11 * time.Hour),
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(withStatus(domain.OrderStatusCheckedIn), nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).
+					DoAndReturn(storedOrder(*withStatus(domain.OrderStatusCheckedIn))).Times(2)
+				mockLoyaltyService.EXPECT().EarnPoints(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedStatus: domain.OrderStatusCheckedOut,
+		},
+		{
+			name:   "cancelling releases the rooms",
+			status: domain.OrderStatusCancelled,
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(withStatus(domain.OrderStatusConfirmed), nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).
+					DoAndReturn(storedOrder(*withStatus(domain.OrderStatusConfirmed))).Times(2)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), testOrder.Bookings).Return(nil)
+			},
+			expectedStatus: domain.OrderStatusCancelled,
+		},
+		{
+			name:          "no-show is marked by the no-show processing",
+			status:        domain.OrderStatusNoShow,
+			mockSetup:     func() {},
+			expectedError: domain.ErrInvalidStatusTransition,
+		},
+		{
+			name:   "invalid transition",
+			status: domain.OrderStatusConfirmed,
+			mockSetup: func() {
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).
+					DoAndReturn(storedOrder(*withStatus(domain.OrderStatusCheckedIn)))
+			},
+			expectedError: domain.ErrInvalidStatusTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			bs.now = func() time.Time { return cmp.Or(tt.now, from.Add(12*time.Hour)) }
+
+			result, err := bs.ChangeOrderStatus(context.Background(), testOrder.Number, tt.status)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, result.Status)
+			}
+		})
+	}
+}
+
+func TestBookingService_CheckIn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelRepo := mocks.NewMockhotelRepository(ctrl)
+	mockOrderService := mocks.NewMockorderService(ctrl)
+
+	bs := NewBookingService(mockHotelRepo, mockOrderService, nil, nil, nil, nil, nil)
+
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	testOrder := domain.Order{
+		ID:     domain.OrderID("1-test-0"),
+		Number: 1,
+		Status: domain.OrderStatusConfirmed,
+		Bookings: []domain.Booking{
+			{HotelID: 101, RoomType: "single", From: from, To: from.AddDate(0, 0, 1), RoomCount: 1},
+		},
+	}
+
+	hotel := &domain.Hotel{ID: 101, Name: "Reddison", Timezone: "Asia/Novosibirsk"}
+
+	updateOrder := func(_ context.Context, _ domain.OrderNumber, update func(*domain.Order) error) (*domain.Order, error) {
+		stored := testOrder
+		if err := update(&stored); err != nil {
+			return nil, err
+		}
+		return &stored, nil
+	}
+
+	tests := []struct {
+		name          string
+		now           time.Time
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name: "arrival day in the hotel time",
+			// 2025-02-01 00:30 in Novosibirsk
+			now: time.Date(2025, 1, 31, 17, 30, 0, 0, time.UTC),
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&testOrder, nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(updateOrder)
+			},
+		},
+		{
+			name: "before arrival in the hotel time",
+			// 2025-01-31 23:30 in Novosibirsk
+			now: time.Date(2025, 1, 31, 16, 30, 0, 0, time.UTC),
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&testOrder, nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(updateOrder)
+			},
+			expectedError: domain.ErrOutsideStayDates,
+		},
+		{
+			name: "order not found",
+			now:  from,
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(nil, domain.ErrOrderNotFound)
+			},
+			expectedError: domain.ErrOrderNotFound,
+		},
+		{
+			name: "hotel error",
+			now:  from,
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&testOrder, nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(nil, domain.ErrHotelNotFound)
+			},
+			expectedError: domain.ErrHotelNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			bs.now = func() time.Time { return tt.now }
+
+			result, err := bs.CheckIn(context.Background(), testOrder.Number)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, domain.OrderStatusCheckedIn, result.Status)
+				assert.Equal(t, tt.now, *result.CheckedInAt)
+			}
+		})
+	}
+}
+
+func TestBookingService_CheckOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelRepo := mocks.NewMockhotelRepository(ctrl)
+	mockOrderService := mocks.NewMockorderService(ctrl)
+	mockPromoService := mocks.NewMockpromoService(ctrl)
+	mockLoyaltyService := mocks.NewMockloyaltyService(ctrl)
+	mockPaymentProvider := mocks.NewMockpaymentProvider(ctrl)
+
+	bs := NewBookingService(mockHotelRepo, mockOrderService, nil, mockPromoService, mockLoyaltyService, nil, mockPaymentProvider)
+
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	testOrder := domain.Order{
+		ID:     domain.OrderID("1-test-0"),
+		Number: 1,
+		Status: domain.OrderStatusCheckedIn,
+		Bookings: []domain.Booking{
+			{HotelID: 101, RoomType: "single", From: from, To: from.AddDate(0, 0, 2), RoomCount: 1},
+		},
+	}
+
+	hotel := &domain.Hotel{ID: 101, Name: "Reddison"}
+
+	rub := func(amount int64) domain.Money {
+		return domain.Money{Amount: amount, Currency: "RUB"}
+	}
+
+	paidOrder := testOrder
+	paidOrder.Lines = []domain.PriceLine{
+		{HotelID: 101, RoomType: "single", Date: from, RoomCount: 1, NightPrice: rub(1000), Amount: rub(1000)},
+		{HotelID: 101, RoomType: "single", Date: from.AddDate(0, 0, 1), RoomCount: 1, NightPrice: rub(1000), Amount: rub(1000)},
+		{HotelID: 101, RoomType: "single", Date: from.AddDate(0, 0, 2), RoomCount: 1, NightPrice: rub(1000), Amount: rub(1000)},
+	}
+	paidOrder.Subtotal = rub(3000)
+	paidOrder.Total = rub(3000)
+	paidOrder.Payments = []domain.Payment{{ID: "pay-1", Amount: rub(3000), Refunded: rub(0)}}
+
+	// the promo code of the order needs two nights, the loyalty discount stays
+	discountedOrder := paidOrder
+	discountedOrder.PromoCode = "LONGSTAY"
+	discountedOrder.LoyaltyPoints = 200
+	discountedOrder.Discounts = []domain.Discount{
+		{Source: domain.DiscountSourcePromo, PromoCode: "LONGSTAY", Amount: rub(300)},
+		{Source: domain.DiscountSourceLoyalty, Points: 200, Amount: rub(200)},
+	}
+	discountedOrder.Total = rub(2500)
+	discountedOrder.Payments = []domain.Payment{{ID: "pay-1", Amount: rub(2500), Refunded: rub(0)}}
+
+	// the check-out failed to release the rest nights
+	unreleasedOrder := testOrder
+	unreleasedOrder.Status = domain.OrderStatusCheckedOut
+	checkedOutAt := from.AddDate(0, 0, 1).Add(11 * time.Hour)
+	unreleasedOrder.CheckedOutAt = &checkedOutAt
+	unreleasedOrder.Bookings = []domain.Booking{
+		{HotelID: 101, RoomType: "single", From: from, To: from, RoomCount: 1},
+	}
+	unreleasedOrder.Settlement = &domain.Settlement{
+		Release: []domain.Booking{
+			{HotelID: 101, RoomType: "single", From: from.AddDate(0, 0, 1), To: from.AddDate(0, 0, 2), RoomCount: 1},
+		},
+		EarnPoints: true,
+	}
+
+	// storedOrder emulates the store keeping its own copy of the order between the updates
+	storedOrder := func(stored domain.Order) func(context.Context, domain.OrderNumber, func(*domain.Order) error) (*domain.Order, error) {
+		stored.Payments = slices.Clone(stored.Payments)
+
+		return func(_ context.Context, _ domain.OrderNumber, update func(*domain.Order) error) (*domain.Order, error) {
+			if err := update(&stored); err != nil {
+				return nil, err
+			}
+			updated := stored
+			updated.Payments = slices.Clone(stored.Payments)
+			return &updated, nil
+		}
+	}
+
+	tests := []struct {
+		name             string
+		now              time.Time
+		mockSetup        func()
+		expectedBookings []domain.Booking
+		expectedReleased []domain.Booking
+		expectedPayments []domain.Payment
+		// expectedCheckedOutAt is now if it's zero
+		expectedCheckedOutAt time.Time
+		expectedError        error
+	}{
+		{
+			name: "departure day",
+			now:  from.AddDate(0, 0, 3).Add(11 * time.Hour),
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&testOrder, nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(testOrder)).Times(2)
+				mockLoyaltyService.EXPECT().EarnPoints(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedBookings: testOrder.Bookings,
+		},
+		{
+			name: "early departure releases the rest nights",
+			now:  from.AddDate(0, 0, 1).Add(11 * time.Hour),
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&testOrder, nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(testOrder)).Times(3)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), []domain.Booking{
+					{HotelID: 101, RoomType: "single", From: from.AddDate(0, 0, 1), To: from.AddDate(0, 0, 2), RoomCount: 1},
+				}).Return(nil)
+				mockLoyaltyService.EXPECT().EarnPoints(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedBookings: []domain.Booking{
+				{HotelID: 101, RoomType: "single", From: from, To: from, RoomCount: 1},
+			},
+			expectedReleased: []domain.Booking{
+				{HotelID: 101, RoomType: "single", From: from.AddDate(0, 0, 1), To: from.AddDate(0, 0, 2), RoomCount: 1},
+			},
+		},
+		{
+			name: "price of the rest nights is refunded on early departure",
+			now:  from.AddDate(0, 0, 1).Add(11 * time.Hour),
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&paidOrder, nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(paidOrder)).Times(4)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), []domain.Booking{
+					{HotelID: 101, RoomType: "single", From: from.AddDate(0, 0, 1), To: from.AddDate(0, 0, 2), RoomCount: 1},
+				}).Return(nil)
+				mockLoyaltyService.EXPECT().EarnPoints(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, order domain.Order) error {
+						assert.Equal(t, rub(1000), order.Total, "points are earned for the used nights")
+						return nil
+					})
+				mockPaymentProvider.EXPECT().Refund(gomock.Any(), domain.PaymentID("pay-1"), rub(2000)).Return(nil)
+			},
+			expectedBookings: []domain.Booking{
+				{HotelID: 101, RoomType: "single", From: from, To: from, RoomCount: 1},
+			},
+			expectedReleased: []domain.Booking{
+				{HotelID: 101, RoomType: "single", From: from.AddDate(0, 0, 1), To: from.AddDate(0, 0, 2), RoomCount: 1},
+			},
+			expectedPayments: []domain.Payment{{ID: "pay-1", Amount: rub(3000), Refunded: rub(2000)}},
+		},
+		{
+			name: "early departure recalculates the discounts for the used nights",
+			now:  from.AddDate(0, 0, 1).Add(11 * time.Hour),
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&discountedOrder, nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockPromoService.EXPECT().ApplyPromo(gomock.Any(), domain.PromoCode("LONGSTAY"), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ domain.PromoCode, order domain.Order) (*domain.Discount, error) {
+						assert.Equal(t, paidOrder.Lines[:1], order.Lines, "promo code is checked against the used nights")
+						return nil, domain.ErrPromoNotApplicable
+					})
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(discountedOrder)).Times(4)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), gomock.Any()).Return(nil)
+				mockLoyaltyService.EXPECT().EarnPoints(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, order domain.Order) error {
+						assert.Equal(t, []domain.Discount{{Source: domain.DiscountSourceLoyalty, Points: 200, Amount: rub(200)}},
+							order.Discounts)
+						assert.Equal(t, rub(800), order.Total)
+						return nil
+					})
+				mockPaymentProvider.EXPECT().Refund(gomock.Any(), domain.PaymentID("pay-1"), rub(1700)).Return(nil)
+			},
+			expectedBookings: []domain.Booking{
+				{HotelID: 101, RoomType: "single", From: from, To: from, RoomCount: 1},
+			},
+			expectedReleased: []domain.Booking{
+				{HotelID: 101, RoomType: "single", From: from.AddDate(0, 0, 1), To: from.AddDate(0, 0, 2), RoomCount: 1},
+			},
+			expectedPayments: []domain.Payment{{ID: "pay-1", Amount: rub(2500), Refunded: rub(1700)}},
+		},
+		{
+			name: "after departure day",
+			now:  from.AddDate(0, 0, 4),
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&testOrder, nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(testOrder))
+			},
+			expectedError: domain.ErrOutsideStayDates,
+		},
+		{
+			name: "release error",
+			now:  from.AddDate(0, 0, 1),
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&testOrder, nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(testOrder))
+				mockHotelRepo.EXPECT().Release(gomock.Any(), gomock.Any()).Return(domain.ErrHotelNotFound)
+			},
+			expectedError: domain.ErrHotelNotFound,
+		},
+		{
+			name: "repeated check-out finishes the release and earns points",
+			// the check-out window has passed, the settlement is finished anyway
+			now: from.AddDate(0, 0, 5),
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&unreleasedOrder, nil)
+				mockHotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(unreleasedOrder)).Times(3)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), unreleasedOrder.Settlement.Release).Return(nil)
+				mockLoyaltyService.EXPECT().EarnPoints(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedBookings:     unreleasedOrder.Bookings,
+			expectedReleased:     unreleasedOrder.Settlement.Release,
+			expectedCheckedOutAt: checkedOutAt,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			bs.now = func() time.Time { return tt.now }
+
+			result, released, err := bs.CheckOut(context.Background(), testOrder.Number)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, domain.OrderStatusCheckedOut, result.Status)
+				expectedCheckedOutAt := tt.expectedCheckedOutAt
+				if expectedCheckedOutAt.IsZero() {
+					expectedCheckedOutAt = tt.now
+				}
+				assert.Equal(t, expectedCheckedOutAt, *result.CheckedOutAt)
+				assert.Nil(t, result.Settlement)
+				assert.Equal(t, tt.expectedBookings, result.Bookings)
+				assert.Equal(t, tt.expectedReleased, released)
+				assert.Equal(t, tt.expectedPayments, result.Payments)
+			}
+		})
+	}
+}
+
+func TestBookingService_MarkNoShow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelRepo := mocks.NewMockhotelRepository(ctrl)
+	mockOrderService := mocks.NewMockorderService(ctrl)
+	mockPaymentProvider := mocks.NewMockpaymentProvider(ctrl)
+
+	bs := NewBookingService(mockHotelRepo, mockOrderService, nil, nil, nil, nil, mockPaymentProvider)
+
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	now := from.AddDate(0, 0, 1).Add(10 * time.Hour)
+
+	bs.now = func() time.Time { return now }
+
+	firstNight := domain.Booking{HotelID: 101, RoomType: "single", From: from, To: from, RoomCount: 1}
+	restNights := domain.Booking{HotelID: 101, RoomType: "single", From: from.AddDate(0, 0, 1), To: from.AddDate(0, 0, 2), RoomCount: 1}
+
+	testOrder := domain.Order{
+		Number: 1,
+		Status: domain.OrderStatusConfirmed,
+		Bookings: []domain.Booking{
+			{HotelID: 101, RoomType: "single", From: from, To: from.AddDate(0, 0, 2), RoomCount: 1},
+		},
+	}
+
+	rub := func(amount int64) domain.Money {
+		return domain.Money{Amount: amount, Currency: "RUB"}
+	}
+
+	paidOrder := testOrder
+	paidOrder.Lines = []domain.PriceLine{
+		{HotelID: 101, RoomType: "single", Date: from, RoomCount: 1, NightPrice: rub(1000), Amount: rub(1000)},
+		{HotelID: 101, RoomType: "single", Date: from.AddDate(0, 0, 1), RoomCount: 1, NightPrice: rub(1200), Amount: rub(1200)},
+		{HotelID: 101, RoomType: "single", Date: from.AddDate(0, 0, 2), RoomCount: 1, NightPrice: rub(1200), Amount: rub(1200)},
+	}
+	paidOrder.Subtotal = rub(3400)
+	paidOrder.Total = rub(3400)
+	paidOrder.Payments = []domain.Payment{{ID: "pay-1", Amount: rub(3400), Refunded: rub(0)}}
+
+	// the release failed after the order was marked
+	unreleasedOrder := testOrder
+	unreleasedOrder.Status = domain.OrderStatusNoShow
+	unreleasedOrder.Bookings = []domain.Booking{firstNight}
+	unreleasedOrder.NoShowAt = &now
+	unreleasedOrder.Settlement = &domain.Settlement{Release: []domain.Booking{restNights}}
+
+	checkedInOrder := testOrder
+	checkedInOrder.Status = domain.OrderStatusCheckedIn
+
+	// storedOrder emulates the store keeping its own copy of the order between the updates
+	storedOrder := func(stored domain.Order) func(context.Context, domain.OrderNumber, func(*domain.Order) error) (*domain.Order, error) {
+		stored.Payments = slices.Clone(stored.Payments)
+
+		return func(_ context.Context, _ domain.OrderNumber, update func(*domain.Order) error) (*domain.Order, error) {
+			if err := update(&stored); err != nil {
+				return nil, err
+			}
+			updated := stored
+			updated.Payments = slices.Clone(stored.Payments)
+			return &updated, nil
+		}
+	}
+
+	tests := []struct {
+		name             string
+		mockSetup        func()
+		expectedTotal    domain.Money
+		expectedPayments []domain.Payment
+		expectedError    error
+	}{
+		{
+			name: "nights after the penalty are released",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&testOrder, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(testOrder)).Times(2)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), []domain.Booking{restNights}).Return(nil)
+			},
+		},
+		{
+			name: "price of the released nights is refunded",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&paidOrder, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(paidOrder)).Times(3)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), []domain.Booking{restNights}).Return(nil)
+				mockPaymentProvider.EXPECT().Refund(gomock.Any(), domain.PaymentID("pay-1"), rub(2400)).Return(nil)
+			},
+			expectedTotal:    rub(1000),
+			expectedPayments: []domain.Payment{{ID: "pay-1", Amount: rub(3400), Refunded: rub(2400)}},
+		},
+		{
+			name: "release error",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&testOrder, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(testOrder))
+				mockHotelRepo.EXPECT().Release(gomock.Any(), []domain.Booking{restNights}).Return(domain.ErrRoomTypeNotFound)
+			},
+			expectedError: domain.ErrRoomTypeNotFound,
+		},
+		{
+			name: "marking again finishes the release",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&unreleasedOrder, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(unreleasedOrder)).Times(2)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), []domain.Booking{restNights}).Return(nil)
+			},
+		},
+		{
+			name: "checked in order isn't marked",
+			mockSetup: func() {
+				mockOrderService.EXPECT().GetOrderByNumber(gomock.Any(), testOrder.Number).Return(&checkedInOrder, nil)
+				mockOrderService.EXPECT().UpdateOrder(gomock.Any(), testOrder.Number, gomock.Any()).DoAndReturn(storedOrder(checkedInOrder))
+			},
+			expectedError: domain.ErrInvalidStatusTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			result, released, err := bs.MarkNoShow(context.Background(), testOrder.Number, 1)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, domain.OrderStatusNoShow, result.Status)
+				assert.Equal(t, now, *result.NoShowAt)
+				assert.Equal(t, []domain.Booking{firstNight}, result.Bookings)
+				assert.Equal(t, []domain.Booking{restNights}, released)
+				assert.Equal(t, tt.expectedTotal, result.Total)
+				assert.Equal(t, tt.expectedPayments, result.Payments)
+				assert.Nil(t, result.Settlement)
+			}
+		})
+	}
+}
@@ -8,7 +8,6 @@ import (
 	domain "applicationDesignTest/internal/domain"
 	context "context"
 	reflect "reflect"
-	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -36,18 +35,47 @@ func (m *MockhotelRepository) EXPECT() *MockhotelRepositoryMockRecorder {
 	return m.recorder
 }
 
-// AddRoomAvailability mocks base method.
-func (m *MockhotelRepository) AddRoomAvailability(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error {
+// GetHotel mocks base method.
+func (m *MockhotelRepository) GetHotel(ctx context.Context, id domain.HotelID) (*domain.Hotel, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddRoomAvailability", ctx, hotelID, roomType, date, rooms)
+	ret := m.ctrl.Call(m, "GetHotel", ctx, id)
+	ret0, _ := ret[0].(*domain.Hotel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHotel indicates an expected call of GetHotel.
+func (mr *MockhotelRepositoryMockRecorder) GetHotel(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotel", reflect.TypeOf((*MockhotelRepository)(nil).GetHotel), ctx, id)
+}
+
+// Release mocks base method.
+func (m *MockhotelRepository) Release(ctx context.Context, bookings []domain.Booking) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, bookings)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// AddRoomAvailability indicates an expected call of AddRoomAvailability.
-func (mr *MockhotelRepositoryMockRecorder) AddRoomAvailability(ctx, hotelID, roomType, date, rooms interface{}) *gomock.Call {
+// Release indicates an expected call of Release.
+func (mr *MockhotelRepositoryMockRecorder) Release(ctx, bookings interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRoomAvailability", reflect.TypeOf((*MockhotelRepository)(nil).AddRoomAvailability), ctx, hotelID, roomType, date, rooms)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockhotelRepository)(nil).Release), ctx, bookings)
+}
+
+// ReplaceReservation mocks base method.
+func (m *MockhotelRepository) ReplaceReservation(ctx context.Context, released, reserved []domain.Booking) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceReservation", ctx, released, reserved)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceReservation indicates an expected call of ReplaceReservation.
+func (mr *MockhotelRepositoryMockRecorder) ReplaceReservation(ctx, released, reserved interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceReservation", reflect.TypeOf((*MockhotelRepository)(nil).ReplaceReservation), ctx, released, reserved)
 }
 
 // Reserve mocks base method.
@@ -116,3 +144,366 @@ func (mr *MockorderServiceMockRecorder) GetOrderByID(ctx, id interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderByID", reflect.TypeOf((*MockorderService)(nil).GetOrderByID), ctx, id)
 }
+
+// GetOrderByNumber mocks base method.
+func (m *MockorderService) GetOrderByNumber(ctx context.Context, orderNumber domain.OrderNumber) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderByNumber", ctx, orderNumber)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderByNumber indicates an expected call of GetOrderByNumber.
+func (mr *MockorderServiceMockRecorder) GetOrderByNumber(ctx, orderNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderByNumber", reflect.TypeOf((*MockorderService)(nil).GetOrderByNumber), ctx, orderNumber)
+}
+
+// NextOrderNumber mocks base method.
+func (m *MockorderService) NextOrderNumber(ctx context.Context) (domain.OrderNumber, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextOrderNumber", ctx)
+	ret0, _ := ret[0].(domain.OrderNumber)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextOrderNumber indicates an expected call of NextOrderNumber.
+func (mr *MockorderServiceMockRecorder) NextOrderNumber(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextOrderNumber", reflect.TypeOf((*MockorderService)(nil).NextOrderNumber), ctx)
+}
+
+// UpdateOrder mocks base method.
+func (m *MockorderService) UpdateOrder(ctx context.Context, orderNumber domain.OrderNumber, update func(*domain.Order) error) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrder", ctx, orderNumber, update)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrder indicates an expected call of UpdateOrder.
+func (mr *MockorderServiceMockRecorder) UpdateOrder(ctx, orderNumber, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrder", reflect.TypeOf((*MockorderService)(nil).UpdateOrder), ctx, orderNumber, update)
+}
+
+// MockpricingService is a mock of pricingService interface.
+type MockpricingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockpricingServiceMockRecorder
+}
+
+// MockpricingServiceMockRecorder is the mock recorder for MockpricingService.
+type MockpricingServiceMockRecorder struct {
+	mock *MockpricingService
+}
+
+// NewMockpricingService creates a new mock instance.
+func NewMockpricingService(ctrl *gomock.Controller) *MockpricingService {
+	mock := &MockpricingService{ctrl: ctrl}
+	mock.recorder = &MockpricingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpricingService) EXPECT() *MockpricingServiceMockRecorder {
+	return m.recorder
+}
+
+// CancellationPolicies mocks base method.
+func (m *MockpricingService) CancellationPolicies(ctx context.Context, bookings []domain.Booking) ([]domain.CancellationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancellationPolicies", ctx, bookings)
+	ret0, _ := ret[0].([]domain.CancellationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancellationPolicies indicates an expected call of CancellationPolicies.
+func (mr *MockpricingServiceMockRecorder) CancellationPolicies(ctx, bookings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancellationPolicies", reflect.TypeOf((*MockpricingService)(nil).CancellationPolicies), ctx, bookings)
+}
+
+// Quote mocks base method.
+func (m *MockpricingService) Quote(ctx context.Context, bookings []domain.Booking) ([]domain.PriceLine, domain.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quote", ctx, bookings)
+	ret0, _ := ret[0].([]domain.PriceLine)
+	ret1, _ := ret[1].(domain.Money)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Quote indicates an expected call of Quote.
+func (mr *MockpricingServiceMockRecorder) Quote(ctx, bookings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quote", reflect.TypeOf((*MockpricingService)(nil).Quote), ctx, bookings)
+}
+
+// MockpromoService is a mock of promoService interface.
+type MockpromoService struct {
+	ctrl     *gomock.Controller
+	recorder *MockpromoServiceMockRecorder
+}
+
+// MockpromoServiceMockRecorder is the mock recorder for MockpromoService.
+type MockpromoServiceMockRecorder struct {
+	mock *MockpromoService
+}
+
+// NewMockpromoService creates a new mock instance.
+func NewMockpromoService(ctrl *gomock.Controller) *MockpromoService {
+	mock := &MockpromoService{ctrl: ctrl}
+	mock.recorder = &MockpromoServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpromoService) EXPECT() *MockpromoServiceMockRecorder {
+	return m.recorder
+}
+
+// ApplyPromo mocks base method.
+func (m *MockpromoService) ApplyPromo(ctx context.Context, code domain.PromoCode, order domain.Order) (*domain.Discount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyPromo", ctx, code, order)
+	ret0, _ := ret[0].(*domain.Discount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyPromo indicates an expected call of ApplyPromo.
+func (mr *MockpromoServiceMockRecorder) ApplyPromo(ctx, code, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPromo", reflect.TypeOf((*MockpromoService)(nil).ApplyPromo), ctx, code, order)
+}
+
+// RedeemPromo mocks base method.
+func (m *MockpromoService) RedeemPromo(ctx context.Context, code domain.PromoCode, userID domain.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeemPromo", ctx, code, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RedeemPromo indicates an expected call of RedeemPromo.
+func (mr *MockpromoServiceMockRecorder) RedeemPromo(ctx, code, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeemPromo", reflect.TypeOf((*MockpromoService)(nil).RedeemPromo), ctx, code, userID)
+}
+
+// RevertPromo mocks base method.
+func (m *MockpromoService) RevertPromo(ctx context.Context, code domain.PromoCode, userID domain.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertPromo", ctx, code, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevertPromo indicates an expected call of RevertPromo.
+func (mr *MockpromoServiceMockRecorder) RevertPromo(ctx, code, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertPromo", reflect.TypeOf((*MockpromoService)(nil).RevertPromo), ctx, code, userID)
+}
+
+// MockloyaltyService is a mock of loyaltyService interface.
+type MockloyaltyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockloyaltyServiceMockRecorder
+}
+
+// MockloyaltyServiceMockRecorder is the mock recorder for MockloyaltyService.
+type MockloyaltyServiceMockRecorder struct {
+	mock *MockloyaltyService
+}
+
+// NewMockloyaltyService creates a new mock instance.
+func NewMockloyaltyService(ctrl *gomock.Controller) *MockloyaltyService {
+	mock := &MockloyaltyService{ctrl: ctrl}
+	mock.recorder = &MockloyaltyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockloyaltyService) EXPECT() *MockloyaltyServiceMockRecorder {
+	return m.recorder
+}
+
+// EarnPoints mocks base method.
+func (m *MockloyaltyService) EarnPoints(ctx context.Context, order domain.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EarnPoints", ctx, order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EarnPoints indicates an expected call of EarnPoints.
+func (mr *MockloyaltyServiceMockRecorder) EarnPoints(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EarnPoints", reflect.TypeOf((*MockloyaltyService)(nil).EarnPoints), ctx, order)
+}
+
+// RedeemPoints mocks base method.
+func (m *MockloyaltyService) RedeemPoints(ctx context.Context, order domain.Order) (*domain.Discount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeemPoints", ctx, order)
+	ret0, _ := ret[0].(*domain.Discount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeemPoints indicates an expected call of RedeemPoints.
+func (mr *MockloyaltyServiceMockRecorder) RedeemPoints(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeemPoints", reflect.TypeOf((*MockloyaltyService)(nil).RedeemPoints), ctx, order)
+}
+
+// ReverseOrder mocks base method.
+func (m *MockloyaltyService) ReverseOrder(ctx context.Context, order domain.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReverseOrder", ctx, order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReverseOrder indicates an expected call of ReverseOrder.
+func (mr *MockloyaltyServiceMockRecorder) ReverseOrder(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReverseOrder", reflect.TypeOf((*MockloyaltyService)(nil).ReverseOrder), ctx, order)
+}
+
+// MockrestrictionService is a mock of restrictionService interface.
+type MockrestrictionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockrestrictionServiceMockRecorder
+}
+
+// MockrestrictionServiceMockRecorder is the mock recorder for MockrestrictionService.
+type MockrestrictionServiceMockRecorder struct {
+	mock *MockrestrictionService
+}
+
+// NewMockrestrictionService creates a new mock instance.
+func NewMockrestrictionService(ctrl *gomock.Controller) *MockrestrictionService {
+	mock := &MockrestrictionService{ctrl: ctrl}
+	mock.recorder = &MockrestrictionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrestrictionService) EXPECT() *MockrestrictionServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckRestrictions mocks base method.
+func (m *MockrestrictionService) CheckRestrictions(ctx context.Context, bookings []domain.Booking) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckRestrictions", ctx, bookings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckRestrictions indicates an expected call of CheckRestrictions.
+func (mr *MockrestrictionServiceMockRecorder) CheckRestrictions(ctx, bookings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckRestrictions", reflect.TypeOf((*MockrestrictionService)(nil).CheckRestrictions), ctx, bookings)
+}
+
+// MockpaymentProvider is a mock of paymentProvider interface.
+type MockpaymentProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockpaymentProviderMockRecorder
+}
+
+// MockpaymentProviderMockRecorder is the mock recorder for MockpaymentProvider.
+type MockpaymentProviderMockRecorder struct {
+	mock *MockpaymentProvider
+}
+
+// NewMockpaymentProvider creates a new mock instance.
+func NewMockpaymentProvider(ctrl *gomock.Controller) *MockpaymentProvider {
+	mock := &MockpaymentProvider{ctrl: ctrl}
+	mock.recorder = &MockpaymentProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpaymentProvider) EXPECT() *MockpaymentProviderMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *MockpaymentProvider) Authorize(ctx context.Context, key domain.PaymentKey, token domain.PaymentToken, amount domain.Money) (domain.PaymentID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", ctx, key, token, amount)
+	ret0, _ := ret[0].(domain.PaymentID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockpaymentProviderMockRecorder) Authorize(ctx, key, token, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockpaymentProvider)(nil).Authorize), ctx, key, token, amount)
+}
+
+// Capture mocks base method.
+func (m *MockpaymentProvider) Capture(ctx context.Context, id domain.PaymentID, amount domain.Money) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capture", ctx, id, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Capture indicates an expected call of Capture.
+func (mr *MockpaymentProviderMockRecorder) Capture(ctx, id, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capture", reflect.TypeOf((*MockpaymentProvider)(nil).Capture), ctx, id, amount)
+}
+
+// FindPayment mocks base method.
+func (m *MockpaymentProvider) FindPayment(ctx context.Context, key domain.PaymentKey) (domain.PaymentID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPayment", ctx, key)
+	ret0, _ := ret[0].(domain.PaymentID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPayment indicates an expected call of FindPayment.
+func (mr *MockpaymentProviderMockRecorder) FindPayment(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPayment", reflect.TypeOf((*MockpaymentProvider)(nil).FindPayment), ctx, key)
+}
+
+// Refund mocks base method.
+func (m *MockpaymentProvider) Refund(ctx context.Context, id domain.PaymentID, amount domain.Money) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", ctx, id, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockpaymentProviderMockRecorder) Refund(ctx, id, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockpaymentProvider)(nil).Refund), ctx, id, amount)
+}
+
+// Void mocks base method.
+func (m *MockpaymentProvider) Void(ctx context.Context, id domain.PaymentID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Void", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Void indicates an expected call of Void.
+func (mr *MockpaymentProviderMockRecorder) Void(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Void", reflect.TypeOf((*MockpaymentProvider)(nil).Void), ctx, id)
+}
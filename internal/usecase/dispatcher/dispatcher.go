@@ -0,0 +1,129 @@
+package dispatcher
+
+//go:generate mockgen -source=dispatcher.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+)
+
+type outboxRepository interface {
+	GetEvents(ctx context.Context, afterID int64, limit int) ([]domain.Event, error)
+	GetOffset(ctx context.Context, subscriber string) (int64, error)
+	SetOffset(ctx context.Context, subscriber string, offset int64) error
+}
+
+type subscriber interface {
+	HandleEvent(ctx context.Context, event domain.Event) error
+}
+
+// Dispatcher delivers the outbox events to the subscribers.
+type Dispatcher struct {
+	outbox      outboxRepository
+	subscribers map[string]subscriber
+	batchSize   int
+}
+
+func NewDispatcher(outbox outboxRepository, batchSize int) *Dispatcher {
+	return &Dispatcher{
+		outbox:      outbox,
+		subscribers: make(map[string]subscriber),
+		batchSize:   batchSize,
+	}
+}
+
+// Subscribe registers the subscriber before the dispatcher is started. The name keys the delivery offset
+// of the subscriber, so it must stay the same between restarts.
+func (d *Dispatcher) Subscribe(name string, s subscriber) {
+	d.subscribers[name] = s
+}
+
+// Dispatch delivers the pending events to every subscriber in the order they were recorded.
+// If a subscriber fails to handle an event, the delivery to it stops and the event is retried
+// by the next call, so every event is delivered at least once. Subscribers don't wait for each other.
+func (d *Dispatcher) Dispatch(ctx context.Context) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+
+	// the offsets of all the subscribers are taken before any delivery, so the outbox knows every subscriber
+	// and doesn't trim the events delivered to the fast ones before the slow ones get them
+	offsets := make(map[string]int64, len(d.subscribers))
+
+	for name := range d.subscribers {
+		offset, err := d.outbox.GetOffset(ctx, name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("subscriber %s: failed to get offset: %w", name, err))
+			continue
+		}
+
+		offsets[name] = offset
+	}
+
+	for name, offset := range offsets {
+		wg.Add(1)
+		go func(name string, s subscriber, offset int64) {
+			defer wg.Done()
+
+			if err := d.deliver(ctx, name, s, offset); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("subscriber %s: %w", name, err))
+				mu.Unlock()
+			}
+		}(name, d.subscribers[name], offset)
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// Run dispatches the events every interval until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.Dispatch(ctx); err != nil {
+				log.Error("failed to dispatch events", err)
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, name string, s subscriber, offset int64) error {
+	for {
+		events, err := d.outbox.GetEvents(ctx, offset, d.batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to get events: %w", err)
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		for _, event := range events {
+			if err := s.HandleEvent(ctx, event); err != nil {
+				return fmt.Errorf("failed to handle event %d: %w", event.ID, err)
+			}
+
+			offset = event.ID
+
+			if err := d.outbox.SetOffset(ctx, name, offset); err != nil {
+				return fmt.Errorf("failed to set offset: %w", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/internal/storage/memorystore"
+	"applicationDesignTest/internal/usecase/dispatcher/mocks"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOutbox := mocks.NewMockoutboxRepository(ctrl)
+	mockSubscriber := mocks.NewMocksubscriber(ctrl)
+
+	d := NewDispatcher(mockOutbox, 2)
+	d.Subscribe("test", mockSubscriber)
+
+	events := []domain.Event{
+		{ID: 1, Type: domain.EventOrderCreated},
+		{ID: 2, Type: domain.EventAvailabilityChanged},
+		{ID: 3, Type: domain.EventOrderCancelled},
+	}
+
+	handleErr := errors.New("handle failed")
+
+	tests := []struct {
+		name          string
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name: "deliver events in batches",
+			mockSetup: func() {
+				gomock.InOrder(
+					mockOutbox.EXPECT().GetOffset(gomock.Any(), "test").Return(int64(0), nil),
+					mockOutbox.EXPECT().GetEvents(gomock.Any(), int64(0), 2).Return(events[:2], nil),
+					mockSubscriber.EXPECT().HandleEvent(gomock.Any(), events[0]).Return(nil),
+					mockOutbox.EXPECT().SetOffset(gomock.Any(), "test", int64(1)).Return(nil),
+					mockSubscriber.EXPECT().HandleEvent(gomock.Any(), events[1]).Return(nil),
+					mockOutbox.EXPECT().SetOffset(gomock.Any(), "test", int64(2)).Return(nil),
+					mockOutbox.EXPECT().GetEvents(gomock.Any(), int64(2), 2).Return(events[2:], nil),
+					mockSubscriber.EXPECT().HandleEvent(gomock.Any(), events[2]).Return(nil),
+					mockOutbox.EXPECT().SetOffset(gomock.Any(), "test", int64(3)).Return(nil),
+					mockOutbox.EXPECT().GetEvents(gomock.Any(), int64(3), 2).Return(nil, nil),
+				)
+			},
+		},
+		{
+			name: "continue from the saved offset",
+			mockSetup: func() {
+				gomock.InOrder(
+					mockOutbox.EXPECT().GetOffset(gomock.Any(), "test").Return(int64(2), nil),
+					mockOutbox.EXPECT().GetEvents(gomock.Any(), int64(2), 2).Return(events[2:], nil),
+					mockSubscriber.EXPECT().HandleEvent(gomock.Any(), events[2]).Return(nil),
+					mockOutbox.EXPECT().SetOffset(gomock.Any(), "test", int64(3)).Return(nil),
+					mockOutbox.EXPECT().GetEvents(gomock.Any(), int64(3), 2).Return(nil, nil),
+				)
+			},
+		},
+		{
+			name: "stop at the failed event",
+			mockSetup: func() {
+				gomock.InOrder(
+					mockOutbox.EXPECT().GetOffset(gomock.Any(), "test").Return(int64(0), nil),
+					mockOutbox.EXPECT().GetEvents(gomock.Any(), int64(0), 2).Return(events[:2], nil),
+					mockSubscriber.EXPECT().HandleEvent(gomock.Any(), events[0]).Return(nil),
+					mockOutbox.EXPECT().SetOffset(gomock.Any(), "test", int64(1)).Return(nil),
+					mockSubscriber.EXPECT().HandleEvent(gomock.Any(), events[1]).Return(handleErr),
+				)
+			},
+			expectedError: handleErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			err := d.Dispatch(context.Background())
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// lateOutbox holds back the offset of the subscriber "b" until "a" has got every event, as if "b" started late.
+type lateOutbox struct {
+	*memorystore.Outbox
+	last      int64
+	delivered chan struct{}
+}
+
+func (o *lateOutbox) GetOffset(ctx context.Context, subscriber string) (int64, error) {
+	if subscriber == "b" {
+		select {
+		case <-o.delivered:
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	return o.Outbox.GetOffset(ctx, subscriber)
+}
+
+func (o *lateOutbox) SetOffset(ctx context.Context, subscriber string, offset int64) error {
+	if err := o.Outbox.SetOffset(ctx, subscriber, offset); err != nil {
+		return err
+	}
+
+	if subscriber == "a" && offset == o.last {
+		close(o.delivered)
+	}
+
+	return nil
+}
+
+// recorder keeps the IDs of the handled events.
+type recorder struct {
+	mu  sync.Mutex
+	ids []int64
+}
+
+func (r *recorder) HandleEvent(ctx context.Context, event domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ids = append(r.ids, event.ID)
+
+	return nil
+}
+
+func TestDispatcher_DispatchToConcurrentSubscribers(t *testing.T) {
+	ctx := context.Background()
+
+	outbox := memorystore.NewOutbox()
+	hotelStore := memorystore.NewHotelStore(outbox)
+
+	assert.NoError(t, hotelStore.AddHotel(ctx, domain.Hotel{ID: 1}))
+	assert.NoError(t, hotelStore.AddRoomType(ctx, domain.HotelRoomType{HotelID: 1, Code: "single", Name: "Single", Capacity: 1}))
+	assert.NoError(t, hotelStore.AddRoomAvailability(ctx, 1, "single", date.Date(2025, 2, 1), 2))
+	assert.NoError(t, hotelStore.AddRoomAvailability(ctx, 1, "single", date.Date(2025, 2, 2), 2))
+
+	events, err := outbox.GetEvents(ctx, 0, 100)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	a, b := &recorder{}, &recorder{}
+
+	d := NewDispatcher(&lateOutbox{Outbox: outbox, last: 2, delivered: make(chan struct{})}, 1)
+	d.Subscribe("a", a)
+	d.Subscribe("b", b)
+
+	assert.NoError(t, d.Dispatch(ctx))
+
+	assert.Equal(t, []int64{1, 2}, a.ids)
+	assert.Equal(t, []int64{1, 2}, b.ids)
+
+	// the events are trimmed once both subscribers have got them
+	events, err = outbox.GetEvents(ctx, 0, 100)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+}
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: dispatcher.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockoutboxRepository is a mock of outboxRepository interface.
+type MockoutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockoutboxRepositoryMockRecorder
+}
+
+// MockoutboxRepositoryMockRecorder is the mock recorder for MockoutboxRepository.
+type MockoutboxRepositoryMockRecorder struct {
+	mock *MockoutboxRepository
+}
+
+// NewMockoutboxRepository creates a new mock instance.
+func NewMockoutboxRepository(ctrl *gomock.Controller) *MockoutboxRepository {
+	mock := &MockoutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockoutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockoutboxRepository) EXPECT() *MockoutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetEvents mocks base method.
+func (m *MockoutboxRepository) GetEvents(ctx context.Context, afterID int64, limit int) ([]domain.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvents", ctx, afterID, limit)
+	ret0, _ := ret[0].([]domain.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvents indicates an expected call of GetEvents.
+func (mr *MockoutboxRepositoryMockRecorder) GetEvents(ctx, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockoutboxRepository)(nil).GetEvents), ctx, afterID, limit)
+}
+
+// GetOffset mocks base method.
+func (m *MockoutboxRepository) GetOffset(ctx context.Context, subscriber string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOffset", ctx, subscriber)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOffset indicates an expected call of GetOffset.
+func (mr *MockoutboxRepositoryMockRecorder) GetOffset(ctx, subscriber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOffset", reflect.TypeOf((*MockoutboxRepository)(nil).GetOffset), ctx, subscriber)
+}
+
+// SetOffset mocks base method.
+func (m *MockoutboxRepository) SetOffset(ctx context.Context, subscriber string, offset int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOffset", ctx, subscriber, offset)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOffset indicates an expected call of SetOffset.
+func (mr *MockoutboxRepositoryMockRecorder) SetOffset(ctx, subscriber, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOffset", reflect.TypeOf((*MockoutboxRepository)(nil).SetOffset), ctx, subscriber, offset)
+}
+
+// Mocksubscriber is a mock of subscriber interface.
+type Mocksubscriber struct {
+	ctrl     *gomock.Controller
+	recorder *MocksubscriberMockRecorder
+}
+
+// MocksubscriberMockRecorder is the mock recorder for Mocksubscriber.
+type MocksubscriberMockRecorder struct {
+	mock *Mocksubscriber
+}
+
+// NewMocksubscriber creates a new mock instance.
+func NewMocksubscriber(ctrl *gomock.Controller) *Mocksubscriber {
+	mock := &Mocksubscriber{ctrl: ctrl}
+	mock.recorder = &MocksubscriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocksubscriber) EXPECT() *MocksubscriberMockRecorder {
+	return m.recorder
+}
+
+// HandleEvent mocks base method.
+func (m *Mocksubscriber) HandleEvent(ctx context.Context, event domain.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleEvent indicates an expected call of HandleEvent.
+func (mr *MocksubscriberMockRecorder) HandleEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleEvent", reflect.TypeOf((*Mocksubscriber)(nil).HandleEvent), ctx, event)
+}
@@ -0,0 +1,274 @@
+package hold
+
+//go:generate mockgen -source=hold.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/keylock"
+	"applicationDesignTest/pkg/log"
+)
+
+type hotelRepository interface {
+	Reserve(ctx context.Context, bookings []domain.Booking) error
+	Release(ctx context.Context, bookings []domain.Booking) error
+}
+
+type holdRepository interface {
+	AddHold(ctx context.Context, hold domain.Hold) error
+	GetHold(ctx context.Context, token domain.HoldToken) (*domain.Hold, error)
+	DeleteHold(ctx context.Context, token domain.HoldToken) (*domain.Hold, error)
+	GetExpiredHolds(ctx context.Context, now time.Time) ([]domain.Hold, error)
+}
+
+type orderService interface {
+	GetOrderByID(ctx context.Context, id domain.OrderID) (*domain.Order, error)
+}
+
+type bookingService interface {
+	PlaceReservedOrder(ctx context.Context, order domain.Order) (*domain.Order, error)
+}
+
+type pricingService interface {
+	Quote(ctx context.Context, bookings []domain.Booking) ([]domain.PriceLine, domain.Money, error)
+	CancellationPolicies(ctx context.Context, bookings []domain.Booking) ([]domain.CancellationPolicy, error)
+}
+
+type restrictionService interface {
+	CheckRestrictions(ctx context.Context, bookings []domain.Booking) error
+}
+
+type HoldService struct {
+	hotelStore         hotelRepository
+	holdStore          holdRepository
+	orderService       orderService
+	bookingService     bookingService
+	pricingService     pricingService
+	restrictionService restrictionService
+	holdLocks          *keylock.Locks[domain.HoldToken]
+	ttl                time.Duration
+	now                func() time.Time
+}
+
+func NewHoldService(hotelStore hotelRepository, holdStore holdRepository, orderService orderService,
+	bookingService bookingService, pricingService pricingService, restrictionService restrictionService,
+	ttl time.Duration) *HoldService {
+	return &HoldService{
+		hotelStore:         hotelStore,
+		holdStore:          holdStore,
+		orderService:       orderService,
+		bookingService:     bookingService,
+		pricingService:     pricingService,
+		restrictionService: restrictionService,
+		holdLocks:          keylock.New[domain.HoldToken](),
+		ttl:                ttl,
+		now:                time.Now,
+	}
+}
+
+// CreateHold reserves the rooms of the bookings until the hold is confirmed or expired.
+func (s *HoldService) CreateHold(ctx context.Context, userID domain.UserID, bookings []domain.Booking) (*domain.Hold, error) {
+	token, err := newHoldToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate hold token: %w", err)
+	}
+
+	if err := s.restrictionService.CheckRestrictions(ctx, bookings); err != nil {
+		return nil, err
+	}
+
+	// the price and the cancellation terms are fixed when the rooms are held
+	lines, total, err := s.pricingService.Quote(ctx, bookings)
+	if err != nil {
+		return nil, err
+	}
+
+	policies, err := s.pricingService.CancellationPolicies(ctx, bookings)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.hotelStore.Reserve(ctx, bookings); err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+
+	hold := domain.Hold{
+		Token:     token,
+		UserID:    userID,
+		Bookings:  bookings,
+		Lines:     lines,
+		Total:     total,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.ttl),
+
+		CancellationPolicies: policies,
+	}
+
+	if err := s.holdStore.AddHold(ctx, hold); err != nil {
+		return nil, s.release(ctx, bookings, fmt.Errorf("failed to add hold: %w", err))
+	}
+
+	return &hold, nil
+}
+
+// ConfirmHold turns the hold into an order identified by the hold token and paid with the card. The hold keeps
+// the rooms until the order is placed, so a declined card can be replaced while the hold is active.
+// Confirming an already confirmed hold returns the existing order with domain.ErrOrderAlreadyExists.
+func (s *HoldService) ConfirmHold(ctx context.Context, token domain.HoldToken, paymentToken domain.PaymentToken) (*domain.Order, error) {
+	// the hold isn't released by the reaper while it's paid
+	unlock := s.holdLocks.Lock(token)
+	defer unlock()
+
+	hold, err := s.holdStore.GetHold(ctx, token)
+	if err != nil {
+		if !errors.Is(err, domain.ErrHoldNotFound) {
+			return nil, fmt.Errorf("failed to get hold: %w", err)
+		}
+
+		// idempotency
+		existOrder, orderErr := s.orderService.GetOrderByID(ctx, domain.OrderID(token))
+		if orderErr != nil {
+			if errors.Is(orderErr, domain.ErrOrderNotFound) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to get order by id: %w", orderErr)
+		}
+
+		return existOrder, domain.ErrOrderAlreadyExists
+	}
+
+	if hold.IsExpired(s.now()) {
+		if _, err := s.holdStore.DeleteHold(ctx, token); err != nil {
+			return nil, fmt.Errorf("failed to delete hold: %w", err)
+		}
+
+		return nil, s.release(ctx, hold.Bookings, domain.ErrHoldExpired)
+	}
+
+	order, err := s.bookingService.PlaceReservedOrder(ctx, domain.Order{
+		ID:       domain.OrderID(hold.Token),
+		UserID:   hold.UserID,
+		Bookings: hold.Bookings,
+		Lines:    hold.Lines,
+		Subtotal: hold.Total,
+
+		CancellationPolicies: hold.CancellationPolicies,
+		PaymentToken:         paymentToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the rooms belong to the order now, the reaper doesn't release them even if the hold is left
+	if _, err := s.holdStore.DeleteHold(ctx, token); err != nil {
+		return nil, fmt.Errorf("failed to delete hold: %w", err)
+	}
+
+	return order, nil
+}
+
+// ReleaseExpired returns the rooms of the expired holds back to the hotels. It returns the number
+// of released holds.
+func (s *HoldService) ReleaseExpired(ctx context.Context) (int, error) {
+	holds, err := s.holdStore.GetExpiredHolds(ctx, s.now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired holds: %w", err)
+	}
+
+	var (
+		released int
+		errs     []error
+	)
+
+	for _, hold := range holds {
+		ok, err := s.releaseExpired(ctx, hold.Token)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to release hold %s: %w", hold.Token, err))
+			continue
+		}
+
+		if ok {
+			released++
+		}
+	}
+
+	return released, errors.Join(errs...)
+}
+
+// releaseExpired deletes the expired hold and releases its rooms. The hold is skipped if it was confirmed
+// meanwhile, false is returned then.
+func (s *HoldService) releaseExpired(ctx context.Context, token domain.HoldToken) (bool, error) {
+	unlock := s.holdLocks.Lock(token)
+	defer unlock()
+
+	hold, err := s.holdStore.DeleteHold(ctx, token)
+	if errors.Is(err, domain.ErrHoldNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to delete hold: %w", err)
+	}
+
+	// the order was placed, but the hold wasn't deleted
+	_, err = s.orderService.GetOrderByID(ctx, domain.OrderID(token))
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, domain.ErrOrderNotFound) {
+		return false, fmt.Errorf("failed to get order by id: %w", err)
+	}
+
+	if err := s.hotelStore.Release(ctx, hold.Bookings); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// RunReaper releases expired holds every interval until ctx is done.
+func (s *HoldService) RunReaper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			released, err := s.ReleaseExpired(ctx)
+			if err != nil {
+				log.Error("failed to release expired holds", err)
+			}
+
+			if released > 0 {
+				log.Info(fmt.Sprintf("released %d expired holds", released))
+			}
+		}
+	}
+}
+
+// release returns the rooms back after a failure and reports the cause.
+func (s *HoldService) release(ctx context.Context, bookings []domain.Booking, cause error) error {
+	if err := s.hotelStore.Release(ctx, bookings); err != nil {
+		return fmt.Errorf("failed to release rooms: %w", errors.Join(cause, err))
+	}
+
+	return cause
+}
+
+func newHoldToken() (domain.HoldToken, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return domain.HoldToken(hex.EncodeToString(b)), nil
+}
@@ -0,0 +1,281 @@
+package hold
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/internal/usecase/hold/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHoldService_CreateHold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelRepo := mocks.NewMockhotelRepository(ctrl)
+	mockHoldRepo := mocks.NewMockholdRepository(ctrl)
+	mockPricingService := mocks.NewMockpricingService(ctrl)
+	mockRestrictionService := mocks.NewMockrestrictionService(ctrl)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	hs := NewHoldService(mockHotelRepo, mockHoldRepo, nil, nil, mockPricingService, mockRestrictionService, 15*time.Minute)
+	hs.now = func() time.Time { return now }
+
+	total := domain.Money{Amount: 1000, Currency: "RUB"}
+
+	bookings := []domain.Booking{
+		{HotelID: 101, RoomType: "single", From: now, To: now.AddDate(0, 0, 1), RoomCount: 1},
+	}
+
+	tests := []struct {
+		name          string
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name: "successfully create",
+			mockSetup: func() {
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), bookings).Return(nil, total, nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), bookings).Return(nil, nil)
+				mockHotelRepo.EXPECT().Reserve(gomock.Any(), bookings).Return(nil)
+				mockHoldRepo.EXPECT().AddHold(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "restriction violated",
+			mockSetup: func() {
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), bookings).
+					Return(&domain.RestrictionError{Rule: domain.RuleClosedToArrival, HotelID: 101, RoomType: "single"})
+			},
+			expectedError: domain.ErrRestrictionViolated,
+		},
+		{
+			name: "rate not found",
+			mockSetup: func() {
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), bookings).Return(nil, domain.Money{}, domain.ErrRateNotFound)
+			},
+			expectedError: domain.ErrRateNotFound,
+		},
+		{
+			name: "rooms not available",
+			mockSetup: func() {
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), bookings).Return(nil, total, nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), bookings).Return(nil, nil)
+				mockHotelRepo.EXPECT().Reserve(gomock.Any(), bookings).Return(domain.ErrRoomsNotAvailable)
+			},
+			expectedError: domain.ErrRoomsNotAvailable,
+		},
+		{
+			name: "addition hold error releases rooms",
+			mockSetup: func() {
+				mockRestrictionService.EXPECT().CheckRestrictions(gomock.Any(), bookings).Return(nil)
+				mockPricingService.EXPECT().Quote(gomock.Any(), bookings).Return(nil, total, nil)
+				mockPricingService.EXPECT().CancellationPolicies(gomock.Any(), bookings).Return(nil, nil)
+				mockHotelRepo.EXPECT().Reserve(gomock.Any(), bookings).Return(nil)
+				mockHoldRepo.EXPECT().AddHold(gomock.Any(), gomock.Any()).Return(errors.New("addition hold failed"))
+				mockHotelRepo.EXPECT().Release(gomock.Any(), bookings).Return(nil)
+			},
+			expectedError: errors.New("failed to add hold: addition hold failed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			result, err := hs.CreateHold(context.Background(), 1, bookings)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, result.Token)
+				assert.Equal(t, now.Add(15*time.Minute), result.ExpiresAt)
+				assert.Equal(t, bookings, result.Bookings)
+				assert.Equal(t, total, result.Total)
+			}
+		})
+	}
+}
+
+func TestHoldService_ConfirmHold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelRepo := mocks.NewMockhotelRepository(ctrl)
+	mockHoldRepo := mocks.NewMockholdRepository(ctrl)
+	mockOrderService := mocks.NewMockorderService(ctrl)
+	mockBookingService := mocks.NewMockbookingService(ctrl)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	hs := NewHoldService(mockHotelRepo, mockHoldRepo, mockOrderService, mockBookingService, nil, nil, 15*time.Minute)
+	hs.now = func() time.Time { return now }
+
+	testHold := domain.Hold{
+		Token:  "token",
+		UserID: 1,
+		Bookings: []domain.Booking{
+			{HotelID: 101, RoomType: "single", From: now, To: now.AddDate(0, 0, 1), RoomCount: 1},
+		},
+		Total:     domain.Money{Amount: 1000, Currency: "RUB"},
+		CreatedAt: now.Add(-time.Minute),
+		ExpiresAt: now.Add(time.Minute),
+	}
+
+	expiredHold := testHold
+	expiredHold.ExpiresAt = now
+
+	testOrder := domain.Order{
+		ID:           "token",
+		UserID:       testHold.UserID,
+		Bookings:     testHold.Bookings,
+		Subtotal:     testHold.Total,
+		PaymentToken: "tok_visa",
+	}
+
+	tests := []struct {
+		name           string
+		mockSetup      func()
+		expectedResult *domain.Order
+		expectedError  error
+	}{
+		{
+			name: "successfully confirm",
+			mockSetup: func() {
+				mockHoldRepo.EXPECT().GetHold(gomock.Any(), testHold.Token).Return(&testHold, nil)
+				mockBookingService.EXPECT().PlaceReservedOrder(gomock.Any(), testOrder).Return(&testOrder, nil)
+				mockHoldRepo.EXPECT().DeleteHold(gomock.Any(), testHold.Token).Return(&testHold, nil)
+			},
+			expectedResult: &testOrder,
+		},
+		{
+			name: "hold already confirmed",
+			mockSetup: func() {
+				mockHoldRepo.EXPECT().GetHold(gomock.Any(), testHold.Token).Return(nil, domain.ErrHoldNotFound)
+				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(&testOrder, nil)
+			},
+			expectedResult: &testOrder,
+			expectedError:  domain.ErrOrderAlreadyExists,
+		},
+		{
+			name: "hold not found",
+			mockSetup: func() {
+				mockHoldRepo.EXPECT().GetHold(gomock.Any(), testHold.Token).Return(nil, domain.ErrHoldNotFound)
+				mockOrderService.EXPECT().GetOrderByID(gomock.Any(), testOrder.ID).Return(nil, domain.ErrOrderNotFound)
+			},
+			expectedError: domain.ErrHoldNotFound,
+		},
+		{
+			name: "hold expired",
+			mockSetup: func() {
+				mockHoldRepo.EXPECT().GetHold(gomock.Any(), testHold.Token).Return(&expiredHold, nil)
+				mockHoldRepo.EXPECT().DeleteHold(gomock.Any(), testHold.Token).Return(&expiredHold, nil)
+				mockHotelRepo.EXPECT().Release(gomock.Any(), testHold.Bookings).Return(nil)
+			},
+			expectedError: domain.ErrHoldExpired,
+		},
+		{
+			name: "placing order error keeps the hold",
+			mockSetup: func() {
+				mockHoldRepo.EXPECT().GetHold(gomock.Any(), testHold.Token).Return(&testHold, nil)
+				mockBookingService.EXPECT().PlaceReservedOrder(gomock.Any(), testOrder).Return(nil, errors.New("placing order failed"))
+			},
+			expectedError: errors.New("placing order failed"),
+		},
+		{
+			name: "declined payment keeps the hold",
+			mockSetup: func() {
+				mockHoldRepo.EXPECT().GetHold(gomock.Any(), testHold.Token).Return(&testHold, nil)
+				mockBookingService.EXPECT().PlaceReservedOrder(gomock.Any(), testOrder).Return(nil, domain.ErrPaymentDeclined)
+			},
+			expectedError: domain.ErrPaymentDeclined,
+		},
+		{
+			name: "hold can be paid with another card after a decline",
+			mockSetup: func() {
+				declinedOrder := testOrder
+				declinedOrder.PaymentToken = "tok_decline"
+
+				mockHoldRepo.EXPECT().GetHold(gomock.Any(), testHold.Token).Return(&testHold, nil).Times(2)
+				mockBookingService.EXPECT().PlaceReservedOrder(gomock.Any(), declinedOrder).Return(nil, domain.ErrPaymentDeclined)
+				mockBookingService.EXPECT().PlaceReservedOrder(gomock.Any(), testOrder).Return(&testOrder, nil)
+				mockHoldRepo.EXPECT().DeleteHold(gomock.Any(), testHold.Token).Return(&testHold, nil)
+
+				_, err := hs.ConfirmHold(context.Background(), testHold.Token, declinedOrder.PaymentToken)
+				assert.ErrorIs(t, err, domain.ErrPaymentDeclined)
+			},
+			expectedResult: &testOrder,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			result, err := hs.ConfirmHold(context.Background(), testHold.Token, testOrder.PaymentToken)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestHoldService_ReleaseExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelRepo := mocks.NewMockhotelRepository(ctrl)
+	mockHoldRepo := mocks.NewMockholdRepository(ctrl)
+	mockOrderService := mocks.NewMockorderService(ctrl)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	hs := NewHoldService(mockHotelRepo, mockHoldRepo, mockOrderService, nil, nil, nil, 15*time.Minute)
+	hs.now = func() time.Time { return now }
+
+	expired := []domain.Hold{
+		{Token: "1", Bookings: []domain.Booking{{HotelID: 101, RoomType: "single", From: now, To: now, RoomCount: 1}}},
+		{Token: "2", Bookings: []domain.Booking{{HotelID: 102, RoomType: "double", From: now, To: now, RoomCount: 2}}},
+		// confirmed after it was listed
+		{Token: "3", Bookings: []domain.Booking{{HotelID: 101, RoomType: "single", From: now, To: now, RoomCount: 1}}},
+		// the order was placed, but the hold wasn't deleted
+		{Token: "4", Bookings: []domain.Booking{{HotelID: 101, RoomType: "single", From: now, To: now, RoomCount: 1}}},
+	}
+
+	mockHoldRepo.EXPECT().GetExpiredHolds(gomock.Any(), now).Return(expired, nil)
+
+	mockHoldRepo.EXPECT().DeleteHold(gomock.Any(), domain.HoldToken("1")).Return(&expired[0], nil)
+	mockOrderService.EXPECT().GetOrderByID(gomock.Any(), domain.OrderID("1")).Return(nil, domain.ErrOrderNotFound)
+	mockHotelRepo.EXPECT().Release(gomock.Any(), expired[0].Bookings).Return(nil)
+
+	mockHoldRepo.EXPECT().DeleteHold(gomock.Any(), domain.HoldToken("2")).Return(&expired[1], nil)
+	mockOrderService.EXPECT().GetOrderByID(gomock.Any(), domain.OrderID("2")).Return(nil, domain.ErrOrderNotFound)
+	mockHotelRepo.EXPECT().Release(gomock.Any(), expired[1].Bookings).Return(domain.ErrHotelNotFound)
+
+	mockHoldRepo.EXPECT().DeleteHold(gomock.Any(), domain.HoldToken("3")).Return(nil, domain.ErrHoldNotFound)
+
+	mockHoldRepo.EXPECT().DeleteHold(gomock.Any(), domain.HoldToken("4")).Return(&expired[3], nil)
+	mockOrderService.EXPECT().GetOrderByID(gomock.Any(), domain.OrderID("4")).Return(&domain.Order{ID: "4"}, nil)
+
+	released, err := hs.ReleaseExpired(context.Background())
+
+	assert.Equal(t, 1, released)
+	assert.ErrorIs(t, err, domain.ErrHotelNotFound)
+}
@@ -0,0 +1,314 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: hold.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockhotelRepository is a mock of hotelRepository interface.
+type MockhotelRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockhotelRepositoryMockRecorder
+}
+
+// MockhotelRepositoryMockRecorder is the mock recorder for MockhotelRepository.
+type MockhotelRepositoryMockRecorder struct {
+	mock *MockhotelRepository
+}
+
+// NewMockhotelRepository creates a new mock instance.
+func NewMockhotelRepository(ctrl *gomock.Controller) *MockhotelRepository {
+	mock := &MockhotelRepository{ctrl: ctrl}
+	mock.recorder = &MockhotelRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhotelRepository) EXPECT() *MockhotelRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Release mocks base method.
+func (m *MockhotelRepository) Release(ctx context.Context, bookings []domain.Booking) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, bookings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockhotelRepositoryMockRecorder) Release(ctx, bookings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockhotelRepository)(nil).Release), ctx, bookings)
+}
+
+// Reserve mocks base method.
+func (m *MockhotelRepository) Reserve(ctx context.Context, bookings []domain.Booking) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, bookings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockhotelRepositoryMockRecorder) Reserve(ctx, bookings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockhotelRepository)(nil).Reserve), ctx, bookings)
+}
+
+// MockholdRepository is a mock of holdRepository interface.
+type MockholdRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockholdRepositoryMockRecorder
+}
+
+// MockholdRepositoryMockRecorder is the mock recorder for MockholdRepository.
+type MockholdRepositoryMockRecorder struct {
+	mock *MockholdRepository
+}
+
+// NewMockholdRepository creates a new mock instance.
+func NewMockholdRepository(ctrl *gomock.Controller) *MockholdRepository {
+	mock := &MockholdRepository{ctrl: ctrl}
+	mock.recorder = &MockholdRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockholdRepository) EXPECT() *MockholdRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddHold mocks base method.
+func (m *MockholdRepository) AddHold(ctx context.Context, hold domain.Hold) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddHold", ctx, hold)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddHold indicates an expected call of AddHold.
+func (mr *MockholdRepositoryMockRecorder) AddHold(ctx, hold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHold", reflect.TypeOf((*MockholdRepository)(nil).AddHold), ctx, hold)
+}
+
+// DeleteHold mocks base method.
+func (m *MockholdRepository) DeleteHold(ctx context.Context, token domain.HoldToken) (*domain.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHold", ctx, token)
+	ret0, _ := ret[0].(*domain.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteHold indicates an expected call of DeleteHold.
+func (mr *MockholdRepositoryMockRecorder) DeleteHold(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHold", reflect.TypeOf((*MockholdRepository)(nil).DeleteHold), ctx, token)
+}
+
+// GetExpiredHolds mocks base method.
+func (m *MockholdRepository) GetExpiredHolds(ctx context.Context, now time.Time) ([]domain.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredHolds", ctx, now)
+	ret0, _ := ret[0].([]domain.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredHolds indicates an expected call of GetExpiredHolds.
+func (mr *MockholdRepositoryMockRecorder) GetExpiredHolds(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredHolds", reflect.TypeOf((*MockholdRepository)(nil).GetExpiredHolds), ctx, now)
+}
+
+// GetHold mocks base method.
+func (m *MockholdRepository) GetHold(ctx context.Context, token domain.HoldToken) (*domain.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHold", ctx, token)
+	ret0, _ := ret[0].(*domain.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHold indicates an expected call of GetHold.
+func (mr *MockholdRepositoryMockRecorder) GetHold(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHold", reflect.TypeOf((*MockholdRepository)(nil).GetHold), ctx, token)
+}
+
+// MockorderService is a mock of orderService interface.
+type MockorderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderServiceMockRecorder
+}
+
+// MockorderServiceMockRecorder is the mock recorder for MockorderService.
+type MockorderServiceMockRecorder struct {
+	mock *MockorderService
+}
+
+// NewMockorderService creates a new mock instance.
+func NewMockorderService(ctrl *gomock.Controller) *MockorderService {
+	mock := &MockorderService{ctrl: ctrl}
+	mock.recorder = &MockorderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorderService) EXPECT() *MockorderServiceMockRecorder {
+	return m.recorder
+}
+
+// GetOrderByID mocks base method.
+func (m *MockorderService) GetOrderByID(ctx context.Context, id domain.OrderID) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderByID indicates an expected call of GetOrderByID.
+func (mr *MockorderServiceMockRecorder) GetOrderByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderByID", reflect.TypeOf((*MockorderService)(nil).GetOrderByID), ctx, id)
+}
+
+// MockbookingService is a mock of bookingService interface.
+type MockbookingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockbookingServiceMockRecorder
+}
+
+// MockbookingServiceMockRecorder is the mock recorder for MockbookingService.
+type MockbookingServiceMockRecorder struct {
+	mock *MockbookingService
+}
+
+// NewMockbookingService creates a new mock instance.
+func NewMockbookingService(ctrl *gomock.Controller) *MockbookingService {
+	mock := &MockbookingService{ctrl: ctrl}
+	mock.recorder = &MockbookingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbookingService) EXPECT() *MockbookingServiceMockRecorder {
+	return m.recorder
+}
+
+// PlaceReservedOrder mocks base method.
+func (m *MockbookingService) PlaceReservedOrder(ctx context.Context, order domain.Order) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceReservedOrder", ctx, order)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceReservedOrder indicates an expected call of PlaceReservedOrder.
+func (mr *MockbookingServiceMockRecorder) PlaceReservedOrder(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceReservedOrder", reflect.TypeOf((*MockbookingService)(nil).PlaceReservedOrder), ctx, order)
+}
+
+// MockpricingService is a mock of pricingService interface.
+type MockpricingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockpricingServiceMockRecorder
+}
+
+// MockpricingServiceMockRecorder is the mock recorder for MockpricingService.
+type MockpricingServiceMockRecorder struct {
+	mock *MockpricingService
+}
+
+// NewMockpricingService creates a new mock instance.
+func NewMockpricingService(ctrl *gomock.Controller) *MockpricingService {
+	mock := &MockpricingService{ctrl: ctrl}
+	mock.recorder = &MockpricingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpricingService) EXPECT() *MockpricingServiceMockRecorder {
+	return m.recorder
+}
+
+// CancellationPolicies mocks base method.
+func (m *MockpricingService) CancellationPolicies(ctx context.Context, bookings []domain.Booking) ([]domain.CancellationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancellationPolicies", ctx, bookings)
+	ret0, _ := ret[0].([]domain.CancellationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancellationPolicies indicates an expected call of CancellationPolicies.
+func (mr *MockpricingServiceMockRecorder) CancellationPolicies(ctx, bookings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancellationPolicies", reflect.TypeOf((*MockpricingService)(nil).CancellationPolicies), ctx, bookings)
+}
+
+// Quote mocks base method.
+func (m *MockpricingService) Quote(ctx context.Context, bookings []domain.Booking) ([]domain.PriceLine, domain.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quote", ctx, bookings)
+	ret0, _ := ret[0].([]domain.PriceLine)
+	ret1, _ := ret[1].(domain.Money)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Quote indicates an expected call of Quote.
+func (mr *MockpricingServiceMockRecorder) Quote(ctx, bookings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quote", reflect.TypeOf((*MockpricingService)(nil).Quote), ctx, bookings)
+}
+
+// MockrestrictionService is a mock of restrictionService interface.
+type MockrestrictionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockrestrictionServiceMockRecorder
+}
+
+// MockrestrictionServiceMockRecorder is the mock recorder for MockrestrictionService.
+type MockrestrictionServiceMockRecorder struct {
+	mock *MockrestrictionService
+}
+
+// NewMockrestrictionService creates a new mock instance.
+func NewMockrestrictionService(ctrl *gomock.Controller) *MockrestrictionService {
+	mock := &MockrestrictionService{ctrl: ctrl}
+	mock.recorder = &MockrestrictionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrestrictionService) EXPECT() *MockrestrictionServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckRestrictions mocks base method.
+func (m *MockrestrictionService) CheckRestrictions(ctx context.Context, bookings []domain.Booking) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckRestrictions", ctx, bookings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckRestrictions indicates an expected call of CheckRestrictions.
+func (mr *MockrestrictionServiceMockRecorder) CheckRestrictions(ctx, bookings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckRestrictions", reflect.TypeOf((*MockrestrictionService)(nil).CheckRestrictions), ctx, bookings)
+}
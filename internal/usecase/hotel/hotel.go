@@ -0,0 +1,97 @@
+package hotel
+
+//go:generate mockgen -source=hotel.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+
+	"applicationDesignTest/internal/domain"
+)
+
+type hotelRepository interface {
+	GetHotel(ctx context.Context, hotelID domain.HotelID) (*domain.Hotel, error)
+	GetHotels(ctx context.Context) ([]domain.Hotel, error)
+	AddHotel(ctx context.Context, hotel domain.Hotel) error
+	UpdateHotel(ctx context.Context, hotel domain.Hotel) error
+	GetRoomTypes(ctx context.Context, hotelID domain.HotelID) ([]domain.HotelRoomType, error)
+	GetRoomType(ctx context.Context, hotelID domain.HotelID, code domain.RoomType) (*domain.HotelRoomType, error)
+	AddRoomType(ctx context.Context, roomType domain.HotelRoomType) error
+	UpdateRoomType(ctx context.Context, roomType domain.HotelRoomType) error
+}
+
+type HotelService struct {
+	hotelStore hotelRepository
+}
+
+func NewHotelService(hotelStore hotelRepository) *HotelService {
+	return &HotelService{
+		hotelStore: hotelStore,
+	}
+}
+
+func (s *HotelService) GetHotel(ctx context.Context, hotelID domain.HotelID) (*domain.Hotel, error) {
+	return s.hotelStore.GetHotel(ctx, hotelID)
+}
+
+func (s *HotelService) GetHotels(ctx context.Context) ([]domain.Hotel, error) {
+	return s.hotelStore.GetHotels(ctx)
+}
+
+func (s *HotelService) AddHotel(ctx context.Context, hotel domain.Hotel) (*domain.Hotel, error) {
+	if err := hotel.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.hotelStore.AddHotel(ctx, hotel); err != nil {
+		return nil, err
+	}
+
+	return &hotel, nil
+}
+
+// UpdateHotel replaces the description of the existing hotel.
+func (s *HotelService) UpdateHotel(ctx context.Context, hotel domain.Hotel) (*domain.Hotel, error) {
+	if err := hotel.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.hotelStore.UpdateHotel(ctx, hotel); err != nil {
+		return nil, err
+	}
+
+	return &hotel, nil
+}
+
+func (s *HotelService) GetRoomTypes(ctx context.Context, hotelID domain.HotelID) ([]domain.HotelRoomType, error) {
+	return s.hotelStore.GetRoomTypes(ctx, hotelID)
+}
+
+func (s *HotelService) GetRoomType(ctx context.Context, hotelID domain.HotelID, code domain.RoomType) (*domain.HotelRoomType, error) {
+	return s.hotelStore.GetRoomType(ctx, hotelID, code)
+}
+
+// AddRoomType adds the room type to the catalog of its hotel.
+func (s *HotelService) AddRoomType(ctx context.Context, roomType domain.HotelRoomType) (*domain.HotelRoomType, error) {
+	if err := roomType.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.hotelStore.AddRoomType(ctx, roomType); err != nil {
+		return nil, err
+	}
+
+	return &roomType, nil
+}
+
+// UpdateRoomType replaces the description of the room type in the catalog.
+func (s *HotelService) UpdateRoomType(ctx context.Context, roomType domain.HotelRoomType) (*domain.HotelRoomType, error) {
+	if err := roomType.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.hotelStore.UpdateRoomType(ctx, roomType); err != nil {
+		return nil, err
+	}
+
+	return &roomType, nil
+}
@@ -0,0 +1,300 @@
+package hotel
+
+import (
+	"context"
+	"testing"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/internal/usecase/hotel/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHotelService_AddHotel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelRepo := mocks.NewMockhotelRepository(ctrl)
+
+	hs := NewHotelService(mockHotelRepo)
+
+	validHotel := domain.Hotel{
+		ID:           1,
+		Name:         "Reddison",
+		Timezone:     "Europe/Moscow",
+		StarRating:   4,
+		CheckInTime:  "14:00",
+		CheckOutTime: "12:00",
+	}
+
+	noName := validHotel
+	noName.Name = ""
+
+	badTimezone := validHotel
+	badTimezone.Timezone = "Mars/Olympus"
+
+	badCheckIn := validHotel
+	badCheckIn.CheckInTime = "2pm"
+
+	tests := []struct {
+		name          string
+		hotel         domain.Hotel
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name:  "valid hotel is stored",
+			hotel: validHotel,
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().AddHotel(gomock.Any(), validHotel).Return(nil)
+			},
+		},
+		{
+			name:          "hotel without name isn't stored",
+			hotel:         noName,
+			mockSetup:     func() {},
+			expectedError: domain.ErrInvalidHotel,
+		},
+		{
+			name:          "hotel with unknown timezone isn't stored",
+			hotel:         badTimezone,
+			mockSetup:     func() {},
+			expectedError: domain.ErrInvalidHotel,
+		},
+		{
+			name:          "hotel with malformed check-in time isn't stored",
+			hotel:         badCheckIn,
+			mockSetup:     func() {},
+			expectedError: domain.ErrInvalidHotel,
+		},
+		{
+			name:  "duplicate hotel",
+			hotel: validHotel,
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().AddHotel(gomock.Any(), validHotel).Return(domain.ErrHotelAlreadyExists)
+			},
+			expectedError: domain.ErrHotelAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			got, err := hs.AddHotel(context.Background(), tt.hotel)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &tt.hotel, got)
+			}
+		})
+	}
+}
+
+func TestHotelService_UpdateHotel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelRepo := mocks.NewMockhotelRepository(ctrl)
+
+	hs := NewHotelService(mockHotelRepo)
+
+	validHotel := domain.Hotel{ID: 1, Name: "Reddison", Timezone: "UTC", StarRating: 5}
+
+	badRating := validHotel
+	badRating.StarRating = 6
+
+	tests := []struct {
+		name          string
+		hotel         domain.Hotel
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name:  "valid hotel is updated",
+			hotel: validHotel,
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().UpdateHotel(gomock.Any(), validHotel).Return(nil)
+			},
+		},
+		{
+			name:          "hotel with star rating above 5 isn't updated",
+			hotel:         badRating,
+			mockSetup:     func() {},
+			expectedError: domain.ErrInvalidHotel,
+		},
+		{
+			name:  "unknown hotel",
+			hotel: validHotel,
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().UpdateHotel(gomock.Any(), validHotel).Return(domain.ErrHotelNotFound)
+			},
+			expectedError: domain.ErrHotelNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			got, err := hs.UpdateHotel(context.Background(), tt.hotel)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &tt.hotel, got)
+			}
+		})
+	}
+}
+
+func TestHotelService_AddRoomType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelRepo := mocks.NewMockhotelRepository(ctrl)
+
+	hs := NewHotelService(mockHotelRepo)
+
+	validRoomType := domain.HotelRoomType{
+		HotelID:  1,
+		Code:     domain.RoomTypeDouble,
+		Name:     "Double",
+		Capacity: 2,
+	}
+
+	noCode := validRoomType
+	noCode.Code = ""
+
+	zeroCapacity := validRoomType
+	zeroCapacity.Capacity = 0
+
+	tests := []struct {
+		name          string
+		roomType      domain.HotelRoomType
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name:     "valid room type is stored",
+			roomType: validRoomType,
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().AddRoomType(gomock.Any(), validRoomType).Return(nil)
+			},
+		},
+		{
+			name:          "room type without code isn't stored",
+			roomType:      noCode,
+			mockSetup:     func() {},
+			expectedError: domain.ErrInvalidRoomType,
+		},
+		{
+			name:          "room type without capacity isn't stored",
+			roomType:      zeroCapacity,
+			mockSetup:     func() {},
+			expectedError: domain.ErrInvalidRoomType,
+		},
+		{
+			name:     "unknown hotel",
+			roomType: validRoomType,
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().AddRoomType(gomock.Any(), validRoomType).Return(domain.ErrHotelNotFound)
+			},
+			expectedError: domain.ErrHotelNotFound,
+		},
+		{
+			name:     "duplicate room type",
+			roomType: validRoomType,
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().AddRoomType(gomock.Any(), validRoomType).Return(domain.ErrRoomTypeAlreadyExists)
+			},
+			expectedError: domain.ErrRoomTypeAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			got, err := hs.AddRoomType(context.Background(), tt.roomType)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &tt.roomType, got)
+			}
+		})
+	}
+}
+
+func TestHotelService_UpdateRoomType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelRepo := mocks.NewMockhotelRepository(ctrl)
+
+	hs := NewHotelService(mockHotelRepo)
+
+	validRoomType := domain.HotelRoomType{
+		HotelID:  1,
+		Code:     domain.RoomTypeLux,
+		Name:     "Lux",
+		Capacity: 3,
+	}
+
+	noName := validRoomType
+	noName.Name = ""
+
+	tests := []struct {
+		name          string
+		roomType      domain.HotelRoomType
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name:     "valid room type is updated",
+			roomType: validRoomType,
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().UpdateRoomType(gomock.Any(), validRoomType).Return(nil)
+			},
+		},
+		{
+			name:          "room type without name isn't updated",
+			roomType:      noName,
+			mockSetup:     func() {},
+			expectedError: domain.ErrInvalidRoomType,
+		},
+		{
+			name:     "unknown room type",
+			roomType: validRoomType,
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().UpdateRoomType(gomock.Any(), validRoomType).Return(domain.ErrRoomTypeNotFound)
+			},
+			expectedError: domain.ErrRoomTypeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			got, err := hs.UpdateRoomType(context.Background(), tt.roomType)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &tt.roomType, got)
+			}
+		})
+	}
+}
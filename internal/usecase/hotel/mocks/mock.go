@@ -0,0 +1,152 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: hotel.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockhotelRepository is a mock of hotelRepository interface.
+type MockhotelRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockhotelRepositoryMockRecorder
+}
+
+// MockhotelRepositoryMockRecorder is the mock recorder for MockhotelRepository.
+type MockhotelRepositoryMockRecorder struct {
+	mock *MockhotelRepository
+}
+
+// NewMockhotelRepository creates a new mock instance.
+func NewMockhotelRepository(ctrl *gomock.Controller) *MockhotelRepository {
+	mock := &MockhotelRepository{ctrl: ctrl}
+	mock.recorder = &MockhotelRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhotelRepository) EXPECT() *MockhotelRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddHotel mocks base method.
+func (m *MockhotelRepository) AddHotel(ctx context.Context, hotel domain.Hotel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddHotel", ctx, hotel)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddHotel indicates an expected call of AddHotel.
+func (mr *MockhotelRepositoryMockRecorder) AddHotel(ctx, hotel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHotel", reflect.TypeOf((*MockhotelRepository)(nil).AddHotel), ctx, hotel)
+}
+
+// AddRoomType mocks base method.
+func (m *MockhotelRepository) AddRoomType(ctx context.Context, roomType domain.HotelRoomType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRoomType", ctx, roomType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRoomType indicates an expected call of AddRoomType.
+func (mr *MockhotelRepositoryMockRecorder) AddRoomType(ctx, roomType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRoomType", reflect.TypeOf((*MockhotelRepository)(nil).AddRoomType), ctx, roomType)
+}
+
+// GetHotel mocks base method.
+func (m *MockhotelRepository) GetHotel(ctx context.Context, hotelID domain.HotelID) (*domain.Hotel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHotel", ctx, hotelID)
+	ret0, _ := ret[0].(*domain.Hotel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHotel indicates an expected call of GetHotel.
+func (mr *MockhotelRepositoryMockRecorder) GetHotel(ctx, hotelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotel", reflect.TypeOf((*MockhotelRepository)(nil).GetHotel), ctx, hotelID)
+}
+
+// GetHotels mocks base method.
+func (m *MockhotelRepository) GetHotels(ctx context.Context) ([]domain.Hotel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHotels", ctx)
+	ret0, _ := ret[0].([]domain.Hotel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHotels indicates an expected call of GetHotels.
+func (mr *MockhotelRepositoryMockRecorder) GetHotels(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotels", reflect.TypeOf((*MockhotelRepository)(nil).GetHotels), ctx)
+}
+
+// GetRoomType mocks base method.
+func (m *MockhotelRepository) GetRoomType(ctx context.Context, hotelID domain.HotelID, code domain.RoomType) (*domain.HotelRoomType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoomType", ctx, hotelID, code)
+	ret0, _ := ret[0].(*domain.HotelRoomType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoomType indicates an expected call of GetRoomType.
+func (mr *MockhotelRepositoryMockRecorder) GetRoomType(ctx, hotelID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoomType", reflect.TypeOf((*MockhotelRepository)(nil).GetRoomType), ctx, hotelID, code)
+}
+
+// GetRoomTypes mocks base method.
+func (m *MockhotelRepository) GetRoomTypes(ctx context.Context, hotelID domain.HotelID) ([]domain.HotelRoomType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoomTypes", ctx, hotelID)
+	ret0, _ := ret[0].([]domain.HotelRoomType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoomTypes indicates an expected call of GetRoomTypes.
+func (mr *MockhotelRepositoryMockRecorder) GetRoomTypes(ctx, hotelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoomTypes", reflect.TypeOf((*MockhotelRepository)(nil).GetRoomTypes), ctx, hotelID)
+}
+
+// UpdateHotel mocks base method.
+func (m *MockhotelRepository) UpdateHotel(ctx context.Context, hotel domain.Hotel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHotel", ctx, hotel)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateHotel indicates an expected call of UpdateHotel.
+func (mr *MockhotelRepositoryMockRecorder) UpdateHotel(ctx, hotel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHotel", reflect.TypeOf((*MockhotelRepository)(nil).UpdateHotel), ctx, hotel)
+}
+
+// UpdateRoomType mocks base method.
+func (m *MockhotelRepository) UpdateRoomType(ctx context.Context, roomType domain.HotelRoomType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRoomType", ctx, roomType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRoomType indicates an expected call of UpdateRoomType.
+func (mr *MockhotelRepositoryMockRecorder) UpdateRoomType(ctx, roomType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRoomType", reflect.TypeOf((*MockhotelRepository)(nil).UpdateRoomType), ctx, roomType)
+}
@@ -0,0 +1,201 @@
+package inventory
+
+//go:generate mockgen -source=inventory.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+type hotelRepository interface {
+	GetHotel(ctx context.Context, hotelID domain.HotelID) (*domain.Hotel, error)
+	GetRoomType(ctx context.Context, hotelID domain.HotelID, code domain.RoomType) (*domain.HotelRoomType, error)
+	GetRoomAvailability(ctx context.Context, hotelID domain.HotelID, from, to time.Time) ([]domain.RoomAvailability, error)
+	AddRoomAvailability(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error
+	AddRoomAvailabilities(ctx context.Context, changes []domain.AvailabilityChange) error
+	SetRoomCapacity(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, capacity int) error
+	ReduceRoomCapacity(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error
+}
+
+type restrictionRepository interface {
+	SetRestriction(ctx context.Context, restriction domain.Restriction) error
+	GetRestrictions(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, from, to time.Time) ([]domain.Restriction, error)
+}
+
+// InventoryService manages the rooms the hotels put up for booking and the restrictions of their sale.
+type InventoryService struct {
+	hotelStore       hotelRepository
+	restrictionStore restrictionRepository
+}
+
+func NewInventoryService(hotelStore hotelRepository, restrictionStore restrictionRepository) *InventoryService {
+	return &InventoryService{
+		hotelStore:       hotelStore,
+		restrictionStore: restrictionStore,
+	}
+}
+
+// AddRoomAvailability adds rooms of the room type on the date.
+func (s *InventoryService) AddRoomAvailability(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error {
+	if rooms <= 0 {
+		return fmt.Errorf("%w: rooms must be positive", domain.ErrInvalidAvailability)
+	}
+
+	return s.hotelStore.AddRoomAvailability(ctx, hotelID, roomType, date, rooms)
+}
+
+// SetRoomCapacity sets the number of rooms of the room type on the date. It can't be less than the reserved rooms.
+func (s *InventoryService) SetRoomCapacity(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, capacity int) error {
+	if capacity < 0 {
+		return fmt.Errorf("%w: capacity can't be negative", domain.ErrInvalidAvailability)
+	}
+
+	return s.hotelStore.SetRoomCapacity(ctx, hotelID, roomType, date, capacity)
+}
+
+// ReduceRoomCapacity takes free rooms of the room type on the date out of the capacity.
+func (s *InventoryService) ReduceRoomCapacity(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error {
+	if rooms <= 0 {
+		return fmt.Errorf("%w: rooms must be positive", domain.ErrInvalidAvailability)
+	}
+
+	return s.hotelStore.ReduceRoomCapacity(ctx, hotelID, roomType, date, rooms)
+}
+
+// UploadAvailability adds the rooms of the upload ranges at once and reports the changed nights.
+// A dry run reports the availability the nights would have.
+func (s *InventoryService) UploadAvailability(ctx context.Context, upload domain.AvailabilityUpload) (*domain.AvailabilityUploadResult, error) {
+	if err := upload.Validate(); err != nil {
+		return nil, err
+	}
+
+	changes := upload.Changes()
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("%w: no dates match the ranges", domain.ErrInvalidAvailability)
+	}
+
+	checked := make(map[domain.RoomType]bool)
+	for _, change := range changes {
+		if checked[change.RoomType] {
+			continue
+		}
+
+		if _, err := s.hotelStore.GetRoomType(ctx, upload.HotelID, change.RoomType); err != nil {
+			return nil, err
+		}
+
+		checked[change.RoomType] = true
+	}
+
+	if !upload.DryRun {
+		if err := s.hotelStore.AddRoomAvailabilities(ctx, changes); err != nil {
+			return nil, err
+		}
+	}
+
+	from, to := changes[0].Date, changes[0].Date
+	for _, change := range changes {
+		if change.Date.Before(from) {
+			from = change.Date
+		}
+		if change.Date.After(to) {
+			to = change.Date
+		}
+	}
+
+	availability, err := s.hotelStore.GetRoomAvailability(ctx, upload.HotelID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability: %w", err)
+	}
+
+	type night struct {
+		roomType domain.RoomType
+		date     time.Time
+	}
+
+	current := make(map[night]domain.RoomAvailability, len(availability))
+	for _, item := range availability {
+		current[night{roomType: item.RoomType, date: item.Date}] = item
+	}
+
+	result := &domain.AvailabilityUploadResult{DryRun: upload.DryRun, Nights: make([]domain.AvailabilityUploadNight, 0, len(changes))}
+
+	for _, change := range changes {
+		item := current[night{roomType: change.RoomType, date: change.Date}]
+
+		uploadNight := domain.AvailabilityUploadNight{
+			RoomType: change.RoomType,
+			Date:     change.Date,
+			Added:    change.Delta,
+			Capacity: item.Capacity,
+			Rooms:    item.Rooms,
+		}
+
+		// the dry run hasn't added the rooms yet
+		if upload.DryRun {
+			uploadNight.Capacity += change.Delta
+			uploadNight.Rooms += change.Delta
+		}
+
+		result.Nights = append(result.Nights, uploadNight)
+	}
+
+	return result, nil
+}
+
+// SetRestriction replaces the restriction of the room type on the date, a restriction without rules lifts it.
+func (s *InventoryService) SetRestriction(ctx context.Context, restriction domain.Restriction) (*domain.Restriction, error) {
+	if err := restriction.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.hotelStore.GetRoomType(ctx, restriction.HotelID, restriction.RoomType); err != nil {
+		return nil, err
+	}
+
+	if err := s.restrictionStore.SetRestriction(ctx, restriction); err != nil {
+		return nil, err
+	}
+
+	return &restriction, nil
+}
+
+// GetRestrictions returns the restrictions of the hotel for the dates of the query. Empty roomType matches any room type.
+func (s *InventoryService) GetRestrictions(ctx context.Context, query domain.CalendarQuery, roomType domain.RoomType) ([]domain.Restriction, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.hotelStore.GetHotel(ctx, query.HotelID); err != nil {
+		return nil, err
+	}
+
+	return s.restrictionStore.GetRestrictions(ctx, query.HotelID, roomType, query.From, query.To)
+}
+
+// CheckRestrictions checks that the bookings break no restriction of their room types.
+// The error names the violated rule.
+func (s *InventoryService) CheckRestrictions(ctx context.Context, bookings []domain.Booking) error {
+	for _, booking := range bookings {
+		// the departure day may be closed too
+		restrictions, err := s.restrictionStore.GetRestrictions(ctx, booking.HotelID, booking.RoomType,
+			booking.From, booking.To.AddDate(0, 0, 1))
+		if err != nil {
+			return fmt.Errorf("failed to get restrictions: %w", err)
+		}
+
+		byDate := make(map[time.Time]domain.Restriction, len(restrictions))
+		for _, restriction := range restrictions {
+			byDate[restriction.Date] = restriction
+		}
+
+		if err := booking.CheckRestrictions(byDate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
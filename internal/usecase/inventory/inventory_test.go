@@ -0,0 +1,323 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/internal/usecase/inventory/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInventoryService_UploadAvailability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelRepo := mocks.NewMockhotelRepository(ctrl)
+
+	is := NewInventoryService(mockHotelRepo, nil)
+
+	// Saturday and Sunday
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+
+	single := &domain.HotelRoomType{HotelID: 1, Code: "single", Name: "Single", Capacity: 1}
+
+	upload := domain.AvailabilityUpload{
+		HotelID: 1,
+		Ranges: []domain.AvailabilityRange{
+			{RoomTypes: []domain.RoomType{"single"}, From: from, To: to, Rooms: 2},
+			// the week of February 1 has one more Saturday room
+			{RoomTypes: []domain.RoomType{"single"}, From: from, To: from.AddDate(0, 0, 6), Weekdays: []time.Weekday{time.Saturday}, Rooms: 1},
+		},
+	}
+
+	changes := []domain.AvailabilityChange{
+		{HotelID: 1, RoomType: "single", Date: from, Delta: 3},
+		{HotelID: 1, RoomType: "single", Date: to, Delta: 2},
+	}
+
+	tests := []struct {
+		name           string
+		upload         func() domain.AvailabilityUpload
+		mockSetup      func()
+		expectedResult *domain.AvailabilityUploadResult
+		expectedError  error
+	}{
+		{
+			name:   "rooms are added",
+			upload: func() domain.AvailabilityUpload { return upload },
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomType("single")).Return(single, nil)
+				mockHotelRepo.EXPECT().AddRoomAvailabilities(gomock.Any(), changes).Return(nil)
+				mockHotelRepo.EXPECT().GetRoomAvailability(gomock.Any(), domain.HotelID(1), from, to).Return([]domain.RoomAvailability{
+					{HotelID: 1, RoomType: "single", Date: from, Rooms: 4, Capacity: 5},
+					{HotelID: 1, RoomType: "single", Date: to, Rooms: 2, Capacity: 2},
+				}, nil)
+			},
+			expectedResult: &domain.AvailabilityUploadResult{
+				Nights: []domain.AvailabilityUploadNight{
+					{RoomType: "single", Date: from, Added: 3, Capacity: 5, Rooms: 4},
+					{RoomType: "single", Date: to, Added: 2, Capacity: 2, Rooms: 2},
+				},
+			},
+		},
+		{
+			name: "dry run reports the availability without adding rooms",
+			upload: func() domain.AvailabilityUpload {
+				dryRun := upload
+				dryRun.DryRun = true
+				return dryRun
+			},
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomType("single")).Return(single, nil)
+				mockHotelRepo.EXPECT().GetRoomAvailability(gomock.Any(), domain.HotelID(1), from, to).Return([]domain.RoomAvailability{
+					{HotelID: 1, RoomType: "single", Date: from, Rooms: 1, Capacity: 2},
+				}, nil)
+			},
+			expectedResult: &domain.AvailabilityUploadResult{
+				DryRun: true,
+				Nights: []domain.AvailabilityUploadNight{
+					{RoomType: "single", Date: from, Added: 3, Capacity: 5, Rooms: 4},
+					{RoomType: "single", Date: to, Added: 2, Capacity: 2, Rooms: 2},
+				},
+			},
+		},
+		{
+			name: "room type not found",
+			upload: func() domain.AvailabilityUpload {
+				return domain.AvailabilityUpload{HotelID: 1, Ranges: []domain.AvailabilityRange{
+					{RoomTypes: []domain.RoomType{"suite"}, From: from, To: to, Rooms: 1},
+				}}
+			},
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomType("suite")).Return(nil, domain.ErrRoomTypeNotFound)
+			},
+			expectedError: domain.ErrRoomTypeNotFound,
+		},
+		{
+			name: "no dates match the weekdays",
+			upload: func() domain.AvailabilityUpload {
+				return domain.AvailabilityUpload{HotelID: 1, Ranges: []domain.AvailabilityRange{
+					{RoomTypes: []domain.RoomType{"single"}, From: from, To: to, Weekdays: []time.Weekday{time.Monday}, Rooms: 1},
+				}}
+			},
+			mockSetup:     func() {},
+			expectedError: domain.ErrInvalidAvailability,
+		},
+		{
+			name:   "store error",
+			upload: func() domain.AvailabilityUpload { return upload },
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().GetRoomType(gomock.Any(), domain.HotelID(1), domain.RoomType("single")).Return(single, nil)
+				mockHotelRepo.EXPECT().AddRoomAvailabilities(gomock.Any(), changes).Return(errors.New("store error"))
+			},
+			expectedError: errors.New("store error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			result, err := is.UploadAvailability(context.Background(), tt.upload())
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestInventoryService_SetRoomCapacity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelRepo := mocks.NewMockhotelRepository(ctrl)
+
+	is := NewInventoryService(mockHotelRepo, nil)
+
+	date := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	belowReserved := &domain.CapacityBelowReservedError{RoomType: "single", Date: date, Capacity: 1, Reserved: 2}
+
+	tests := []struct {
+		name          string
+		capacity      int
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name:     "capacity is set",
+			capacity: 5,
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().SetRoomCapacity(gomock.Any(), domain.HotelID(1), domain.RoomType("single"), date, 5).Return(nil)
+			},
+		},
+		{
+			name:     "all rooms are taken out",
+			capacity: 0,
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().SetRoomCapacity(gomock.Any(), domain.HotelID(1), domain.RoomType("single"), date, 0).Return(nil)
+			},
+		},
+		{
+			name:     "capacity below reserved rooms",
+			capacity: 1,
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().SetRoomCapacity(gomock.Any(), domain.HotelID(1), domain.RoomType("single"), date, 1).Return(belowReserved)
+			},
+			expectedError: domain.ErrCapacityBelowReserved,
+		},
+		{
+			name:          "negative capacity",
+			capacity:      -1,
+			mockSetup:     func() {},
+			expectedError: domain.ErrInvalidAvailability,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			err := is.SetRoomCapacity(context.Background(), 1, "single", date, tt.capacity)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestInventoryService_ReduceRoomCapacity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelRepo := mocks.NewMockhotelRepository(ctrl)
+
+	is := NewInventoryService(mockHotelRepo, nil)
+
+	date := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		rooms         int
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name:  "free rooms are taken out",
+			rooms: 2,
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().ReduceRoomCapacity(gomock.Any(), domain.HotelID(1), domain.RoomType("single"), date, 2).Return(nil)
+			},
+		},
+		{
+			name:  "reserved rooms can't be taken out",
+			rooms: 3,
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().ReduceRoomCapacity(gomock.Any(), domain.HotelID(1), domain.RoomType("single"), date, 3).
+					Return(&domain.CapacityBelowReservedError{RoomType: "single", Date: date, Capacity: 0, Reserved: 1})
+			},
+			expectedError: domain.ErrCapacityBelowReserved,
+		},
+		{
+			name:          "non-positive rooms",
+			rooms:         0,
+			mockSetup:     func() {},
+			expectedError: domain.ErrInvalidAvailability,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			err := is.ReduceRoomCapacity(context.Background(), 1, "single", date, tt.rooms)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestInventoryService_CheckRestrictions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRestrictionRepo := mocks.NewMockrestrictionRepository(ctrl)
+
+	is := NewInventoryService(nil, mockRestrictionRepo)
+
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	departure := to.AddDate(0, 0, 1)
+
+	bookings := []domain.Booking{{HotelID: 1, RoomType: "single", From: from, To: to, RoomCount: 1}}
+
+	tests := []struct {
+		name          string
+		restrictions  []domain.Restriction
+		expectedRule  domain.RestrictionRule
+		expectedError error
+	}{
+		{
+			name: "no rule is broken",
+			restrictions: []domain.Restriction{
+				{HotelID: 1, RoomType: "single", Date: from, MinStay: 2, MaxStay: 7},
+				{HotelID: 1, RoomType: "single", Date: to, ClosedToArrival: true},
+			},
+		},
+		{
+			name:          "stop sell on a night",
+			restrictions:  []domain.Restriction{{HotelID: 1, RoomType: "single", Date: to, StopSell: true}},
+			expectedRule:  domain.RuleStopSell,
+			expectedError: domain.ErrRestrictionViolated,
+		},
+		{
+			name:          "closed to departure",
+			restrictions:  []domain.Restriction{{HotelID: 1, RoomType: "single", Date: departure, ClosedToDeparture: true}},
+			expectedRule:  domain.RuleClosedToDeparture,
+			expectedError: domain.ErrRestrictionViolated,
+		},
+		{
+			name:          "too short stay",
+			restrictions:  []domain.Restriction{{HotelID: 1, RoomType: "single", Date: from, MinStay: 3}},
+			expectedRule:  domain.RuleMinStay,
+			expectedError: domain.ErrRestrictionViolated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRestrictionRepo.EXPECT().GetRestrictions(gomock.Any(), domain.HotelID(1), domain.RoomType("single"), from, departure).
+				Return(tt.restrictions, nil)
+
+			err := is.CheckRestrictions(context.Background(), bookings)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+
+				var restrictionErr *domain.RestrictionError
+				if assert.ErrorAs(t, err, &restrictionErr) {
+					assert.Equal(t, tt.expectedRule, restrictionErr.Rule)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: inventory.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockhotelRepository is a mock of hotelRepository interface.
+type MockhotelRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockhotelRepositoryMockRecorder
+}
+
+// MockhotelRepositoryMockRecorder is the mock recorder for MockhotelRepository.
+type MockhotelRepositoryMockRecorder struct {
+	mock *MockhotelRepository
+}
+
+// NewMockhotelRepository creates a new mock instance.
+func NewMockhotelRepository(ctrl *gomock.Controller) *MockhotelRepository {
+	mock := &MockhotelRepository{ctrl: ctrl}
+	mock.recorder = &MockhotelRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhotelRepository) EXPECT() *MockhotelRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddRoomAvailabilities mocks base method.
+func (m *MockhotelRepository) AddRoomAvailabilities(ctx context.Context, changes []domain.AvailabilityChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRoomAvailabilities", ctx, changes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRoomAvailabilities indicates an expected call of AddRoomAvailabilities.
+func (mr *MockhotelRepositoryMockRecorder) AddRoomAvailabilities(ctx, changes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRoomAvailabilities", reflect.TypeOf((*MockhotelRepository)(nil).AddRoomAvailabilities), ctx, changes)
+}
+
+// AddRoomAvailability mocks base method.
+func (m *MockhotelRepository) AddRoomAvailability(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRoomAvailability", ctx, hotelID, roomType, date, rooms)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRoomAvailability indicates an expected call of AddRoomAvailability.
+func (mr *MockhotelRepositoryMockRecorder) AddRoomAvailability(ctx, hotelID, roomType, date, rooms interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRoomAvailability", reflect.TypeOf((*MockhotelRepository)(nil).AddRoomAvailability), ctx, hotelID, roomType, date, rooms)
+}
+
+// GetHotel mocks base method.
+func (m *MockhotelRepository) GetHotel(ctx context.Context, hotelID domain.HotelID) (*domain.Hotel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHotel", ctx, hotelID)
+	ret0, _ := ret[0].(*domain.Hotel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHotel indicates an expected call of GetHotel.
+func (mr *MockhotelRepositoryMockRecorder) GetHotel(ctx, hotelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotel", reflect.TypeOf((*MockhotelRepository)(nil).GetHotel), ctx, hotelID)
+}
+
+// GetRoomAvailability mocks base method.
+func (m *MockhotelRepository) GetRoomAvailability(ctx context.Context, hotelID domain.HotelID, from, to time.Time) ([]domain.RoomAvailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoomAvailability", ctx, hotelID, from, to)
+	ret0, _ := ret[0].([]domain.RoomAvailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoomAvailability indicates an expected call of GetRoomAvailability.
+func (mr *MockhotelRepositoryMockRecorder) GetRoomAvailability(ctx, hotelID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoomAvailability", reflect.TypeOf((*MockhotelRepository)(nil).GetRoomAvailability), ctx, hotelID, from, to)
+}
+
+// GetRoomType mocks base method.
+func (m *MockhotelRepository) GetRoomType(ctx context.Context, hotelID domain.HotelID, code domain.RoomType) (*domain.HotelRoomType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoomType", ctx, hotelID, code)
+	ret0, _ := ret[0].(*domain.HotelRoomType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoomType indicates an expected call of GetRoomType.
+func (mr *MockhotelRepositoryMockRecorder) GetRoomType(ctx, hotelID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoomType", reflect.TypeOf((*MockhotelRepository)(nil).GetRoomType), ctx, hotelID, code)
+}
+
+// ReduceRoomCapacity mocks base method.
+func (m *MockhotelRepository) ReduceRoomCapacity(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, rooms int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReduceRoomCapacity", ctx, hotelID, roomType, date, rooms)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReduceRoomCapacity indicates an expected call of ReduceRoomCapacity.
+func (mr *MockhotelRepositoryMockRecorder) ReduceRoomCapacity(ctx, hotelID, roomType, date, rooms interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReduceRoomCapacity", reflect.TypeOf((*MockhotelRepository)(nil).ReduceRoomCapacity), ctx, hotelID, roomType, date, rooms)
+}
+
+// SetRoomCapacity mocks base method.
+func (m *MockhotelRepository) SetRoomCapacity(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time, capacity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRoomCapacity", ctx, hotelID, roomType, date, capacity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRoomCapacity indicates an expected call of SetRoomCapacity.
+func (mr *MockhotelRepositoryMockRecorder) SetRoomCapacity(ctx, hotelID, roomType, date, capacity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRoomCapacity", reflect.TypeOf((*MockhotelRepository)(nil).SetRoomCapacity), ctx, hotelID, roomType, date, capacity)
+}
+
+// MockrestrictionRepository is a mock of restrictionRepository interface.
+type MockrestrictionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockrestrictionRepositoryMockRecorder
+}
+
+// MockrestrictionRepositoryMockRecorder is the mock recorder for MockrestrictionRepository.
+type MockrestrictionRepositoryMockRecorder struct {
+	mock *MockrestrictionRepository
+}
+
+// NewMockrestrictionRepository creates a new mock instance.
+func NewMockrestrictionRepository(ctrl *gomock.Controller) *MockrestrictionRepository {
+	mock := &MockrestrictionRepository{ctrl: ctrl}
+	mock.recorder = &MockrestrictionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrestrictionRepository) EXPECT() *MockrestrictionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetRestrictions mocks base method.
+func (m *MockrestrictionRepository) GetRestrictions(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, from, to time.Time) ([]domain.Restriction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRestrictions", ctx, hotelID, roomType, from, to)
+	ret0, _ := ret[0].([]domain.Restriction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRestrictions indicates an expected call of GetRestrictions.
+func (mr *MockrestrictionRepositoryMockRecorder) GetRestrictions(ctx, hotelID, roomType, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRestrictions", reflect.TypeOf((*MockrestrictionRepository)(nil).GetRestrictions), ctx, hotelID, roomType, from, to)
+}
+
+// SetRestriction mocks base method.
+func (m *MockrestrictionRepository) SetRestriction(ctx context.Context, restriction domain.Restriction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRestriction", ctx, restriction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRestriction indicates an expected call of SetRestriction.
+func (mr *MockrestrictionRepositoryMockRecorder) SetRestriction(ctx, restriction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRestriction", reflect.TypeOf((*MockrestrictionRepository)(nil).SetRestriction), ctx, restriction)
+}
@@ -0,0 +1,136 @@
+package loyalty
+
+//go:generate mockgen -source=loyalty.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"fmt"
+
+	"applicationDesignTest/internal/domain"
+)
+
+type loyaltyRepository interface {
+	AppendEntry(ctx context.Context, entry domain.LoyaltyEntry) (*domain.LoyaltyEntry, error)
+	GetBalance(ctx context.Context, userID domain.UserID) (int64, error)
+	GetUserEntries(ctx context.Context, userID domain.UserID) ([]domain.LoyaltyEntry, error)
+	GetOrderEntries(ctx context.Context, orderNumber domain.OrderNumber) ([]domain.LoyaltyEntry, error)
+}
+
+type LoyaltyService struct {
+	loyaltyStore loyaltyRepository
+	earnPercent  int64
+	pointValue   int64
+}
+
+// NewLoyaltyService creates the service which credits earnPercent of the order total as points.
+// One point is worth pointValue minor currency units.
+func NewLoyaltyService(loyaltyStore loyaltyRepository, earnPercent, pointValue int64) *LoyaltyService {
+	return &LoyaltyService{
+		loyaltyStore: loyaltyStore,
+		earnPercent:  earnPercent,
+		pointValue:   pointValue,
+	}
+}
+
+func (s *LoyaltyService) GetAccount(ctx context.Context, userID domain.UserID) (*domain.LoyaltyAccount, error) {
+	balance, err := s.loyaltyStore.GetBalance(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	history, err := s.loyaltyStore.GetUserEntries(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history: %w", err)
+	}
+
+	return &domain.LoyaltyAccount{
+		UserID:  userID,
+		Balance: balance,
+		History: history,
+	}, nil
+}
+
+// RedeemPoints debits the points requested by the order and returns the discount they give.
+// The points can't be worth more than the order total. The order must have its number already taken,
+// the ledger entries are kept by it.
+func (s *LoyaltyService) RedeemPoints(ctx context.Context, order domain.Order) (*domain.Discount, error) {
+	if order.LoyaltyPoints <= 0 {
+		return nil, fmt.Errorf("%w: points must be positive", domain.ErrInvalidPoints)
+	}
+
+	amount := order.LoyaltyPoints * s.pointValue
+	if amount > order.Total.Amount {
+		return nil, fmt.Errorf("%w: %d points are worth more than the order total", domain.ErrInvalidPoints, order.LoyaltyPoints)
+	}
+
+	_, err := s.loyaltyStore.AppendEntry(ctx, domain.LoyaltyEntry{
+		UserID:      order.UserID,
+		OrderNumber: order.Number,
+		Type:        domain.LoyaltyEntryRedeem,
+		Points:      -order.LoyaltyPoints,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Discount{
+		Source: domain.DiscountSourceLoyalty,
+		Points: order.LoyaltyPoints,
+		Amount: domain.Money{Amount: amount, Currency: order.Total.Currency},
+	}, nil
+}
+
+// EarnPoints credits the points for the completed order. The points are credited once per order,
+// so the earning can be repeated if it wasn't known to succeed.
+func (s *LoyaltyService) EarnPoints(ctx context.Context, order domain.Order) error {
+	points := order.Total.Amount * s.earnPercent / 100 / s.pointValue
+	if points == 0 {
+		return nil
+	}
+
+	entries, err := s.loyaltyStore.GetOrderEntries(ctx, order.Number)
+	if err != nil {
+		return fmt.Errorf("failed to get order entries: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.Type == domain.LoyaltyEntryEarn {
+			return nil
+		}
+	}
+
+	_, err = s.loyaltyStore.AppendEntry(ctx, domain.LoyaltyEntry{
+		UserID:      order.UserID,
+		OrderNumber: order.Number,
+		Type:        domain.LoyaltyEntryEarn,
+		Points:      points,
+	})
+
+	return err
+}
+
+// ReverseOrder adds an entry compensating all points earned and redeemed by the order.
+func (s *LoyaltyService) ReverseOrder(ctx context.Context, order domain.Order) error {
+	entries, err := s.loyaltyStore.GetOrderEntries(ctx, order.Number)
+	if err != nil {
+		return fmt.Errorf("failed to get order entries: %w", err)
+	}
+
+	var points int64
+	for _, entry := range entries {
+		points += entry.Points
+	}
+
+	if points == 0 {
+		return nil
+	}
+
+	_, err = s.loyaltyStore.AppendEntry(ctx, domain.LoyaltyEntry{
+		UserID:      order.UserID,
+		OrderNumber: order.Number,
+		Type:        domain.LoyaltyEntryReversal,
+		Points:      -points,
+	})
+
+	return err
+}
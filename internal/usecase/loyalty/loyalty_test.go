@@ -0,0 +1,227 @@
+package loyalty
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/internal/usecase/loyalty/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoyaltyService_RedeemPoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLoyaltyRepo := mocks.NewMockloyaltyRepository(ctrl)
+
+	ls := NewLoyaltyService(mockLoyaltyRepo, 5, 100)
+
+	testOrder := domain.Order{
+		Number: 7,
+		UserID: 1,
+		Total:  domain.Money{Amount: 1000, Currency: "RUB"},
+	}
+
+	withPoints := func(points int64) domain.Order {
+		order := testOrder
+		order.LoyaltyPoints = points
+		return order
+	}
+
+	tests := []struct {
+		name             string
+		order            domain.Order
+		mockSetup        func()
+		expectedDiscount *domain.Discount
+		expectedError    error
+	}{
+		{
+			name:  "points are debited by the order number",
+			order: withPoints(3),
+			mockSetup: func() {
+				mockLoyaltyRepo.EXPECT().AppendEntry(gomock.Any(), domain.LoyaltyEntry{
+					UserID:      1,
+					OrderNumber: 7,
+					Type:        domain.LoyaltyEntryRedeem,
+					Points:      -3,
+				}).Return(&domain.LoyaltyEntry{}, nil)
+			},
+			expectedDiscount: &domain.Discount{
+				Source: domain.DiscountSourceLoyalty,
+				Points: 3,
+				Amount: domain.Money{Amount: 300, Currency: "RUB"},
+			},
+		},
+		{
+			name:          "points worth more than the total",
+			order:         withPoints(11),
+			mockSetup:     func() {},
+			expectedError: domain.ErrInvalidPoints,
+		},
+		{
+			name:          "negative points",
+			order:         withPoints(-1),
+			mockSetup:     func() {},
+			expectedError: domain.ErrInvalidPoints,
+		},
+		{
+			name:  "insufficient points",
+			order: withPoints(3),
+			mockSetup: func() {
+				mockLoyaltyRepo.EXPECT().AppendEntry(gomock.Any(), gomock.Any()).Return(nil, domain.ErrInsufficientPoints)
+			},
+			expectedError: domain.ErrInsufficientPoints,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			discount, err := ls.RedeemPoints(context.Background(), tt.order)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedDiscount, discount)
+			}
+		})
+	}
+}
+
+func TestLoyaltyService_EarnPoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLoyaltyRepo := mocks.NewMockloyaltyRepository(ctrl)
+
+	ls := NewLoyaltyService(mockLoyaltyRepo, 5, 100)
+
+	order := func(total int64) domain.Order {
+		return domain.Order{Number: 7, UserID: 1, Total: domain.Money{Amount: total, Currency: "RUB"}}
+	}
+
+	tests := []struct {
+		name          string
+		order         domain.Order
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name:  "percent of the total is credited",
+			order: order(10000),
+			mockSetup: func() {
+				mockLoyaltyRepo.EXPECT().GetOrderEntries(gomock.Any(), domain.OrderNumber(7)).Return([]domain.LoyaltyEntry{
+					{UserID: 1, OrderNumber: 7, Type: domain.LoyaltyEntryRedeem, Points: -3},
+				}, nil)
+				mockLoyaltyRepo.EXPECT().AppendEntry(gomock.Any(), domain.LoyaltyEntry{
+					UserID:      1,
+					OrderNumber: 7,
+					Type:        domain.LoyaltyEntryEarn,
+					Points:      5,
+				}).Return(&domain.LoyaltyEntry{}, nil)
+			},
+		},
+		{
+			name:      "less than a point",
+			order:     order(1999),
+			mockSetup: func() {},
+		},
+		{
+			name:  "points are earned once",
+			order: order(10000),
+			mockSetup: func() {
+				mockLoyaltyRepo.EXPECT().GetOrderEntries(gomock.Any(), domain.OrderNumber(7)).Return([]domain.LoyaltyEntry{
+					{UserID: 1, OrderNumber: 7, Type: domain.LoyaltyEntryEarn, Points: 5},
+				}, nil)
+			},
+		},
+		{
+			name:  "ledger error",
+			order: order(10000),
+			mockSetup: func() {
+				mockLoyaltyRepo.EXPECT().GetOrderEntries(gomock.Any(), domain.OrderNumber(7)).Return(nil, errors.New("ledger failed"))
+			},
+			expectedError: errors.New("failed to get order entries: ledger failed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			err := ls.EarnPoints(context.Background(), tt.order)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoyaltyService_ReverseOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLoyaltyRepo := mocks.NewMockloyaltyRepository(ctrl)
+
+	ls := NewLoyaltyService(mockLoyaltyRepo, 5, 100)
+
+	testOrder := domain.Order{Number: 7, UserID: 1}
+
+	tests := []struct {
+		name            string
+		entries         []domain.LoyaltyEntry
+		expectedReverse int64
+	}{
+		{
+			name: "redeemed points are credited back",
+			entries: []domain.LoyaltyEntry{
+				{UserID: 1, OrderNumber: 7, Type: domain.LoyaltyEntryRedeem, Points: -3},
+			},
+			expectedReverse: 3,
+		},
+		{
+			name: "earned and redeemed points are netted",
+			entries: []domain.LoyaltyEntry{
+				{UserID: 1, OrderNumber: 7, Type: domain.LoyaltyEntryRedeem, Points: -3},
+				{UserID: 1, OrderNumber: 7, Type: domain.LoyaltyEntryEarn, Points: 5},
+			},
+			expectedReverse: -2,
+		},
+		{
+			name: "reversed order isn't reversed again",
+			entries: []domain.LoyaltyEntry{
+				{UserID: 1, OrderNumber: 7, Type: domain.LoyaltyEntryRedeem, Points: -3},
+				{UserID: 1, OrderNumber: 7, Type: domain.LoyaltyEntryReversal, Points: 3},
+			},
+		},
+		{
+			name: "no entries",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLoyaltyRepo.EXPECT().GetOrderEntries(gomock.Any(), testOrder.Number).Return(tt.entries, nil)
+
+			if tt.expectedReverse != 0 {
+				mockLoyaltyRepo.EXPECT().AppendEntry(gomock.Any(), domain.LoyaltyEntry{
+					UserID:      1,
+					OrderNumber: 7,
+					Type:        domain.LoyaltyEntryReversal,
+					Points:      tt.expectedReverse,
+				}).Return(&domain.LoyaltyEntry{}, nil)
+			}
+
+			assert.NoError(t, ls.ReverseOrder(context.Background(), testOrder))
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: loyalty.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockloyaltyRepository is a mock of loyaltyRepository interface.
+type MockloyaltyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockloyaltyRepositoryMockRecorder
+}
+
+// MockloyaltyRepositoryMockRecorder is the mock recorder for MockloyaltyRepository.
+type MockloyaltyRepositoryMockRecorder struct {
+	mock *MockloyaltyRepository
+}
+
+// NewMockloyaltyRepository creates a new mock instance.
+func NewMockloyaltyRepository(ctrl *gomock.Controller) *MockloyaltyRepository {
+	mock := &MockloyaltyRepository{ctrl: ctrl}
+	mock.recorder = &MockloyaltyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockloyaltyRepository) EXPECT() *MockloyaltyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AppendEntry mocks base method.
+func (m *MockloyaltyRepository) AppendEntry(ctx context.Context, entry domain.LoyaltyEntry) (*domain.LoyaltyEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendEntry", ctx, entry)
+	ret0, _ := ret[0].(*domain.LoyaltyEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AppendEntry indicates an expected call of AppendEntry.
+func (mr *MockloyaltyRepositoryMockRecorder) AppendEntry(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendEntry", reflect.TypeOf((*MockloyaltyRepository)(nil).AppendEntry), ctx, entry)
+}
+
+// GetBalance mocks base method.
+func (m *MockloyaltyRepository) GetBalance(ctx context.Context, userID domain.UserID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalance", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalance indicates an expected call of GetBalance.
+func (mr *MockloyaltyRepositoryMockRecorder) GetBalance(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockloyaltyRepository)(nil).GetBalance), ctx, userID)
+}
+
+// GetOrderEntries mocks base method.
+func (m *MockloyaltyRepository) GetOrderEntries(ctx context.Context, orderNumber domain.OrderNumber) ([]domain.LoyaltyEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderEntries", ctx, orderNumber)
+	ret0, _ := ret[0].([]domain.LoyaltyEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderEntries indicates an expected call of GetOrderEntries.
+func (mr *MockloyaltyRepositoryMockRecorder) GetOrderEntries(ctx, orderNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderEntries", reflect.TypeOf((*MockloyaltyRepository)(nil).GetOrderEntries), ctx, orderNumber)
+}
+
+// GetUserEntries mocks base method.
+func (m *MockloyaltyRepository) GetUserEntries(ctx context.Context, userID domain.UserID) ([]domain.LoyaltyEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserEntries", ctx, userID)
+	ret0, _ := ret[0].([]domain.LoyaltyEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserEntries indicates an expected call of GetUserEntries.
+func (mr *MockloyaltyRepositoryMockRecorder) GetUserEntries(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserEntries", reflect.TypeOf((*MockloyaltyRepository)(nil).GetUserEntries), ctx, userID)
+}
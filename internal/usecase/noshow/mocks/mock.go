@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: noshow.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockhotelRepository is a mock of hotelRepository interface.
+type MockhotelRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockhotelRepositoryMockRecorder
+}
+
+// MockhotelRepositoryMockRecorder is the mock recorder for MockhotelRepository.
+type MockhotelRepositoryMockRecorder struct {
+	mock *MockhotelRepository
+}
+
+// NewMockhotelRepository creates a new mock instance.
+func NewMockhotelRepository(ctrl *gomock.Controller) *MockhotelRepository {
+	mock := &MockhotelRepository{ctrl: ctrl}
+	mock.recorder = &MockhotelRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhotelRepository) EXPECT() *MockhotelRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetHotel mocks base method.
+func (m *MockhotelRepository) GetHotel(ctx context.Context, id domain.HotelID) (*domain.Hotel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHotel", ctx, id)
+	ret0, _ := ret[0].(*domain.Hotel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHotel indicates an expected call of GetHotel.
+func (mr *MockhotelRepositoryMockRecorder) GetHotel(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotel", reflect.TypeOf((*MockhotelRepository)(nil).GetHotel), ctx, id)
+}
+
+// MockorderRepository is a mock of orderRepository interface.
+type MockorderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderRepositoryMockRecorder
+}
+
+// MockorderRepositoryMockRecorder is the mock recorder for MockorderRepository.
+type MockorderRepositoryMockRecorder struct {
+	mock *MockorderRepository
+}
+
+// NewMockorderRepository creates a new mock instance.
+func NewMockorderRepository(ctrl *gomock.Controller) *MockorderRepository {
+	mock := &MockorderRepository{ctrl: ctrl}
+	mock.recorder = &MockorderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorderRepository) EXPECT() *MockorderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetOrders mocks base method.
+func (m *MockorderRepository) GetOrders(ctx context.Context) ([]domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrders", ctx)
+	ret0, _ := ret[0].([]domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrders indicates an expected call of GetOrders.
+func (mr *MockorderRepositoryMockRecorder) GetOrders(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockorderRepository)(nil).GetOrders), ctx)
+}
+
+// MockbookingService is a mock of bookingService interface.
+type MockbookingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockbookingServiceMockRecorder
+}
+
+// MockbookingServiceMockRecorder is the mock recorder for MockbookingService.
+type MockbookingServiceMockRecorder struct {
+	mock *MockbookingService
+}
+
+// NewMockbookingService creates a new mock instance.
+func NewMockbookingService(ctrl *gomock.Controller) *MockbookingService {
+	mock := &MockbookingService{ctrl: ctrl}
+	mock.recorder = &MockbookingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbookingService) EXPECT() *MockbookingServiceMockRecorder {
+	return m.recorder
+}
+
+// MarkNoShow mocks base method.
+func (m *MockbookingService) MarkNoShow(ctx context.Context, orderNumber domain.OrderNumber, penaltyNights int) (*domain.Order, []domain.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNoShow", ctx, orderNumber, penaltyNights)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].([]domain.Booking)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// MarkNoShow indicates an expected call of MarkNoShow.
+func (mr *MockbookingServiceMockRecorder) MarkNoShow(ctx, orderNumber, penaltyNights interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNoShow", reflect.TypeOf((*MockbookingService)(nil).MarkNoShow), ctx, orderNumber, penaltyNights)
+}
@@ -0,0 +1,154 @@
+package noshow
+
+//go:generate mockgen -source=noshow.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+)
+
+type hotelRepository interface {
+	GetHotel(ctx context.Context, id domain.HotelID) (*domain.Hotel, error)
+}
+
+type orderRepository interface {
+	GetOrders(ctx context.Context) ([]domain.Order, error)
+}
+
+// bookingService marks the order under the same lock as the other changes of the order.
+type bookingService interface {
+	MarkNoShow(ctx context.Context, orderNumber domain.OrderNumber, penaltyNights int) (*domain.Order, []domain.Booking, error)
+}
+
+// NoShowService marks the confirmed orders whose guests didn't check in by the no-show cutoff of the hotel.
+// The store records the no-show event of the order, billing charges the penalty nights by it.
+type NoShowService struct {
+	hotelStore     hotelRepository
+	orderStore     orderRepository
+	bookingService bookingService
+	cutoff         string
+	penaltyNights  int
+	now            func() time.Time
+}
+
+func NewNoShowService(hotelStore hotelRepository, orderStore orderRepository, bookingService bookingService,
+	cutoff string, penaltyNights int) *NoShowService {
+	return &NoShowService{
+		hotelStore:     hotelStore,
+		orderStore:     orderStore,
+		bookingService: bookingService,
+		cutoff:         cutoff,
+		penaltyNights:  penaltyNights,
+		now:            time.Now,
+	}
+}
+
+// ProcessNoShows marks the due orders as no-show and releases their nights except the penalty ones.
+// The release which failed on the previous run is finished. It returns the number of marked orders.
+func (s *NoShowService) ProcessNoShows(ctx context.Context) (int, error) {
+	orders, err := s.orderStore.GetOrders(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get orders: %w", err)
+	}
+
+	now := s.now()
+
+	var (
+		marked int
+		errs   []error
+	)
+
+	for _, order := range orders {
+		if order.Status == domain.OrderStatusNoShow && order.Settlement != nil {
+			if _, err := s.markNoShow(ctx, order.Number); err != nil {
+				errs = append(errs, fmt.Errorf("order %d: %w", order.Number, err))
+			}
+
+			continue
+		}
+
+		if order.Status != domain.OrderStatusConfirmed || len(order.Bookings) == 0 {
+			continue
+		}
+
+		due, err := s.isDue(ctx, order, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("order %d: %w", order.Number, err))
+			continue
+		}
+
+		if !due {
+			continue
+		}
+
+		ok, err := s.markNoShow(ctx, order.Number)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("order %d: %w", order.Number, err))
+			continue
+		}
+
+		if ok {
+			marked++
+		}
+	}
+
+	return marked, errors.Join(errs...)
+}
+
+// Run processes the no-shows every interval until ctx is done.
+func (s *NoShowService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			marked, err := s.ProcessNoShows(ctx)
+			if err != nil {
+				log.Error("failed to process no-shows", err)
+			}
+
+			if marked > 0 {
+				log.Info(fmt.Sprintf("marked %d orders as no-show", marked))
+			}
+		}
+	}
+}
+
+// isDue reports whether the no-show cutoff of the hotel the order arrives to has passed.
+func (s *NoShowService) isDue(ctx context.Context, order domain.Order, now time.Time) (bool, error) {
+	arrival := order.Arrival()
+
+	hotel, err := s.hotelStore.GetHotel(ctx, arrival.HotelID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get hotel: %w", err)
+	}
+
+	deadline, err := hotel.NoShowDeadline(arrival.From, s.cutoff)
+	if err != nil {
+		return false, err
+	}
+
+	return !now.Before(deadline), nil
+}
+
+// markNoShow marks the order and releases its nights. The order is skipped if it was checked in
+// or cancelled meanwhile, false is returned then.
+func (s *NoShowService) markNoShow(ctx context.Context, orderNumber domain.OrderNumber) (bool, error) {
+	_, _, err := s.bookingService.MarkNoShow(ctx, orderNumber, s.penaltyNights)
+	if errors.Is(err, domain.ErrInvalidStatusTransition) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to mark order: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,129 @@
+package noshow
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/internal/usecase/noshow/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoShowService_ProcessNoShows(t *testing.T) {
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	booking := domain.Booking{HotelID: 101, RoomType: "single", From: from, To: from.AddDate(0, 0, 2), RoomCount: 1}
+	restNights := domain.Booking{HotelID: 101, RoomType: "single", From: from.AddDate(0, 0, 1), To: from.AddDate(0, 0, 2), RoomCount: 1}
+
+	confirmed := domain.Order{Number: 1, Status: domain.OrderStatusConfirmed, Bookings: []domain.Booking{booking}}
+	checkedIn := domain.Order{Number: 2, Status: domain.OrderStatusCheckedIn, Bookings: []domain.Booking{booking}}
+	// the release of the nights failed on the previous run
+	unreleased := domain.Order{Number: 4, Status: domain.OrderStatusNoShow, Bookings: []domain.Booking{booking},
+		Settlement: &domain.Settlement{Release: []domain.Booking{restNights}}}
+
+	hotel := &domain.Hotel{ID: 101, Name: "Reddison", Timezone: "Asia/Novosibirsk", NoShowCutoff: "10:00"}
+
+	tests := []struct {
+		name           string
+		now            time.Time
+		mockSetup      func(hotelRepo *mocks.MockhotelRepository, orderRepo *mocks.MockorderRepository, bookings *mocks.MockbookingService)
+		expectedMarked int
+		expectedError  error
+	}{
+		{
+			name: "cutoff passed in the hotel time",
+			// 2025-02-02 10:00 in Novosibirsk
+			now: time.Date(2025, 2, 2, 3, 0, 0, 0, time.UTC),
+			mockSetup: func(hotelRepo *mocks.MockhotelRepository, orderRepo *mocks.MockorderRepository, bookings *mocks.MockbookingService) {
+				orderRepo.EXPECT().GetOrders(gomock.Any()).Return([]domain.Order{confirmed, checkedIn}, nil)
+				hotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				bookings.EXPECT().MarkNoShow(gomock.Any(), confirmed.Number, 1).Return(&confirmed, []domain.Booking{restNights}, nil)
+			},
+			expectedMarked: 1,
+		},
+		{
+			name: "cutoff not passed",
+			// 2025-02-02 09:59 in Novosibirsk
+			now: time.Date(2025, 2, 2, 2, 59, 0, 0, time.UTC),
+			mockSetup: func(hotelRepo *mocks.MockhotelRepository, orderRepo *mocks.MockorderRepository, bookings *mocks.MockbookingService) {
+				orderRepo.EXPECT().GetOrders(gomock.Any()).Return([]domain.Order{confirmed}, nil)
+				hotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+			},
+		},
+		{
+			name: "checked in meanwhile",
+			now:  from.AddDate(0, 0, 2),
+			mockSetup: func(hotelRepo *mocks.MockhotelRepository, orderRepo *mocks.MockorderRepository, bookings *mocks.MockbookingService) {
+				orderRepo.EXPECT().GetOrders(gomock.Any()).Return([]domain.Order{confirmed}, nil)
+				hotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				bookings.EXPECT().MarkNoShow(gomock.Any(), confirmed.Number, 1).
+					Return(nil, nil, &domain.StatusTransitionError{From: domain.OrderStatusCheckedIn, To: domain.OrderStatusNoShow})
+			},
+		},
+		{
+			name: "hotel error doesn't stop other orders",
+			now:  from.AddDate(0, 0, 2),
+			mockSetup: func(hotelRepo *mocks.MockhotelRepository, orderRepo *mocks.MockorderRepository, bookings *mocks.MockbookingService) {
+				unknownHotel := domain.Order{Number: 3, Status: domain.OrderStatusConfirmed, Bookings: []domain.Booking{
+					{HotelID: 999, RoomType: "single", From: from, To: from, RoomCount: 1},
+				}}
+
+				orderRepo.EXPECT().GetOrders(gomock.Any()).Return([]domain.Order{unknownHotel, confirmed}, nil)
+				hotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(999)).Return(nil, domain.ErrHotelNotFound)
+				hotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				bookings.EXPECT().MarkNoShow(gomock.Any(), confirmed.Number, 1).Return(&confirmed, []domain.Booking{restNights}, nil)
+			},
+			expectedMarked: 1,
+			expectedError:  domain.ErrHotelNotFound,
+		},
+		{
+			name: "release error",
+			now:  from.AddDate(0, 0, 2),
+			mockSetup: func(hotelRepo *mocks.MockhotelRepository, orderRepo *mocks.MockorderRepository, bookings *mocks.MockbookingService) {
+				orderRepo.EXPECT().GetOrders(gomock.Any()).Return([]domain.Order{confirmed}, nil)
+				hotelRepo.EXPECT().GetHotel(gomock.Any(), domain.HotelID(101)).Return(hotel, nil)
+				bookings.EXPECT().MarkNoShow(gomock.Any(), confirmed.Number, 1).
+					Return(nil, nil, fmt.Errorf("failed to release rooms: %w", domain.ErrRoomTypeNotFound))
+			},
+			expectedError: domain.ErrRoomTypeNotFound,
+		},
+		{
+			name: "failed release is finished on the next run",
+			now:  from.AddDate(0, 0, 2),
+			mockSetup: func(hotelRepo *mocks.MockhotelRepository, orderRepo *mocks.MockorderRepository, bookings *mocks.MockbookingService) {
+				orderRepo.EXPECT().GetOrders(gomock.Any()).Return([]domain.Order{unreleased}, nil)
+				bookings.EXPECT().MarkNoShow(gomock.Any(), unreleased.Number, 1).Return(&unreleased, []domain.Booking{restNights}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockHotelRepo := mocks.NewMockhotelRepository(ctrl)
+			mockOrderRepo := mocks.NewMockorderRepository(ctrl)
+			mockBookingService := mocks.NewMockbookingService(ctrl)
+
+			tt.mockSetup(mockHotelRepo, mockOrderRepo, mockBookingService)
+
+			s := NewNoShowService(mockHotelRepo, mockOrderRepo, mockBookingService, "06:00", 1)
+			s.now = func() time.Time { return tt.now }
+
+			marked, err := s.ProcessNoShows(context.Background())
+
+			assert.Equal(t, tt.expectedMarked, marked)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package notification
+
+//go:generate mockgen -source=notification.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"text/template"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/internal/mail"
+	"applicationDesignTest/pkg/log"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var templateFuncs = map[string]any{
+	"date": func(t time.Time) string {
+		return t.Format("2006-01-02")
+	},
+	"money": func(m domain.Money) string {
+		return fmt.Sprintf("%d.%02d %s", m.Amount/100, m.Amount%100, m.Currency)
+	},
+}
+
+var (
+	orderCreatedText = template.Must(template.New("order_created.txt").
+				Funcs(templateFuncs).ParseFS(templates, "templates/order_created.txt"))
+	orderCreatedHTML = htmltemplate.Must(htmltemplate.New("order_created.html").
+				Funcs(templateFuncs).ParseFS(templates, "templates/order_created.html"))
+)
+
+type userRepository interface {
+	GetUser(ctx context.Context, id domain.UserID) (*domain.User, error)
+}
+
+type sender interface {
+	Send(ctx context.Context, msg mail.Message) error
+}
+
+type NotificationService struct {
+	userStore userRepository
+	sender    sender
+	from      string
+	attempts  int
+	backoff   time.Duration
+}
+
+// NewNotificationService creates the service which makes up to attempts tries to send an email,
+// doubling the pause between them starting from backoff.
+func NewNotificationService(userStore userRepository, sender sender, from string, attempts int, backoff time.Duration) *NotificationService {
+	return &NotificationService{
+		userStore: userStore,
+		sender:    sender,
+		from:      from,
+		attempts:  attempts,
+		backoff:   backoff,
+	}
+}
+
+// HandleEvent sends the confirmation email when an order is created. The emails which can't be sent
+// after all attempts are dropped, so a broken mail server doesn't stop the delivery of the following events.
+func (s *NotificationService) HandleEvent(ctx context.Context, event domain.Event) error {
+	if event.Type != domain.EventOrderCreated {
+		return nil
+	}
+
+	if err := s.SendOrderConfirmation(ctx, *event.Order); err != nil {
+		log.Error(fmt.Sprintf("failed to send confirmation email of order %d", event.Order.Number), err)
+	}
+
+	return nil
+}
+
+// SendOrderConfirmation sends the confirmation email of the order to its user, retrying on failures.
+func (s *NotificationService) SendOrderConfirmation(ctx context.Context, order domain.Order) error {
+	user, err := s.userStore.GetUser(ctx, order.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	msg, err := s.orderConfirmation(*user, order)
+	if err != nil {
+		return fmt.Errorf("failed to render email: %w", err)
+	}
+
+	return s.sendWithRetry(ctx, msg)
+}
+
+func (s *NotificationService) orderConfirmation(user domain.User, order domain.Order) (mail.Message, error) {
+	data := struct {
+		User  domain.User
+		Order domain.Order
+	}{
+		User:  user,
+		Order: order,
+	}
+
+	var text, html bytes.Buffer
+
+	if err := orderCreatedText.Execute(&text, data); err != nil {
+		return mail.Message{}, err
+	}
+
+	if err := orderCreatedHTML.Execute(&html, data); err != nil {
+		return mail.Message{}, err
+	}
+
+	return mail.Message{
+		From:    s.from,
+		To:      []string{user.Email},
+		Subject: fmt.Sprintf("Заказ №%d подтвержден", order.Number),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+func (s *NotificationService) sendWithRetry(ctx context.Context, msg mail.Message) error {
+	var errs []error
+
+	backoff := s.backoff
+
+	for attempt := 1; attempt <= s.attempts; attempt++ {
+		err := s.sender.Send(ctx, msg)
+		if err == nil {
+			return nil
+		}
+
+		errs = append(errs, fmt.Errorf("attempt %d: %w", attempt, err))
+
+		if attempt == s.attempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(append(errs, ctx.Err())...)
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+
+	return fmt.Errorf("failed to send email: %w", errors.Join(errs...))
+}
@@ -0,0 +1,132 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/internal/mail"
+	"applicationDesignTest/internal/usecase/notification/mocks"
+	"applicationDesignTest/pkg/date"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationService_SendOrderConfirmation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockuserRepository(ctrl)
+	mockSender := mocks.NewMocksender(ctrl)
+
+	ns := NewNotificationService(mockUserRepo, mockSender, "booking@example.com", 3, time.Millisecond)
+
+	user := domain.User{ID: 1, FirstName: "Ivan", Email: "ivanov@example.com"}
+
+	order := domain.Order{
+		Number: 7,
+		UserID: user.ID,
+		Bookings: []domain.Booking{
+			{HotelID: 1, RoomType: "single", From: date.Date(2025, 2, 1), To: date.Date(2025, 2, 2), RoomCount: 2},
+		},
+		Total: domain.Money{Amount: 1000050, Currency: "RUB"},
+	}
+
+	checkMessage := func(_ context.Context, msg mail.Message) error {
+		assert.Equal(t, []string{user.Email}, msg.To)
+		assert.Equal(t, "Заказ №7 подтвержден", msg.Subject)
+
+		for _, body := range []string{msg.Text, msg.HTML} {
+			assert.Contains(t, body, "Ivan")
+			assert.Contains(t, body, "2025-02-01")
+			assert.Contains(t, body, "2025-02-02")
+			assert.Contains(t, body, "10000.50 RUB")
+		}
+
+		return nil
+	}
+
+	sendErr := errors.New("connection refused")
+
+	tests := []struct {
+		name          string
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name: "successfully send",
+			mockSetup: func() {
+				mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Return(&user, nil)
+				mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(checkMessage)
+			},
+		},
+		{
+			name: "send after retries",
+			mockSetup: func() {
+				mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Return(&user, nil)
+				gomock.InOrder(
+					mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(sendErr).Times(2),
+					mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil),
+				)
+			},
+		},
+		{
+			name: "all attempts failed",
+			mockSetup: func() {
+				mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Return(&user, nil)
+				mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(sendErr).Times(3)
+			},
+			expectedError: sendErr,
+		},
+		{
+			name: "user not found",
+			mockSetup: func() {
+				mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Return(nil, domain.ErrUserNotFound)
+			},
+			expectedError: domain.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			err := ns.SendOrderConfirmation(context.Background(), order)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNotificationService_HandleEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockuserRepository(ctrl)
+	mockSender := mocks.NewMocksender(ctrl)
+
+	ns := NewNotificationService(mockUserRepo, mockSender, "booking@example.com", 1, time.Millisecond)
+
+	order := domain.Order{Number: 1, UserID: 1}
+	user := domain.User{ID: 1, Email: "ivanov@example.com"}
+
+	t.Run("send confirmation of created order", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUser(gomock.Any(), order.UserID).Return(&user, nil)
+		mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+		err := ns.HandleEvent(context.Background(), domain.Event{Type: domain.EventOrderCreated, Order: &order})
+		assert.NoError(t, err)
+	})
+
+	t.Run("skip other events", func(t *testing.T) {
+		err := ns.HandleEvent(context.Background(), domain.Event{Type: domain.EventOrderCancelled, Order: &order})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: order.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockorderRepository is a mock of orderRepository interface.
+type MockorderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderRepositoryMockRecorder
+}
+
+// MockorderRepositoryMockRecorder is the mock recorder for MockorderRepository.
+type MockorderRepositoryMockRecorder struct {
+	mock *MockorderRepository
+}
+
+// NewMockorderRepository creates a new mock instance.
+func NewMockorderRepository(ctrl *gomock.Controller) *MockorderRepository {
+	mock := &MockorderRepository{ctrl: ctrl}
+	mock.recorder = &MockorderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorderRepository) EXPECT() *MockorderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddOrder mocks base method.
+func (m *MockorderRepository) AddOrder(ctx context.Context, order domain.Order) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOrder", ctx, order)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddOrder indicates an expected call of AddOrder.
+func (mr *MockorderRepositoryMockRecorder) AddOrder(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrder", reflect.TypeOf((*MockorderRepository)(nil).AddOrder), ctx, order)
+}
+
+// GetOrderByID mocks base method.
+func (m *MockorderRepository) GetOrderByID(ctx context.Context, id domain.OrderID) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderByID indicates an expected call of GetOrderByID.
+func (mr *MockorderRepositoryMockRecorder) GetOrderByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderByID", reflect.TypeOf((*MockorderRepository)(nil).GetOrderByID), ctx, id)
+}
+
+// GetOrderByNumber mocks base method.
+func (m *MockorderRepository) GetOrderByNumber(ctx context.Context, orderNumber domain.OrderNumber) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderByNumber", ctx, orderNumber)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderByNumber indicates an expected call of GetOrderByNumber.
+func (mr *MockorderRepositoryMockRecorder) GetOrderByNumber(ctx, orderNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderByNumber", reflect.TypeOf((*MockorderRepository)(nil).GetOrderByNumber), ctx, orderNumber)
+}
+
+// NextOrderNumber mocks base method.
+func (m *MockorderRepository) NextOrderNumber(ctx context.Context) (domain.OrderNumber, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextOrderNumber", ctx)
+	ret0, _ := ret[0].(domain.OrderNumber)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextOrderNumber indicates an expected call of NextOrderNumber.
+func (mr *MockorderRepositoryMockRecorder) NextOrderNumber(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextOrderNumber", reflect.TypeOf((*MockorderRepository)(nil).NextOrderNumber), ctx)
+}
+
+// UpdateOrder mocks base method.
+func (m *MockorderRepository) UpdateOrder(ctx context.Context, orderNumber domain.OrderNumber, update func(*domain.Order) error) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrder", ctx, orderNumber, update)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrder indicates an expected call of UpdateOrder.
+func (mr *MockorderRepositoryMockRecorder) UpdateOrder(ctx, orderNumber, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrder", reflect.TypeOf((*MockorderRepository)(nil).UpdateOrder), ctx, orderNumber, update)
+}
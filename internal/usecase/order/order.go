@@ -1,5 +1,7 @@
 package order
 
+//go:generate mockgen -source=order.go -destination=mocks/mock.go -package=mocks
+
 import (
 	"context"
 
@@ -9,7 +11,9 @@ import (
 type orderRepository interface {
 	GetOrderByID(ctx context.Context, id domain.OrderID) (*domain.Order, error)
 	GetOrderByNumber(ctx context.Context, orderNumber domain.OrderNumber) (*domain.Order, error)
+	NextOrderNumber(ctx context.Context) (domain.OrderNumber, error)
 	AddOrder(ctx context.Context, order domain.Order) (*domain.Order, error)
+	UpdateOrder(ctx context.Context, orderNumber domain.OrderNumber, update func(order *domain.Order) error) (*domain.Order, error)
 }
 
 type OrderService struct {
@@ -30,6 +34,14 @@ func (s *OrderService) GetOrderByNumber(ctx context.Context, orderNumber domain.
 	return s.orderStore.GetOrderByNumber(ctx, orderNumber)
 }
 
+func (s *OrderService) NextOrderNumber(ctx context.Context) (domain.OrderNumber, error) {
+	return s.orderStore.NextOrderNumber(ctx)
+}
+
 func (s *OrderService) AddOrder(ctx context.Context, order domain.Order) (*domain.Order, error) {
 	return s.orderStore.AddOrder(ctx, order)
 }
+
+func (s *OrderService) UpdateOrder(ctx context.Context, orderNumber domain.OrderNumber, update func(order *domain.Order) error) (*domain.Order, error) {
+	return s.orderStore.UpdateOrder(ctx, orderNumber, update)
+}
@@ -0,0 +1,211 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/internal/usecase/order/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderService_GetOrderByNumber(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mocks.NewMockorderRepository(ctrl)
+
+	os := NewOrderService(mockOrderRepo)
+
+	order := &domain.Order{ID: "order-1", Number: 1}
+
+	tests := []struct {
+		name          string
+		mockSetup     func()
+		expectedOrder *domain.Order
+		expectedError error
+	}{
+		{
+			name: "order is found",
+			mockSetup: func() {
+				mockOrderRepo.EXPECT().GetOrderByNumber(gomock.Any(), domain.OrderNumber(1)).Return(order, nil)
+			},
+			expectedOrder: order,
+		},
+		{
+			name: "order isn't found",
+			mockSetup: func() {
+				mockOrderRepo.EXPECT().GetOrderByNumber(gomock.Any(), domain.OrderNumber(1)).Return(nil, domain.ErrOrderNotFound)
+			},
+			expectedError: domain.ErrOrderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			got, err := os.GetOrderByNumber(context.Background(), 1)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedOrder, got)
+			}
+		})
+	}
+}
+
+func TestOrderService_NextOrderNumber(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mocks.NewMockorderRepository(ctrl)
+
+	os := NewOrderService(mockOrderRepo)
+
+	storeErr := errors.New("store error")
+
+	tests := []struct {
+		name           string
+		mockSetup      func()
+		expectedNumber domain.OrderNumber
+		expectedError  error
+	}{
+		{
+			name: "number is taken from the store",
+			mockSetup: func() {
+				mockOrderRepo.EXPECT().NextOrderNumber(gomock.Any()).Return(domain.OrderNumber(42), nil)
+			},
+			expectedNumber: 42,
+		},
+		{
+			name: "store error",
+			mockSetup: func() {
+				mockOrderRepo.EXPECT().NextOrderNumber(gomock.Any()).Return(domain.OrderNumber(0), storeErr)
+			},
+			expectedError: storeErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			got, err := os.NextOrderNumber(context.Background())
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedNumber, got)
+			}
+		})
+	}
+}
+
+func TestOrderService_AddOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mocks.NewMockorderRepository(ctrl)
+
+	os := NewOrderService(mockOrderRepo)
+
+	order := domain.Order{ID: "order-1", Number: 1}
+
+	tests := []struct {
+		name          string
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name: "order is stored",
+			mockSetup: func() {
+				mockOrderRepo.EXPECT().AddOrder(gomock.Any(), order).Return(&order, nil)
+			},
+		},
+		{
+			name: "duplicate order",
+			mockSetup: func() {
+				mockOrderRepo.EXPECT().AddOrder(gomock.Any(), order).Return(&order, domain.ErrOrderAlreadyExists)
+			},
+			expectedError: domain.ErrOrderAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			got, err := os.AddOrder(context.Background(), order)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &order, got)
+			}
+		})
+	}
+}
+
+func TestOrderService_UpdateOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mocks.NewMockorderRepository(ctrl)
+
+	os := NewOrderService(mockOrderRepo)
+
+	tests := []struct {
+		name           string
+		mockSetup      func()
+		expectedStatus domain.OrderStatus
+		expectedError  error
+	}{
+		{
+			name: "update is applied to the stored order",
+			mockSetup: func() {
+				mockOrderRepo.EXPECT().UpdateOrder(gomock.Any(), domain.OrderNumber(1), gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ domain.OrderNumber, update func(order *domain.Order) error) (*domain.Order, error) {
+						order := &domain.Order{ID: "order-1", Number: 1, Status: domain.OrderStatusConfirmed}
+						if err := update(order); err != nil {
+							return nil, err
+						}
+						return order, nil
+					})
+			},
+			expectedStatus: domain.OrderStatusCancelled,
+		},
+		{
+			name: "order isn't found",
+			mockSetup: func() {
+				mockOrderRepo.EXPECT().UpdateOrder(gomock.Any(), domain.OrderNumber(1), gomock.Any()).Return(nil, domain.ErrOrderNotFound)
+			},
+			expectedError: domain.ErrOrderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			got, err := os.UpdateOrder(context.Background(), 1, func(order *domain.Order) error {
+				order.Status = domain.OrderStatusCancelled
+				return nil
+			})
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, got.Status)
+			}
+		})
+	}
+}
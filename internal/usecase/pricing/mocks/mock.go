@@ -0,0 +1,148 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pricing.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockrateRepository is a mock of rateRepository interface.
+type MockrateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockrateRepositoryMockRecorder
+}
+
+// MockrateRepositoryMockRecorder is the mock recorder for MockrateRepository.
+type MockrateRepositoryMockRecorder struct {
+	mock *MockrateRepository
+}
+
+// NewMockrateRepository creates a new mock instance.
+func NewMockrateRepository(ctrl *gomock.Controller) *MockrateRepository {
+	mock := &MockrateRepository{ctrl: ctrl}
+	mock.recorder = &MockrateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrateRepository) EXPECT() *MockrateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetCancellationPolicy mocks base method.
+func (m *MockrateRepository) GetCancellationPolicy(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType) (*domain.CancellationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCancellationPolicy", ctx, hotelID, roomType)
+	ret0, _ := ret[0].(*domain.CancellationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCancellationPolicy indicates an expected call of GetCancellationPolicy.
+func (mr *MockrateRepositoryMockRecorder) GetCancellationPolicy(ctx, hotelID, roomType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCancellationPolicy", reflect.TypeOf((*MockrateRepository)(nil).GetCancellationPolicy), ctx, hotelID, roomType)
+}
+
+// GetRate mocks base method.
+func (m *MockrateRepository) GetRate(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time) (*domain.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRate", ctx, hotelID, roomType, date)
+	ret0, _ := ret[0].(*domain.Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRate indicates an expected call of GetRate.
+func (mr *MockrateRepositoryMockRecorder) GetRate(ctx, hotelID, roomType, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRate", reflect.TypeOf((*MockrateRepository)(nil).GetRate), ctx, hotelID, roomType, date)
+}
+
+// SetCancellationPolicy mocks base method.
+func (m *MockrateRepository) SetCancellationPolicy(ctx context.Context, policy domain.CancellationPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCancellationPolicy", ctx, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCancellationPolicy indicates an expected call of SetCancellationPolicy.
+func (mr *MockrateRepositoryMockRecorder) SetCancellationPolicy(ctx, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCancellationPolicy", reflect.TypeOf((*MockrateRepository)(nil).SetCancellationPolicy), ctx, policy)
+}
+
+// SetRate mocks base method.
+func (m *MockrateRepository) SetRate(ctx context.Context, rate domain.Rate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRate", ctx, rate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRate indicates an expected call of SetRate.
+func (mr *MockrateRepositoryMockRecorder) SetRate(ctx, rate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRate", reflect.TypeOf((*MockrateRepository)(nil).SetRate), ctx, rate)
+}
+
+// MockhotelRepository is a mock of hotelRepository interface.
+type MockhotelRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockhotelRepositoryMockRecorder
+}
+
+// MockhotelRepositoryMockRecorder is the mock recorder for MockhotelRepository.
+type MockhotelRepositoryMockRecorder struct {
+	mock *MockhotelRepository
+}
+
+// NewMockhotelRepository creates a new mock instance.
+func NewMockhotelRepository(ctrl *gomock.Controller) *MockhotelRepository {
+	mock := &MockhotelRepository{ctrl: ctrl}
+	mock.recorder = &MockhotelRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhotelRepository) EXPECT() *MockhotelRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetHotel mocks base method.
+func (m *MockhotelRepository) GetHotel(ctx context.Context, id domain.HotelID) (*domain.Hotel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHotel", ctx, id)
+	ret0, _ := ret[0].(*domain.Hotel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHotel indicates an expected call of GetHotel.
+func (mr *MockhotelRepositoryMockRecorder) GetHotel(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotel", reflect.TypeOf((*MockhotelRepository)(nil).GetHotel), ctx, id)
+}
+
+// GetRoomType mocks base method.
+func (m *MockhotelRepository) GetRoomType(ctx context.Context, hotelID domain.HotelID, code domain.RoomType) (*domain.HotelRoomType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoomType", ctx, hotelID, code)
+	ret0, _ := ret[0].(*domain.HotelRoomType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoomType indicates an expected call of GetRoomType.
+func (mr *MockhotelRepositoryMockRecorder) GetRoomType(ctx, hotelID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoomType", reflect.TypeOf((*MockhotelRepository)(nil).GetRoomType), ctx, hotelID, code)
+}
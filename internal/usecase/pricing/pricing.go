@@ -0,0 +1,153 @@
+package pricing
+
+//go:generate mockgen -source=pricing.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+type rateRepository interface {
+	SetRate(ctx context.Context, rate domain.Rate) error
+	GetRate(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, date time.Time) (*domain.Rate, error)
+	SetCancellationPolicy(ctx context.Context, policy domain.CancellationPolicy) error
+	GetCancellationPolicy(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType) (*domain.CancellationPolicy, error)
+}
+
+type hotelRepository interface {
+	GetHotel(ctx context.Context, id domain.HotelID) (*domain.Hotel, error)
+	GetRoomType(ctx context.Context, hotelID domain.HotelID, code domain.RoomType) (*domain.HotelRoomType, error)
+}
+
+type PricingService struct {
+	rateStore  rateRepository
+	hotelStore hotelRepository
+}
+
+func NewPricingService(rateStore rateRepository, hotelStore hotelRepository) *PricingService {
+	return &PricingService{
+		rateStore:  rateStore,
+		hotelStore: hotelStore,
+	}
+}
+
+func (s *PricingService) SetRate(ctx context.Context, rate domain.Rate) error {
+	if rate.Price.Amount <= 0 || rate.Price.Currency == "" {
+		return fmt.Errorf("%w: price must be positive and have a currency", domain.ErrInvalidPrice)
+	}
+
+	// the rate is set for a room type of the hotel catalog
+	if _, err := s.hotelStore.GetRoomType(ctx, rate.HotelID, rate.RoomType); err != nil {
+		return err
+	}
+
+	return s.rateStore.SetRate(ctx, rate)
+}
+
+// Quote prices every night of the bookings at the current rates and returns the line items and their total.
+func (s *PricingService) Quote(ctx context.Context, bookings []domain.Booking) ([]domain.PriceLine, domain.Money, error) {
+	var (
+		lines []domain.PriceLine
+		total domain.Money
+	)
+
+	for _, booking := range bookings {
+		for date := booking.From; !date.After(booking.To); date = date.AddDate(0, 0, 1) {
+			rate, err := s.rateStore.GetRate(ctx, booking.HotelID, booking.RoomType, date)
+			if err != nil {
+				return nil, domain.Money{}, fmt.Errorf("%w: room '%s' in hotel id=%v on %s",
+					err, booking.RoomType, booking.HotelID, date.Format(time.DateOnly))
+			}
+
+			if total.Currency == "" {
+				total.Currency = rate.Price.Currency
+			}
+
+			if rate.Price.Currency != total.Currency {
+				return nil, domain.Money{}, fmt.Errorf("%w: %s and %s",
+					domain.ErrCurrencyMismatch, total.Currency, rate.Price.Currency)
+			}
+
+			line := domain.PriceLine{
+				HotelID:    booking.HotelID,
+				RoomType:   booking.RoomType,
+				Date:       date,
+				RoomCount:  booking.RoomCount,
+				NightPrice: rate.Price,
+				Amount: domain.Money{
+					Amount:   rate.Price.Amount * int64(booking.RoomCount),
+					Currency: rate.Price.Currency,
+				},
+			}
+
+			lines = append(lines, line)
+			total.Amount += line.Amount.Amount
+		}
+	}
+
+	return lines, total, nil
+}
+
+// SetCancellationPolicy sets the cancellation policy of the room type, or the default policy of the hotel
+// if the room type is empty. The policy applies to the orders placed after it's set.
+func (s *PricingService) SetCancellationPolicy(ctx context.Context, policy domain.CancellationPolicy) (*domain.CancellationPolicy, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	if policy.RoomType == "" {
+		if _, err := s.hotelStore.GetHotel(ctx, policy.HotelID); err != nil {
+			return nil, err
+		}
+	} else if _, err := s.hotelStore.GetRoomType(ctx, policy.HotelID, policy.RoomType); err != nil {
+		return nil, err
+	}
+
+	if err := s.rateStore.SetCancellationPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// CancellationPolicies returns the current cancellation policy of each booked room type: its own policy
+// or the default one of the hotel. The room types without a policy are cancelled for free and skipped.
+func (s *PricingService) CancellationPolicies(ctx context.Context, bookings []domain.Booking) ([]domain.CancellationPolicy, error) {
+	var policies []domain.CancellationPolicy
+
+	for _, booking := range bookings {
+		if containsPolicy(policies, booking.HotelID, booking.RoomType) {
+			continue
+		}
+
+		policy, err := s.rateStore.GetCancellationPolicy(ctx, booking.HotelID, booking.RoomType)
+		if errors.Is(err, domain.ErrPolicyNotFound) {
+			policy, err = s.rateStore.GetCancellationPolicy(ctx, booking.HotelID, "")
+		}
+		if errors.Is(err, domain.ErrPolicyNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cancellation policy: %w", err)
+		}
+
+		policy.RoomType = booking.RoomType
+		policies = append(policies, *policy)
+	}
+
+	return policies, nil
+}
+
+func containsPolicy(policies []domain.CancellationPolicy, hotelID domain.HotelID, roomType domain.RoomType) bool {
+	for _, policy := range policies {
+		if policy.HotelID == hotelID && policy.RoomType == roomType {
+			return true
+		}
+	}
+
+	return false
+}
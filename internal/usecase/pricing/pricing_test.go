@@ -0,0 +1,186 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/internal/usecase/pricing/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPricingService_Quote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRateRepo := mocks.NewMockrateRepository(ctrl)
+
+	ps := NewPricingService(mockRateRepo, nil)
+
+	testDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rate := func(roomType domain.RoomType, date time.Time, amount int64, currency domain.Currency) *domain.Rate {
+		return &domain.Rate{
+			HotelID:  1,
+			RoomType: roomType,
+			Date:     date,
+			Price:    domain.Money{Amount: amount, Currency: currency},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		bookings      []domain.Booking
+		mockSetup     func()
+		expectedLines []domain.PriceLine
+		expectedTotal domain.Money
+		expectedError error
+	}{
+		{
+			name: "price every night",
+			bookings: []domain.Booking{
+				{HotelID: 1, RoomType: "single", From: testDate, To: testDate.AddDate(0, 0, 1), RoomCount: 2},
+				{HotelID: 1, RoomType: "double", From: testDate, To: testDate, RoomCount: 1},
+			},
+			mockSetup: func() {
+				mockRateRepo.EXPECT().GetRate(gomock.Any(), domain.HotelID(1), domain.RoomType("single"), testDate).
+					Return(rate("single", testDate, 1000, "RUB"), nil)
+				mockRateRepo.EXPECT().GetRate(gomock.Any(), domain.HotelID(1), domain.RoomType("single"), testDate.AddDate(0, 0, 1)).
+					Return(rate("single", testDate.AddDate(0, 0, 1), 1500, "RUB"), nil)
+				mockRateRepo.EXPECT().GetRate(gomock.Any(), domain.HotelID(1), domain.RoomType("double"), testDate).
+					Return(rate("double", testDate, 3000, "RUB"), nil)
+			},
+			expectedLines: []domain.PriceLine{
+				{
+					HotelID: 1, RoomType: "single", Date: testDate, RoomCount: 2,
+					NightPrice: domain.Money{Amount: 1000, Currency: "RUB"},
+					Amount:     domain.Money{Amount: 2000, Currency: "RUB"},
+				},
+				{
+					HotelID: 1, RoomType: "single", Date: testDate.AddDate(0, 0, 1), RoomCount: 2,
+					NightPrice: domain.Money{Amount: 1500, Currency: "RUB"},
+					Amount:     domain.Money{Amount: 3000, Currency: "RUB"},
+				},
+				{
+					HotelID: 1, RoomType: "double", Date: testDate, RoomCount: 1,
+					NightPrice: domain.Money{Amount: 3000, Currency: "RUB"},
+					Amount:     domain.Money{Amount: 3000, Currency: "RUB"},
+				},
+			},
+			expectedTotal: domain.Money{Amount: 8000, Currency: "RUB"},
+		},
+		{
+			name: "rate not found",
+			bookings: []domain.Booking{
+				{HotelID: 1, RoomType: "single", From: testDate, To: testDate.AddDate(0, 0, 1), RoomCount: 1},
+			},
+			mockSetup: func() {
+				mockRateRepo.EXPECT().GetRate(gomock.Any(), domain.HotelID(1), domain.RoomType("single"), testDate).
+					Return(rate("single", testDate, 1000, "RUB"), nil)
+				mockRateRepo.EXPECT().GetRate(gomock.Any(), domain.HotelID(1), domain.RoomType("single"), testDate.AddDate(0, 0, 1)).
+					Return(nil, domain.ErrRateNotFound)
+			},
+			expectedError: domain.ErrRateNotFound,
+		},
+		{
+			name: "different currencies",
+			bookings: []domain.Booking{
+				{HotelID: 1, RoomType: "single", From: testDate, To: testDate.AddDate(0, 0, 1), RoomCount: 1},
+			},
+			mockSetup: func() {
+				mockRateRepo.EXPECT().GetRate(gomock.Any(), domain.HotelID(1), domain.RoomType("single"), testDate).
+					Return(rate("single", testDate, 1000, "RUB"), nil)
+				mockRateRepo.EXPECT().GetRate(gomock.Any(), domain.HotelID(1), domain.RoomType("single"), testDate.AddDate(0, 0, 1)).
+					Return(rate("single", testDate.AddDate(0, 0, 1), 10, "USD"), nil)
+			},
+			expectedError: domain.ErrCurrencyMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			lines, total, err := ps.Quote(context.Background(), tt.bookings)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedLines, lines)
+				assert.Equal(t, tt.expectedTotal, total)
+			}
+		})
+	}
+}
+
+func TestPricingService_CancellationPolicies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRateRepo := mocks.NewMockrateRepository(ctrl)
+
+	ps := NewPricingService(mockRateRepo, nil)
+
+	testDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	bookings := []domain.Booking{
+		{HotelID: 1, RoomType: "single", From: testDate, To: testDate, RoomCount: 1},
+		{HotelID: 1, RoomType: "double", From: testDate, To: testDate, RoomCount: 1},
+		{HotelID: 1, RoomType: "single", From: testDate.AddDate(0, 0, 2), To: testDate.AddDate(0, 0, 2), RoomCount: 1},
+		{HotelID: 2, RoomType: "single", From: testDate, To: testDate, RoomCount: 1},
+	}
+
+	tests := []struct {
+		name             string
+		mockSetup        func()
+		expectedPolicies []domain.CancellationPolicy
+		expectedError    error
+	}{
+		{
+			name: "room type policy, hotel default and no policy",
+			mockSetup: func() {
+				mockRateRepo.EXPECT().GetCancellationPolicy(gomock.Any(), domain.HotelID(1), domain.RoomType("single")).
+					Return(&domain.CancellationPolicy{HotelID: 1, RoomType: "single", NonRefundable: true}, nil)
+				mockRateRepo.EXPECT().GetCancellationPolicy(gomock.Any(), domain.HotelID(1), domain.RoomType("double")).
+					Return(nil, domain.ErrPolicyNotFound)
+				mockRateRepo.EXPECT().GetCancellationPolicy(gomock.Any(), domain.HotelID(1), domain.RoomType("")).
+					Return(&domain.CancellationPolicy{HotelID: 1, FreeDays: 3, PenaltyNights: 1}, nil)
+				mockRateRepo.EXPECT().GetCancellationPolicy(gomock.Any(), domain.HotelID(2), domain.RoomType("single")).
+					Return(nil, domain.ErrPolicyNotFound)
+				mockRateRepo.EXPECT().GetCancellationPolicy(gomock.Any(), domain.HotelID(2), domain.RoomType("")).
+					Return(nil, domain.ErrPolicyNotFound)
+			},
+			expectedPolicies: []domain.CancellationPolicy{
+				{HotelID: 1, RoomType: "single", NonRefundable: true},
+				{HotelID: 1, RoomType: "double", FreeDays: 3, PenaltyNights: 1},
+			},
+		},
+		{
+			name: "store error",
+			mockSetup: func() {
+				mockRateRepo.EXPECT().GetCancellationPolicy(gomock.Any(), domain.HotelID(1), domain.RoomType("single")).
+					Return(nil, assert.AnError)
+			},
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			policies, err := ps.CancellationPolicies(context.Background(), bookings)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPolicies, policies)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: promo.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockpromoRepository is a mock of promoRepository interface.
+type MockpromoRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockpromoRepositoryMockRecorder
+}
+
+// MockpromoRepositoryMockRecorder is the mock recorder for MockpromoRepository.
+type MockpromoRepositoryMockRecorder struct {
+	mock *MockpromoRepository
+}
+
+// NewMockpromoRepository creates a new mock instance.
+func NewMockpromoRepository(ctrl *gomock.Controller) *MockpromoRepository {
+	mock := &MockpromoRepository{ctrl: ctrl}
+	mock.recorder = &MockpromoRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpromoRepository) EXPECT() *MockpromoRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddPromo mocks base method.
+func (m *MockpromoRepository) AddPromo(ctx context.Context, promo domain.Promo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPromo", ctx, promo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPromo indicates an expected call of AddPromo.
+func (mr *MockpromoRepositoryMockRecorder) AddPromo(ctx, promo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPromo", reflect.TypeOf((*MockpromoRepository)(nil).AddPromo), ctx, promo)
+}
+
+// GetPromo mocks base method.
+func (m *MockpromoRepository) GetPromo(ctx context.Context, code domain.PromoCode) (*domain.Promo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromo", ctx, code)
+	ret0, _ := ret[0].(*domain.Promo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromo indicates an expected call of GetPromo.
+func (mr *MockpromoRepositoryMockRecorder) GetPromo(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromo", reflect.TypeOf((*MockpromoRepository)(nil).GetPromo), ctx, code)
+}
+
+// Redeem mocks base method.
+func (m *MockpromoRepository) Redeem(ctx context.Context, code domain.PromoCode, userID domain.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeem", ctx, code, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Redeem indicates an expected call of Redeem.
+func (mr *MockpromoRepositoryMockRecorder) Redeem(ctx, code, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeem", reflect.TypeOf((*MockpromoRepository)(nil).Redeem), ctx, code, userID)
+}
+
+// Unredeem mocks base method.
+func (m *MockpromoRepository) Unredeem(ctx context.Context, code domain.PromoCode, userID domain.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unredeem", ctx, code, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unredeem indicates an expected call of Unredeem.
+func (mr *MockpromoRepositoryMockRecorder) Unredeem(ctx, code, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unredeem", reflect.TypeOf((*MockpromoRepository)(nil).Unredeem), ctx, code, userID)
+}
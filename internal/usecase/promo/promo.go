@@ -0,0 +1,73 @@
+package promo
+
+//go:generate mockgen -source=promo.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+type promoRepository interface {
+	AddPromo(ctx context.Context, promo domain.Promo) error
+	GetPromo(ctx context.Context, code domain.PromoCode) (*domain.Promo, error)
+	Redeem(ctx context.Context, code domain.PromoCode, userID domain.UserID) error
+	Unredeem(ctx context.Context, code domain.PromoCode, userID domain.UserID) error
+}
+
+type PromoService struct {
+	promoStore promoRepository
+	now        func() time.Time
+}
+
+func NewPromoService(promoStore promoRepository) *PromoService {
+	return &PromoService{
+		promoStore: promoStore,
+		now:        time.Now,
+	}
+}
+
+func (s *PromoService) AddPromo(ctx context.Context, promo domain.Promo) error {
+	if err := promo.Validate(); err != nil {
+		return err
+	}
+
+	return s.promoStore.AddPromo(ctx, promo)
+}
+
+// ApplyPromo checks the promo code against the priced order and returns the discount. The use of the code
+// isn't counted, it's counted by RedeemPromo when the order is about to be placed. A placed order is checked
+// at the moment it was placed, so it keeps the promo code when its nights are changed after the code expired.
+func (s *PromoService) ApplyPromo(ctx context.Context, code domain.PromoCode, order domain.Order) (*domain.Discount, error) {
+	promo, err := s.promoStore.GetPromo(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	at := order.CreatedAt
+	if at.IsZero() {
+		at = s.now()
+	}
+
+	amount, err := promo.Discount(order, at)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Discount{
+		Source:    domain.DiscountSourcePromo,
+		PromoCode: code,
+		Amount:    amount,
+	}, nil
+}
+
+// RedeemPromo counts a use of the promo code by the user. The use must be taken back with RevertPromo
+// if the order isn't placed or is cancelled.
+func (s *PromoService) RedeemPromo(ctx context.Context, code domain.PromoCode, userID domain.UserID) error {
+	return s.promoStore.Redeem(ctx, code, userID)
+}
+
+func (s *PromoService) RevertPromo(ctx context.Context, code domain.PromoCode, userID domain.UserID) error {
+	return s.promoStore.Unredeem(ctx, code, userID)
+}
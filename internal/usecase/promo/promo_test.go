@@ -0,0 +1,198 @@
+package promo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/internal/usecase/promo/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromoService_AddPromo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPromoRepo := mocks.NewMockpromoRepository(ctrl)
+
+	ps := NewPromoService(mockPromoRepo)
+
+	validPromo := domain.Promo{
+		Code:         "WINTER10",
+		DiscountType: domain.DiscountTypePercent,
+		Value:        10,
+		ValidFrom:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		ValidTo:      time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+	}
+
+	invalidPromo := validPromo
+	invalidPromo.Value = 101
+
+	tests := []struct {
+		name          string
+		promo         domain.Promo
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name:  "valid promo is stored",
+			promo: validPromo,
+			mockSetup: func() {
+				mockPromoRepo.EXPECT().AddPromo(gomock.Any(), validPromo).Return(nil)
+			},
+		},
+		{
+			name:          "invalid promo isn't stored",
+			promo:         invalidPromo,
+			mockSetup:     func() {},
+			expectedError: domain.ErrInvalidPromo,
+		},
+		{
+			name:  "duplicate code",
+			promo: validPromo,
+			mockSetup: func() {
+				mockPromoRepo.EXPECT().AddPromo(gomock.Any(), validPromo).Return(domain.ErrPromoAlreadyExists)
+			},
+			expectedError: domain.ErrPromoAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			err := ps.AddPromo(context.Background(), tt.promo)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPromoService_ApplyPromo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPromoRepo := mocks.NewMockpromoRepository(ctrl)
+
+	ps := NewPromoService(mockPromoRepo)
+	ps.now = func() time.Time { return time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC) }
+
+	testDate := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	testOrder := domain.Order{
+		UserID: 1,
+		Bookings: []domain.Booking{
+			{HotelID: 1, RoomType: "single", From: testDate, To: testDate, RoomCount: 1},
+		},
+		Lines: []domain.PriceLine{
+			{HotelID: 1, RoomType: "single", Date: testDate, RoomCount: 1, Amount: domain.Money{Amount: 1000, Currency: "RUB"}},
+		},
+		Subtotal: domain.Money{Amount: 1000, Currency: "RUB"},
+	}
+
+	promo := &domain.Promo{
+		Code:         "WINTER10",
+		DiscountType: domain.DiscountTypePercent,
+		Value:        10,
+		ValidFrom:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		ValidTo:      time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+	}
+
+	expiredPromo := *promo
+	expiredPromo.ValidTo = time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		// createdAt is the moment the order was placed, zero for a new order
+		createdAt        time.Time
+		mockSetup        func()
+		expectedDiscount *domain.Discount
+		expectedError    error
+	}{
+		{
+			name: "discount is returned without counting the use",
+			mockSetup: func() {
+				mockPromoRepo.EXPECT().GetPromo(gomock.Any(), promo.Code).Return(promo, nil)
+			},
+			expectedDiscount: &domain.Discount{
+				Source:    domain.DiscountSourcePromo,
+				PromoCode: "WINTER10",
+				Amount:    domain.Money{Amount: 100, Currency: "RUB"},
+			},
+		},
+		{
+			name: "promo not found",
+			mockSetup: func() {
+				mockPromoRepo.EXPECT().GetPromo(gomock.Any(), promo.Code).Return(nil, domain.ErrPromoNotFound)
+			},
+			expectedError: domain.ErrPromoNotFound,
+		},
+		{
+			name: "expired promo",
+			mockSetup: func() {
+				mockPromoRepo.EXPECT().GetPromo(gomock.Any(), promo.Code).Return(&expiredPromo, nil)
+			},
+			expectedError: domain.ErrPromoNotApplicable,
+		},
+		{
+			name:      "placed order is checked at the moment it was placed",
+			createdAt: time.Date(2025, 1, 5, 12, 0, 0, 0, time.UTC),
+			mockSetup: func() {
+				mockPromoRepo.EXPECT().GetPromo(gomock.Any(), promo.Code).Return(&expiredPromo, nil)
+			},
+			expectedDiscount: &domain.Discount{
+				Source:    domain.DiscountSourcePromo,
+				PromoCode: "WINTER10",
+				Amount:    domain.Money{Amount: 100, Currency: "RUB"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			order := testOrder
+			order.CreatedAt = tt.createdAt
+
+			discount, err := ps.ApplyPromo(context.Background(), promo.Code, order)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedDiscount, discount)
+			}
+		})
+	}
+}
+
+func TestPromoService_RedeemPromo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPromoRepo := mocks.NewMockpromoRepository(ctrl)
+
+	ps := NewPromoService(mockPromoRepo)
+
+	t.Run("use is counted and taken back", func(t *testing.T) {
+		mockPromoRepo.EXPECT().Redeem(gomock.Any(), domain.PromoCode("WINTER10"), domain.UserID(1)).Return(nil)
+		mockPromoRepo.EXPECT().Unredeem(gomock.Any(), domain.PromoCode("WINTER10"), domain.UserID(1)).Return(nil)
+
+		assert.NoError(t, ps.RedeemPromo(context.Background(), "WINTER10", 1))
+		assert.NoError(t, ps.RevertPromo(context.Background(), "WINTER10", 1))
+	})
+
+	t.Run("usage limit", func(t *testing.T) {
+		mockPromoRepo.EXPECT().Redeem(gomock.Any(), domain.PromoCode("WINTER10"), domain.UserID(1)).Return(domain.ErrPromoUsageLimit)
+
+		assert.ErrorIs(t, ps.RedeemPromo(context.Background(), "WINTER10", 1), domain.ErrPromoUsageLimit)
+	})
+}
@@ -0,0 +1,120 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: search.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	domain "applicationDesignTest/internal/domain"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockhotelRepository is a mock of hotelRepository interface.
+type MockhotelRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockhotelRepositoryMockRecorder
+}
+
+// MockhotelRepositoryMockRecorder is the mock recorder for MockhotelRepository.
+type MockhotelRepositoryMockRecorder struct {
+	mock *MockhotelRepository
+}
+
+// NewMockhotelRepository creates a new mock instance.
+func NewMockhotelRepository(ctrl *gomock.Controller) *MockhotelRepository {
+	mock := &MockhotelRepository{ctrl: ctrl}
+	mock.recorder = &MockhotelRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhotelRepository) EXPECT() *MockhotelRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetHotels mocks base method.
+func (m *MockhotelRepository) GetHotels(ctx context.Context) ([]domain.Hotel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHotels", ctx)
+	ret0, _ := ret[0].([]domain.Hotel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHotels indicates an expected call of GetHotels.
+func (mr *MockhotelRepositoryMockRecorder) GetHotels(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotels", reflect.TypeOf((*MockhotelRepository)(nil).GetHotels), ctx)
+}
+
+// GetRoomAvailability mocks base method.
+func (m *MockhotelRepository) GetRoomAvailability(ctx context.Context, hotelID domain.HotelID, from, to time.Time) ([]domain.RoomAvailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoomAvailability", ctx, hotelID, from, to)
+	ret0, _ := ret[0].([]domain.RoomAvailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoomAvailability indicates an expected call of GetRoomAvailability.
+func (mr *MockhotelRepositoryMockRecorder) GetRoomAvailability(ctx, hotelID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoomAvailability", reflect.TypeOf((*MockhotelRepository)(nil).GetRoomAvailability), ctx, hotelID, from, to)
+}
+
+// GetRoomTypes mocks base method.
+func (m *MockhotelRepository) GetRoomTypes(ctx context.Context, hotelID domain.HotelID) ([]domain.HotelRoomType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoomTypes", ctx, hotelID)
+	ret0, _ := ret[0].([]domain.HotelRoomType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoomTypes indicates an expected call of GetRoomTypes.
+func (mr *MockhotelRepositoryMockRecorder) GetRoomTypes(ctx, hotelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoomTypes", reflect.TypeOf((*MockhotelRepository)(nil).GetRoomTypes), ctx, hotelID)
+}
+
+// MockrestrictionRepository is a mock of restrictionRepository interface.
+type MockrestrictionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockrestrictionRepositoryMockRecorder
+}
+
+// MockrestrictionRepositoryMockRecorder is the mock recorder for MockrestrictionRepository.
+type MockrestrictionRepositoryMockRecorder struct {
+	mock *MockrestrictionRepository
+}
+
+// NewMockrestrictionRepository creates a new mock instance.
+func NewMockrestrictionRepository(ctrl *gomock.Controller) *MockrestrictionRepository {
+	mock := &MockrestrictionRepository{ctrl: ctrl}
+	mock.recorder = &MockrestrictionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrestrictionRepository) EXPECT() *MockrestrictionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetRestrictions mocks base method.
+func (m *MockrestrictionRepository) GetRestrictions(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, from, to time.Time) ([]domain.Restriction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRestrictions", ctx, hotelID, roomType, from, to)
+	ret0, _ := ret[0].([]domain.Restriction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRestrictions indicates an expected call of GetRestrictions.
+func (mr *MockrestrictionRepositoryMockRecorder) GetRestrictions(ctx, hotelID, roomType, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRestrictions", reflect.TypeOf((*MockrestrictionRepository)(nil).GetRestrictions), ctx, hotelID, roomType, from, to)
+}
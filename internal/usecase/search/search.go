@@ -0,0 +1,235 @@
+package search
+
+//go:generate mockgen -source=search.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+)
+
+type hotelRepository interface {
+	GetHotels(ctx context.Context) ([]domain.Hotel, error)
+	GetRoomTypes(ctx context.Context, hotelID domain.HotelID) ([]domain.HotelRoomType, error)
+	GetRoomAvailability(ctx context.Context, hotelID domain.HotelID, from, to time.Time) ([]domain.RoomAvailability, error)
+}
+
+type restrictionRepository interface {
+	GetRestrictions(ctx context.Context, hotelID domain.HotelID, roomType domain.RoomType, from, to time.Time) ([]domain.Restriction, error)
+}
+
+type SearchService struct {
+	hotelStore       hotelRepository
+	restrictionStore restrictionRepository
+}
+
+func NewSearchService(hotelStore hotelRepository, restrictionStore restrictionRepository) *SearchService {
+	return &SearchService{
+		hotelStore:       hotelStore,
+		restrictionStore: restrictionStore,
+	}
+}
+
+// SearchAvailability returns the availability of every matching room type for the stay of the query.
+// A night without availability counts as no free rooms. The stay is checked against the restrictions
+// like a booking, a room type whose restriction closes the stay isn't bookable.
+func (s *SearchService) SearchAvailability(ctx context.Context, query domain.AvailabilityQuery) (*domain.AvailabilityPage, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	hotels, err := s.hotelStore.GetHotels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hotels: %w", err)
+	}
+
+	page := &domain.AvailabilityPage{Items: []domain.RoomTypeAvailability{}, Offset: query.Offset, Limit: query.Limit}
+
+	for _, hotel := range hotels {
+		if query.City != "" && !strings.EqualFold(hotel.City, query.City) {
+			continue
+		}
+
+		roomTypes, err := s.hotelStore.GetRoomTypes(ctx, hotel.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get room types of hotel id=%v: %w", hotel.ID, err)
+		}
+
+		var matched []domain.HotelRoomType
+		for _, roomType := range roomTypes {
+			if query.RoomType == "" || roomType.Code == query.RoomType {
+				matched = append(matched, roomType)
+			}
+		}
+
+		if len(matched) == 0 {
+			continue
+		}
+
+		// only the requested page is priced by the availability, the rest is counted
+		if page.Total+len(matched) <= query.Offset || len(page.Items) == query.Limit {
+			page.Total += len(matched)
+			continue
+		}
+
+		availability, err := s.hotelStore.GetRoomAvailability(ctx, hotel.ID, query.From, query.To)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get availability of hotel id=%v: %w", hotel.ID, err)
+		}
+
+		minRooms := stayMinRooms(availability, query.From, query.To)
+
+		// the departure day may be closed too
+		restrictions, err := s.restrictionStore.GetRestrictions(ctx, hotel.ID, "", query.From, query.To.AddDate(0, 0, 1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get restrictions of hotel id=%v: %w", hotel.ID, err)
+		}
+
+		restrictionsByRoomType := make(map[domain.RoomType]map[time.Time]domain.Restriction)
+		for _, restriction := range restrictions {
+			if restrictionsByRoomType[restriction.RoomType] == nil {
+				restrictionsByRoomType[restriction.RoomType] = make(map[time.Time]domain.Restriction)
+			}
+
+			restrictionsByRoomType[restriction.RoomType][restriction.Date] = restriction
+		}
+
+		for _, roomType := range matched {
+			page.Total++
+
+			if page.Total <= query.Offset || len(page.Items) == query.Limit {
+				continue
+			}
+
+			rooms := minRooms[roomType.Code]
+
+			stay := domain.Booking{HotelID: hotel.ID, RoomType: roomType.Code, From: query.From, To: query.To, RoomCount: query.Rooms}
+
+			var rule domain.RestrictionRule
+
+			var restrictionErr *domain.RestrictionError
+			if errors.As(stay.CheckRestrictions(restrictionsByRoomType[roomType.Code]), &restrictionErr) {
+				rule = restrictionErr.Rule
+			}
+
+			page.Items = append(page.Items, domain.RoomTypeAvailability{
+				HotelID:     hotel.ID,
+				HotelName:   hotel.Name,
+				City:        hotel.City,
+				RoomType:    roomType.Code,
+				Name:        roomType.Name,
+				Capacity:    roomType.Capacity,
+				Bookable:    rooms >= query.Rooms && rule == "",
+				MinRooms:    rooms,
+				Restriction: rule,
+			})
+		}
+	}
+
+	return page, nil
+}
+
+// stayMinRooms returns the smallest number of free rooms per room type among the nights from one date
+// to another. A room type missing some night has zero rooms.
+func stayMinRooms(availability []domain.RoomAvailability, from, to time.Time) map[domain.RoomType]int {
+	nights := int(to.Sub(from).Hours()/24) + 1
+
+	minRooms := make(map[domain.RoomType]int)
+	counted := make(map[domain.RoomType]int)
+
+	for _, item := range availability {
+		if rooms, ok := minRooms[item.RoomType]; !ok || item.Rooms < rooms {
+			minRooms[item.RoomType] = item.Rooms
+		}
+
+		counted[item.RoomType]++
+	}
+
+	for roomType, count := range counted {
+		if count < nights {
+			minRooms[roomType] = 0
+		}
+	}
+
+	return minRooms
+}
+
+// GetCalendar returns the availability and the restrictions of every room type of the hotel catalog
+// for every date of the query. A date without availability has no rooms.
+func (s *SearchService) GetCalendar(ctx context.Context, query domain.CalendarQuery) (*domain.Calendar, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	roomTypes, err := s.hotelStore.GetRoomTypes(ctx, query.HotelID)
+	if err != nil {
+		return nil, err
+	}
+
+	availability, err := s.hotelStore.GetRoomAvailability(ctx, query.HotelID, query.From, query.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability of hotel id=%v: %w", query.HotelID, err)
+	}
+
+	restrictions, err := s.restrictionStore.GetRestrictions(ctx, query.HotelID, "", query.From, query.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get restrictions of hotel id=%v: %w", query.HotelID, err)
+	}
+
+	calendar := &domain.Calendar{
+		HotelID:   query.HotelID,
+		From:      query.From,
+		To:        query.To,
+		RoomTypes: make([]domain.RoomType, 0, len(roomTypes)),
+	}
+
+	for _, roomType := range roomTypes {
+		calendar.RoomTypes = append(calendar.RoomTypes, roomType.Code)
+	}
+
+	days := make(map[time.Time]domain.CalendarDay)
+
+	for date := query.From; !date.After(query.To); date = date.AddDate(0, 0, 1) {
+		day := domain.CalendarDay{Date: date, Rooms: make(map[domain.RoomType]domain.CalendarCell, len(roomTypes))}
+		for _, roomType := range roomTypes {
+			day.Rooms[roomType.Code] = domain.CalendarCell{}
+		}
+
+		days[date] = day
+		calendar.Days = append(calendar.Days, day)
+	}
+
+	for _, item := range availability {
+		day, ok := days[item.Date]
+		if !ok {
+			continue
+		}
+
+		if cell, ok := day.Rooms[item.RoomType]; ok {
+			cell.Total, cell.Reserved, cell.Free = item.Capacity, item.Reserved(), item.Rooms
+			day.Rooms[item.RoomType] = cell
+		}
+	}
+
+	for _, restriction := range restrictions {
+		day, ok := days[restriction.Date]
+		if !ok {
+			continue
+		}
+
+		if cell, ok := day.Rooms[restriction.RoomType]; ok {
+			cell.StopSell = restriction.StopSell
+			cell.ClosedToArrival = restriction.ClosedToArrival
+			cell.ClosedToDeparture = restriction.ClosedToDeparture
+			cell.MinStay = restriction.MinStay
+			cell.MaxStay = restriction.MaxStay
+			day.Rooms[restriction.RoomType] = cell
+		}
+	}
+
+	return calendar, nil
+}
@@ -0,0 +1,260 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/internal/usecase/search/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchService_SearchAvailability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelRepo := mocks.NewMockhotelRepository(ctrl)
+	mockRestrictionRepo := mocks.NewMockrestrictionRepository(ctrl)
+
+	ss := NewSearchService(mockHotelRepo, mockRestrictionRepo)
+
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+
+	hotels := []domain.Hotel{
+		{ID: 1, Name: "Reddison", City: "Moscow"},
+		{ID: 2, Name: "Cosmos", City: "Kazan"},
+	}
+
+	single := domain.HotelRoomType{HotelID: 1, Code: "single", Name: "Single", Capacity: 1}
+	double := domain.HotelRoomType{HotelID: 1, Code: "double", Name: "Double", Capacity: 2}
+	dorm := domain.HotelRoomType{HotelID: 2, Code: "dorm", Name: "Dorm bed", Capacity: 1}
+
+	reddisonAvailability := []domain.RoomAvailability{
+		// double has no availability on the second night
+		{HotelID: 1, RoomType: "double", Date: from, Rooms: 5},
+		{HotelID: 1, RoomType: "single", Date: from, Rooms: 3},
+		{HotelID: 1, RoomType: "single", Date: to, Rooms: 1},
+	}
+
+	cosmosAvailability := []domain.RoomAvailability{
+		{HotelID: 2, RoomType: "dorm", Date: from, Rooms: 10},
+		{HotelID: 2, RoomType: "dorm", Date: to, Rooms: 8},
+	}
+
+	tests := []struct {
+		name          string
+		query         domain.AvailabilityQuery
+		mockSetup     func()
+		expectedPage  *domain.AvailabilityPage
+		expectedError error
+	}{
+		{
+			name:  "all hotels and room types",
+			query: domain.AvailabilityQuery{From: from, To: to, Rooms: 2, Limit: 10},
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().GetHotels(gomock.Any()).Return(hotels, nil)
+				mockHotelRepo.EXPECT().GetRoomTypes(gomock.Any(), domain.HotelID(1)).Return([]domain.HotelRoomType{double, single}, nil)
+				mockHotelRepo.EXPECT().GetRoomAvailability(gomock.Any(), domain.HotelID(1), from, to).Return(reddisonAvailability, nil)
+				mockRestrictionRepo.EXPECT().GetRestrictions(gomock.Any(), domain.HotelID(1), domain.RoomType(""), from, to.AddDate(0, 0, 1)).Return(nil, nil)
+				mockHotelRepo.EXPECT().GetRoomTypes(gomock.Any(), domain.HotelID(2)).Return([]domain.HotelRoomType{dorm}, nil)
+				mockHotelRepo.EXPECT().GetRoomAvailability(gomock.Any(), domain.HotelID(2), from, to).Return(cosmosAvailability, nil)
+				mockRestrictionRepo.EXPECT().GetRestrictions(gomock.Any(), domain.HotelID(2), domain.RoomType(""), from, to.AddDate(0, 0, 1)).Return(nil, nil)
+			},
+			expectedPage: &domain.AvailabilityPage{
+				Items: []domain.RoomTypeAvailability{
+					{HotelID: 1, HotelName: "Reddison", City: "Moscow", RoomType: "double", Name: "Double", Capacity: 2, Bookable: false, MinRooms: 0},
+					{HotelID: 1, HotelName: "Reddison", City: "Moscow", RoomType: "single", Name: "Single", Capacity: 1, Bookable: false, MinRooms: 1},
+					{HotelID: 2, HotelName: "Cosmos", City: "Kazan", RoomType: "dorm", Name: "Dorm bed", Capacity: 1, Bookable: true, MinRooms: 8},
+				},
+				Total: 3,
+				Limit: 10,
+			},
+		},
+		{
+			name:  "filter by city and room type",
+			query: domain.AvailabilityQuery{From: from, To: to, Rooms: 1, City: "moscow", RoomType: "single", Limit: 10},
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().GetHotels(gomock.Any()).Return(hotels, nil)
+				mockHotelRepo.EXPECT().GetRoomTypes(gomock.Any(), domain.HotelID(1)).Return([]domain.HotelRoomType{double, single}, nil)
+				mockHotelRepo.EXPECT().GetRoomAvailability(gomock.Any(), domain.HotelID(1), from, to).Return(reddisonAvailability, nil)
+				mockRestrictionRepo.EXPECT().GetRestrictions(gomock.Any(), domain.HotelID(1), domain.RoomType(""), from, to.AddDate(0, 0, 1)).Return(nil, nil)
+			},
+			expectedPage: &domain.AvailabilityPage{
+				Items: []domain.RoomTypeAvailability{
+					{HotelID: 1, HotelName: "Reddison", City: "Moscow", RoomType: "single", Name: "Single", Capacity: 1, Bookable: true, MinRooms: 1},
+				},
+				Total: 1,
+				Limit: 10,
+			},
+		},
+		{
+			name:  "stop-sell night closes the stay",
+			query: domain.AvailabilityQuery{From: from, To: to, Rooms: 1, City: "kazan", Limit: 10},
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().GetHotels(gomock.Any()).Return(hotels, nil)
+				mockHotelRepo.EXPECT().GetRoomTypes(gomock.Any(), domain.HotelID(2)).Return([]domain.HotelRoomType{dorm}, nil)
+				mockHotelRepo.EXPECT().GetRoomAvailability(gomock.Any(), domain.HotelID(2), from, to).Return(cosmosAvailability, nil)
+				mockRestrictionRepo.EXPECT().GetRestrictions(gomock.Any(), domain.HotelID(2), domain.RoomType(""), from, to.AddDate(0, 0, 1)).
+					Return([]domain.Restriction{{HotelID: 2, RoomType: "dorm", Date: to, StopSell: true}}, nil)
+			},
+			expectedPage: &domain.AvailabilityPage{
+				Items: []domain.RoomTypeAvailability{
+					{HotelID: 2, HotelName: "Cosmos", City: "Kazan", RoomType: "dorm", Name: "Dorm bed", Capacity: 1, Bookable: false, MinRooms: 8,
+						Restriction: domain.RuleStopSell},
+				},
+				Total: 1,
+				Limit: 10,
+			},
+		},
+		{
+			name:  "stay shorter than the min stay isn't bookable",
+			query: domain.AvailabilityQuery{From: from, To: to, Rooms: 1, City: "kazan", Limit: 10},
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().GetHotels(gomock.Any()).Return(hotels, nil)
+				mockHotelRepo.EXPECT().GetRoomTypes(gomock.Any(), domain.HotelID(2)).Return([]domain.HotelRoomType{dorm}, nil)
+				mockHotelRepo.EXPECT().GetRoomAvailability(gomock.Any(), domain.HotelID(2), from, to).Return(cosmosAvailability, nil)
+				mockRestrictionRepo.EXPECT().GetRestrictions(gomock.Any(), domain.HotelID(2), domain.RoomType(""), from, to.AddDate(0, 0, 1)).
+					Return([]domain.Restriction{{HotelID: 2, RoomType: "dorm", Date: from, MinStay: 3}}, nil)
+			},
+			expectedPage: &domain.AvailabilityPage{
+				Items: []domain.RoomTypeAvailability{
+					{HotelID: 2, HotelName: "Cosmos", City: "Kazan", RoomType: "dorm", Name: "Dorm bed", Capacity: 1, Bookable: false, MinRooms: 8,
+						Restriction: domain.RuleMinStay},
+				},
+				Total: 1,
+				Limit: 10,
+			},
+		},
+		{
+			name:  "availability of hotels outside the page isn't read",
+			query: domain.AvailabilityQuery{From: from, To: to, Rooms: 1, Offset: 2, Limit: 1},
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().GetHotels(gomock.Any()).Return(hotels, nil)
+				mockHotelRepo.EXPECT().GetRoomTypes(gomock.Any(), domain.HotelID(1)).Return([]domain.HotelRoomType{double, single}, nil)
+				mockHotelRepo.EXPECT().GetRoomTypes(gomock.Any(), domain.HotelID(2)).Return([]domain.HotelRoomType{dorm}, nil)
+				mockHotelRepo.EXPECT().GetRoomAvailability(gomock.Any(), domain.HotelID(2), from, to).Return(cosmosAvailability, nil)
+				mockRestrictionRepo.EXPECT().GetRestrictions(gomock.Any(), domain.HotelID(2), domain.RoomType(""), from, to.AddDate(0, 0, 1)).Return(nil, nil)
+			},
+			expectedPage: &domain.AvailabilityPage{
+				Items: []domain.RoomTypeAvailability{
+					{HotelID: 2, HotelName: "Cosmos", City: "Kazan", RoomType: "dorm", Name: "Dorm bed", Capacity: 1, Bookable: true, MinRooms: 8},
+				},
+				Total:  3,
+				Offset: 2,
+				Limit:  1,
+			},
+		},
+		{
+			name:          "invalid date range",
+			query:         domain.AvailabilityQuery{From: to, To: from, Rooms: 1, Limit: 10},
+			mockSetup:     func() {},
+			expectedError: domain.ErrInvalidQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			page, err := ss.SearchAvailability(context.Background(), tt.query)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPage, page)
+			}
+		})
+	}
+}
+
+func TestSearchService_GetCalendar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHotelRepo := mocks.NewMockhotelRepository(ctrl)
+	mockRestrictionRepo := mocks.NewMockrestrictionRepository(ctrl)
+
+	ss := NewSearchService(mockHotelRepo, mockRestrictionRepo)
+
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+
+	single := domain.HotelRoomType{HotelID: 1, Code: "single", Name: "Single", Capacity: 1}
+	double := domain.HotelRoomType{HotelID: 1, Code: "double", Name: "Double", Capacity: 2}
+
+	tests := []struct {
+		name             string
+		query            domain.CalendarQuery
+		mockSetup        func()
+		expectedCalendar *domain.Calendar
+		expectedError    error
+	}{
+		{
+			name:  "every date and room type",
+			query: domain.CalendarQuery{HotelID: 1, From: from, To: to},
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().GetRoomTypes(gomock.Any(), domain.HotelID(1)).Return([]domain.HotelRoomType{double, single}, nil)
+				mockHotelRepo.EXPECT().GetRoomAvailability(gomock.Any(), domain.HotelID(1), from, to).Return([]domain.RoomAvailability{
+					// double has no availability on the second date
+					{HotelID: 1, RoomType: "double", Date: from, Rooms: 5, Capacity: 5},
+					{HotelID: 1, RoomType: "single", Date: from, Rooms: 1, Capacity: 3},
+					{HotelID: 1, RoomType: "single", Date: to, Rooms: 0, Capacity: 1},
+				}, nil)
+				mockRestrictionRepo.EXPECT().GetRestrictions(gomock.Any(), domain.HotelID(1), domain.RoomType(""), from, to).Return([]domain.Restriction{
+					{HotelID: 1, RoomType: "double", Date: from, ClosedToArrival: true, MinStay: 2},
+					{HotelID: 1, RoomType: "double", Date: to, StopSell: true},
+				}, nil)
+			},
+			expectedCalendar: &domain.Calendar{
+				HotelID:   1,
+				From:      from,
+				To:        to,
+				RoomTypes: []domain.RoomType{"double", "single"},
+				Days: []domain.CalendarDay{
+					{Date: from, Rooms: map[domain.RoomType]domain.CalendarCell{
+						"double": {Total: 5, Reserved: 0, Free: 5, ClosedToArrival: true, MinStay: 2},
+						"single": {Total: 3, Reserved: 2, Free: 1},
+					}},
+					{Date: to, Rooms: map[domain.RoomType]domain.CalendarCell{
+						"double": {StopSell: true},
+						"single": {Total: 1, Reserved: 1, Free: 0},
+					}},
+				},
+			},
+		},
+		{
+			name:  "hotel not found",
+			query: domain.CalendarQuery{HotelID: 2, From: from, To: to},
+			mockSetup: func() {
+				mockHotelRepo.EXPECT().GetRoomTypes(gomock.Any(), domain.HotelID(2)).Return(nil, domain.ErrHotelNotFound)
+			},
+			expectedError: domain.ErrHotelNotFound,
+		},
+		{
+			name:          "too long period",
+			query:         domain.CalendarQuery{HotelID: 1, From: from, To: from.AddDate(1, 0, 1)},
+			mockSetup:     func() {},
+			expectedError: domain.ErrInvalidQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			calendar, err := ss.GetCalendar(context.Background(), tt.query)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCalendar, calendar)
+			}
+		})
+	}
+}
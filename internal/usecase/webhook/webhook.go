@@ -0,0 +1,404 @@
+package webhook
+
+//go:generate mockgen -source=webhook.go -destination=mocks/mock.go -package=mocks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/pkg/log"
+)
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+type webhookRepository interface {
+	AddWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error)
+	GetWebhook(ctx context.Context, id domain.WebhookID) (*domain.Webhook, error)
+	GetWebhooks(ctx context.Context) ([]domain.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id domain.WebhookID) error
+	AddDeadLetter(ctx context.Context, deadLetter domain.DeadLetter) error
+	GetDeadLetters(ctx context.Context) ([]domain.DeadLetter, error)
+}
+
+const (
+	// queueLimit is the most events waiting for one webhook, the events over it are put to the dead letters
+	// at once, so an endpoint which is down for long doesn't take the memory.
+	queueLimit = 1000
+	// idlePause is the longest pause of Run between the checks of the queues.
+	idlePause = time.Minute
+)
+
+var errQueueFull = errors.New("delivery queue is full")
+
+// delivery is the delivery state of a webhook: the events waiting for it in the order they happened,
+// the progress of the first one and the dead letters which aren't stored yet.
+type delivery struct {
+	webhook     domain.Webhook
+	queue       []domain.Event
+	lastQueued  int64
+	attempts    int
+	retryAt     time.Time
+	posting     bool
+	deadLetters []domain.DeadLetter
+}
+
+// next removes the delivered or failed first event from the queue.
+func (d *delivery) next() {
+	d.queue = d.queue[1:]
+	d.attempts = 0
+	d.retryAt = time.Time{}
+}
+
+type WebhookService struct {
+	webhookStore webhookRepository
+	client       *http.Client
+	attempts     int
+	backoff      time.Duration
+	now          func() time.Time
+
+	mu         sync.Mutex
+	deliveries map[domain.WebhookID]*delivery
+	wake       chan struct{}
+	posts      sync.WaitGroup
+}
+
+// NewWebhookService creates the service which makes up to attempts tries to deliver an event,
+// doubling the pause between them starting from backoff.
+func NewWebhookService(webhookStore webhookRepository, client *http.Client, attempts int, backoff time.Duration) *WebhookService {
+	return &WebhookService{
+		webhookStore: webhookStore,
+		client:       client,
+		attempts:     attempts,
+		backoff:      backoff,
+		now:          time.Now,
+		deliveries:   make(map[domain.WebhookID]*delivery),
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// AddWebhook validates and stores the webhook. A random secret is generated if it's empty.
+func (s *WebhookService) AddWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error) {
+	if webhook.Secret == "" {
+		secret, err := newSecret()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate secret: %w", err)
+		}
+
+		webhook.Secret = secret
+	}
+
+	if err := webhook.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.webhookStore.AddWebhook(ctx, webhook)
+}
+
+func (s *WebhookService) GetWebhook(ctx context.Context, id domain.WebhookID) (*domain.Webhook, error) {
+	return s.webhookStore.GetWebhook(ctx, id)
+}
+
+// GetWebhooks returns the webhooks of the hotel, or all of them if hotelID is zero.
+func (s *WebhookService) GetWebhooks(ctx context.Context, hotelID domain.HotelID) ([]domain.Webhook, error) {
+	webhooks, err := s.webhookStore.GetWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if hotelID == 0 {
+		return webhooks, nil
+	}
+
+	hotelWebhooks := []domain.Webhook{}
+	for _, webhook := range webhooks {
+		if webhook.HotelID == hotelID {
+			hotelWebhooks = append(hotelWebhooks, webhook)
+		}
+	}
+
+	return hotelWebhooks, nil
+}
+
+// UpdateWebhook validates and replaces the webhook. The secret is kept if it's empty.
+func (s *WebhookService) UpdateWebhook(ctx context.Context, webhook domain.Webhook) (*domain.Webhook, error) {
+	if webhook.Secret == "" {
+		current, err := s.webhookStore.GetWebhook(ctx, webhook.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		webhook.Secret = current.Secret
+	}
+
+	if err := webhook.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.webhookStore.UpdateWebhook(ctx, webhook)
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id domain.WebhookID) error {
+	return s.webhookStore.DeleteWebhook(ctx, id)
+}
+
+func (s *WebhookService) GetDeadLetters(ctx context.Context) ([]domain.DeadLetter, error) {
+	return s.webhookStore.GetDeadLetters(ctx)
+}
+
+// HandleEvent queues the event for the webhooks of its hotels, Run delivers it. Each webhook has its own queue,
+// so a failing endpoint delays only its own events. An event already queued for a webhook isn't queued again.
+// The queues are kept in memory, the events left in them on a restart aren't delivered.
+func (s *WebhookService) HandleEvent(ctx context.Context, event domain.Event) error {
+	webhooks, err := s.webhookStore.GetWebhooks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get webhooks: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := make(map[domain.WebhookID]bool, len(webhooks))
+
+	for _, webhook := range webhooks {
+		current[webhook.ID] = true
+
+		if !webhook.Matches(event) {
+			continue
+		}
+
+		d, ok := s.deliveries[webhook.ID]
+		if !ok {
+			d = &delivery{}
+			s.deliveries[webhook.ID] = d
+		}
+
+		// the events are posted to the current url with the current secret
+		d.webhook = webhook
+
+		if event.ID <= d.lastQueued {
+			continue
+		}
+
+		d.lastQueued = event.ID
+		hotelEvent := event.ForHotel(webhook.HotelID)
+
+		if len(d.queue) >= queueLimit {
+			d.deadLetters = append(d.deadLetters, s.deadLetter(webhook.ID, hotelEvent, 0, errQueueFull))
+			continue
+		}
+
+		d.queue = append(d.queue, hotelEvent)
+	}
+
+	// the events of the deleted webhooks aren't delivered, their dead letters are still stored
+	for id, d := range s.deliveries {
+		if current[id] {
+			continue
+		}
+
+		d.queue = nil
+
+		if !d.posting && len(d.deadLetters) == 0 {
+			delete(s.deliveries, id)
+		}
+	}
+
+	s.notify()
+
+	return nil
+}
+
+// Run delivers the queued events until ctx is done. The first event of each webhook is posted in the background
+// and retried with the pause doubling from backoff, the next one waits for it. An event which isn't delivered
+// after all attempts is put to the dead letters, the dead letters which fail to be stored are retried without
+// posting the event again.
+func (s *WebhookService) Run(ctx context.Context) {
+	for {
+		pause := s.deliverDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			s.posts.Wait()
+			return
+		case <-s.wake:
+		case <-time.After(pause):
+		}
+	}
+}
+
+// deliverDue starts posting the first event of every webhook whose retry time has come and stores
+// the dead letters. It returns the pause until the next retry.
+func (s *WebhookService) deliverDue(ctx context.Context) time.Duration {
+	now := s.now()
+	pause := idlePause
+
+	deadLetters := make(map[domain.WebhookID][]domain.DeadLetter)
+
+	s.mu.Lock()
+
+	for id, d := range s.deliveries {
+		if len(d.deadLetters) > 0 {
+			deadLetters[id] = slices.Clone(d.deadLetters)
+		}
+
+		if d.posting || len(d.queue) == 0 {
+			continue
+		}
+
+		if d.retryAt.After(now) {
+			pause = min(pause, d.retryAt.Sub(now))
+			continue
+		}
+
+		d.posting = true
+		s.posts.Add(1)
+
+		go s.attempt(ctx, id, d.webhook, d.queue[0])
+	}
+
+	s.mu.Unlock()
+
+	for id, letters := range deadLetters {
+		stored := 0
+
+		for _, deadLetter := range letters {
+			if err := s.webhookStore.AddDeadLetter(ctx, deadLetter); err != nil {
+				log.Error(fmt.Sprintf("failed to add dead letter of webhook id=%v", id), err)
+				pause = min(pause, s.backoff)
+				break
+			}
+
+			stored++
+		}
+
+		s.mu.Lock()
+		if d, ok := s.deliveries[id]; ok {
+			d.deadLetters = d.deadLetters[stored:]
+		}
+		s.mu.Unlock()
+	}
+
+	return pause
+}
+
+// attempt posts the event to the webhook once and records the result in the delivery of the webhook.
+func (s *WebhookService) attempt(ctx context.Context, id domain.WebhookID, webhook domain.Webhook, event domain.Event) {
+	defer s.posts.Done()
+
+	err := s.post(ctx, webhook, event)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.deliveries[id]
+	if !ok {
+		return
+	}
+
+	d.posting = false
+
+	switch {
+	case err == nil:
+		d.next()
+	case ctx.Err() != nil:
+		// the service is stopped, the event isn't counted as failed
+	default:
+		d.attempts++
+
+		if d.attempts >= s.attempts {
+			d.deadLetters = append(d.deadLetters, s.deadLetter(id, event, d.attempts, err))
+			d.next()
+		} else {
+			d.retryAt = s.now().Add(s.backoff << (d.attempts - 1))
+		}
+	}
+
+	s.notify()
+}
+
+func (s *WebhookService) deadLetter(id domain.WebhookID, event domain.Event, attempts int, err error) domain.DeadLetter {
+	return domain.DeadLetter{
+		WebhookID: id,
+		Event:     event,
+		Attempts:  attempts,
+		LastError: err.Error(),
+		FailedAt:  s.now(),
+	}
+}
+
+// notify wakes Run up without blocking, a pending wake-up is enough.
+func (s *WebhookService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *WebhookService) post(ctx context.Context, webhook domain.Webhook, event domain.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(event.Type))
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(event.ID, 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// the connection is reused only if the body is read
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign returns the signature of the payload sent in the X-Webhook-Signature header.
+// Partners compute it with their secret to check that the payload came from us.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,334 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"applicationDesignTest/internal/domain"
+	"applicationDesignTest/internal/usecase/webhook/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookService_HandleEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := mocks.NewMockwebhookRepository(ctrl)
+
+	var (
+		failures atomic.Int32
+		requests atomic.Int32
+		received atomic.Value
+		done     chan struct{}
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		body, _ := io.ReadAll(r.Body)
+
+		if r.Header.Get(HeaderSignature) != Sign("secret", body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if failures.Load() > 0 {
+			failures.Add(-1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var event domain.Event
+		if err := json.Unmarshal(body, &event); err == nil {
+			received.Store(event)
+		}
+
+		close(done)
+	}))
+	defer server.Close()
+
+	webhooks := []domain.Webhook{
+		{ID: 1, HotelID: 1, URL: server.URL, Secret: "secret"},
+		{ID: 2, HotelID: 2, URL: server.URL, Secret: "secret"},
+		{ID: 3, HotelID: 1, URL: server.URL, Secret: "secret", EventTypes: []domain.EventType{domain.EventOrderCancelled}},
+	}
+
+	event := domain.Event{
+		ID:   5,
+		Type: domain.EventOrderCreated,
+		Order: &domain.Order{
+			ID: "1",
+			Bookings: []domain.Booking{
+				{HotelID: 1, RoomType: "single", RoomCount: 1},
+				{HotelID: 3, RoomType: "lux", RoomCount: 1},
+			},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		failures         int32
+		mockSetup        func()
+		expectedRequests int32
+	}{
+		{
+			name: "deliver to the hotel webhooks only",
+			mockSetup: func() {
+				mockWebhookRepo.EXPECT().GetWebhooks(gomock.Any()).Return(webhooks, nil).Times(2)
+			},
+			expectedRequests: 1,
+		},
+		{
+			name:     "deliver after retries",
+			failures: 2,
+			mockSetup: func() {
+				mockWebhookRepo.EXPECT().GetWebhooks(gomock.Any()).Return(webhooks, nil).Times(2)
+			},
+			expectedRequests: 3,
+		},
+		{
+			name:     "put to dead letters after all attempts",
+			failures: 3,
+			mockSetup: func() {
+				mockWebhookRepo.EXPECT().GetWebhooks(gomock.Any()).Return(webhooks, nil).Times(2)
+				mockWebhookRepo.EXPECT().AddDeadLetter(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, deadLetter domain.DeadLetter) error {
+						assert.Equal(t, domain.WebhookID(1), deadLetter.WebhookID)
+						assert.Equal(t, event.ID, deadLetter.Event.ID)
+						assert.Equal(t, 3, deadLetter.Attempts)
+						assert.Contains(t, deadLetter.LastError, "503")
+						close(done)
+						return nil
+					})
+			},
+			expectedRequests: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests.Store(0)
+			failures.Store(tt.failures)
+			received = atomic.Value{}
+			done = make(chan struct{})
+
+			tt.mockSetup()
+
+			ws := NewWebhookService(mockWebhookRepo, server.Client(), 3, time.Millisecond)
+			stop := run(ws)
+
+			assert.NoError(t, ws.HandleEvent(context.Background(), event))
+			// the dispatcher hands the event again when another subscriber failed
+			assert.NoError(t, ws.HandleEvent(context.Background(), event))
+
+			wait(t, done)
+			stop()
+
+			assert.Equal(t, tt.expectedRequests, requests.Load())
+
+			if tt.failures < 3 {
+				delivered, ok := received.Load().(domain.Event)
+				if assert.True(t, ok) {
+					assert.Equal(t, event.ID, delivered.ID)
+					// bookings of other hotels aren't disclosed
+					assert.Equal(t, []domain.Booking{event.Order.Bookings[0]}, delivered.Order.Bookings)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookService_HandleEvent_FailingWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := mocks.NewMockwebhookRepository(ctrl)
+
+	var failed atomic.Int32
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		failed.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	delivered := make(chan int64, 2)
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event domain.Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err == nil {
+			delivered <- event.ID
+		}
+	}))
+	defer up.Close()
+
+	webhooks := []domain.Webhook{
+		{ID: 1, HotelID: 1, URL: down.URL, Secret: "secret"},
+		{ID: 2, HotelID: 1, URL: up.URL, Secret: "secret"},
+	}
+
+	mockWebhookRepo.EXPECT().GetWebhooks(gomock.Any()).Return(webhooks, nil).Times(2)
+
+	// the retry of the failing webhook is far away, the other webhook gets the events meanwhile
+	ws := NewWebhookService(mockWebhookRepo, http.DefaultClient, 3, time.Hour)
+	stop := run(ws)
+	defer stop()
+
+	for id := int64(1); id <= 2; id++ {
+		event := domain.Event{
+			ID:    id,
+			Type:  domain.EventOrderCreated,
+			Order: &domain.Order{Bookings: []domain.Booking{{HotelID: 1, RoomType: "lux", RoomCount: 1}}},
+		}
+
+		assert.NoError(t, ws.HandleEvent(context.Background(), event))
+	}
+
+	for id := int64(1); id <= 2; id++ {
+		select {
+		case got := <-delivered:
+			assert.Equal(t, id, got)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("event %d isn't delivered", id)
+		}
+	}
+
+	assert.Equal(t, int32(1), failed.Load())
+}
+
+func TestWebhookService_HandleEvent_DeadLetterNotStored(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := mocks.NewMockwebhookRepository(ctrl)
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	done := make(chan struct{})
+
+	webhooks := []domain.Webhook{{ID: 1, HotelID: 1, URL: server.URL, Secret: "secret"}}
+
+	gomock.InOrder(
+		mockWebhookRepo.EXPECT().GetWebhooks(gomock.Any()).Return(webhooks, nil),
+		mockWebhookRepo.EXPECT().AddDeadLetter(gomock.Any(), gomock.Any()).Return(errors.New("store error")),
+		mockWebhookRepo.EXPECT().AddDeadLetter(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, deadLetter domain.DeadLetter) error {
+				assert.Equal(t, domain.WebhookID(1), deadLetter.WebhookID)
+				close(done)
+				return nil
+			}),
+	)
+
+	ws := NewWebhookService(mockWebhookRepo, server.Client(), 1, time.Millisecond)
+	stop := run(ws)
+
+	event := domain.Event{
+		ID:    1,
+		Type:  domain.EventOrderCreated,
+		Order: &domain.Order{Bookings: []domain.Booking{{HotelID: 1, RoomType: "lux", RoomCount: 1}}},
+	}
+
+	assert.NoError(t, ws.HandleEvent(context.Background(), event))
+
+	wait(t, done)
+	stop()
+
+	// storing the dead letter again doesn't post the event again
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+// run starts the delivery of the service, the returned func stops it and waits until it's stopped.
+func run(ws *WebhookService) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+
+	go func() {
+		ws.Run(ctx)
+		close(stopped)
+	}()
+
+	return func() {
+		cancel()
+		<-stopped
+	}
+}
+
+func wait(t *testing.T, done <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("event isn't delivered")
+	}
+}
+
+func TestWebhookService_AddWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := mocks.NewMockwebhookRepository(ctrl)
+
+	ws := NewWebhookService(mockWebhookRepo, http.DefaultClient, 1, time.Millisecond)
+
+	tests := []struct {
+		name          string
+		webhook       domain.Webhook
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name:    "generate secret",
+			webhook: domain.Webhook{HotelID: 1, URL: "https://partner.example.com/hook"},
+			mockSetup: func() {
+				mockWebhookRepo.EXPECT().AddWebhook(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, webhook domain.Webhook) (*domain.Webhook, error) {
+						assert.Len(t, webhook.Secret, 64)
+						return &webhook, nil
+					})
+			},
+		},
+		{
+			name:          "invalid url",
+			webhook:       domain.Webhook{HotelID: 1, URL: "partner.example.com/hook", Secret: "secret"},
+			mockSetup:     func() {},
+			expectedError: domain.ErrInvalidWebhook,
+		},
+		{
+			name: "unknown event type",
+			webhook: domain.Webhook{HotelID: 1, URL: "https://partner.example.com/hook", Secret: "secret",
+				EventTypes: []domain.EventType{"room_cleaned"}},
+			mockSetup:     func() {},
+			expectedError: domain.ErrInvalidWebhook,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			_, err := ws.AddWebhook(context.Background(), tt.webhook)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package keylock
+
+import "sync"
+
+// Locks serializes the work on the same key, the work on different keys runs in parallel.
+type Locks[K comparable] struct {
+	mu    sync.Mutex
+	locks map[K]*lock
+}
+
+type lock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+func New[K comparable]() *Locks[K] {
+	return &Locks[K]{
+		locks: make(map[K]*lock),
+	}
+}
+
+// Lock locks the key and returns the function unlocking it.
+func (l *Locks[K]) Lock(key K) func() {
+	l.mu.Lock()
+	lk, ok := l.locks[key]
+	if !ok {
+		lk = &lock{}
+		l.locks[key] = lk
+	}
+	lk.refs++
+	l.mu.Unlock()
+
+	lk.mu.Lock()
+
+	return func() {
+		lk.mu.Unlock()
+
+		l.mu.Lock()
+		lk.refs--
+		if lk.refs == 0 {
+			delete(l.locks, key)
+		}
+		l.mu.Unlock()
+	}
+}
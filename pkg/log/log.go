@@ -4,7 +4,8 @@ import (
 	"go.uber.org/zap"
 )
 
-var logger *zap.Logger
+// logger discards everything until InitializeLogger is called.
+var logger = zap.NewNop()
 
 func InitializeLogger() {
 	var err error